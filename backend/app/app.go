@@ -4,6 +4,9 @@ import (
 	"context"
 	"log/slog"
 
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"github.com/dmitrorlov/splitr/backend/config"
 	"github.com/dmitrorlov/splitr/backend/pkg/database"
 	"github.com/dmitrorlov/splitr/backend/pkg/logging"
 	"github.com/dmitrorlov/splitr/backend/usecase"
@@ -20,12 +23,23 @@ type App struct {
 	db          *database.Database
 	wailsLogger *logging.WailsAdapter
 
-	commandUC          usecase.CommandExecutor
-	hostUC             usecase.Host
-	networkUC          usecase.Network
-	networkHostUC      usecase.NetworkHost
-	networkHostSetupUC usecase.NetworkHostSetup
-	updateUC           usecase.Update
+	commandUC             usecase.CommandExecutor
+	hostUC                usecase.Host
+	hostGroupUC           usecase.HostGroup
+	networkUC             usecase.Network
+	networkHostUC         usecase.NetworkHost
+	networkHostImportUC   usecase.NetworkHostImport
+	networkSubscriptionUC usecase.NetworkSubscription
+	networkHostSetupUC    usecase.NetworkHostSetup
+	routeConflictUC       usecase.RouteConflict
+	updateUC              usecase.Update
+	// simulationUC is nil unless the app runs in simulation mode.
+	simulationUC usecase.Simulation
+
+	operationCfg *config.Operation
+	operations   *operations
+	// emitEvent sends events to the frontend, it needs the context Wails starts the app with.
+	emitEvent func(ctx context.Context, eventName string, optionalData ...any)
 }
 
 func New(
@@ -34,10 +48,16 @@ func New(
 	wailsLogger *logging.WailsAdapter,
 	commandUC usecase.CommandExecutor,
 	hostUC usecase.Host,
+	hostGroupUC usecase.HostGroup,
 	networkUC usecase.Network,
 	networkHostUC usecase.NetworkHost,
+	networkHostImportUC usecase.NetworkHostImport,
+	networkSubscriptionUC usecase.NetworkSubscription,
 	networkHostSetupUC usecase.NetworkHostSetup,
+	routeConflictUC usecase.RouteConflict,
 	updateUC usecase.Update,
+	simulationUC usecase.Simulation,
+	operationCfg *config.Operation,
 ) *App {
 	return &App{
 		appName:     appName,
@@ -48,12 +68,21 @@ func New(
 		db:          db,
 		wailsLogger: wailsLogger,
 
-		commandUC:          commandUC,
-		hostUC:             hostUC,
-		networkUC:          networkUC,
-		networkHostUC:      networkHostUC,
-		networkHostSetupUC: networkHostSetupUC,
-		updateUC:           updateUC,
+		commandUC:             commandUC,
+		hostUC:                hostUC,
+		hostGroupUC:           hostGroupUC,
+		networkUC:             networkUC,
+		networkHostUC:         networkHostUC,
+		networkHostImportUC:   networkHostImportUC,
+		networkSubscriptionUC: networkSubscriptionUC,
+		networkHostSetupUC:    networkHostSetupUC,
+		routeConflictUC:       routeConflictUC,
+		updateUC:              updateUC,
+		simulationUC:          simulationUC,
+
+		operationCfg: operationCfg,
+		operations:   newOperations(),
+		emitEvent:    wailsRuntime.EventsEmit,
 	}
 }
 
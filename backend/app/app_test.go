@@ -3,11 +3,13 @@ package app
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/dmitrorlov/splitr/backend/config"
 	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
 	"github.com/dmitrorlov/splitr/backend/pkg/database"
 	"github.com/dmitrorlov/splitr/backend/pkg/logging"
@@ -48,9 +50,13 @@ func TestNew(t *testing.T) {
 			mockLogger := &logging.WailsAdapter{}
 			mockCommandUC := mock_usecase.NewMockCommandExecutor(ctrl)
 			mockHostUC := mock_usecase.NewMockHost(ctrl)
+			mockHostGroupUC := mock_usecase.NewMockHostGroup(ctrl)
 			mockNetworkUC := mock_usecase.NewMockNetwork(ctrl)
 			mockNetworkHostUC := mock_usecase.NewMockNetworkHost(ctrl)
+			mockNetworkHostImportUC := mock_usecase.NewMockNetworkHostImport(ctrl)
+			mockNetworkSubscriptionUC := mock_usecase.NewMockNetworkSubscription(ctrl)
 			mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+			mockRouteConflictUC := mock_usecase.NewMockRouteConflict(ctrl)
 			mockUpdateUC := mock_usecase.NewMockUpdate(ctrl)
 
 			app := New(
@@ -62,10 +68,16 @@ func TestNew(t *testing.T) {
 				mockLogger,
 				mockCommandUC,
 				mockHostUC,
+				mockHostGroupUC,
 				mockNetworkUC,
 				mockNetworkHostUC,
+				mockNetworkHostImportUC,
+				mockNetworkSubscriptionUC,
 				mockNetworkHostSetupUC,
+				mockRouteConflictUC,
 				mockUpdateUC,
+				nil,
+				&config.Operation{Timeout: time.Minute},
 			)
 
 			require.NotNil(t, app)
@@ -77,10 +89,17 @@ func TestNew(t *testing.T) {
 			assert.Equal(t, mockLogger, app.wailsLogger)
 			assert.Equal(t, mockCommandUC, app.commandUC)
 			assert.Equal(t, mockHostUC, app.hostUC)
+			assert.Equal(t, mockHostGroupUC, app.hostGroupUC)
 			assert.Equal(t, mockNetworkUC, app.networkUC)
 			assert.Equal(t, mockNetworkHostUC, app.networkHostUC)
+			assert.Equal(t, mockNetworkHostImportUC, app.networkHostImportUC)
+			assert.Equal(t, mockNetworkSubscriptionUC, app.networkSubscriptionUC)
 			assert.Equal(t, mockNetworkHostSetupUC, app.networkHostSetupUC)
+			assert.Equal(t, mockRouteConflictUC, app.routeConflictUC)
 			assert.Equal(t, mockUpdateUC, app.updateUC)
+			assert.Nil(t, app.simulationUC)
+			assert.Equal(t, time.Minute, app.operationCfg.Timeout)
+			assert.NotNil(t, app.operations)
 			assert.Nil(t, app.ctx)
 		})
 	}
@@ -129,9 +148,13 @@ func TestApp_OnBeforeClose_Success(t *testing.T) {
 	mockLogger := &logging.WailsAdapter{}
 	mockCommandUC := mock_usecase.NewMockCommandExecutor(ctrl)
 	mockHostUC := mock_usecase.NewMockHost(ctrl)
+	mockHostGroupUC := mock_usecase.NewMockHostGroup(ctrl)
 	mockNetworkUC := mock_usecase.NewMockNetwork(ctrl)
 	mockNetworkHostUC := mock_usecase.NewMockNetworkHost(ctrl)
+	mockNetworkHostImportUC := mock_usecase.NewMockNetworkHostImport(ctrl)
+	mockNetworkSubscriptionUC := mock_usecase.NewMockNetworkSubscription(ctrl)
 	mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+	mockRouteConflictUC := mock_usecase.NewMockRouteConflict(ctrl)
 	mockUpdateUC := mock_usecase.NewMockUpdate(ctrl)
 
 	app := New(
@@ -143,10 +166,16 @@ func TestApp_OnBeforeClose_Success(t *testing.T) {
 		mockLogger,
 		mockCommandUC,
 		mockHostUC,
+		mockHostGroupUC,
 		mockNetworkUC,
 		mockNetworkHostUC,
+		mockNetworkHostImportUC,
+		mockNetworkSubscriptionUC,
 		mockNetworkHostSetupUC,
+		mockRouteConflictUC,
 		mockUpdateUC,
+		nil,
+		&config.Operation{Timeout: time.Minute},
 	)
 
 	// Can't test actual database close since it's a concrete type
@@ -164,9 +193,13 @@ func TestApp_OnBeforeClose_DatabaseError(t *testing.T) {
 	mockLogger := &logging.WailsAdapter{}
 	mockCommandUC := mock_usecase.NewMockCommandExecutor(ctrl)
 	mockHostUC := mock_usecase.NewMockHost(ctrl)
+	mockHostGroupUC := mock_usecase.NewMockHostGroup(ctrl)
 	mockNetworkUC := mock_usecase.NewMockNetwork(ctrl)
 	mockNetworkHostUC := mock_usecase.NewMockNetworkHost(ctrl)
+	mockNetworkHostImportUC := mock_usecase.NewMockNetworkHostImport(ctrl)
+	mockNetworkSubscriptionUC := mock_usecase.NewMockNetworkSubscription(ctrl)
 	mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+	mockRouteConflictUC := mock_usecase.NewMockRouteConflict(ctrl)
 	mockUpdateUC := mock_usecase.NewMockUpdate(ctrl)
 
 	app := New(
@@ -178,10 +211,16 @@ func TestApp_OnBeforeClose_DatabaseError(t *testing.T) {
 		mockLogger,
 		mockCommandUC,
 		mockHostUC,
+		mockHostGroupUC,
 		mockNetworkUC,
 		mockNetworkHostUC,
+		mockNetworkHostImportUC,
+		mockNetworkSubscriptionUC,
 		mockNetworkHostSetupUC,
+		mockRouteConflictUC,
 		mockUpdateUC,
+		nil,
+		&config.Operation{Timeout: time.Minute},
 	)
 
 	// Test behavior when database close might fail
@@ -198,9 +237,13 @@ func TestApp_OnBeforeClose_NilDatabase(t *testing.T) {
 	mockLogger := &logging.WailsAdapter{}
 	mockCommandUC := mock_usecase.NewMockCommandExecutor(ctrl)
 	mockHostUC := mock_usecase.NewMockHost(ctrl)
+	mockHostGroupUC := mock_usecase.NewMockHostGroup(ctrl)
 	mockNetworkUC := mock_usecase.NewMockNetwork(ctrl)
 	mockNetworkHostUC := mock_usecase.NewMockNetworkHost(ctrl)
+	mockNetworkHostImportUC := mock_usecase.NewMockNetworkHostImport(ctrl)
+	mockNetworkSubscriptionUC := mock_usecase.NewMockNetworkSubscription(ctrl)
 	mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+	mockRouteConflictUC := mock_usecase.NewMockRouteConflict(ctrl)
 	mockUpdateUC := mock_usecase.NewMockUpdate(ctrl)
 
 	app := New(
@@ -212,10 +255,16 @@ func TestApp_OnBeforeClose_NilDatabase(t *testing.T) {
 		mockLogger,
 		mockCommandUC,
 		mockHostUC,
+		mockHostGroupUC,
 		mockNetworkUC,
 		mockNetworkHostUC,
+		mockNetworkHostImportUC,
+		mockNetworkSubscriptionUC,
 		mockNetworkHostSetupUC,
+		mockRouteConflictUC,
 		mockUpdateUC,
+		nil,
+		&config.Operation{Timeout: time.Minute},
 	)
 
 	result := app.OnBeforeClose(context.Background())
@@ -249,9 +298,13 @@ func TestApp_AllFields(t *testing.T) {
 	mockLogger := &logging.WailsAdapter{}
 	mockCommandUC := mock_usecase.NewMockCommandExecutor(ctrl)
 	mockHostUC := mock_usecase.NewMockHost(ctrl)
+	mockHostGroupUC := mock_usecase.NewMockHostGroup(ctrl)
 	mockNetworkUC := mock_usecase.NewMockNetwork(ctrl)
 	mockNetworkHostUC := mock_usecase.NewMockNetworkHost(ctrl)
+	mockNetworkHostImportUC := mock_usecase.NewMockNetworkHostImport(ctrl)
+	mockNetworkSubscriptionUC := mock_usecase.NewMockNetworkSubscription(ctrl)
 	mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+	mockRouteConflictUC := mock_usecase.NewMockRouteConflict(ctrl)
 	mockUpdateUC := mock_usecase.NewMockUpdate(ctrl)
 
 	app := New(
@@ -263,10 +316,16 @@ func TestApp_AllFields(t *testing.T) {
 		mockLogger,
 		mockCommandUC,
 		mockHostUC,
+		mockHostGroupUC,
 		mockNetworkUC,
 		mockNetworkHostUC,
+		mockNetworkHostImportUC,
+		mockNetworkSubscriptionUC,
 		mockNetworkHostSetupUC,
+		mockRouteConflictUC,
 		mockUpdateUC,
+		nil,
+		&config.Operation{Timeout: time.Minute},
 	)
 
 	assert.Equal(t, "Splitr Test", app.appName)
@@ -280,6 +339,7 @@ func TestApp_AllFields(t *testing.T) {
 	assert.NotNil(t, app.networkUC)
 	assert.NotNil(t, app.networkHostUC)
 	assert.NotNil(t, app.networkHostSetupUC)
+	assert.NotNil(t, app.routeConflictUC)
 	assert.NotNil(t, app.updateUC)
 }
 
@@ -288,12 +348,16 @@ func createTestApp(ctrl *gomock.Controller) *App {
 	mockLogger := &logging.WailsAdapter{}
 	mockCommandUC := mock_usecase.NewMockCommandExecutor(ctrl)
 	mockHostUC := mock_usecase.NewMockHost(ctrl)
+	mockHostGroupUC := mock_usecase.NewMockHostGroup(ctrl)
 	mockNetworkUC := mock_usecase.NewMockNetwork(ctrl)
 	mockNetworkHostUC := mock_usecase.NewMockNetworkHost(ctrl)
+	mockNetworkHostImportUC := mock_usecase.NewMockNetworkHostImport(ctrl)
+	mockNetworkSubscriptionUC := mock_usecase.NewMockNetworkSubscription(ctrl)
 	mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+	mockRouteConflictUC := mock_usecase.NewMockRouteConflict(ctrl)
 	mockUpdateUC := mock_usecase.NewMockUpdate(ctrl)
 
-	return New(
+	app := New(
 		"Test App",
 		"1.0.0",
 		"Test Author",
@@ -302,9 +366,19 @@ func createTestApp(ctrl *gomock.Controller) *App {
 		mockLogger,
 		mockCommandUC,
 		mockHostUC,
+		mockHostGroupUC,
 		mockNetworkUC,
 		mockNetworkHostUC,
+		mockNetworkHostImportUC,
+		mockNetworkSubscriptionUC,
 		mockNetworkHostSetupUC,
+		mockRouteConflictUC,
 		mockUpdateUC,
+		nil,
+		&config.Operation{Timeout: time.Minute},
 	)
+	// Events need the context Wails starts the app with.
+	app.emitEvent = func(context.Context, string, ...any) {}
+
+	return app
 }
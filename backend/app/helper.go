@@ -9,23 +9,31 @@ import (
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-const jsonExtension = ".json"
+const (
+	jsonExtension  = ".json"
+	pacExtension   = ".pac"
+	shellExtension = ".sh"
+)
+
+// exportFileType describes the extension and dialog filter of an exported file.
+type exportFileType struct {
+	extension   string
+	displayName string
+}
 
 func (a *App) SaveFileWithDialog(filename, data string) (string, error) {
-	// Sanitize the filename to remove any invalid characters
-	safeFilename := strings.ReplaceAll(filename, "/", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, "\\", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, ":", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, "*", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, "?", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, "\"", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, "<", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, ">", "_")
-	safeFilename = strings.ReplaceAll(safeFilename, "|", "_")
+	return a.saveFileWithDialog(filename, data, exportFileType{
+		extension:   jsonExtension,
+		displayName: "JSON Files (*.json)",
+	})
+}
+
+func (a *App) saveFileWithDialog(filename, data string, fileType exportFileType) (string, error) {
+	safeFilename := sanitizeFilename(filename)
 
-	// Ensure the filename has a .json extension
-	if !strings.HasSuffix(strings.ToLower(safeFilename), jsonExtension) {
-		safeFilename += jsonExtension
+	// Ensure the filename has the expected extension
+	if !strings.HasSuffix(strings.ToLower(safeFilename), fileType.extension) {
+		safeFilename += fileType.extension
 	}
 
 	// Show save file dialog
@@ -34,8 +42,8 @@ func (a *App) SaveFileWithDialog(filename, data string) (string, error) {
 		DefaultFilename: safeFilename,
 		Filters: []wailsRuntime.FileFilter{
 			{
-				DisplayName: "JSON Files (*.json)",
-				Pattern:     "*.json",
+				DisplayName: fileType.displayName,
+				Pattern:     "*" + fileType.extension,
 			},
 			{
 				DisplayName: "All Files (*.*)",
@@ -66,3 +74,53 @@ func (a *App) SaveFileWithDialog(filename, data string) (string, error) {
 
 	return absPath, nil
 }
+
+// saveFilesWithDirectoryDialog asks for a directory and writes each file into it.
+// It returns the absolute directory path, or an empty string if the dialog was cancelled.
+func (a *App) saveFilesWithDirectoryDialog(title string, files map[string]string) (string, error) {
+	dirPath, err := wailsRuntime.OpenDirectoryDialog(a.ctx, wailsRuntime.OpenDialogOptions{
+		Title:                title,
+		CanCreateDirectories: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to show directory dialog: %w", err)
+	}
+
+	// If user cancelled the dialog, dirPath will be empty
+	if dirPath == "" {
+		return "", nil
+	}
+
+	return writeFilesToDirectory(dirPath, files)
+}
+
+func writeFilesToDirectory(dirPath string, files map[string]string) (string, error) {
+	for filename, data := range files {
+		err := os.WriteFile(filepath.Join(dirPath, sanitizeFilename(filename)), []byte(data), 0600)
+		if err != nil {
+			return "", fmt.Errorf("failed to write file %s: %w", filename, err)
+		}
+	}
+
+	absPath, err := filepath.Abs(dirPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	return absPath, nil
+}
+
+// sanitizeFilename replaces characters that are invalid in file names.
+func sanitizeFilename(filename string) string {
+	safeFilename := strings.ReplaceAll(filename, "/", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, "\\", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, ":", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, "*", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, "?", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, "\"", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, "<", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, ">", "_")
+	safeFilename = strings.ReplaceAll(safeFilename, "|", "_")
+
+	return safeFilename
+}
@@ -403,3 +403,37 @@ func TestApp_SaveFileWithDialog_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteFilesToDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+
+	result, err := writeFilesToDirectory(tempDir, map[string]string{
+		"Corp VPN_routes_apply.sh":  "#!/bin/sh\necho apply\n",
+		"Corp/VPN_routes_remove.sh": "#!/bin/sh\necho remove\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tempDir, result)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "Corp VPN_routes_apply.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho apply\n", string(content))
+
+	info, err := os.Stat(filepath.Join(tempDir, "Corp_VPN_routes_remove.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestWriteFilesToDirectory_MissingDirectory(t *testing.T) {
+	result, err := writeFilesToDirectory(filepath.Join(t.TempDir(), "missing"), map[string]string{
+		"routes.sh": "#!/bin/sh\n",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to write file routes.sh")
+	assert.Empty(t, result)
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	assert.Equal(t, "a_b_c_d_e_f_g_h_i_j", sanitizeFilename(`a/b\c:d*e?f"g<h>i|j`))
+	assert.Equal(t, "Corp VPN", sanitizeFilename("Corp VPN"))
+}
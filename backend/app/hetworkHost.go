@@ -1,19 +1,51 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/dmitrorlov/splitr/backend/entity"
 )
 
-// AddNetworkHost adds a host to a network.
-func (a *App) AddNetworkHost(networkID uint64, address, description string) (*entity.NetworkHost, error) {
+// AddNetworkHost adds a host to a network. A non-empty expiresIn, e.g. "4h", makes the host temporary.
+func (a *App) AddNetworkHost(
+	networkID uint64,
+	address, description, expiresIn string,
+) (*entity.AddNetworkHostResult, error) {
 	networkHost, err := entity.NewNetworkHost(networkID, address, description)
 	if err != nil {
 		return nil, err
 	}
 
+	ttl, err := entity.ParseNetworkHostTTL(expiresIn)
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		networkHost.ExpireAfter(ttl)
+	}
+
+	networkHost, err = a.networkHostUC.Add(a.ctx, networkHost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AddNetworkHostResult{
+		NetworkHost:    networkHost,
+		RouteConflicts: a.addressRouteConflicts(a.ctx, networkID, networkHost.Address),
+	}, nil
+}
+
+// AddNetworkExclusion adds an address that is never routed through the network, even when
+// it falls inside a routed range.
+func (a *App) AddNetworkExclusion(networkID uint64, address, description string) (*entity.NetworkHost, error) {
+	networkHost, err := entity.NewNetworkHost(networkID, address, description)
+	if err != nil {
+		return nil, err
+	}
+	networkHost.Kind = entity.NetworkHostKindExclude
+
 	return a.networkHostUC.Add(a.ctx, networkHost)
 }
 
@@ -31,14 +63,84 @@ func (a *App) DeleteNetworkHost(id uint64) error {
 	return a.networkHostUC.Delete(a.ctx, id)
 }
 
-// SyncNetworkHostSetup synchronizes network host setup.
-func (a *App) SyncNetworkHostSetup(networkID uint64) error {
-	return a.networkHostSetupUC.SyncByNetworkID(a.ctx, networkID)
+// SetNetworkHostEnabled turns routing of a network host on or off without deleting it.
+func (a *App) SetNetworkHostEnabled(id uint64, enabled bool) (*entity.NetworkHost, error) {
+	return a.networkHostUC.SetEnabled(a.ctx, id, enabled)
+}
+
+// SetNetworkHostPinnedIPs pins IPs to a hostname network host. In "replace" mode only the pinned IPs
+// are routed, in "append" mode they are routed along with the hostname's DNS results.
+func (a *App) SetNetworkHostPinnedIPs(id uint64, pinnedIPs []string, mode string) (*entity.NetworkHost, error) {
+	pinMode, err := entity.ParseNetworkHostPinMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.networkHostUC.SetPinnedIPs(a.ctx, id, pinnedIPs, pinMode)
 }
 
-// ResetNetworkHostSetup resets additional routes for a network.
+// SetNetworkHostRouteOverride sets the router, subnet mask or interface a network host's routes are built with
+// instead of the network's. Blank values are taken from the network, all blank clears the override.
+func (a *App) SetNetworkHostRouteOverride(
+	id uint64,
+	router, subnetMask, networkInterface string,
+) (*entity.NetworkHost, error) {
+	override, err := entity.NewRouteOverride(router, subnetMask, networkInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.networkHostUC.SetRouteOverride(a.ctx, id, override)
+}
+
+// SyncNetworkHostSetup synchronizes network host setup. The result lists the hostnames that could not be
+// resolved and were routed to their last known IPs instead, and the conflicts of the applied routes.
+// The sync runs as a cancellable operation.
+func (a *App) SyncNetworkHostSetup(networkID uint64) (*entity.SyncResult, error) {
+	return runOperation(a, entity.OperationKindSync, networkID, func(ctx context.Context) (*entity.SyncResult, error) {
+		result, err := a.networkHostSetupUC.SyncByNetworkIDWithResult(ctx, networkID)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Applied {
+			result.RouteConflicts = a.networkRouteConflicts(ctx, networkID)
+		}
+
+		return result, nil
+	})
+}
+
+// PreviewNetworkRoutes returns the routes a sync would apply for a network, exclusions already subtracted,
+// each labelled as going through the tunnel or directly through the physical gateway.
+func (a *App) PreviewNetworkRoutes(networkID uint64) ([]*entity.RoutePreview, error) {
+	return a.networkHostSetupUC.PreviewByNetworkID(a.ctx, networkID)
+}
+
+// ResetNetworkHostSetup resets additional routes for a network. The reset runs as a cancellable operation.
 func (a *App) ResetNetworkHostSetup(networkID uint64) error {
-	return a.networkHostSetupUC.ResetByNetworkID(a.ctx, networkID)
+	_, err := runOperation(a, entity.OperationKindReset, networkID, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.networkHostSetupUC.ResetByNetworkID(ctx, networkID)
+	})
+
+	return err
+}
+
+// ExportNetworkRouteScripts resolves a network's routes and saves apply and teardown
+// shell scripts into a directory chosen with a native dialog.
+// It returns the directory path, or an empty string if the dialog was cancelled.
+func (a *App) ExportNetworkRouteScripts(networkID uint64) (string, error) {
+	scripts, err := a.networkHostSetupUC.ExportScriptsByNetworkID(a.ctx, networkID)
+	if err != nil {
+		return "", fmt.Errorf("failed to export network route scripts: %w", err)
+	}
+
+	baseFilename := scripts.Apply.NetworkName + "_routes"
+
+	return a.saveFilesWithDirectoryDialog("Save Route Scripts", map[string]string{
+		baseFilename + "_apply" + shellExtension:    scripts.Apply.String(),
+		baseFilename + "_teardown" + shellExtension: scripts.Teardown.String(),
+	})
 }
 
 // ExportNetworkHosts exports network hosts to JSON without network ID (for context-specific export).
@@ -57,11 +159,33 @@ func (a *App) ExportNetworkHosts(networkID uint64) (string, error) {
 }
 
 // ImportNetworkHosts imports network hosts from JSON (supports both old and new formats).
+// The import runs as a cancellable operation.
 func (a *App) ImportNetworkHosts(networkID uint64, jsonData string) error {
-	err := a.networkHostUC.ImportByNetworkIDFromJSON(a.ctx, networkID, jsonData)
+	_, err := runOperation(a, entity.OperationKindImport, networkID, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.networkHostUC.ImportByNetworkIDFromJSON(ctx, networkID, jsonData)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to import network hosts: %w", err)
 	}
 
 	return nil
 }
+
+// ExportNetworkHostsPAC generates a proxy auto-config file for a network and saves it with a native dialog.
+// It returns the saved file path, or an empty string if the dialog was cancelled.
+func (a *App) ExportNetworkHostsPAC(networkID uint64, proxyTarget string) (string, error) {
+	proxy, err := entity.NewPACProxy(proxyTarget)
+	if err != nil {
+		return "", err
+	}
+
+	pacFile, err := a.networkHostUC.ExportPACByNetworkID(a.ctx, networkID, proxy)
+	if err != nil {
+		return "", fmt.Errorf("failed to export network hosts PAC: %w", err)
+	}
+
+	return a.saveFileWithDialog(pacFile.NetworkName, pacFile.String(), exportFileType{
+		extension:   pacExtension,
+		displayName: "Proxy Auto-Config Files (*.pac)",
+	})
+}
@@ -85,11 +85,15 @@ func TestApp_AddNetworkHost_Success(t *testing.T) {
 					}
 					return tt.expected, nil
 				})
+			app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+				CheckAddress(gomock.Any(), tt.networkID, tt.address).
+				Return([]*entity.RouteConflict{}, nil)
 
-			result, err := app.AddNetworkHost(tt.networkID, tt.address, tt.description)
+			result, err := app.AddNetworkHost(tt.networkID, tt.address, tt.description, "")
 
 			require.NoError(t, err)
-			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expected, result.NetworkHost)
+			assert.Empty(t, result.RouteConflicts)
 		})
 	}
 }
@@ -133,7 +137,7 @@ func TestApp_AddNetworkHost_ValidationError(t *testing.T) {
 			app := createTestApp(ctrl)
 			app.OnStartup(context.Background())
 
-			result, err := app.AddNetworkHost(tt.networkID, tt.address, tt.description)
+			result, err := app.AddNetworkHost(tt.networkID, tt.address, tt.description, "")
 
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tt.expectedErr)
@@ -142,6 +146,130 @@ func TestApp_AddNetworkHost_ValidationError(t *testing.T) {
 	}
 }
 
+func TestApp_AddNetworkHost_WithExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error) {
+			return networkHost, nil
+		})
+	app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+		CheckAddress(gomock.Any(), uint64(1), "vendor.example.com").
+		Return([]*entity.RouteConflict{}, nil)
+
+	before := time.Now()
+	result, err := app.AddNetworkHost(1, "vendor.example.com", "Demo", "4h")
+
+	require.NoError(t, err)
+	require.NotNil(t, result.NetworkHost.ExpiresAt)
+	assert.WithinDuration(t, before.Add(4*time.Hour), result.NetworkHost.ExpiresAt.Time, time.Minute)
+}
+
+func TestApp_AddNetworkHost_RouteConflicts(t *testing.T) {
+	conflict := &entity.RouteConflict{
+		Kind:          entity.RouteConflictKindLocalSubnet,
+		NetworkID:     1,
+		NetworkName:   "Corp",
+		Route:         "192.168.1.0/24",
+		ConflictsWith: "192.168.1.0/24",
+	}
+
+	tests := []struct {
+		name              string
+		conflicts         []*entity.RouteConflict
+		checkErr          error
+		expectedConflicts []*entity.RouteConflict
+	}{
+		{
+			name:              "conflicts are returned with the added host",
+			conflicts:         []*entity.RouteConflict{conflict},
+			expectedConflicts: []*entity.RouteConflict{conflict},
+		},
+		{
+			name:     "failed check doesn't fail the add",
+			checkErr: errors.New("no default route"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := createTestApp(ctrl)
+			app.OnStartup(context.Background())
+
+			app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+				Add(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error) {
+					return networkHost, nil
+				})
+			app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+				CheckAddress(gomock.Any(), uint64(1), "192.168.1.0/24").
+				Return(tt.conflicts, tt.checkErr)
+
+			result, err := app.AddNetworkHost(1, "192.168.1.0/24", "", "")
+
+			require.NoError(t, err)
+			assert.Equal(t, "192.168.1.0/24", result.NetworkHost.Address)
+			assert.Equal(t, tt.expectedConflicts, result.RouteConflicts)
+		})
+	}
+}
+
+func TestApp_AddNetworkHost_InvalidExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	result, err := app.AddNetworkHost(1, "vendor.example.com", "Demo", "soon")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid expiry duration")
+	assert.Nil(t, result)
+}
+
+func TestApp_AddNetworkExclusion_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error) {
+			return networkHost, nil
+		})
+
+	result, err := app.AddNetworkExclusion(1, "10.99.0.0/16", "Office LAN")
+
+	require.NoError(t, err)
+	assert.Equal(t, "10.99.0.0/16", result.Address)
+	assert.True(t, result.IsExclusion())
+}
+
+func TestApp_AddNetworkExclusion_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	result, err := app.AddNetworkExclusion(1, "10.99.0.0/99", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid address")
+	assert.Nil(t, result)
+}
+
 func TestApp_AddNetworkHost_UseCaseError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -154,7 +282,7 @@ func TestApp_AddNetworkHost_UseCaseError(t *testing.T) {
 		Add(gomock.Any(), gomock.Any()).
 		Return(nil, expectedError)
 
-	result, err := app.AddNetworkHost(1, "192.168.1.1", "Test host")
+	result, err := app.AddNetworkHost(1, "192.168.1.1", "Test host", "")
 
 	require.Error(t, err)
 	assert.Equal(t, expectedError, err)
@@ -291,6 +419,132 @@ func TestApp_DeleteNetworkHost_Error(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 }
 
+func TestApp_SetNetworkHostEnabled_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	hostID := uint64(123)
+	expectedHost := &entity.NetworkHost{ID: hostID, NetworkID: 1, Address: "example.com", Enabled: false}
+	app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+		SetEnabled(gomock.Any(), hostID, false).
+		Return(expectedHost, nil)
+
+	result, err := app.SetNetworkHostEnabled(hostID, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedHost, result)
+}
+
+func TestApp_SetNetworkHostPinnedIPs(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		expectedHost := &entity.NetworkHost{
+			ID:        123,
+			NetworkID: 1,
+			Address:   "git.corp.example",
+			PinnedIPs: entity.IPList{"10.0.0.5"},
+			PinMode:   entity.NetworkHostPinModeAppend,
+		}
+		app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+			SetPinnedIPs(gomock.Any(), uint64(123), []string{"10.0.0.5"}, entity.NetworkHostPinModeAppend).
+			Return(expectedHost, nil)
+
+		result, err := app.SetNetworkHostPinnedIPs(123, []string{"10.0.0.5"}, "append")
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedHost, result)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		result, err := app.SetNetworkHostPinnedIPs(123, []string{"10.0.0.5"}, "prepend")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid pin mode")
+		assert.Nil(t, result)
+	})
+}
+
+func TestApp_SetNetworkHostRouteOverride(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		expectedHost := &entity.NetworkHost{
+			ID:               123,
+			NetworkID:        1,
+			Address:          "git.corp.example",
+			Router:           "192.168.50.1",
+			NetworkInterface: "en7",
+		}
+		app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+			SetRouteOverride(
+				gomock.Any(),
+				uint64(123),
+				entity.RouteOverride{Router: "192.168.50.1", NetworkInterface: "en7"},
+			).
+			Return(expectedHost, nil)
+
+		result, err := app.SetNetworkHostRouteOverride(123, "192.168.50.1", "", "en7")
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedHost, result)
+	})
+
+	t.Run("invalid router", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		result, err := app.SetNetworkHostRouteOverride(123, "gateway.local", "", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid router")
+		assert.Nil(t, result)
+	})
+}
+
+func TestApp_PreviewNetworkRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expectedPreviews := entity.NewRoutePreviews(
+		[]*entity.NetworkHostSetup{
+			{NetworkHostID: 1, NetworkHostIP: "10.20.0.0", SubnetMask: "255.255.128.0", Router: "192.168.1.1"},
+		},
+		nil,
+	)
+	app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
+		PreviewByNetworkID(gomock.Any(), uint64(1)).
+		Return(expectedPreviews, nil)
+
+	result, err := app.PreviewNetworkRoutes(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedPreviews, result)
+}
+
 func TestApp_SyncNetworkHostSetup_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -299,13 +553,33 @@ func TestApp_SyncNetworkHostSetup_Success(t *testing.T) {
 	app.OnStartup(context.Background())
 
 	networkID := uint64(456)
+	expectedResult := &entity.SyncResult{
+		NetworkID:  networkID,
+		Applied:    true,
+		RouteCount: 2,
+		StaleResolutions: []*entity.StaleResolution{
+			{Address: "git.corp.example", IPs: []string{"10.0.0.5"}, Error: "lookup failed"},
+		},
+	}
 	app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
-		SyncByNetworkID(gomock.Any(), networkID).
-		Return(nil)
-
-	err := app.SyncNetworkHostSetup(networkID)
+		SyncByNetworkIDWithResult(gomock.Any(), networkID).
+		Return(expectedResult, nil)
+	conflicts := []*entity.RouteConflict{{
+		Kind:          entity.RouteConflictKindVPNServer,
+		NetworkID:     networkID,
+		NetworkName:   "Corp",
+		Route:         "10.0.0.0/8",
+		ConflictsWith: "10.1.1.1",
+	}}
+	app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+		AnalyzeByNetworkID(gomock.Any(), networkID).
+		Return(conflicts, nil)
+
+	result, err := app.SyncNetworkHostSetup(networkID)
 
 	require.NoError(t, err)
+	assert.Equal(t, expectedResult, result)
+	assert.Equal(t, conflicts, result.RouteConflicts)
 }
 
 func TestApp_SyncNetworkHostSetup_Error(t *testing.T) {
@@ -318,13 +592,14 @@ func TestApp_SyncNetworkHostSetup_Error(t *testing.T) {
 	networkID := uint64(456)
 	expectedError := errors.New("sync failed")
 	app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
-		SyncByNetworkID(gomock.Any(), networkID).
-		Return(expectedError)
+		SyncByNetworkIDWithResult(gomock.Any(), networkID).
+		Return(nil, expectedError)
 
-	err := app.SyncNetworkHostSetup(networkID)
+	result, err := app.SyncNetworkHostSetup(networkID)
 
 	require.Error(t, err)
 	assert.Equal(t, expectedError, err)
+	assert.Nil(t, result)
 }
 
 func TestApp_ResetNetworkHostSetup_Success(t *testing.T) {
@@ -453,6 +728,58 @@ func TestApp_ExportNetworkHosts_UseCaseError(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestApp_ExportNetworkHostsPAC_InvalidProxy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	result, err := app.ExportNetworkHostsPAC(1, "proxy.example.com")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid proxy address")
+	assert.Empty(t, result)
+}
+
+func TestApp_ExportNetworkHostsPAC_UseCaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	networkID := uint64(1)
+	app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+		ExportPACByNetworkID(gomock.Any(), networkID, entity.PACProxy("PROXY proxy.example.com:3128")).
+		Return(nil, errors.New("network not found"))
+
+	result, err := app.ExportNetworkHostsPAC(networkID, "proxy.example.com:3128")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to export network hosts PAC")
+	assert.Empty(t, result)
+}
+
+func TestApp_ExportNetworkRouteScripts_UseCaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	networkID := uint64(1)
+	app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
+		ExportScriptsByNetworkID(gomock.Any(), networkID).
+		Return(nil, errors.New("failed to get current network info"))
+
+	result, err := app.ExportNetworkRouteScripts(networkID)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to export network route scripts")
+	assert.Empty(t, result)
+}
+
 func TestApp_ImportNetworkHosts_Success(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -529,13 +856,21 @@ func TestApp_NetworkHostMethods_ContextUsage(t *testing.T) {
 	app := createTestApp(ctrl)
 	ctx := context.WithValue(context.Background(), contextKey("test"), "value")
 	app.OnStartup(ctx)
+	// Operations run with a context derived from the app context.
+	operationCtx := gomock.Cond(func(x any) bool {
+		operationCtx, ok := x.(context.Context)
+		return ok && operationCtx.Value(contextKey("test")) == "value"
+	})
 
 	t.Run("AddNetworkHost uses context", func(t *testing.T) {
 		app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
 			Add(ctx, gomock.Any()).
-			Return(&entity.NetworkHost{}, nil)
+			Return(&entity.NetworkHost{NetworkID: 1, Address: "192.168.1.1"}, nil)
+		app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+			CheckAddress(ctx, uint64(1), "192.168.1.1").
+			Return([]*entity.RouteConflict{}, nil)
 
-		_, err := app.AddNetworkHost(1, "192.168.1.1", "test")
+		_, err := app.AddNetworkHost(1, "192.168.1.1", "test", "")
 		require.NoError(t, err)
 	})
 
@@ -559,16 +894,16 @@ func TestApp_NetworkHostMethods_ContextUsage(t *testing.T) {
 
 	t.Run("SyncNetworkHostSetup uses context", func(t *testing.T) {
 		app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
-			SyncByNetworkID(ctx, uint64(1)).
-			Return(nil)
+			SyncByNetworkIDWithResult(operationCtx, uint64(1)).
+			Return(&entity.SyncResult{NetworkID: 1}, nil)
 
-		err := app.SyncNetworkHostSetup(1)
+		_, err := app.SyncNetworkHostSetup(1)
 		require.NoError(t, err)
 	})
 
 	t.Run("ResetNetworkHostSetup uses context", func(t *testing.T) {
 		app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
-			ResetByNetworkID(ctx, uint64(1)).
+			ResetByNetworkID(operationCtx, uint64(1)).
 			Return(nil)
 
 		err := app.ResetNetworkHostSetup(1)
@@ -589,7 +924,7 @@ func TestApp_NetworkHostMethods_ContextUsage(t *testing.T) {
 
 	t.Run("ImportNetworkHosts uses context", func(t *testing.T) {
 		app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
-			ImportByNetworkIDFromJSON(ctx, uint64(1), gomock.Any()).
+			ImportByNetworkIDFromJSON(operationCtx, uint64(1), gomock.Any()).
 			Return(nil)
 
 		err := app.ImportNetworkHosts(1, `{"hosts": []}`)
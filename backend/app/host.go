@@ -19,7 +19,33 @@ func (a *App) ListHosts(search string) ([]*entity.Host, error) {
 	return a.hostUC.List(a.ctx, &entity.ListHostFilter{Search: search})
 }
 
+// ListHostsWithUsage returns all hosts together with the networks they are used in.
+func (a *App) ListHostsWithUsage(search string) ([]*entity.HostWithUsage, error) {
+	return a.hostUC.ListWithUsage(a.ctx, &entity.ListHostFilter{Search: search})
+}
+
+// UpdateHost changes a host, networks it is attached to pick up the change.
+func (a *App) UpdateHost(id uint64, address, description string) (*entity.Host, error) {
+	host, err := entity.NewHost(address, description)
+	if err != nil {
+		return nil, err
+	}
+
+	host.ID = id
+	return a.hostUC.Update(a.ctx, host)
+}
+
 // DeleteHost deletes a host by ID.
 func (a *App) DeleteHost(id uint64) error {
 	return a.hostUC.Delete(a.ctx, id)
 }
+
+// AttachHostToNetwork routes a library host through a network.
+func (a *App) AttachHostToNetwork(hostID, networkID uint64) (*entity.NetworkHost, error) {
+	return a.hostUC.AttachToNetwork(a.ctx, hostID, networkID)
+}
+
+// DetachHostFromNetwork stops routing a library host through a network.
+func (a *App) DetachHostFromNetwork(hostID, networkID uint64) error {
+	return a.hostUC.DetachFromNetwork(a.ctx, hostID, networkID)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// AddHostGroup adds a new host group.
+func (a *App) AddHostGroup(name, description string) (*entity.HostGroup, error) {
+	hostGroup, err := entity.NewHostGroup(name, description)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.hostGroupUC.Add(a.ctx, hostGroup)
+}
+
+// ListHostGroups returns all host groups.
+func (a *App) ListHostGroups(search string) ([]*entity.HostGroup, error) {
+	return a.hostGroupUC.List(a.ctx, &entity.ListHostGroupFilter{Search: search})
+}
+
+// ListNetworkHostGroups returns the host groups attached to a network.
+func (a *App) ListNetworkHostGroups(networkID uint64) ([]*entity.HostGroup, error) {
+	return a.hostGroupUC.List(a.ctx, &entity.ListHostGroupFilter{NetworkID: []uint64{networkID}})
+}
+
+// DeleteHostGroup deletes a host group, networks it was attached to stop routing its addresses.
+func (a *App) DeleteHostGroup(id uint64) error {
+	return a.hostGroupUC.Delete(a.ctx, id)
+}
+
+// AddHostGroupHost adds an address to a host group.
+func (a *App) AddHostGroupHost(hostGroupID uint64, address, description string) (*entity.HostGroupHost, error) {
+	hostGroupHost, err := entity.NewHostGroupHost(hostGroupID, address, description)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.hostGroupUC.AddHost(a.ctx, hostGroupHost)
+}
+
+// ListHostGroupHosts returns the addresses of a host group.
+func (a *App) ListHostGroupHosts(hostGroupID uint64) ([]*entity.HostGroupHost, error) {
+	return a.hostGroupUC.ListHosts(a.ctx, hostGroupID)
+}
+
+// DeleteHostGroupHost removes an address from its host group.
+func (a *App) DeleteHostGroupHost(id uint64) error {
+	return a.hostGroupUC.DeleteHost(a.ctx, id)
+}
+
+// AttachHostGroupToNetwork routes a host group through a network.
+func (a *App) AttachHostGroupToNetwork(hostGroupID, networkID uint64) error {
+	return a.hostGroupUC.AttachToNetwork(a.ctx, hostGroupID, networkID)
+}
+
+// DetachHostGroupFromNetwork stops routing a host group through a network.
+func (a *App) DetachHostGroupFromNetwork(hostGroupID, networkID uint64) error {
+	return a.hostGroupUC.DetachFromNetwork(a.ctx, hostGroupID, networkID)
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+)
+
+func TestApp_AddHostGroup(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+			Add(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, hostGroup *entity.HostGroup) (*entity.HostGroup, error) {
+				assert.Equal(t, "GitHub Enterprise", hostGroup.Name)
+				require.NotNil(t, hostGroup.Description)
+				assert.Equal(t, "Code hosting", *hostGroup.Description)
+				hostGroup.ID = 1
+				return hostGroup, nil
+			})
+
+		result, err := app.AddHostGroup("GitHub Enterprise", "Code hosting")
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), result.ID)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		result, err := app.AddHostGroup(" ", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "host group name is required")
+		assert.Nil(t, result)
+	})
+}
+
+func TestApp_ListHostGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	hostGroups := []*entity.HostGroup{{ID: 1, Name: "Build farm"}}
+	app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+		List(gomock.Any(), &entity.ListHostGroupFilter{Search: "build"}).
+		Return(hostGroups, nil)
+
+	result, err := app.ListHostGroups("build")
+
+	require.NoError(t, err)
+	assert.Equal(t, hostGroups, result)
+}
+
+func TestApp_ListNetworkHostGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	hostGroups := []*entity.HostGroup{{ID: 1, Name: "Build farm"}}
+	app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+		List(gomock.Any(), &entity.ListHostGroupFilter{NetworkID: []uint64{2}}).
+		Return(hostGroups, nil)
+
+	result, err := app.ListNetworkHostGroups(2)
+
+	require.NoError(t, err)
+	assert.Equal(t, hostGroups, result)
+}
+
+func TestApp_DeleteHostGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+		Delete(gomock.Any(), uint64(1)).
+		Return(nil)
+
+	err := app.DeleteHostGroup(1)
+
+	require.NoError(t, err)
+}
+
+func TestApp_AddHostGroupHost(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+			AddHost(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, hostGroupHost *entity.HostGroupHost) (*entity.HostGroupHost, error) {
+				assert.Equal(t, uint64(1), hostGroupHost.HostGroupID)
+				assert.Equal(t, "ci.example.com", hostGroupHost.Address)
+				hostGroupHost.ID = 10
+				return hostGroupHost, nil
+			})
+
+		result, err := app.AddHostGroupHost(1, "ci.example.com", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), result.ID)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		result, err := app.AddHostGroupHost(1, "not a host", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid address")
+		assert.Nil(t, result)
+	})
+}
+
+func TestApp_ListHostGroupHosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	hosts := []*entity.HostGroupHost{{ID: 10, HostGroupID: 1, Address: "ci.example.com"}}
+	app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+		ListHosts(gomock.Any(), uint64(1)).
+		Return(hosts, nil)
+
+	result, err := app.ListHostGroupHosts(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, hosts, result)
+}
+
+func TestApp_DeleteHostGroupHost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+		DeleteHost(gomock.Any(), uint64(10)).
+		Return(nil)
+
+	err := app.DeleteHostGroupHost(10)
+
+	require.NoError(t, err)
+}
+
+func TestApp_AttachHostGroupToNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+		AttachToNetwork(gomock.Any(), uint64(1), uint64(2)).
+		Return(nil)
+
+	err := app.AttachHostGroupToNetwork(1, 2)
+
+	require.NoError(t, err)
+}
+
+func TestApp_DetachHostGroupFromNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expectedError := errors.New("sync failed")
+	app.hostGroupUC.(*mock_usecase.MockHostGroup).EXPECT().
+		DetachFromNetwork(gomock.Any(), uint64(1), uint64(2)).
+		Return(expectedError)
+
+	err := app.DetachHostGroupFromNetwork(1, 2)
+
+	require.ErrorIs(t, err, expectedError)
+}
@@ -460,6 +460,103 @@ func TestApp_DeleteHost_Error(t *testing.T) {
 	}
 }
 
+func TestApp_ListHostsWithUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expected := []*entity.HostWithUsage{
+		{
+			Host:   entity.Host{ID: 1, Address: "git.example.com"},
+			UsedIn: []*entity.Network{{ID: 100, Name: "Corp VPN"}},
+		},
+	}
+	app.hostUC.(*mock_usecase.MockHost).EXPECT().
+		ListWithUsage(gomock.Any(), &entity.ListHostFilter{Search: "git"}).
+		Return(expected, nil)
+
+	result, err := app.ListHostsWithUsage("git")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestApp_UpdateHost(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		app.hostUC.(*mock_usecase.MockHost).EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, host *entity.Host) (*entity.Host, error) {
+				assert.Equal(t, uint64(7), host.ID)
+				assert.Equal(t, "git.example.com", host.Address)
+				require.NotNil(t, host.Description)
+				assert.Equal(t, "Git server", *host.Description)
+				return host, nil
+			})
+
+		result, err := app.UpdateHost(7, "git.example.com", "Git server")
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), result.ID)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		result, err := app.UpdateHost(7, "", "Git server")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestApp_AttachHostToNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	hostID := uint64(1)
+	expected := &entity.NetworkHost{ID: 10, NetworkID: 100, Address: "git.example.com", HostID: &hostID}
+	app.hostUC.(*mock_usecase.MockHost).EXPECT().
+		AttachToNetwork(gomock.Any(), hostID, uint64(100)).
+		Return(expected, nil)
+
+	result, err := app.AttachHostToNetwork(hostID, 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestApp_DetachHostFromNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.hostUC.(*mock_usecase.MockHost).EXPECT().
+		DetachFromNetwork(gomock.Any(), uint64(1), uint64(100)).
+		Return(errors.New("sync failed"))
+
+	err := app.DetachHostFromNetwork(1, 100)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sync failed")
+}
+
 func TestApp_HostMethods_ContextUsage(t *testing.T) {
 	// Test that all methods properly use the app context
 	ctrl := gomock.NewController(t)
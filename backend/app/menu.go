@@ -7,6 +7,8 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/menu/keys"
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
 )
 
 // CreateMenu creates the macOS application menu.
@@ -50,9 +52,9 @@ func (a *App) about() {
 	}
 }
 
-// checkForUpdates checks for updates and shows appropriate dialog.
+// checkForUpdates checks for updates and shows appropriate dialog. The check runs as a cancellable operation.
 func (a *App) checkForUpdates() {
-	updateInfo, err := a.updateUC.CheckForUpdates()
+	updateInfo, err := runOperation(a, entity.OperationKindUpdateCheck, 0, a.updateUC.CheckForUpdates)
 	if err != nil {
 		slog.Error("failed to check for updates", "error", err)
 		_, dialogErr := wailsRuntime.MessageDialog(a.ctx, wailsRuntime.MessageDialogOptions{
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/dmitrorlov/splitr/backend/config"
 	"github.com/dmitrorlov/splitr/backend/entity"
 	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
 	"github.com/dmitrorlov/splitr/backend/pkg/database"
@@ -124,7 +126,7 @@ func TestApp_CheckForUpdates_UpdateAvailable(t *testing.T) {
 			app.OnStartup(context.Background())
 
 			app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-				CheckForUpdates().
+				CheckForUpdates(gomock.Any()).
 				Return(tt.updateInfo, nil)
 
 			// We can't easily test the actual dialog behavior without mocking
@@ -168,7 +170,7 @@ func TestApp_CheckForUpdates_NoUpdateAvailable(t *testing.T) {
 			app.OnStartup(context.Background())
 
 			app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-				CheckForUpdates().
+				CheckForUpdates(gomock.Any()).
 				Return(tt.updateInfo, nil)
 
 			app.checkForUpdates()
@@ -219,7 +221,7 @@ func TestApp_CheckForUpdates_Error(t *testing.T) {
 			app.OnStartup(context.Background())
 
 			app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-				CheckForUpdates().
+				CheckForUpdates(gomock.Any()).
 				Return(nil, tt.error)
 
 			// Should not panic when update check fails
@@ -350,7 +352,7 @@ func TestApp_Menu_ContextUsage(t *testing.T) {
 	t.Run("check for updates uses correct context", func(t *testing.T) {
 		t.Skip("Skip - dialog calls not testable")
 		app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-			CheckForUpdates().
+			CheckForUpdates(gomock.Any()).
 			Return(&entity.UpdateInfo{Available: false}, nil)
 
 		app.checkForUpdates()
@@ -433,7 +435,7 @@ func TestApp_UpdateInfo_DialogContent(t *testing.T) {
 			app.OnStartup(context.Background())
 
 			app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-				CheckForUpdates().
+				CheckForUpdates(gomock.Any()).
 				Return(tt.updateInfo, nil)
 
 			// Test that the method handles different types of update information
@@ -475,7 +477,7 @@ func TestApp_Menu_EdgeCases(t *testing.T) {
 		// Don't call OnStartup to leave context as nil
 
 		app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-			CheckForUpdates().
+			CheckForUpdates(gomock.Any()).
 			Return(&entity.UpdateInfo{Available: false}, nil)
 
 		app.checkForUpdates()
@@ -492,7 +494,7 @@ func TestApp_Menu_ConcurrentDialogs(t *testing.T) {
 	app.OnStartup(context.Background())
 
 	app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-		CheckForUpdates().
+		CheckForUpdates(gomock.Any()).
 		Return(&entity.UpdateInfo{Available: false}, nil).
 		AnyTimes()
 
@@ -569,7 +571,7 @@ func TestApp_UpdateInfo_ErrorScenarios(t *testing.T) {
 			app.OnStartup(context.Background())
 
 			app.updateUC.(*mock_usecase.MockUpdate).EXPECT().
-				CheckForUpdates().
+				CheckForUpdates(gomock.Any()).
 				Return(tt.updateInfo, tt.error)
 
 			// Should handle malformed data gracefully without panicking
@@ -584,12 +586,16 @@ func createTestAppWithInfo(ctrl *gomock.Controller, appName, appVersion, authorN
 	mockLogger := &logging.WailsAdapter{}
 	mockCommandUC := mock_usecase.NewMockCommandExecutor(ctrl)
 	mockHostUC := mock_usecase.NewMockHost(ctrl)
+	mockHostGroupUC := mock_usecase.NewMockHostGroup(ctrl)
 	mockNetworkUC := mock_usecase.NewMockNetwork(ctrl)
 	mockNetworkHostUC := mock_usecase.NewMockNetworkHost(ctrl)
+	mockNetworkHostImportUC := mock_usecase.NewMockNetworkHostImport(ctrl)
+	mockNetworkSubscriptionUC := mock_usecase.NewMockNetworkSubscription(ctrl)
 	mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+	mockRouteConflictUC := mock_usecase.NewMockRouteConflict(ctrl)
 	mockUpdateUC := mock_usecase.NewMockUpdate(ctrl)
 
-	return New(
+	app := New(
 		appName,
 		appVersion,
 		authorName,
@@ -598,9 +604,19 @@ func createTestAppWithInfo(ctrl *gomock.Controller, appName, appVersion, authorN
 		mockLogger,
 		mockCommandUC,
 		mockHostUC,
+		mockHostGroupUC,
 		mockNetworkUC,
 		mockNetworkHostUC,
+		mockNetworkHostImportUC,
+		mockNetworkSubscriptionUC,
 		mockNetworkHostSetupUC,
+		mockRouteConflictUC,
 		mockUpdateUC,
+		nil,
+		&config.Operation{Timeout: time.Minute},
 	)
+	// Events need the context Wails starts the app with.
+	app.emitEvent = func(context.Context, string, ...any) {}
+
+	return app
 }
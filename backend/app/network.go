@@ -29,6 +29,37 @@ func (a *App) DeleteNetwork(id uint64) error {
 	return a.networkUC.Delete(a.ctx, id)
 }
 
+// SetNetworkRoutingMode switches a network between split mode, where only its hosts go through the VPN,
+// and inverse mode, where everything but its hosts does.
+func (a *App) SetNetworkRoutingMode(id uint64, mode string) (*entity.Network, error) {
+	routingMode, err := entity.ParseRoutingMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.networkUC.SetRoutingMode(a.ctx, id, routingMode)
+}
+
+// SetNetworkDNS sets the domains resolved through the network's nameservers while it is the active VPN.
+// Empty domains and nameservers stop scoping DNS for the network.
+func (a *App) SetNetworkDNS(id uint64, domains, nameservers []string) (*entity.Network, error) {
+	return a.networkUC.SetDNS(a.ctx, id, domains, nameservers)
+}
+
+// SetNetworkRouteOverride sets the router, subnet mask or interface sync uses for a network instead of
+// detecting them from the default network service. Blank values are detected, all blank clears the override.
+func (a *App) SetNetworkRouteOverride(
+	id uint64,
+	router, subnetMask, networkInterface string,
+) (*entity.Network, error) {
+	override, err := entity.NewRouteOverride(router, subnetMask, networkInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.networkUC.SetRouteOverride(a.ctx, id, override)
+}
+
 // ListVPNServices returns available VPN services.
 func (a *App) ListVPNServices() ([]entity.VPNService, error) {
 	return a.networkUC.ListVPNServices(a.ctx)
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// PreviewSystemRoutesImport lists hosts that can be adopted from the routes already configured
+// on the network's service, optionally with reverse-resolved hostname suggestions.
+// The preview runs as a cancellable operation, reverse lookups can be slow.
+func (a *App) PreviewSystemRoutesImport(
+	networkID uint64,
+	reverseResolve bool,
+) (*entity.NetworkHostImportPreview, error) {
+	preview, err := runOperation(
+		a,
+		entity.OperationKindImport,
+		networkID,
+		func(ctx context.Context) (*entity.NetworkHostImportPreview, error) {
+			return a.networkHostImportUC.PreviewFromSystemRoutes(ctx, networkID, reverseResolve)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview system routes import: %w", err)
+	}
+
+	return preview, nil
+}
+
+// PreviewSSHConfigImport lists hosts that can be imported from ~/.ssh/config and, optionally, ~/.ssh/known_hosts.
+func (a *App) PreviewSSHConfigImport(
+	networkID uint64,
+	includeKnownHosts bool,
+) (*entity.NetworkHostImportPreview, error) {
+	preview, err := a.networkHostImportUC.PreviewFromSSHConfig(a.ctx, networkID, includeKnownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview ssh config import: %w", err)
+	}
+
+	return preview, nil
+}
+
+// ImportNetworkHostCandidates saves hosts selected from an import preview. The import runs as a cancellable operation.
+func (a *App) ImportNetworkHostCandidates(networkID uint64, hosts []entity.NetworkHostDTO) error {
+	_, err := runOperation(a, entity.OperationKindImport, networkID, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.networkHostUC.ImportByNetworkID(ctx, networkID, hosts)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import network hosts: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+)
+
+func TestApp_PreviewSystemRoutesImport_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	preview := &entity.NetworkHostImportPreview{
+		NetworkID: 1,
+		Candidates: []entity.NetworkHostImportCandidate{
+			{Address: "10.1.2.3", SuggestedHostnames: []string{"git.corp.example"}},
+			{Address: "10.20.0.0/16", AlreadyExists: true},
+		},
+	}
+	app.networkHostImportUC.(*mock_usecase.MockNetworkHostImport).EXPECT().
+		PreviewFromSystemRoutes(gomock.Any(), uint64(1), true).
+		Return(preview, nil)
+
+	result, err := app.PreviewSystemRoutesImport(1, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, preview, result)
+}
+
+func TestApp_PreviewSystemRoutesImport_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkHostImportUC.(*mock_usecase.MockNetworkHostImport).EXPECT().
+		PreviewFromSystemRoutes(gomock.Any(), uint64(1), false).
+		Return(nil, errors.New("networksetup failed"))
+
+	result, err := app.PreviewSystemRoutesImport(1, false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to preview system routes import: networksetup failed")
+	assert.Nil(t, result)
+}
+
+func TestApp_ImportNetworkHostCandidates_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	hosts := []entity.NetworkHostDTO{{Address: "10.1.2.3"}, {Address: "10.20.0.0/16"}}
+	app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+		ImportByNetworkID(gomock.Any(), uint64(1), hosts).
+		Return(nil)
+
+	err := app.ImportNetworkHostCandidates(1, hosts)
+
+	require.NoError(t, err)
+}
+
+func TestApp_ImportNetworkHostCandidates_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkHostUC.(*mock_usecase.MockNetworkHost).EXPECT().
+		ImportByNetworkID(gomock.Any(), uint64(1), gomock.Any()).
+		Return(errors.New("database error"))
+
+	err := app.ImportNetworkHostCandidates(1, []entity.NetworkHostDTO{{Address: "10.1.2.3"}})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to import network hosts: database error")
+}
+
+func TestApp_PreviewSSHConfigImport_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	preview := &entity.NetworkHostImportPreview{
+		NetworkID:  1,
+		Candidates: []entity.NetworkHostImportCandidate{{Address: "gitlab.corp.example", Description: "gitlab"}},
+		Skipped:    []entity.NetworkHostImportSkipped{{Value: "*.corp.example", Reason: "wildcard pattern"}},
+	}
+	app.networkHostImportUC.(*mock_usecase.MockNetworkHostImport).EXPECT().
+		PreviewFromSSHConfig(gomock.Any(), uint64(1), true).
+		Return(preview, nil)
+
+	result, err := app.PreviewSSHConfigImport(1, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, preview, result)
+}
+
+func TestApp_PreviewSSHConfigImport_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkHostImportUC.(*mock_usecase.MockNetworkHostImport).EXPECT().
+		PreviewFromSSHConfig(gomock.Any(), uint64(1), false).
+		Return(nil, errors.New("permission denied"))
+
+	result, err := app.PreviewSSHConfigImport(1, false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to preview ssh config import: permission denied")
+	assert.Nil(t, result)
+}
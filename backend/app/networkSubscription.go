@@ -0,0 +1,25 @@
+package app
+
+import (
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// AddNetworkSubscription subscribes a network to a remote host list and fetches it.
+func (a *App) AddNetworkSubscription(networkID uint64, url string) (*entity.NetworkSubscription, error) {
+	return a.networkSubscriptionUC.Add(a.ctx, networkID, url)
+}
+
+// ListNetworkSubscriptions returns the host list subscriptions of a network.
+func (a *App) ListNetworkSubscriptions(networkID uint64) ([]*entity.NetworkSubscription, error) {
+	return a.networkSubscriptionUC.List(a.ctx, networkID)
+}
+
+// DeleteNetworkSubscription removes a subscription and the hosts it manages.
+func (a *App) DeleteNetworkSubscription(id uint64) error {
+	return a.networkSubscriptionUC.Delete(a.ctx, id)
+}
+
+// RefreshNetworkSubscription fetches a subscription's host list now instead of waiting for the schedule.
+func (a *App) RefreshNetworkSubscription(id uint64) error {
+	return a.networkSubscriptionUC.Refresh(a.ctx, id)
+}
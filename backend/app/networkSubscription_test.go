@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+)
+
+func TestApp_AddNetworkSubscription_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	subscription := &entity.NetworkSubscription{ID: 1, NetworkID: 2, URL: "https://it.corp.example/hosts.json"}
+	app.networkSubscriptionUC.(*mock_usecase.MockNetworkSubscription).EXPECT().
+		Add(gomock.Any(), uint64(2), "https://it.corp.example/hosts.json").
+		Return(subscription, nil)
+
+	result, err := app.AddNetworkSubscription(2, "https://it.corp.example/hosts.json")
+
+	require.NoError(t, err)
+	assert.Equal(t, subscription, result)
+}
+
+func TestApp_AddNetworkSubscription_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expectedError := errors.New("invalid subscription URL")
+	app.networkSubscriptionUC.(*mock_usecase.MockNetworkSubscription).EXPECT().
+		Add(gomock.Any(), uint64(2), "bad").
+		Return(nil, expectedError)
+
+	result, err := app.AddNetworkSubscription(2, "bad")
+
+	require.Error(t, err)
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, result)
+}
+
+func TestApp_ListNetworkSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	subscriptions := []*entity.NetworkSubscription{{ID: 1, NetworkID: 2, URL: "https://it.corp.example/hosts.json"}}
+	app.networkSubscriptionUC.(*mock_usecase.MockNetworkSubscription).EXPECT().
+		List(gomock.Any(), uint64(2)).
+		Return(subscriptions, nil)
+
+	result, err := app.ListNetworkSubscriptions(2)
+
+	require.NoError(t, err)
+	assert.Equal(t, subscriptions, result)
+}
+
+func TestApp_DeleteNetworkSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkSubscriptionUC.(*mock_usecase.MockNetworkSubscription).EXPECT().
+		Delete(gomock.Any(), uint64(1)).
+		Return(nil)
+
+	err := app.DeleteNetworkSubscription(1)
+
+	require.NoError(t, err)
+}
+
+func TestApp_RefreshNetworkSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expectedError := errors.New("unexpected status code 500")
+	app.networkSubscriptionUC.(*mock_usecase.MockNetworkSubscription).EXPECT().
+		Refresh(gomock.Any(), uint64(1)).
+		Return(expectedError)
+
+	err := app.RefreshNetworkSubscription(1)
+
+	require.Error(t, err)
+	assert.Equal(t, expectedError, err)
+}
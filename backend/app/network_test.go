@@ -429,6 +429,113 @@ func TestApp_DeleteNetwork_Error(t *testing.T) {
 	}
 }
 
+func TestApp_SetNetworkRoutingMode_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expectedNetwork := &entity.Network{ID: 123, Name: "TestNetwork", RoutingMode: entity.RoutingModeInverse}
+	app.networkUC.(*mock_usecase.MockNetwork).EXPECT().
+		SetRoutingMode(gomock.Any(), uint64(123), entity.RoutingModeInverse).
+		Return(expectedNetwork, nil)
+
+	result, err := app.SetNetworkRoutingMode(123, "inverse")
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedNetwork, result)
+}
+
+func TestApp_SetNetworkRoutingMode_InvalidMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	result, err := app.SetNetworkRoutingMode(123, "full")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid routing mode")
+	assert.Nil(t, result)
+}
+
+func TestApp_SetNetworkDNS_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expectedNetwork := &entity.Network{
+		ID:             123,
+		Name:           "TestNetwork",
+		DNSDomains:     entity.DomainList{"corp.example.com"},
+		DNSNameservers: entity.IPList{"10.8.0.1"},
+	}
+	app.networkUC.(*mock_usecase.MockNetwork).EXPECT().
+		SetDNS(gomock.Any(), uint64(123), []string{"corp.example.com"}, []string{"10.8.0.1"}).
+		Return(expectedNetwork, nil)
+
+	result, err := app.SetNetworkDNS(123, []string{"corp.example.com"}, []string{"10.8.0.1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedNetwork, result)
+}
+
+func TestApp_SetNetworkDNS_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkUC.(*mock_usecase.MockNetwork).EXPECT().
+		SetDNS(gomock.Any(), uint64(123), []string{"corp.example.com"}, []string(nil)).
+		Return(nil, errors.New("DNS domains and nameservers must be set together"))
+
+	result, err := app.SetNetworkDNS(123, []string{"corp.example.com"}, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DNS domains and nameservers must be set together")
+	assert.Nil(t, result)
+}
+
+func TestApp_SetNetworkRouteOverride_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	override := entity.RouteOverride{Router: "192.168.50.1", SubnetMask: "255.255.255.0", NetworkInterface: "en7"}
+	expectedNetwork := &entity.Network{ID: 123, Name: "TestNetwork"}
+	expectedNetwork.SetRouteOverride(override)
+	app.networkUC.(*mock_usecase.MockNetwork).EXPECT().
+		SetRouteOverride(gomock.Any(), uint64(123), override).
+		Return(expectedNetwork, nil)
+
+	result, err := app.SetNetworkRouteOverride(123, "192.168.50.1", "255.255.255.0", "en7")
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedNetwork, result)
+}
+
+func TestApp_SetNetworkRouteOverride_InvalidMask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	result, err := app.SetNetworkRouteOverride(123, "", "255.0.255.0", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid subnet mask")
+	assert.Nil(t, result)
+}
+
 func TestApp_ListVPNServices_Success(t *testing.T) {
 	tests := []struct {
 		name     string
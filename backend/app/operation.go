@@ -0,0 +1,147 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+// Events the frontend follows operations with. The started event carries the operation,
+// the finished one its ID.
+const (
+	operationStartedEvent  = "operation:started"
+	operationFinishedEvent = "operation:finished"
+)
+
+// operations keeps the long-running calls in progress so the UI can list and cancel them.
+type operations struct {
+	mu      sync.Mutex
+	nextID  uint64
+	running map[uint64]*runningOperation
+}
+
+type runningOperation struct {
+	operation *entity.Operation
+	cancel    context.CancelFunc
+}
+
+func newOperations() *operations {
+	return &operations{
+		running: make(map[uint64]*runningOperation),
+	}
+}
+
+func (o *operations) add(
+	kind entity.OperationKind,
+	networkID uint64,
+	timeout time.Duration,
+	cancel context.CancelFunc,
+) *entity.Operation {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.nextID++
+	operation := entity.NewOperation(o.nextID, kind, networkID, timeout)
+
+	o.running[operation.ID] = &runningOperation{
+		operation: operation,
+		cancel:    cancel,
+	}
+
+	return operation
+}
+
+func (o *operations) remove(id uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.running, id)
+}
+
+func (o *operations) cancel(id uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	running, ok := o.running[id]
+	if !ok {
+		return errs.ErrOperationNotFound
+	}
+
+	running.cancel()
+
+	return nil
+}
+
+func (o *operations) list() []*entity.Operation {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	list := make([]*entity.Operation, 0, len(o.running))
+	for _, running := range o.running {
+		list = append(list, running.operation)
+	}
+	slices.SortFunc(list, func(a, b *entity.Operation) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return list
+}
+
+// runOperation runs fn as a cancellable operation that times out after the configured operation timeout.
+// The frontend is told when it starts, with the ID CancelOperation takes, and when it finishes.
+// Errors of an operation that was cancelled or timed out wrap errs.ErrOperationCancelled or
+// errs.ErrOperationTimedOut, other errors are returned as they are.
+func runOperation[T any](
+	a *App,
+	kind entity.OperationKind,
+	networkID uint64,
+	fn func(ctx context.Context) (T, error),
+) (T, error) {
+	parent := a.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	timeout := a.operationCfg.Timeout
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	operation := a.operations.add(kind, networkID, timeout, cancel)
+	a.emitEvent(parent, operationStartedEvent, operation)
+	defer func() {
+		a.operations.remove(operation.ID)
+		a.emitEvent(parent, operationFinishedEvent, operation.ID)
+	}()
+
+	result, err := fn(ctx)
+	if err == nil {
+		return result, nil
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return result, fmt.Errorf("%w after %s: %w", errs.ErrOperationTimedOut, timeout, err)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return result, fmt.Errorf("%w: %w", errs.ErrOperationCancelled, err)
+	default:
+		return result, err
+	}
+}
+
+// CancelOperation cancels a running operation. The call waiting for it returns an error wrapping
+// errs.ErrOperationCancelled.
+func (a *App) CancelOperation(id uint64) error {
+	return a.operations.cancel(id)
+}
+
+// ListOperations returns the operations in progress, oldest first.
+func (a *App) ListOperations() []*entity.Operation {
+	return a.operations.list()
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+// waitForContext returns a sync that blocks until its context is done.
+func waitForContext(ctx context.Context, _ uint64) (*entity.SyncResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestApp_SyncNetworkHostSetup_Operation(t *testing.T) {
+	t.Run("listed while running and removed after", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		var running []*entity.Operation
+		app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
+			SyncByNetworkIDWithResult(gomock.Any(), uint64(7)).
+			DoAndReturn(func(ctx context.Context, _ uint64) (*entity.SyncResult, error) {
+				running = app.ListOperations()
+				deadline, ok := ctx.Deadline()
+				require.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+				return &entity.SyncResult{}, nil
+			})
+
+		_, err := app.SyncNetworkHostSetup(7)
+
+		require.NoError(t, err)
+		require.Len(t, running, 1)
+		assert.Equal(t, entity.OperationKindSync, running[0].Kind)
+		assert.Equal(t, uint64(7), running[0].NetworkID)
+		assert.Empty(t, app.ListOperations())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
+			SyncByNetworkIDWithResult(gomock.Any(), uint64(7)).
+			DoAndReturn(waitForContext)
+
+		errCh := make(chan error)
+		go func() {
+			_, err := app.SyncNetworkHostSetup(7)
+			errCh <- err
+		}()
+		require.Eventually(t, func() bool { return len(app.ListOperations()) == 1 }, time.Second, time.Millisecond)
+
+		require.NoError(t, app.CancelOperation(app.ListOperations()[0].ID))
+
+		err := <-errCh
+		require.ErrorIs(t, err, errs.ErrOperationCancelled)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, app.ListOperations())
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+		app.operationCfg.Timeout = 10 * time.Millisecond
+
+		app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
+			SyncByNetworkIDWithResult(gomock.Any(), uint64(7)).
+			DoAndReturn(waitForContext)
+
+		_, err := app.SyncNetworkHostSetup(7)
+
+		require.ErrorIs(t, err, errs.ErrOperationTimedOut)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestApp_SyncNetworkHostSetup_OperationEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	type event struct {
+		name string
+		data []any
+	}
+	var events []event
+	app.emitEvent = func(_ context.Context, eventName string, optionalData ...any) {
+		events = append(events, event{name: eventName, data: optionalData})
+	}
+
+	app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
+		SyncByNetworkIDWithResult(gomock.Any(), uint64(7)).
+		Return(&entity.SyncResult{}, nil)
+
+	_, err := app.SyncNetworkHostSetup(7)
+
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, operationStartedEvent, events[0].name)
+	require.Len(t, events[0].data, 1)
+	started, ok := events[0].data[0].(*entity.Operation)
+	require.True(t, ok)
+	assert.Equal(t, entity.OperationKindSync, started.Kind)
+	assert.Equal(t, uint64(7), started.NetworkID)
+	assert.Equal(t, operationFinishedEvent, events[1].name)
+	assert.Equal(t, []any{started.ID}, events[1].data)
+}
+
+func TestApp_ResetNetworkHostSetup_Cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	app.networkHostSetupUC.(*mock_usecase.MockNetworkHostSetup).EXPECT().
+		ResetByNetworkID(gomock.Any(), uint64(7)).
+		DoAndReturn(func(ctx context.Context, _ uint64) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- app.ResetNetworkHostSetup(7)
+	}()
+	require.Eventually(t, func() bool { return len(app.ListOperations()) == 1 }, time.Second, time.Millisecond)
+
+	operation := app.ListOperations()[0]
+	assert.Equal(t, entity.OperationKindReset, operation.Kind)
+	require.NoError(t, app.CancelOperation(operation.ID))
+
+	require.ErrorIs(t, <-errCh, errs.ErrOperationCancelled)
+}
+
+func TestApp_CancelOperation_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+
+	err := app.CancelOperation(42)
+
+	require.ErrorIs(t, err, errs.ErrOperationNotFound)
+}
+
+func TestApp_ListOperations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	block := func(ctx context.Context) (*entity.SyncResult, error) {
+		<-release
+		return &entity.SyncResult{}, ctx.Err()
+	}
+	for _, kind := range []entity.OperationKind{entity.OperationKindImport, entity.OperationKindUpdateCheck} {
+		go func() {
+			_, _ = runOperation(app, kind, 0, block)
+			done <- struct{}{}
+		}()
+		require.Eventually(t, func() bool {
+			operations := app.ListOperations()
+			return len(operations) > 0 && operations[len(operations)-1].Kind == kind
+		}, time.Second, time.Millisecond)
+	}
+
+	operations := app.ListOperations()
+
+	require.Len(t, operations, 2)
+	assert.Equal(t, uint64(1), operations[0].ID)
+	assert.Equal(t, entity.OperationKindImport, operations[0].Kind)
+	assert.Equal(t, uint64(2), operations[1].ID)
+	assert.Equal(t, entity.OperationKindUpdateCheck, operations[1].Kind)
+
+	close(release)
+	<-done
+	<-done
+	assert.Empty(t, app.ListOperations())
+}
+
+func TestRunOperation_ErrorsPassThrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	expectedErr := errors.New("import failed")
+
+	_, err := runOperation(app, entity.OperationKindImport, 1, func(context.Context) (struct{}, error) {
+		return struct{}{}, expectedErr
+	})
+
+	assert.Equal(t, expectedErr, err)
+}
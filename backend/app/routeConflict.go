@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// AnalyzeRouteConflicts checks the routes of all networks against the local subnet,
+// the VPN server addresses and each other.
+func (a *App) AnalyzeRouteConflicts() ([]*entity.RouteConflict, error) {
+	return a.routeConflictUC.Analyze(a.ctx)
+}
+
+// AnalyzeNetworkRouteConflicts returns the conflicts involving a network's routes, meant to be shown before a sync.
+func (a *App) AnalyzeNetworkRouteConflicts(networkID uint64) ([]*entity.RouteConflict, error) {
+	return a.routeConflictUC.AnalyzeByNetworkID(a.ctx, networkID)
+}
+
+// CheckNetworkHostConflicts returns the conflicts the address would cause if added to a network.
+func (a *App) CheckNetworkHostConflicts(networkID uint64, address string) ([]*entity.RouteConflict, error) {
+	return a.routeConflictUC.CheckAddress(a.ctx, networkID, address)
+}
+
+// networkRouteConflicts returns the conflicts of a network's routes to report with a sync.
+// They are only warnings, a failed check is logged instead of failing the sync.
+func (a *App) networkRouteConflicts(ctx context.Context, networkID uint64) []*entity.RouteConflict {
+	conflicts, err := a.routeConflictUC.AnalyzeByNetworkID(ctx, networkID)
+	if err != nil {
+		slog.Warn("failed to check route conflicts", "network_id", networkID, "error", err)
+		return nil
+	}
+
+	return conflicts
+}
+
+// addressRouteConflicts returns the conflicts an added address causes, a failed check is logged
+// instead of failing the add.
+func (a *App) addressRouteConflicts(ctx context.Context, networkID uint64, address string) []*entity.RouteConflict {
+	conflicts, err := a.routeConflictUC.CheckAddress(ctx, networkID, address)
+	if err != nil {
+		slog.Warn("failed to check route conflicts", "network_id", networkID, "address", address, "error", err)
+		return nil
+	}
+
+	return conflicts
+}
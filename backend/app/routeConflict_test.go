@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+)
+
+func testRouteConflicts() []*entity.RouteConflict {
+	return []*entity.RouteConflict{
+		{
+			Kind:          entity.RouteConflictKindLocalSubnet,
+			NetworkID:     1,
+			NetworkName:   "Corp",
+			Route:         "192.168.0.0/16",
+			ConflictsWith: "192.168.1.0/24",
+		},
+	}
+}
+
+func TestApp_AnalyzeRouteConflicts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expected := testRouteConflicts()
+	app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+		Analyze(gomock.Any()).
+		Return(expected, nil)
+
+	result, err := app.AnalyzeRouteConflicts()
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestApp_AnalyzeNetworkRouteConflicts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	expected := testRouteConflicts()
+	app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+		AnalyzeByNetworkID(gomock.Any(), uint64(1)).
+		Return(expected, nil)
+
+	result, err := app.AnalyzeNetworkRouteConflicts(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestApp_CheckNetworkHostConflicts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		expected := testRouteConflicts()
+		app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+			CheckAddress(gomock.Any(), uint64(1), "192.168.1.20").
+			Return(expected, nil)
+
+		result, err := app.CheckNetworkHostConflicts(1, "192.168.1.20")
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := createTestApp(ctrl)
+		app.OnStartup(context.Background())
+
+		app.routeConflictUC.(*mock_usecase.MockRouteConflict).EXPECT().
+			CheckAddress(gomock.Any(), uint64(1), "missing.example").
+			Return(nil, errors.New("lookup failed"))
+
+		result, err := app.CheckNetworkHostConflicts(1, "missing.example")
+
+		require.EqualError(t, err, "lookup failed")
+		assert.Nil(t, result)
+	})
+}
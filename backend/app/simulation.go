@@ -0,0 +1,38 @@
+package app
+
+import (
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+// IsSimulationEnabled reports whether the app runs on the simulated network stack.
+func (a *App) IsSimulationEnabled() bool {
+	return a.simulationUC != nil
+}
+
+// ListSimulatedVPN returns the VPN services of the simulated network stack with their connection state.
+func (a *App) ListSimulatedVPN() ([]*entity.SimulatedVPN, error) {
+	if a.simulationUC == nil {
+		return nil, errs.ErrSimulationDisabled
+	}
+
+	return a.simulationUC.ListSimulatedVPN(a.ctx)
+}
+
+// ConnectSimulatedVPN connects a VPN service of the simulated network stack.
+func (a *App) ConnectSimulatedVPN(vpnService string) error {
+	if a.simulationUC == nil {
+		return errs.ErrSimulationDisabled
+	}
+
+	return a.simulationUC.ConnectVPN(a.ctx, entity.VPNService(vpnService))
+}
+
+// DisconnectSimulatedVPN disconnects a VPN service of the simulated network stack.
+func (a *App) DisconnectSimulatedVPN(vpnService string) error {
+	if a.simulationUC == nil {
+		return errs.ErrSimulationDisabled
+	}
+
+	return a.simulationUC.DisconnectVPN(a.ctx, entity.VPNService(vpnService))
+}
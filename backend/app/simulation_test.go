@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestApp_Simulation_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	app.OnStartup(context.Background())
+
+	assert.False(t, app.IsSimulationEnabled())
+
+	_, err := app.ListSimulatedVPN()
+	require.ErrorIs(t, err, errs.ErrSimulationDisabled)
+	require.ErrorIs(t, app.ConnectSimulatedVPN("Office VPN"), errs.ErrSimulationDisabled)
+	require.ErrorIs(t, app.DisconnectSimulatedVPN("Office VPN"), errs.ErrSimulationDisabled)
+}
+
+func TestApp_Simulation_Enabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := createTestApp(ctrl)
+	ctx := context.Background()
+	app.OnStartup(ctx)
+	mockSimulationUC := mock_usecase.NewMockSimulation(ctrl)
+	app.simulationUC = mockSimulationUC
+
+	assert.True(t, app.IsSimulationEnabled())
+
+	expectedVPNs := []*entity.SimulatedVPN{{Service: "Office VPN", ServerAddress: "vpn.example.com", Connected: true}}
+	mockSimulationUC.EXPECT().ListSimulatedVPN(ctx).Return(expectedVPNs, nil)
+	vpns, err := app.ListSimulatedVPN()
+	require.NoError(t, err)
+	assert.Equal(t, expectedVPNs, vpns)
+
+	mockSimulationUC.EXPECT().ConnectVPN(ctx, entity.VPNService("Office VPN")).Return(nil)
+	require.NoError(t, app.ConnectSimulatedVPN("Office VPN"))
+
+	mockSimulationUC.EXPECT().DisconnectVPN(ctx, entity.VPNService("Lab VPN")).Return(errs.ErrVPNServiceNotFound)
+	require.ErrorIs(t, app.DisconnectSimulatedVPN("Lab VPN"), errs.ErrVPNServiceNotFound)
+}
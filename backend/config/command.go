@@ -0,0 +1,11 @@
+package config
+
+// Command configures how system commands are run. Transcripts are JSON Lines files of the commands a session ran
+// with their output, recorded to reproduce a user's parser bug and replayed in place of the real commands.
+type Command struct {
+	// RecordPath is the transcript file every command run is appended to, recording is off when empty.
+	RecordPath string `env:"SPLITR_COMMAND_RECORD_PATH"`
+	// ReplayPath is a recorded transcript served instead of running commands, e.g. on Linux or in a debug build.
+	// It takes precedence over RecordPath.
+	ReplayPath string `env:"SPLITR_COMMAND_REPLAY_PATH"`
+}
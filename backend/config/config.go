@@ -12,6 +12,22 @@ type Config struct {
 	Logging logging.Config
 
 	GitHub GitHub
+
+	Subscription Subscription
+
+	Expiry Expiry
+
+	Route Route
+
+	DNS DNS
+
+	NetworkWatch NetworkWatch
+
+	Operation Operation
+
+	Command Command
+
+	Simulation Simulation
 }
 
 type envReader func(interface{}) error
@@ -27,5 +43,10 @@ func newWithEnvReader(readEnv envReader) (*Config, error) {
 		return nil, fmt.Errorf("failed to read env: %w", err)
 	}
 
+	err = cfg.Expiry.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry config: %w", err)
+	}
+
 	return cfg, err
 }
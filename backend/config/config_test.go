@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,6 +55,244 @@ func TestNew_Success_WithDefaults(t *testing.T) {
 	assert.Empty(t, cfg.GitHub.Token)
 }
 
+func TestNew_WithSubscriptionConfig(t *testing.T) {
+	t.Run("default refresh interval", func(t *testing.T) {
+		restore := clearEnv(t, "SPLITR_SUBSCRIPTION_REFRESH_INTERVAL")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, cfg.Subscription.RefreshInterval)
+	})
+
+	t.Run("custom refresh interval", func(t *testing.T) {
+		restore := setEnv(t, "SPLITR_SUBSCRIPTION_REFRESH_INTERVAL", "15m")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, cfg.Subscription.RefreshInterval)
+	})
+}
+
+func TestNew_WithExpiryConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		restoreInterval := clearEnv(t, "SPLITR_EXPIRY_CHECK_INTERVAL")
+		defer restoreInterval()
+		restoreAction := clearEnv(t, "SPLITR_EXPIRY_ACTION")
+		defer restoreAction()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, cfg.Expiry.CheckInterval)
+		assert.Equal(t, "disable", cfg.Expiry.Action)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		restoreInterval := setEnv(t, "SPLITR_EXPIRY_CHECK_INTERVAL", "30s")
+		defer restoreInterval()
+		restoreAction := setEnv(t, "SPLITR_EXPIRY_ACTION", "delete")
+		defer restoreAction()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, cfg.Expiry.CheckInterval)
+		assert.Equal(t, "delete", cfg.Expiry.Action)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		restoreAction := setEnv(t, "SPLITR_EXPIRY_ACTION", "remove")
+		defer restoreAction()
+
+		cfg, err := New()
+
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), `invalid SPLITR_EXPIRY_ACTION "remove"`)
+	})
+}
+
+func TestNew_WithRouteConfig(t *testing.T) {
+	t.Run("default aggregate prefix length", func(t *testing.T) {
+		restore := clearEnv(t, "SPLITR_ROUTE_AGGREGATE_PREFIX_LENGTH")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, 32, cfg.Route.AggregatePrefixLength)
+	})
+
+	t.Run("custom aggregate prefix length", func(t *testing.T) {
+		restore := setEnv(t, "SPLITR_ROUTE_AGGREGATE_PREFIX_LENGTH", "24")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, 24, cfg.Route.AggregatePrefixLength)
+	})
+}
+
+func TestNew_WithDNSConfig(t *testing.T) {
+	t.Run("default cache TTL", func(t *testing.T) {
+		restore := clearEnv(t, "SPLITR_DNS_CACHE_TTL")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, cfg.DNS.CacheTTL)
+	})
+
+	t.Run("custom cache TTL", func(t *testing.T) {
+		restore := setEnv(t, "SPLITR_DNS_CACHE_TTL", "10m")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Minute, cfg.DNS.CacheTTL)
+	})
+
+	t.Run("default resolver dir", func(t *testing.T) {
+		restore := clearEnv(t, "SPLITR_DNS_RESOLVER_DIR")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, "/etc/resolver", cfg.DNS.ResolverDir)
+	})
+
+	t.Run("forwarder defaults", func(t *testing.T) {
+		restore := clearEnv(t, "SPLITR_DNS_FORWARDER_ENABLED")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.False(t, cfg.DNS.Forwarder.Enabled)
+		assert.Equal(t, "127.0.0.1:53535", cfg.DNS.Forwarder.ListenAddress)
+		assert.Equal(t, 5*time.Minute, cfg.DNS.Forwarder.MinTTL)
+		assert.Equal(t, time.Minute, cfg.DNS.Forwarder.CheckInterval)
+	})
+
+	t.Run("custom forwarder upstream", func(t *testing.T) {
+		restore := setEnv(t, "SPLITR_DNS_FORWARDER_UPSTREAM", "10.8.0.1:53")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, "10.8.0.1:53", cfg.DNS.Forwarder.Upstream)
+	})
+}
+
+func TestNew_WithNetworkWatchConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		restoreEnabled := clearEnv(t, "SPLITR_NETWORK_WATCH_ENABLED")
+		defer restoreEnabled()
+		restoreInterval := clearEnv(t, "SPLITR_NETWORK_WATCH_INTERVAL")
+		defer restoreInterval()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.True(t, cfg.NetworkWatch.Enabled)
+		assert.Equal(t, 30*time.Second, cfg.NetworkWatch.CheckInterval)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		restoreEnabled := setEnv(t, "SPLITR_NETWORK_WATCH_ENABLED", "false")
+		defer restoreEnabled()
+		restoreInterval := setEnv(t, "SPLITR_NETWORK_WATCH_INTERVAL", "5s")
+		defer restoreInterval()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.False(t, cfg.NetworkWatch.Enabled)
+		assert.Equal(t, 5*time.Second, cfg.NetworkWatch.CheckInterval)
+	})
+}
+
+func TestNew_WithOperationConfig(t *testing.T) {
+	t.Run("default timeout", func(t *testing.T) {
+		restore := clearEnv(t, "SPLITR_OPERATION_TIMEOUT")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, cfg.Operation.Timeout)
+	})
+
+	t.Run("custom timeout", func(t *testing.T) {
+		restore := setEnv(t, "SPLITR_OPERATION_TIMEOUT", "30s")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, cfg.Operation.Timeout)
+	})
+}
+
+func TestNew_WithCommandConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		restoreRecord := clearEnv(t, "SPLITR_COMMAND_RECORD_PATH")
+		defer restoreRecord()
+		restoreReplay := clearEnv(t, "SPLITR_COMMAND_REPLAY_PATH")
+		defer restoreReplay()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Command.RecordPath)
+		assert.Empty(t, cfg.Command.ReplayPath)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		restoreRecord := setEnv(t, "SPLITR_COMMAND_RECORD_PATH", "/tmp/record.jsonl")
+		defer restoreRecord()
+		restoreReplay := setEnv(t, "SPLITR_COMMAND_REPLAY_PATH", "/tmp/replay.jsonl")
+		defer restoreReplay()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/record.jsonl", cfg.Command.RecordPath)
+		assert.Equal(t, "/tmp/replay.jsonl", cfg.Command.ReplayPath)
+	})
+}
+
+func TestNew_WithSimulationConfig(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		restore := clearEnv(t, "SPLITR_SIMULATE")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.False(t, cfg.Simulation.Enabled)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		restore := setEnv(t, "SPLITR_SIMULATE", "true")
+		defer restore()
+
+		cfg, err := New()
+
+		require.NoError(t, err)
+		assert.True(t, cfg.Simulation.Enabled)
+	})
+}
+
 func TestNew_Success_WithCustomValues(t *testing.T) {
 	tests := []struct {
 		name          string
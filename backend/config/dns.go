@@ -0,0 +1,28 @@
+package config
+
+import (
+	"time"
+)
+
+type DNS struct {
+	// CacheTTL is how long a cached resolution counts as current. Lookups always go live first,
+	// a failed lookup falls back to the cached IPs however old they are and reports them as stale.
+	CacheTTL time.Duration `env:"SPLITR_DNS_CACHE_TTL" env-default:"1h"`
+	// ResolverDir is where the per-domain resolver files of a network's DNS domains are written.
+	ResolverDir string `env:"SPLITR_DNS_RESOLVER_DIR" env-default:"/etc/resolver"`
+
+	Forwarder DNSForwarder
+}
+
+// DNSForwarder configures the local DNS forwarder that routes the subdomains of wildcard network hosts.
+// Point the system or a resolver file at ListenAddress for it to see the queries.
+type DNSForwarder struct {
+	Enabled bool `env:"SPLITR_DNS_FORWARDER_ENABLED" env-default:"false"`
+	// ListenAddress stays off port 5353, which macOS's mDNSResponder holds for multicast DNS.
+	ListenAddress string `env:"SPLITR_DNS_FORWARDER_LISTEN" env-default:"127.0.0.1:53535"`
+	// Upstream is the "host:port" of the DNS server queries are forwarded to, required when enabled.
+	Upstream string `env:"SPLITR_DNS_FORWARDER_UPSTREAM"`
+	// MinTTL is the shortest time a learned IP stays routed, so short DNS TTLs don't churn the routes.
+	MinTTL        time.Duration `env:"SPLITR_DNS_FORWARDER_MIN_TTL"        env-default:"5m"`
+	CheckInterval time.Duration `env:"SPLITR_DNS_FORWARDER_CHECK_INTERVAL" env-default:"1m"`
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type Expiry struct {
+	CheckInterval time.Duration `env:"SPLITR_EXPIRY_CHECK_INTERVAL" env-default:"1m"`
+	// Action is what happens to an expired network host: "disable" or "delete".
+	Action string `env:"SPLITR_EXPIRY_ACTION" env-default:"disable"`
+}
+
+// validate rejects an unknown action, so a typo doesn't silently keep expired hosts instead of deleting them.
+func (e *Expiry) validate() error {
+	switch e.Action {
+	case "disable", "delete":
+		return nil
+	default:
+		return fmt.Errorf("invalid SPLITR_EXPIRY_ACTION %q, expected \"disable\" or \"delete\"", e.Action)
+	}
+}
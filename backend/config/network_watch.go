@@ -0,0 +1,12 @@
+package config
+
+import (
+	"time"
+)
+
+// NetworkWatch configures the watcher that re-syncs the active network when the physical network changes.
+type NetworkWatch struct {
+	Enabled bool `env:"SPLITR_NETWORK_WATCH_ENABLED" env-default:"true"`
+	// CheckInterval is how often the default interface and its router and subnet mask are checked.
+	CheckInterval time.Duration `env:"SPLITR_NETWORK_WATCH_INTERVAL" env-default:"30s"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import (
+	"time"
+)
+
+// Operation configures the long-running calls the UI makes, such as syncs, resets, imports and update checks.
+type Operation struct {
+	// Timeout is how long a single operation may run before it is cancelled.
+	Timeout time.Duration `env:"SPLITR_OPERATION_TIMEOUT" env-default:"2m"`
+}
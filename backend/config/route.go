@@ -0,0 +1,7 @@
+package config
+
+type Route struct {
+	// AggregatePrefixLength is the widest prefix nearby routes may be collapsed into before they are applied.
+	// 32 keeps the routed addresses exact and only drops duplicates and merges complete halves.
+	AggregatePrefixLength int `env:"SPLITR_ROUTE_AGGREGATE_PREFIX_LENGTH" env-default:"32"`
+}
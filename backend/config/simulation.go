@@ -0,0 +1,7 @@
+package config
+
+// Simulation configures simulation mode, which replaces the macOS network stack with one kept in memory,
+// so the app runs anywhere, e.g. on Linux for UI work.
+type Simulation struct {
+	Enabled bool `env:"SPLITR_SIMULATE" env-default:"false"`
+}
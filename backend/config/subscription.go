@@ -0,0 +1,9 @@
+package config
+
+import (
+	"time"
+)
+
+type Subscription struct {
+	RefreshInterval time.Duration `env:"SPLITR_SUBSCRIPTION_REFRESH_INTERVAL" env-default:"1h"`
+}
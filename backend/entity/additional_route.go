@@ -0,0 +1,8 @@
+package entity
+
+// AdditionalRoute is an additional IPv4 route configured on a network service.
+type AdditionalRoute struct {
+	Destination string `json:"destination"`
+	SubnetMask  string `json:"subnet_mask"`
+	Router      string `json:"router,omitempty"`
+}
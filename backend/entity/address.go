@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+)
+
+const hostSubnetMask = "255.255.255.255"
+
+// IsCIDR reports whether address is an IPv4 network in canonical CIDR notation, e.g. "10.0.0.0/8".
+func IsCIDR(address string) bool {
+	_, _, ok := ParseCIDR(address)
+	return ok
+}
+
+// ParseCIDR splits an IPv4 CIDR address into its network IP and dotted subnet mask.
+func ParseCIDR(address string) (string, string, bool) {
+	if !strings.Contains(address, "/") {
+		return "", "", false
+	}
+
+	ip, ipNet, err := net.ParseCIDR(address)
+	if err != nil || ip.To4() == nil || !ip.Equal(ipNet.IP) {
+		return "", "", false
+	}
+
+	return ipNet.IP.String(), net.IP(ipNet.Mask).String(), true
+}
+
+// AddressFromRoute converts a route destination and subnet mask into a host address.
+// Host routes and destinations with host bits set become a plain IP,
+// network routes become CIDR notation.
+func AddressFromRoute(destination, subnetMask string) (string, bool) {
+	ip := net.ParseIP(destination).To4()
+	maskIP := net.ParseIP(subnetMask).To4()
+	if ip == nil || maskIP == nil {
+		return "", false
+	}
+
+	mask := net.IPMask(maskIP)
+	ones, bits := mask.Size()
+	if bits == 0 {
+		return "", false
+	}
+
+	if subnetMask == hostSubnetMask || !ip.Equal(ip.Mask(mask)) {
+		return ip.String(), true
+	}
+
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(ones, bits)}).String(), true
+}
+
+// RoutePrefix returns the IPv4 prefix a route destination and dotted subnet mask cover.
+func RoutePrefix(destination, subnetMask string) (netip.Prefix, bool) {
+	addr, err := netip.ParseAddr(destination)
+	maskIP := net.ParseIP(subnetMask).To4()
+	if err != nil || !addr.Is4() || maskIP == nil {
+		return netip.Prefix{}, false
+	}
+
+	ones, bits := net.IPMask(maskIP).Size()
+	if bits == 0 {
+		return netip.Prefix{}, false
+	}
+
+	return netip.PrefixFrom(addr, ones).Masked(), true
+}
+
+// IsValidAddress reports whether address is an IPv4 address, a hostname or an IPv4 CIDR.
+func IsValidAddress(address string) bool {
+	return ipOrHostnameRegex.MatchString(address) || IsCIDR(address)
+}
+
+// IsHostname reports whether address is a hostname rather than an IP address or a CIDR.
+func IsHostname(address string) bool {
+	return IsValidAddress(address) && !IsCIDR(address) && net.ParseIP(address) == nil
+}
+
+// IsWildcard reports whether address covers every subdomain of a hostname, written as "*.example.com"
+// or ".example.com". Wildcards can't be resolved, only matched against names seen in DNS answers.
+func IsWildcard(address string) bool {
+	_, ok := WildcardSuffix(address)
+	return ok
+}
+
+// WildcardSuffix returns the domain whose subdomains a wildcard address covers, e.g. "example.com".
+func WildcardSuffix(address string) (string, bool) {
+	suffix, ok := strings.CutPrefix(address, "*.")
+	if !ok {
+		suffix, ok = strings.CutPrefix(address, ".")
+	}
+
+	if !ok || !IsHostname(suffix) {
+		return "", false
+	}
+
+	return suffix, true
+}
+
+// MatchesWildcard reports whether name is a subdomain of the domain a wildcard address covers.
+// Names are compared case-insensitively and a trailing root dot is ignored.
+func MatchesWildcard(address, name string) bool {
+	suffix, ok := WildcardSuffix(address)
+	if !ok {
+		return false
+	}
+
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	return strings.HasSuffix(name, "."+strings.ToLower(suffix))
+}
@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCIDR(t *testing.T) {
+	tests := []struct {
+		name         string
+		address      string
+		expectedIP   string
+		expectedMask string
+		expectedOK   bool
+	}{
+		{
+			name:         "class A network",
+			address:      "10.0.0.0/8",
+			expectedIP:   "10.0.0.0",
+			expectedMask: "255.0.0.0",
+			expectedOK:   true,
+		},
+		{
+			name:         "host prefix",
+			address:      "192.168.1.10/32",
+			expectedIP:   "192.168.1.10",
+			expectedMask: "255.255.255.255",
+			expectedOK:   true,
+		},
+		{
+			name:    "host bits set",
+			address: "192.168.1.1/24",
+		},
+		{
+			name:    "plain IP",
+			address: "192.168.1.1",
+		},
+		{
+			name:    "hostname",
+			address: "example.com",
+		},
+		{
+			name:    "IPv6 network",
+			address: "2001:db8::/32",
+		},
+		{
+			name:    "invalid prefix length",
+			address: "10.0.0.0/33",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip, mask, ok := ParseCIDR(tt.address)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedIP, ip)
+			assert.Equal(t, tt.expectedMask, mask)
+			assert.Equal(t, tt.expectedOK, IsCIDR(tt.address))
+		})
+	}
+}
+
+func TestAddressFromRoute(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		subnetMask  string
+		expected    string
+		expectedOK  bool
+	}{
+		{
+			name:        "host route",
+			destination: "10.1.2.3",
+			subnetMask:  "255.255.255.255",
+			expected:    "10.1.2.3",
+			expectedOK:  true,
+		},
+		{
+			name:        "network route",
+			destination: "10.20.0.0",
+			subnetMask:  "255.255.0.0",
+			expected:    "10.20.0.0/16",
+			expectedOK:  true,
+		},
+		{
+			name:        "host with LAN mask stays an IP",
+			destination: "10.1.2.3",
+			subnetMask:  "255.255.255.0",
+			expected:    "10.1.2.3",
+			expectedOK:  true,
+		},
+		{
+			name:        "non-contiguous mask",
+			destination: "10.0.0.0",
+			subnetMask:  "255.0.255.0",
+		},
+		{
+			name:        "invalid destination",
+			destination: "not-an-ip",
+			subnetMask:  "255.255.255.0",
+		},
+		{
+			name:        "invalid mask",
+			destination: "10.0.0.0",
+			subnetMask:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, ok := AddressFromRoute(tt.destination, tt.subnetMask)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expected, address)
+		})
+	}
+}
+
+func TestIsValidAddress(t *testing.T) {
+	assert.True(t, IsValidAddress("10.0.0.1"))
+	assert.True(t, IsValidAddress("example.com"))
+	assert.True(t, IsValidAddress("10.0.0.0/8"))
+	assert.False(t, IsValidAddress("10.0.0.1/8"))
+	assert.False(t, IsValidAddress("-invalid.com"))
+	assert.False(t, IsValidAddress(""))
+}
+
+func TestIsHostname(t *testing.T) {
+	assert.True(t, IsHostname("git.corp.example"))
+	assert.False(t, IsHostname("10.0.0.1"))
+	assert.False(t, IsHostname("10.0.0.0/8"))
+	assert.False(t, IsHostname("-invalid.com"))
+	assert.False(t, IsHostname("*.corp.example.com"))
+}
+
+func TestWildcardSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{name: "star prefix", address: "*.corp.example.com", expected: "corp.example.com"},
+		{name: "dot prefix", address: ".corp.example.com", expected: "corp.example.com"},
+		{name: "plain hostname", address: "corp.example.com"},
+		{name: "star inside name", address: "git.*.example.com"},
+		{name: "wildcard IP", address: "*.10.0.0.1"},
+		{name: "bare star", address: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suffix, ok := WildcardSuffix(tt.address)
+
+			assert.Equal(t, tt.expected != "", ok)
+			assert.Equal(t, tt.expected, suffix)
+			assert.Equal(t, ok, IsWildcard(tt.address))
+		})
+	}
+}
+
+func TestMatchesWildcard(t *testing.T) {
+	assert.True(t, MatchesWildcard("*.corp.example.com", "git.corp.example.com"))
+	assert.True(t, MatchesWildcard("*.corp.example.com", "a.b.corp.example.com."))
+	assert.True(t, MatchesWildcard(".corp.example.com", "GIT.Corp.Example.com"))
+	assert.False(t, MatchesWildcard("*.corp.example.com", "corp.example.com"))
+	assert.False(t, MatchesWildcard("*.corp.example.com", "gitcorp.example.com"))
+	assert.False(t, MatchesWildcard("corp.example.com", "git.corp.example.com"))
+}
+
+func TestRoutePrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		subnetMask  string
+		expected    string
+	}{
+		{name: "host route", destination: "10.0.0.5", subnetMask: "255.255.255.255", expected: "10.0.0.5/32"},
+		{name: "network route", destination: "10.20.0.0", subnetMask: "255.255.0.0", expected: "10.20.0.0/16"},
+		{name: "host bits are masked", destination: "10.0.0.5", subnetMask: "255.255.255.0", expected: "10.0.0.0/24"},
+		{name: "invalid destination", destination: "example.com", subnetMask: "255.255.255.0"},
+		{name: "invalid mask", destination: "10.0.0.5", subnetMask: "255.0.255.0"},
+		{name: "IPv6 destination", destination: "fd00::1", subnetMask: "255.255.255.255"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, ok := RoutePrefix(tt.destination, tt.subnetMask)
+
+			assert.Equal(t, tt.expected != "", ok)
+			if ok {
+				assert.Equal(t, tt.expected, prefix.String())
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"errors"
 	"strings"
+	"time"
 )
 
 type Command struct {
@@ -9,6 +11,111 @@ type Command struct {
 	Args       []string
 }
 
+// CommandResult is what a command that ran printed and how it exited.
+type CommandResult struct {
+	// Stdout holds the output lines.
+	Stdout   []string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+}
+
+// CommandRecord is a command run saved to a transcript with what it printed and how it exited.
+type CommandRecord struct {
+	Executable string   `json:"executable"`
+	Args       []string `json:"args"`
+	// NotStarted is set for commands that could not be started, they have no output.
+	NotStarted bool     `json:"not_started,omitempty"`
+	Stdout     []string `json:"stdout,omitempty"`
+	Stderr     string   `json:"stderr,omitempty"`
+	ExitCode   int      `json:"exit_code"`
+	DurationMS int64    `json:"duration_ms"`
+	// Error is the message the command failed with, empty when it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// NewCommandRecord records a command run that returned result and err.
+func NewCommandRecord(command *Command, result *CommandResult, err error) *CommandRecord {
+	record := &CommandRecord{
+		Executable: command.Executable,
+		Args:       command.Args,
+		NotStarted: result == nil,
+	}
+	if result != nil {
+		record.Stdout = result.Stdout
+		record.Stderr = result.Stderr
+		record.ExitCode = result.ExitCode
+		record.DurationMS = result.Duration.Milliseconds()
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	return record
+}
+
+// Command returns the recorded command.
+func (r *CommandRecord) Command() *Command {
+	return &Command{
+		Executable: r.Executable,
+		Args:       r.Args,
+	}
+}
+
+// Result returns the recorded result and error the way the command run returned them.
+func (r *CommandRecord) Result() (*CommandResult, error) {
+	var err error
+	if r.Error != "" {
+		err = errors.New(r.Error)
+	}
+
+	if r.NotStarted {
+		return nil, err
+	}
+
+	return &CommandResult{
+		Stdout:   r.Stdout,
+		Stderr:   r.Stderr,
+		ExitCode: r.ExitCode,
+		Duration: time.Duration(r.DurationMS) * time.Millisecond,
+	}, err
+}
+
 func (c *Command) String() string {
 	return c.Executable + " " + strings.Join(c.Args, " ")
 }
+
+// ShellString renders the command for a POSIX shell, quoting arguments where needed.
+func (c *Command) ShellString() string {
+	parts := make([]string, 0, len(c.Args)+1)
+	parts = append(parts, shellQuote(c.Executable))
+	for _, arg := range c.Args {
+		parts = append(parts, shellQuote(arg))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// shellQuote wraps s in single quotes unless it only contains characters
+// that are safe to pass to a POSIX shell unquoted.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+
+	isSafe := strings.IndexFunc(s, func(r rune) bool {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return false
+		case strings.ContainsRune("-_./:=@%+,", r):
+			return false
+		default:
+			return true
+		}
+	}) == -1
+	if isSafe {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -1,9 +1,13 @@
 package entity
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommand_String(t *testing.T) {
@@ -82,3 +86,89 @@ func TestCommand_NilArgs(t *testing.T) {
 	result := command.String()
 	assert.Equal(t, "test ", result)
 }
+
+func TestCommand_ShellString(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  Command
+		expected string
+	}{
+		{
+			name:     "safe args are left unquoted",
+			command:  Command{Executable: "route", Args: []string{"-n", "add", "-net", "10.0.0.1"}},
+			expected: "route -n add -net 10.0.0.1",
+		},
+		{
+			name:     "args with spaces are quoted",
+			command:  Command{Executable: "networksetup", Args: []string{"-setadditionalroutes", "Corp VPN"}},
+			expected: "networksetup -setadditionalroutes 'Corp VPN'",
+		},
+		{
+			name:     "single quotes are escaped",
+			command:  Command{Executable: "echo", Args: []string{"it's"}},
+			expected: `echo 'it'"'"'s'`,
+		},
+		{
+			name:     "shell metacharacters are quoted",
+			command:  Command{Executable: "echo", Args: []string{"$HOME", "a;b", "*"}},
+			expected: "echo '$HOME' 'a;b' '*'",
+		},
+		{
+			name:     "empty arg is preserved",
+			command:  Command{Executable: "echo", Args: []string{""}},
+			expected: "echo ''",
+		},
+		{
+			name:     "nil args",
+			command:  Command{Executable: "ls"},
+			expected: "ls",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.command.ShellString())
+		})
+	}
+}
+
+func TestCommandRecord_Result(t *testing.T) {
+	command := &Command{Executable: "networksetup", Args: []string{"-getinfo", "Wi-Fi"}}
+
+	t.Run("successful run round trips through JSON", func(t *testing.T) {
+		result := &CommandResult{
+			Stdout:   []string{"Subnet mask: 255.255.255.0", "Router: 192.168.1.1", ""},
+			ExitCode: 0,
+			Duration: 25 * time.Millisecond,
+		}
+
+		data, err := json.Marshal(NewCommandRecord(command, result, nil))
+		require.NoError(t, err)
+		var record CommandRecord
+		require.NoError(t, json.Unmarshal(data, &record))
+
+		assert.Equal(t, command, record.Command())
+		replayed, replayedErr := record.Result()
+		require.NoError(t, replayedErr)
+		assert.Equal(t, result, replayed)
+	})
+
+	t.Run("failed run keeps stderr, exit code and error", func(t *testing.T) {
+		result := &CommandResult{Stderr: "** Error: The parameters were not valid.", ExitCode: 4}
+
+		record := NewCommandRecord(command, result, errors.New("failed to execute command: exit status 4"))
+
+		replayed, err := record.Result()
+		require.EqualError(t, err, "failed to execute command: exit status 4")
+		assert.Equal(t, result, replayed)
+	})
+
+	t.Run("command that did not start has no result", func(t *testing.T) {
+		record := NewCommandRecord(command, nil, errors.New("executable file not found"))
+
+		assert.True(t, record.NotStarted)
+		replayed, err := record.Result()
+		require.Error(t, err)
+		assert.Nil(t, replayed)
+	})
+}
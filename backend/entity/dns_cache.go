@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"time"
+)
+
+// DNSCacheEntry is the last successful resolution of a hostname, routed to when a live lookup fails.
+type DNSCacheEntry struct {
+	Address    string    `db:"address"     json:"Address"`
+	IPs        IPList    `db:"ips"         json:"IPs"`
+	ResolvedAt Timestamp `db:"resolved_at" json:"ResolvedAt"`
+	// TTL is how long after ResolvedAt the IPs count as current, in seconds.
+	TTL int64 `db:"ttl" json:"TTL"`
+}
+
+// NewDNSCacheEntry records IPs a hostname resolved to just now.
+func NewDNSCacheEntry(address string, ips []string, ttl time.Duration) *DNSCacheEntry {
+	return &DNSCacheEntry{
+		Address:    address,
+		IPs:        ips,
+		ResolvedAt: NewTimestamp(),
+		TTL:        int64(ttl.Seconds()),
+	}
+}
+
+// ExpiresAt returns when the cached IPs stop counting as current.
+func (e *DNSCacheEntry) ExpiresAt() time.Time {
+	return e.ResolvedAt.Add(time.Duration(e.TTL) * time.Second)
+}
+
+// IsExpired reports whether the cached IPs are past their TTL by now.
+func (e *DNSCacheEntry) IsExpired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt())
+}
+
+// StaleResolution is a hostname that was routed to its cached IPs because a live lookup failed.
+type StaleResolution struct {
+	Address    string    `json:"Address"`
+	IPs        []string  `json:"IPs"`
+	ResolvedAt Timestamp `json:"ResolvedAt"`
+	// Expired is set when the cached IPs were already past their TTL.
+	Expired bool `json:"Expired"`
+	// Error is why the live lookup failed.
+	Error string `json:"Error"`
+}
+
+// NewStaleResolution describes routing a hostname to a cache entry after the live lookup failed with lookupErr.
+func NewStaleResolution(entry *DNSCacheEntry, lookupErr error, now time.Time) *StaleResolution {
+	return &StaleResolution{
+		Address:    entry.Address,
+		IPs:        entry.IPs,
+		ResolvedAt: entry.ResolvedAt,
+		Expired:    entry.IsExpired(now),
+		Error:      lookupErr.Error(),
+	}
+}
+
+// SyncResult reports how a network's routes were applied.
+type SyncResult struct {
+	NetworkID uint64 `json:"NetworkID"`
+	// Applied is false when the network isn't the connected VPN and its routes weren't changed.
+	Applied bool `json:"Applied"`
+	// RouteCount is the number of routes handed to the system.
+	RouteCount int `json:"RouteCount"`
+	// StaleResolutions are the hostnames routed to cached IPs, empty when every lookup succeeded.
+	StaleResolutions []*StaleResolution `json:"StaleResolutions"`
+	// SkippedDNSDomains are DNS domains of the network whose resolver file Splitr doesn't own.
+	SkippedDNSDomains []string `json:"SkippedDNSDomains"`
+	// ScopedDNSError is why the network's resolver files couldn't be written, empty when they were.
+	ScopedDNSError string `json:"ScopedDNSError"`
+	// RouteConflicts warn about applied routes likely to break connectivity, empty when the routes weren't applied.
+	RouteConflicts []*RouteConflict `json:"RouteConflicts"`
+}
+
+// HasStaleResolutions reports whether any hostname was routed to cached IPs.
+func (r *SyncResult) HasStaleResolutions() bool {
+	return len(r.StaleResolutions) > 0
+}
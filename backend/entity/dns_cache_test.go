@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDNSCacheEntry(t *testing.T) {
+	entry := NewDNSCacheEntry("git.corp.example", []string{"10.0.0.5"}, 90*time.Second)
+
+	assert.Equal(t, "git.corp.example", entry.Address)
+	assert.Equal(t, IPList{"10.0.0.5"}, entry.IPs)
+	assert.Equal(t, int64(90), entry.TTL)
+	assert.WithinDuration(t, time.Now(), entry.ResolvedAt.Time, time.Second)
+}
+
+func TestDNSCacheEntry_IsExpired(t *testing.T) {
+	resolvedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := &DNSCacheEntry{ResolvedAt: TimestampFromTime(resolvedAt), TTL: 3600}
+
+	assert.Equal(t, resolvedAt.Add(time.Hour), entry.ExpiresAt())
+	assert.False(t, entry.IsExpired(resolvedAt.Add(59*time.Minute)))
+	assert.True(t, entry.IsExpired(resolvedAt.Add(time.Hour)))
+}
+
+func TestNewStaleResolution(t *testing.T) {
+	resolvedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := &DNSCacheEntry{
+		Address:    "git.corp.example",
+		IPs:        IPList{"10.0.0.5"},
+		ResolvedAt: TimestampFromTime(resolvedAt),
+		TTL:        3600,
+	}
+
+	staleResolution := NewStaleResolution(entry, errors.New("no such host"), resolvedAt.Add(2*time.Hour))
+
+	assert.Equal(t, &StaleResolution{
+		Address:    "git.corp.example",
+		IPs:        []string{"10.0.0.5"},
+		ResolvedAt: TimestampFromTime(resolvedAt),
+		Expired:    true,
+		Error:      "no such host",
+	}, staleResolution)
+}
+
+func TestSyncResult_HasStaleResolutions(t *testing.T) {
+	assert.False(t, (&SyncResult{}).HasStaleResolutions())
+
+	result := &SyncResult{StaleResolutions: []*StaleResolution{{Address: "git.corp.example"}}}
+	assert.True(t, result.HasStaleResolutions())
+}
@@ -11,8 +11,15 @@ type Host struct {
 	CreatedAt   Timestamp `db:"created_at"  json:"CreatedAt"`
 }
 
+// HostWithUsage is a library host together with the networks it is attached to.
+type HostWithUsage struct {
+	Host
+
+	UsedIn []*Network `json:"UsedIn"`
+}
+
 func NewHost(address, description string) (*Host, error) {
-	if !ipOrHostnameRegex.MatchString(address) {
+	if !IsValidAddress(address) {
 		return nil, errors.New("invalid address")
 	}
 
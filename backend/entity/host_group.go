@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// HostGroup is a named bundle of addresses that can be attached to several networks at once.
+type HostGroup struct {
+	ID          uint64    `db:"id"          json:"ID"`
+	Name        string    `db:"name"        json:"Name"`
+	Description *string   `db:"description" json:"Description"`
+	CreatedAt   Timestamp `db:"created_at"  json:"CreatedAt"`
+}
+
+// HostGroupHost is an address that belongs to a host group.
+type HostGroupHost struct {
+	ID          uint64    `db:"id"            json:"ID"`
+	HostGroupID uint64    `db:"host_group_id" json:"HostGroupID"`
+	Address     string    `db:"address"       json:"Address"`
+	Description *string   `db:"description"   json:"Description"`
+	CreatedAt   Timestamp `db:"created_at"    json:"CreatedAt"`
+}
+
+func NewHostGroup(name, description string) (*HostGroup, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("host group name is required")
+	}
+
+	hostGroup := &HostGroup{
+		Name:      name,
+		CreatedAt: NewTimestamp(),
+	}
+
+	if description != "" {
+		hostGroup.Description = &description
+	}
+
+	return hostGroup, nil
+}
+
+func NewHostGroupHost(hostGroupID uint64, address, description string) (*HostGroupHost, error) {
+	if !IsValidAddress(address) {
+		return nil, errors.New("invalid address")
+	}
+
+	hostGroupHost := &HostGroupHost{
+		HostGroupID: hostGroupID,
+		Address:     address,
+		CreatedAt:   NewTimestamp(),
+	}
+
+	if description != "" {
+		hostGroupHost.Description = &description
+	}
+
+	return hostGroupHost, nil
+}
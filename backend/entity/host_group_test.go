@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHostGroup(t *testing.T) {
+	tests := []struct {
+		name                string
+		groupName           string
+		description         string
+		expectedName        string
+		expectedDescription *string
+		expectedError       string
+	}{
+		{
+			name:                "name and description",
+			groupName:           "GitHub Enterprise",
+			description:         "Code hosting",
+			expectedName:        "GitHub Enterprise",
+			expectedDescription: stringPtr("Code hosting"),
+		},
+		{
+			name:         "name is trimmed",
+			groupName:    "  Build farm ",
+			expectedName: "Build farm",
+		},
+		{
+			name:          "empty name",
+			groupName:     "   ",
+			expectedError: "host group name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewHostGroup(tt.groupName, tt.description)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, result.Name)
+			assert.Equal(t, tt.expectedDescription, result.Description)
+			assert.False(t, result.CreatedAt.Time.IsZero())
+		})
+	}
+}
+
+func TestNewHostGroupHost(t *testing.T) {
+	t.Run("valid address", func(t *testing.T) {
+		result, err := NewHostGroupHost(3, "jira.example.com", "Jira")
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), result.HostGroupID)
+		assert.Equal(t, "jira.example.com", result.Address)
+		require.NotNil(t, result.Description)
+		assert.Equal(t, "Jira", *result.Description)
+	})
+
+	t.Run("CIDR without description", func(t *testing.T) {
+		result, err := NewHostGroupHost(3, "10.20.0.0/16", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "10.20.0.0/16", result.Address)
+		assert.Nil(t, result.Description)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		result, err := NewHostGroupHost(3, "not a host", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid address")
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// IPList is a list of IP addresses stored as comma-separated text.
+type IPList []string
+
+// Scan implements the sql.Scanner interface for database reads.
+func (l *IPList) Scan(value any) error {
+	values, err := scanCommaSeparated(value, "IPList")
+	if err != nil {
+		return err
+	}
+
+	*l = values
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database writes.
+func (l *IPList) Value() (driver.Value, error) {
+	return strings.Join(*l, ","), nil
+}
+
+// DomainList is a list of domain names stored as comma-separated text.
+type DomainList []string
+
+// Scan implements the sql.Scanner interface for database reads.
+func (l *DomainList) Scan(value any) error {
+	values, err := scanCommaSeparated(value, "DomainList")
+	if err != nil {
+		return err
+	}
+
+	*l = values
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database writes.
+func (l *DomainList) Value() (driver.Value, error) {
+	return strings.Join(*l, ","), nil
+}
+
+// scanCommaSeparated splits comma-separated text read from the database, empty text is no values.
+func scanCommaSeparated(value any, typeName string) ([]string, error) {
+	var text string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return nil, fmt.Errorf("cannot scan %T into %s", value, typeName)
+	}
+
+	if text == "" {
+		return nil, nil
+	}
+
+	return strings.Split(text, ","), nil
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPList_ScanValue(t *testing.T) {
+	ipList := IPList{"10.0.0.5", "10.0.0.6"}
+	value, err := ipList.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5,10.0.0.6", value)
+
+	var scanned IPList
+	require.NoError(t, scanned.Scan("10.0.0.5,10.0.0.6"))
+	assert.Equal(t, ipList, scanned)
+
+	require.NoError(t, scanned.Scan([]byte("")))
+	assert.Nil(t, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+
+	require.Error(t, scanned.Scan(42))
+}
+
+func TestDomainList_ScanValue(t *testing.T) {
+	domainList := DomainList{"corp.example.com", "lab.example.com"}
+	value, err := domainList.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "corp.example.com,lab.example.com", value)
+
+	var scanned DomainList
+	require.NoError(t, scanned.Scan([]byte("corp.example.com,lab.example.com")))
+	assert.Equal(t, domainList, scanned)
+
+	require.NoError(t, scanned.Scan(""))
+	assert.Nil(t, scanned)
+
+	err = scanned.Scan(42)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "into DomainList")
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+)
+
+// LearnedIP is an IP a subdomain of a wildcard network host was seen resolving to by the DNS forwarder.
+// It is routed like a resolved IP of the host until it expires.
+type LearnedIP struct {
+	NetworkHostID uint64 `db:"network_host_id" json:"NetworkHostID"`
+	NetworkID     uint64 `db:"network_id"      json:"NetworkID"`
+	IP            string `db:"ip"              json:"IP"`
+	// Name is the queried name the IP was learned from.
+	Name      string    `db:"name"       json:"Name"`
+	ExpiresAt Timestamp `db:"expires_at" json:"ExpiresAt"`
+}
+
+// NewLearnedIP records that name, a subdomain of the wildcard network host, resolved to ip for ttl.
+func NewLearnedIP(networkHost *NetworkHost, name, ip string, ttl time.Duration) *LearnedIP {
+	return &LearnedIP{
+		NetworkHostID: networkHost.ID,
+		NetworkID:     networkHost.NetworkID,
+		IP:            ip,
+		Name:          name,
+		ExpiresAt:     TimestampFromTime(time.Now().Add(ttl)),
+	}
+}
+
+// IsExpired reports whether the IP is no longer routed by now.
+func (l *LearnedIP) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt.Time)
+}
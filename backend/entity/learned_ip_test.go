@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLearnedIP(t *testing.T) {
+	networkHost := &NetworkHost{ID: 7, NetworkID: 3, Address: "*.corp.example.com"}
+
+	learnedIP := NewLearnedIP(networkHost, "git.corp.example.com", "10.0.0.5", time.Minute)
+
+	assert.Equal(t, uint64(7), learnedIP.NetworkHostID)
+	assert.Equal(t, uint64(3), learnedIP.NetworkID)
+	assert.Equal(t, "10.0.0.5", learnedIP.IP)
+	assert.Equal(t, "git.corp.example.com", learnedIP.Name)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), learnedIP.ExpiresAt.Time, time.Second)
+}
+
+func TestLearnedIP_IsExpired(t *testing.T) {
+	expiresAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	learnedIP := &LearnedIP{ExpiresAt: TimestampFromTime(expiresAt)}
+
+	assert.False(t, learnedIP.IsExpired(expiresAt.Add(-time.Second)))
+	assert.True(t, learnedIP.IsExpired(expiresAt))
+}
@@ -0,0 +1,14 @@
+package entity
+
+type ListHostGroupFilter struct {
+	ID        []uint64 `json:"id,omitempty"`
+	NetworkID []uint64 `json:"network_id,omitempty"`
+	Search    string   `json:"search,omitempty"`
+}
+
+type ListHostGroupHostFilter struct {
+	ID          []uint64 `json:"id,omitempty"`
+	HostGroupID []uint64 `json:"host_group_id,omitempty"`
+	// NetworkID selects the hosts of every group attached to the given networks.
+	NetworkID []uint64 `json:"network_id,omitempty"`
+}
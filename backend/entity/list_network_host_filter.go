@@ -1,8 +1,15 @@
 package entity
 
+import "time"
+
 type ListNetworkHostFilter struct {
-	ID        []uint64 `json:"id,omitempty"`
-	NetworkID []uint64 `json:"network_id,omitempty"`
-	Address   []string `json:"address,omitempty"`
-	Search    string   `json:"search,omitempty"`
+	ID             []uint64 `json:"id,omitempty"`
+	NetworkID      []uint64 `json:"network_id,omitempty"`
+	SubscriptionID []uint64 `json:"subscription_id,omitempty"`
+	HostID         []uint64 `json:"host_id,omitempty"`
+	Address        []string `json:"address,omitempty"`
+	Enabled        *bool    `json:"enabled,omitempty"`
+	Search         string   `json:"search,omitempty"`
+	// ExpiredAt selects temporary hosts that have expired by the given time.
+	ExpiredAt *time.Time `json:"expired_at,omitempty"`
 }
@@ -0,0 +1,6 @@
+package entity
+
+type ListNetworkSubscriptionFilter struct {
+	ID        []uint64 `json:"id,omitempty"`
+	NetworkID []uint64 `json:"network_id,omitempty"`
+}
@@ -1,9 +1,143 @@
 package entity
 
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// RoutingMode tells which traffic of a network goes through the VPN.
+type RoutingMode string
+
+const (
+	// RoutingModeSplit routes only the listed hosts through the VPN.
+	RoutingModeSplit RoutingMode = "split"
+	// RoutingModeInverse routes everything through the VPN except the listed hosts.
+	RoutingModeInverse RoutingMode = "inverse"
+)
+
+// ParseRoutingMode parses a routing mode, an empty value is split.
+func ParseRoutingMode(value string) (RoutingMode, error) {
+	switch mode := RoutingMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return RoutingModeSplit, nil
+	case RoutingModeSplit, RoutingModeInverse:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid routing mode %q", value)
+	}
+}
+
+// ParseDNSDomains validates domains to resolve through a network's nameservers,
+// dropping blanks, trailing root dots and duplicates.
+func ParseDNSDomains(values []string) (DomainList, error) {
+	domains := make(DomainList, 0, len(values))
+	for _, value := range values {
+		domain := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(value), "."))
+		if domain == "" {
+			continue
+		}
+
+		if !IsHostname(domain) {
+			return nil, fmt.Errorf("invalid DNS domain %q", value)
+		}
+
+		if !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+
+	return domains, nil
+}
+
+// ParseNameservers validates nameserver IPs, dropping blanks and duplicates.
+func ParseNameservers(values []string) (IPList, error) {
+	nameservers := make(IPList, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid nameserver %q: must be an IP address", value)
+		}
+
+		if !slices.Contains(nameservers, ip.String()) {
+			nameservers = append(nameservers, ip.String())
+		}
+	}
+
+	return nameservers, nil
+}
+
 type Network struct {
-	ID        uint64    `db:"id"         json:"ID"`
-	Name      string    `db:"name"       json:"Name"`
-	CreatedAt Timestamp `db:"created_at" json:"CreatedAt"`
+	ID          uint64      `db:"id"           json:"ID"`
+	Name        string      `db:"name"         json:"Name"`
+	RoutingMode RoutingMode `db:"routing_mode" json:"RoutingMode"`
+	CreatedAt   Timestamp   `db:"created_at"   json:"CreatedAt"`
+
+	// DNSDomains are resolved by DNSNameservers while the network is the active VPN, through resolver files.
+	DNSDomains     DomainList `db:"dns_domains"     json:"DNSDomains"`
+	DNSNameservers IPList     `db:"dns_nameservers" json:"DNSNameservers"`
+
+	// Router, SubnetMask and NetworkInterface override what sync detects from the default network service,
+	// see RouteOverride.
+	Router           string           `db:"router"            json:"Router"`
+	SubnetMask       string           `db:"subnet_mask"       json:"SubnetMask"`
+	NetworkInterface NetworkInterface `db:"network_interface" json:"NetworkInterface"`
+}
+
+// IsInverse reports whether the network routes everything through the VPN except its listed hosts.
+func (n *Network) IsInverse() bool {
+	return n.RoutingMode == RoutingModeInverse
+}
+
+// HasScopedDNS reports whether the network has domains to resolve through its own nameservers.
+func (n *Network) HasScopedDNS() bool {
+	return len(n.DNSDomains) > 0 && len(n.DNSNameservers) > 0
+}
+
+// SetDNS sets the domains resolved through the network's nameservers, no domains and nameservers clears them.
+// Domains are useless without nameservers and the other way round, so one can't be set without the other.
+func (n *Network) SetDNS(domains, nameservers []string) error {
+	dnsDomains, err := ParseDNSDomains(domains)
+	if err != nil {
+		return err
+	}
+
+	dnsNameservers, err := ParseNameservers(nameservers)
+	if err != nil {
+		return err
+	}
+
+	if (len(dnsDomains) == 0) != (len(dnsNameservers) == 0) {
+		return errors.New("DNS domains and nameservers must be set together")
+	}
+
+	n.DNSDomains = dnsDomains
+	n.DNSNameservers = dnsNameservers
+
+	return nil
+}
+
+// RouteOverride returns what the network overrides of the detected network info.
+func (n *Network) RouteOverride() RouteOverride {
+	return RouteOverride{
+		Router:           n.Router,
+		SubnetMask:       n.SubnetMask,
+		NetworkInterface: n.NetworkInterface,
+	}
+}
+
+// SetRouteOverride sets what the network overrides of the detected network info, an empty override clears it.
+func (n *Network) SetRouteOverride(override RouteOverride) {
+	n.Router = override.Router
+	n.SubnetMask = override.SubnetMask
+	n.NetworkInterface = override.NetworkInterface
 }
 
 type NetworkWithStatus struct {
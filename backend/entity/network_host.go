@@ -2,25 +2,132 @@ package entity
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"strings"
 	"time"
 )
 
+// NetworkHostKind tells whether a network host is routed through the VPN or punched out of the routed ranges.
+type NetworkHostKind string
+
+const (
+	NetworkHostKindInclude NetworkHostKind = "include"
+	NetworkHostKindExclude NetworkHostKind = "exclude"
+)
+
+// ParseNetworkHostKind parses a network host kind, an empty value is an include.
+func ParseNetworkHostKind(value string) (NetworkHostKind, error) {
+	switch kind := NetworkHostKind(strings.ToLower(strings.TrimSpace(value))); kind {
+	case "":
+		return NetworkHostKindInclude, nil
+	case NetworkHostKindInclude, NetworkHostKindExclude:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("invalid network host kind %q", value)
+	}
+}
+
+// NetworkHostExpiryAction is what happens to a temporary host once it expires.
+type NetworkHostExpiryAction string
+
+const (
+	NetworkHostExpiryActionDisable NetworkHostExpiryAction = "disable"
+	NetworkHostExpiryActionDelete  NetworkHostExpiryAction = "delete"
+)
+
+// NetworkHostPinMode tells how the pinned IPs of a hostname combine with what DNS returns for it.
+type NetworkHostPinMode string
+
+const (
+	// NetworkHostPinModeReplace routes only the pinned IPs, the hostname isn't resolved at all.
+	NetworkHostPinModeReplace NetworkHostPinMode = "replace"
+	// NetworkHostPinModeAppend routes the pinned IPs along with whatever the hostname resolves to.
+	NetworkHostPinModeAppend NetworkHostPinMode = "append"
+)
+
+// ParseNetworkHostPinMode parses a pin mode, an empty value replaces DNS results.
+func ParseNetworkHostPinMode(value string) (NetworkHostPinMode, error) {
+	switch mode := NetworkHostPinMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return NetworkHostPinModeReplace, nil
+	case NetworkHostPinModeReplace, NetworkHostPinModeAppend:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid pin mode %q", value)
+	}
+}
+
+// ParsePinnedIPs validates IPv4 addresses to pin, dropping blanks and duplicates.
+func ParsePinnedIPs(values []string) (IPList, error) {
+	pinnedIPs := make(IPList, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		ip := net.ParseIP(value).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid pinned IP %q: must be an IPv4 address", value)
+		}
+
+		if _, ok := seen[ip.String()]; ok {
+			continue
+		}
+		seen[ip.String()] = struct{}{}
+
+		pinnedIPs = append(pinnedIPs, ip.String())
+	}
+
+	return pinnedIPs, nil
+}
+
+// AddNetworkHostResult is a network host just added, with the route conflicts its address causes.
+type AddNetworkHostResult struct {
+	NetworkHost    *NetworkHost     `json:"NetworkHost"`
+	RouteConflicts []*RouteConflict `json:"RouteConflicts"`
+}
+
 type NetworkHost struct {
 	ID          uint64    `db:"id"          json:"ID"`
 	NetworkID   uint64    `db:"network_id"  json:"NetworkID"`
 	Address     string    `db:"address"     json:"Address"`
 	Description *string   `db:"description" json:"Description"`
 	CreatedAt   Timestamp `db:"created_at"  json:"CreatedAt"`
+
+	// SubscriptionID is set for hosts managed by a network subscription.
+	SubscriptionID *uint64 `db:"subscription_id" json:"SubscriptionID"`
+	// HostID is set for hosts attached from the host library, they follow changes to the library host.
+	HostID *uint64 `db:"host_id" json:"HostID"`
+	// Enabled is false for hosts that are kept in the network but not routed.
+	Enabled bool `db:"enabled" json:"Enabled"`
+	// ExpiresAt is set for temporary hosts, they are disabled or removed once it passes.
+	ExpiresAt *Timestamp `db:"expires_at" json:"ExpiresAt"`
+	// Kind is NetworkHostKindExclude for addresses that are never routed, even inside an included range.
+	Kind NetworkHostKind `db:"kind" json:"Kind"`
+	// PinnedIPs are routed for a hostname instead of or along with its DNS results, depending on PinMode.
+	PinnedIPs IPList             `db:"pinned_ips" json:"PinnedIPs"`
+	PinMode   NetworkHostPinMode `db:"pin_mode"   json:"PinMode"`
+	// Router, SubnetMask and NetworkInterface override what the host's routes take from the network,
+	// see RouteOverride.
+	Router           string           `db:"router"            json:"Router"`
+	SubnetMask       string           `db:"subnet_mask"       json:"SubnetMask"`
+	NetworkInterface NetworkInterface `db:"network_interface" json:"NetworkInterface"`
 }
 
 func NewNetworkHost(networkID uint64, address, description string) (*NetworkHost, error) {
-	if !ipOrHostnameRegex.MatchString(address) {
+	if !IsValidAddress(address) && !IsWildcard(address) {
 		return nil, errors.New("invalid address")
 	}
 
 	networkHost := &NetworkHost{
 		NetworkID: networkID,
 		Address:   address,
+		Enabled:   true,
+		Kind:      NetworkHostKindInclude,
+		PinMode:   NetworkHostPinModeReplace,
 		CreatedAt: NewTimestamp(),
 	}
 
@@ -31,6 +138,100 @@ func NewNetworkHost(networkID uint64, address, description string) (*NetworkHost
 	return networkHost, nil
 }
 
+// IsManaged reports whether the host is kept in sync by a subscription rather than added manually.
+func (h *NetworkHost) IsManaged() bool {
+	return h.SubscriptionID != nil
+}
+
+// IsExclusion reports whether the host is subtracted from the network's routes instead of routed.
+func (h *NetworkHost) IsExclusion() bool {
+	return h.Kind == NetworkHostKindExclude
+}
+
+// IsWildcard reports whether the host covers the subdomains of a domain. Such hosts are routed to the IPs
+// the DNS forwarder sees their subdomains resolve to.
+func (h *NetworkHost) IsWildcard() bool {
+	return IsWildcard(h.Address)
+}
+
+// HasPinnedIPs reports whether the host has IPs pinned to it.
+func (h *NetworkHost) HasPinnedIPs() bool {
+	return len(h.PinnedIPs) > 0
+}
+
+// PinIPs pins IPs to a hostname host, no IPs unpins it. IP and CIDR hosts can't have pinned IPs.
+func (h *NetworkHost) PinIPs(values []string, mode NetworkHostPinMode) error {
+	pinnedIPs, err := ParsePinnedIPs(values)
+	if err != nil {
+		return err
+	}
+
+	if len(pinnedIPs) > 0 && !IsHostname(h.Address) {
+		return fmt.Errorf("cannot pin IPs to %s: only hostnames can have pinned IPs", h.Address)
+	}
+
+	h.PinnedIPs = pinnedIPs
+	h.PinMode = mode
+	if h.PinMode == "" {
+		h.PinMode = NetworkHostPinModeReplace
+	}
+
+	return nil
+}
+
+// RouteOverride returns what the host overrides of the network info its routes are built with.
+func (h *NetworkHost) RouteOverride() RouteOverride {
+	return RouteOverride{
+		Router:           h.Router,
+		SubnetMask:       h.SubnetMask,
+		NetworkInterface: h.NetworkInterface,
+	}
+}
+
+// SetRouteOverride sets what the host overrides of the network info its routes are built with,
+// an empty override clears it. Exclusions aren't routed, so they can't have one.
+func (h *NetworkHost) SetRouteOverride(override RouteOverride) error {
+	if !override.IsEmpty() && h.IsExclusion() {
+		return fmt.Errorf("cannot override the route of %s: exclusions aren't routed", h.Address)
+	}
+
+	h.Router = override.Router
+	h.SubnetMask = override.SubnetMask
+	h.NetworkInterface = override.NetworkInterface
+
+	return nil
+}
+
+// ExpireAfter makes the host temporary, it expires once ttl has passed.
+func (h *NetworkHost) ExpireAfter(ttl time.Duration) {
+	expiresAt := TimestampFromTime(time.Now().Add(ttl).UTC())
+	h.ExpiresAt = &expiresAt
+}
+
+// IsExpired reports whether the host is temporary and has expired by now.
+func (h *NetworkHost) IsExpired(now time.Time) bool {
+	return h.ExpiresAt != nil && !h.ExpiresAt.After(now)
+}
+
+// ParseNetworkHostTTL parses how long a temporary host lives, e.g. "30m" or "4h".
+// An empty value means the host never expires and yields zero.
+func ParseNetworkHostTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid expiry duration %q: %w", value, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid expiry duration %q: must be positive", value)
+	}
+
+	return ttl, nil
+}
+
 // NetworkHostExportPayload represents the structure for exporting/importing network hosts
 // Used for system-wide exports that may include network ID context.
 type NetworkHostExportPayload struct {
@@ -50,4 +251,16 @@ type NetworkHostContextExportPayload struct {
 type NetworkHostDTO struct {
 	Address     string `json:"address"`
 	Description string `json:"description,omitempty"`
+	// Enabled is missing from exports made before hosts could be disabled, those hosts are enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Kind is missing from exports made before exclusions existed, those hosts are includes.
+	Kind NetworkHostKind `json:"kind,omitempty"`
+	// PinnedIPs and PinMode are missing from exports made before IPs could be pinned.
+	PinnedIPs []string           `json:"pinned_ips,omitempty"`
+	PinMode   NetworkHostPinMode `json:"pin_mode,omitempty"`
+}
+
+// IsEnabled reports whether the host should be imported as enabled.
+func (d NetworkHostDTO) IsEnabled() bool {
+	return d.Enabled == nil || *d.Enabled
 }
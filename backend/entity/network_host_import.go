@@ -0,0 +1,22 @@
+package entity
+
+// NetworkHostImportCandidate is a host found by an import source and offered for review before saving.
+type NetworkHostImportCandidate struct {
+	Address            string   `json:"address"`
+	Description        string   `json:"description,omitempty"`
+	SuggestedHostnames []string `json:"suggested_hostnames,omitempty"`
+	AlreadyExists      bool     `json:"already_exists"`
+}
+
+// NetworkHostImportSkipped is an entry of an import source that can't be turned into a host.
+type NetworkHostImportSkipped struct {
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// NetworkHostImportPreview lists what an import source would add to a network.
+type NetworkHostImportPreview struct {
+	NetworkID  uint64                       `json:"network_id"`
+	Candidates []NetworkHostImportCandidate `json:"candidates"`
+	Skipped    []NetworkHostImportSkipped   `json:"skipped,omitempty"`
+}
@@ -1,5 +1,9 @@
 package entity
 
+import (
+	"net/netip"
+)
+
 type NetworkHostSetup struct {
 	ID            uint64    `db:"id"              json:"ID"`
 	NetworkHostID uint64    `db:"network_host_id" json:"NetworkHostID"`
@@ -7,4 +11,12 @@ type NetworkHostSetup struct {
 	SubnetMask    string    `db:"subnet_mask"     json:"SubnetMask"`
 	Router        string    `db:"router"          json:"Router"`
 	CreatedAt     Timestamp `db:"created_at"      json:"CreatedAt"`
+	// NetworkHostIDs lists the network hosts an applied route serves once routes are de-duplicated
+	// and aggregated. It isn't stored.
+	NetworkHostIDs []uint64 `db:"-" json:"NetworkHostIDs,omitempty"`
+}
+
+// Prefix returns the IPv4 prefix the route covers.
+func (s *NetworkHostSetup) Prefix() (netip.Prefix, bool) {
+	return RoutePrefix(s.NetworkHostIP, s.SubnetMask)
 }
@@ -210,3 +210,12 @@ func TestNetworkHostSetup_DatabaseTags(t *testing.T) {
 	assert.Equal(t, "192.168.1.254", setup.Router)
 	assert.False(t, setup.CreatedAt.Time.IsZero())
 }
+
+func TestNetworkHostSetup_Prefix(t *testing.T) {
+	prefix, ok := (&NetworkHostSetup{NetworkHostIP: "10.20.0.0", SubnetMask: "255.255.0.0"}).Prefix()
+	assert.True(t, ok)
+	assert.Equal(t, "10.20.0.0/16", prefix.String())
+
+	_, ok = (&NetworkHostSetup{NetworkHostIP: "example.com", SubnetMask: "255.255.0.0"}).Prefix()
+	assert.False(t, ok)
+}
@@ -40,6 +40,18 @@ func TestNewNetworkHost_ValidInputs(t *testing.T) {
 			address:     "database.local",
 			description: "",
 		},
+		{
+			name:        "valid CIDR network",
+			networkID:   500,
+			address:     "10.20.0.0/16",
+			description: "Office subnet",
+		},
+		{
+			name:        "wildcard domain",
+			networkID:   600,
+			address:     "*.corp.example.com",
+			description: "Every corp subdomain",
+		},
 	}
 
 	for _, tt := range tests {
@@ -53,6 +65,9 @@ func TestNewNetworkHost_ValidInputs(t *testing.T) {
 			assert.Equal(t, tt.address, networkHost.Address)
 			assert.Equal(t, uint64(0), networkHost.ID)           // Should be zero for new host
 			assert.False(t, networkHost.CreatedAt.Time.IsZero()) // Should have timestamp
+			assert.True(t, networkHost.Enabled)
+			assert.Equal(t, NetworkHostKindInclude, networkHost.Kind)
+			assert.False(t, networkHost.IsExclusion())
 
 			if tt.description == "" {
 				assert.Nil(t, networkHost.Description)
@@ -85,6 +100,10 @@ func TestNewNetworkHost_InvalidAddresses(t *testing.T) {
 			name:    "space in address",
 			address: "192.168.1.1 ",
 		},
+		{
+			name:    "wildcard without domain",
+			address: "*.com1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -287,3 +306,172 @@ func TestNetworkHostContextExportPayload_EmptyHosts(t *testing.T) {
 
 	assert.Empty(t, payload.Hosts)
 }
+
+func TestNetworkHostDTO_IsEnabled(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	assert.True(t, NetworkHostDTO{Address: "a.example.com"}.IsEnabled())
+	assert.True(t, NetworkHostDTO{Address: "a.example.com", Enabled: &enabled}.IsEnabled())
+	assert.False(t, NetworkHostDTO{Address: "a.example.com", Enabled: &disabled}.IsEnabled())
+}
+
+func TestNetworkHost_ExpireAfter(t *testing.T) {
+	networkHost, err := NewNetworkHost(1, "vendor.example.com", "")
+	require.NoError(t, err)
+	assert.Nil(t, networkHost.ExpiresAt)
+	assert.False(t, networkHost.IsExpired(time.Now()))
+
+	networkHost.ExpireAfter(time.Hour)
+
+	require.NotNil(t, networkHost.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), networkHost.ExpiresAt.Time, time.Minute)
+	assert.False(t, networkHost.IsExpired(time.Now()))
+	assert.True(t, networkHost.IsExpired(time.Now().Add(2*time.Hour)))
+}
+
+func TestParseNetworkHostTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Duration
+		expectedErr string
+	}{
+		{name: "empty means permanent", value: "", expected: 0},
+		{name: "blank means permanent", value: "  ", expected: 0},
+		{name: "minutes", value: "30m", expected: 30 * time.Minute},
+		{name: "hours", value: "4h", expected: 4 * time.Hour},
+		{name: "not a duration", value: "soon", expectedErr: "invalid expiry duration"},
+		{name: "negative", value: "-1h", expectedErr: "must be positive"},
+		{name: "zero", value: "0s", expectedErr: "must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := ParseNetworkHostTTL(tt.value)
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ttl)
+		})
+	}
+}
+
+func TestParseNetworkHostKind(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    NetworkHostKind
+		expectedErr bool
+	}{
+		{name: "empty is include", value: "", expected: NetworkHostKindInclude},
+		{name: "include", value: "include", expected: NetworkHostKindInclude},
+		{name: "exclude", value: "exclude", expected: NetworkHostKindExclude},
+		{name: "case and spaces are ignored", value: " Exclude ", expected: NetworkHostKindExclude},
+		{name: "unknown", value: "block", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := ParseNetworkHostKind(tt.value)
+
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid network host kind")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, kind)
+		})
+	}
+}
+
+func TestParseNetworkHostPinMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    NetworkHostPinMode
+		expectedErr bool
+	}{
+		{name: "empty is replace", value: "", expected: NetworkHostPinModeReplace},
+		{name: "replace", value: "replace", expected: NetworkHostPinModeReplace},
+		{name: "append", value: " Append ", expected: NetworkHostPinModeAppend},
+		{name: "unknown", value: "prepend", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := ParseNetworkHostPinMode(tt.value)
+
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid pin mode")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestParsePinnedIPs(t *testing.T) {
+	pinnedIPs, err := ParsePinnedIPs([]string{" 10.0.0.5", "", "10.0.0.6", "10.0.0.5"})
+	require.NoError(t, err)
+	assert.Equal(t, IPList{"10.0.0.5", "10.0.0.6"}, pinnedIPs)
+
+	_, err = ParsePinnedIPs([]string{"fd00::1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an IPv4 address")
+
+	_, err = ParsePinnedIPs([]string{"10.0.0.0/8"})
+	require.Error(t, err)
+}
+
+func TestNetworkHost_PinIPs(t *testing.T) {
+	networkHost, err := NewNetworkHost(1, "git.corp.example", "")
+	require.NoError(t, err)
+	assert.False(t, networkHost.HasPinnedIPs())
+
+	err = networkHost.PinIPs([]string{"10.0.0.5"}, NetworkHostPinModeAppend)
+	require.NoError(t, err)
+	assert.True(t, networkHost.HasPinnedIPs())
+	assert.Equal(t, NetworkHostPinModeAppend, networkHost.PinMode)
+
+	err = networkHost.PinIPs(nil, "")
+	require.NoError(t, err)
+	assert.False(t, networkHost.HasPinnedIPs())
+	assert.Equal(t, NetworkHostPinModeReplace, networkHost.PinMode)
+
+	ipHost, err := NewNetworkHost(1, "10.1.2.3", "")
+	require.NoError(t, err)
+
+	err = ipHost.PinIPs([]string{"10.0.0.5"}, NetworkHostPinModeReplace)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only hostnames can have pinned IPs")
+
+	require.NoError(t, ipHost.PinIPs(nil, NetworkHostPinModeReplace))
+}
+
+func TestNetworkHost_SetRouteOverride(t *testing.T) {
+	networkHost, err := NewNetworkHost(1, "git.corp.example", "")
+	require.NoError(t, err)
+
+	override := RouteOverride{Router: "192.168.50.1", NetworkInterface: "en7"}
+	require.NoError(t, networkHost.SetRouteOverride(override))
+	assert.Equal(t, override, networkHost.RouteOverride())
+
+	require.NoError(t, networkHost.SetRouteOverride(RouteOverride{}))
+	assert.True(t, networkHost.RouteOverride().IsEmpty())
+
+	networkHost.Kind = NetworkHostKindExclude
+	err = networkHost.SetRouteOverride(override)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exclusions aren't routed")
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"net/netip"
 )
 
 type (
@@ -11,10 +12,18 @@ type (
 )
 
 type NetworkInfo struct {
-	SubnetMask string
-	Router     string
+	// NetworkInterface is the interface the network service was found by.
+	NetworkInterface NetworkInterface
+	NetworkService   NetworkService
+	SubnetMask       string
+	Router           string
 }
 
 func (n *NetworkInfo) String() string {
 	return fmt.Sprintf("Subnet Mask: %s, Router: %s", n.SubnetMask, n.Router)
 }
+
+// LocalPrefix returns the local subnet the physical network service is attached to.
+func (n *NetworkInfo) LocalPrefix() (netip.Prefix, bool) {
+	return RoutePrefix(n.Router, n.SubnetMask)
+}
@@ -188,3 +188,12 @@ func TestVPNService_CommonValues(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkInfo_LocalPrefix(t *testing.T) {
+	prefix, ok := (&NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}).LocalPrefix()
+	assert.True(t, ok)
+	assert.Equal(t, "192.168.1.0/24", prefix.String())
+
+	_, ok = (&NetworkInfo{}).LocalPrefix()
+	assert.False(t, ok)
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type NetworkSetupGenerationStatus string
+
+const (
+	NetworkSetupGenerationStatusPending NetworkSetupGenerationStatus = "pending"
+	NetworkSetupGenerationStatusApplied NetworkSetupGenerationStatus = "applied"
+	NetworkSetupGenerationStatusFailed  NetworkSetupGenerationStatus = "failed"
+)
+
+// NetworkSetupGeneration is the set of routes a sync hands to the system for a network. It is recorded as pending
+// before the routes change and marked applied once the network host setups are stored with it,
+// so a sync failing half way can restore the routes of the last applied generation.
+type NetworkSetupGeneration struct {
+	ID        uint64                       `db:"id"         json:"ID"`
+	NetworkID uint64                       `db:"network_id" json:"NetworkID"`
+	Status    NetworkSetupGenerationStatus `db:"status"     json:"Status"`
+	// NetworkService is the physical service DirectRoutes are set on, empty unless the network is in inverse mode.
+	NetworkService NetworkService `db:"network_service" json:"NetworkService"`
+	TunnelRoutes   RouteList      `db:"tunnel_routes"   json:"TunnelRoutes"`
+	DirectRoutes   RouteList      `db:"direct_routes"   json:"DirectRoutes"`
+	CreatedAt      Timestamp      `db:"created_at"      json:"CreatedAt"`
+}
+
+// NewNetworkSetupGeneration returns a pending generation of the routes set on the network's VPN service
+// and, with a network service, the routes set on the physical service.
+func NewNetworkSetupGeneration(
+	networkID uint64,
+	networkService NetworkService,
+	tunnelRoutes, directRoutes []*NetworkHostSetup,
+) *NetworkSetupGeneration {
+	return &NetworkSetupGeneration{
+		NetworkID:      networkID,
+		Status:         NetworkSetupGenerationStatusPending,
+		NetworkService: networkService,
+		TunnelRoutes:   tunnelRoutes,
+		DirectRoutes:   directRoutes,
+		CreatedAt:      NewTimestamp(),
+	}
+}
+
+// RouteList is a list of applied routes stored as JSON text. Only the address, subnet mask and router are kept.
+type RouteList []*NetworkHostSetup
+
+type storedRoute struct {
+	IP         string `json:"ip"`
+	SubnetMask string `json:"subnet_mask"`
+	Router     string `json:"router"`
+}
+
+// Scan implements the sql.Scanner interface for database reads.
+func (l *RouteList) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into RouteList", value)
+	}
+
+	if len(data) == 0 {
+		*l = nil
+		return nil
+	}
+
+	var routes []storedRoute
+	if err := json.Unmarshal(data, &routes); err != nil {
+		return fmt.Errorf("failed to unmarshal RouteList: %w", err)
+	}
+
+	list := make(RouteList, 0, len(routes))
+	for _, route := range routes {
+		list = append(list, &NetworkHostSetup{
+			NetworkHostIP: route.IP,
+			SubnetMask:    route.SubnetMask,
+			Router:        route.Router,
+		})
+	}
+
+	*l = list
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database writes.
+func (l *RouteList) Value() (driver.Value, error) {
+	routes := make([]storedRoute, 0, len(*l))
+	for _, setup := range *l {
+		routes = append(routes, storedRoute{
+			IP:         setup.NetworkHostIP,
+			SubnetMask: setup.SubnetMask,
+			Router:     setup.Router,
+		})
+	}
+
+	data, err := json.Marshal(routes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RouteList: %w", err)
+	}
+
+	return string(data), nil
+}
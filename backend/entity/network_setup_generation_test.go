@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNetworkSetupGeneration(t *testing.T) {
+	tunnelRoutes := []*NetworkHostSetup{{NetworkHostIP: "10.0.0.5", SubnetMask: "255.255.255.255"}}
+
+	generation := NewNetworkSetupGeneration(1, "Wi-Fi", tunnelRoutes, nil)
+
+	assert.Equal(t, uint64(1), generation.NetworkID)
+	assert.Equal(t, NetworkSetupGenerationStatusPending, generation.Status)
+	assert.Equal(t, NetworkService("Wi-Fi"), generation.NetworkService)
+	assert.Equal(t, RouteList(tunnelRoutes), generation.TunnelRoutes)
+	assert.Empty(t, generation.DirectRoutes)
+	assert.False(t, generation.CreatedAt.IsZero())
+}
+
+func TestRouteList_ScanValue(t *testing.T) {
+	routeList := RouteList{
+		{NetworkHostIP: "10.0.0.0", SubnetMask: "255.0.0.0", Router: "192.168.1.1"},
+		{NetworkHostIP: "10.99.0.0", SubnetMask: "255.255.0.0", Router: "192.168.1.1"},
+	}
+	value, err := routeList.Value()
+	require.NoError(t, err)
+	require.IsType(t, "", value)
+	assert.JSONEq(t, `[
+		{"ip": "10.0.0.0", "subnet_mask": "255.0.0.0", "router": "192.168.1.1"},
+		{"ip": "10.99.0.0", "subnet_mask": "255.255.0.0", "router": "192.168.1.1"}
+	]`, value.(string))
+
+	var scanned RouteList
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, routeList, scanned)
+
+	empty := RouteList{}
+	value, err = empty.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", value)
+	require.NoError(t, scanned.Scan([]byte("[]")))
+	assert.Empty(t, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+
+	require.Error(t, scanned.Scan("not json"))
+	require.Error(t, scanned.Scan(42))
+}
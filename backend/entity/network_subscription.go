@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// NetworkSubscription is a remote host list a network follows. Hosts it provides are managed:
+// they are added and removed on refresh, independently of manually added hosts. HostList keeps the last
+// fetched list, so the hosts can be reconciled again while the server reports the list unchanged.
+type NetworkSubscription struct {
+	ID              uint64     `db:"id"                json:"ID"`
+	NetworkID       uint64     `db:"network_id"        json:"NetworkID"`
+	URL             string     `db:"url"               json:"URL"`
+	ETag            *string    `db:"etag"              json:"ETag"`
+	LastRefreshedAt *Timestamp `db:"last_refreshed_at" json:"LastRefreshedAt"`
+	LastError       *string    `db:"last_error"        json:"LastError"`
+	HostList        *string    `db:"host_list"         json:"-"`
+	CreatedAt       Timestamp  `db:"created_at"        json:"CreatedAt"`
+}
+
+func NewNetworkSubscription(networkID uint64, rawURL string) (*NetworkSubscription, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" {
+		return nil, errors.New("invalid subscription URL")
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, errors.New("subscription URL must use http or https")
+	}
+
+	return &NetworkSubscription{
+		NetworkID: networkID,
+		URL:       rawURL,
+		CreatedAt: NewTimestamp(),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNetworkSubscription(t *testing.T) {
+	tests := []struct {
+		name          string
+		rawURL        string
+		expectedURL   string
+		expectedError string
+	}{
+		{
+			name:        "https URL",
+			rawURL:      "https://it.corp.example/vpn-hosts.json",
+			expectedURL: "https://it.corp.example/vpn-hosts.json",
+		},
+		{
+			name:        "http URL with surrounding spaces",
+			rawURL:      "  http://10.0.0.1:8080/hosts.txt ",
+			expectedURL: "http://10.0.0.1:8080/hosts.txt",
+		},
+		{
+			name:          "unsupported scheme",
+			rawURL:        "ftp://it.corp.example/hosts.txt",
+			expectedError: "subscription URL must use http or https",
+		},
+		{
+			name:          "missing host",
+			rawURL:        "https:///hosts.txt",
+			expectedError: "invalid subscription URL",
+		},
+		{
+			name:          "not a URL",
+			rawURL:        "hosts.txt",
+			expectedError: "invalid subscription URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, err := NewNetworkSubscription(10, tt.rawURL)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, subscription)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, uint64(10), subscription.NetworkID)
+			assert.Equal(t, tt.expectedURL, subscription.URL)
+			assert.Nil(t, subscription.ETag)
+			assert.Nil(t, subscription.LastRefreshedAt)
+			assert.False(t, subscription.CreatedAt.Time.IsZero())
+		})
+	}
+}
+
+func TestNetworkHost_IsManaged(t *testing.T) {
+	subscriptionID := uint64(1)
+
+	assert.False(t, (&NetworkHost{Address: "example.com"}).IsManaged())
+	assert.True(t, (&NetworkHost{Address: "example.com", SubscriptionID: &subscriptionID}).IsManaged())
+}
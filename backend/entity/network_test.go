@@ -155,3 +155,94 @@ func TestNetworkWithStatus_EmbeddedNetworkAccess(t *testing.T) {
 	assert.Equal(t, "Embedded Test", networkWithStatus.Name)
 	assert.Equal(t, timestamp, networkWithStatus.CreatedAt)
 }
+
+func TestParseRoutingMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    RoutingMode
+		expectedErr bool
+	}{
+		{name: "empty is split", value: "", expected: RoutingModeSplit},
+		{name: "split", value: "split", expected: RoutingModeSplit},
+		{name: "inverse", value: "inverse", expected: RoutingModeInverse},
+		{name: "case and spaces are ignored", value: " Inverse ", expected: RoutingModeInverse},
+		{name: "unknown", value: "full", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := ParseRoutingMode(tt.value)
+
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid routing mode")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestNetwork_IsInverse(t *testing.T) {
+	assert.False(t, (&Network{}).IsInverse())
+	assert.False(t, (&Network{RoutingMode: RoutingModeSplit}).IsInverse())
+	assert.True(t, (&Network{RoutingMode: RoutingModeInverse}).IsInverse())
+}
+
+func TestParseDNSDomains(t *testing.T) {
+	domains, err := ParseDNSDomains([]string{" Corp.Example.com.", "", "corp.example.com", "lab.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, DomainList{"corp.example.com", "lab.example.com"}, domains)
+
+	_, err = ParseDNSDomains([]string{"10.0.0.1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid DNS domain "10.0.0.1"`)
+
+	_, err = ParseDNSDomains([]string{"../etc/passwd"})
+	require.Error(t, err)
+}
+
+func TestParseNameservers(t *testing.T) {
+	nameservers, err := ParseNameservers([]string{" 10.8.0.1", "", "FD00::53", "10.8.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, IPList{"10.8.0.1", "fd00::53"}, nameservers)
+
+	_, err = ParseNameservers([]string{"dns.example.com"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an IP address")
+}
+
+func TestNetwork_SetDNS(t *testing.T) {
+	network := &Network{}
+
+	require.NoError(t, network.SetDNS([]string{"corp.example.com"}, []string{"10.8.0.1"}))
+	assert.Equal(t, DomainList{"corp.example.com"}, network.DNSDomains)
+	assert.Equal(t, IPList{"10.8.0.1"}, network.DNSNameservers)
+	assert.True(t, network.HasScopedDNS())
+
+	err := network.SetDNS([]string{"corp.example.com"}, nil)
+	require.EqualError(t, err, "DNS domains and nameservers must be set together")
+	assert.True(t, network.HasScopedDNS())
+
+	require.Error(t, network.SetDNS(nil, []string{"not an ip"}))
+
+	require.NoError(t, network.SetDNS(nil, nil))
+	assert.Empty(t, network.DNSDomains)
+	assert.Empty(t, network.DNSNameservers)
+	assert.False(t, network.HasScopedDNS())
+}
+
+func TestNetwork_SetRouteOverride(t *testing.T) {
+	network := &Network{ID: 1, Name: "Corp"}
+	assert.True(t, network.RouteOverride().IsEmpty())
+
+	override := RouteOverride{Router: "192.168.50.1", SubnetMask: "255.255.255.0", NetworkInterface: "en7"}
+	network.SetRouteOverride(override)
+	assert.Equal(t, override, network.RouteOverride())
+
+	network.SetRouteOverride(RouteOverride{})
+	assert.True(t, network.RouteOverride().IsEmpty())
+}
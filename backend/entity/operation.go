@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+)
+
+type OperationKind string
+
+const (
+	OperationKindSync        OperationKind = "sync"
+	OperationKindReset       OperationKind = "reset"
+	OperationKindImport      OperationKind = "import"
+	OperationKindUpdateCheck OperationKind = "update_check"
+)
+
+// Operation is a long-running call the UI is waiting for. It can be cancelled by ID and is cancelled
+// by itself once its deadline passes.
+type Operation struct {
+	ID   uint64        `json:"ID"`
+	Kind OperationKind `json:"Kind"`
+	// NetworkID is the network a sync, reset or import works on, zero for other operations.
+	NetworkID uint64    `json:"NetworkID,omitempty"`
+	StartedAt Timestamp `json:"StartedAt"`
+	Deadline  Timestamp `json:"Deadline"`
+}
+
+// NewOperation returns an operation started just now that may run for timeout.
+func NewOperation(id uint64, kind OperationKind, networkID uint64, timeout time.Duration) *Operation {
+	startedAt := NewTimestamp()
+
+	return &Operation{
+		ID:        id,
+		Kind:      kind,
+		NetworkID: networkID,
+		StartedAt: startedAt,
+		Deadline:  TimestampFromTime(startedAt.Add(timeout)),
+	}
+}
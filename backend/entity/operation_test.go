@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOperation(t *testing.T) {
+	operation := NewOperation(3, OperationKindSync, 7, time.Minute)
+
+	assert.Equal(t, uint64(3), operation.ID)
+	assert.Equal(t, OperationKindSync, operation.Kind)
+	assert.Equal(t, uint64(7), operation.NetworkID)
+	assert.False(t, operation.StartedAt.IsZero())
+	assert.Equal(t, time.Minute, operation.Deadline.Sub(operation.StartedAt.Time))
+}
@@ -0,0 +1,206 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PACProxyDirect = PACProxy("DIRECT")
+
+	pacFunctionIndent = "    "
+)
+
+// PACProxy is a FindProxyForURL return value, e.g. "PROXY proxy.example.com:3128".
+type PACProxy string
+
+// NewPACProxy builds a PAC proxy directive from a user supplied target.
+// It accepts either a bare "host:port", which is treated as an HTTP proxy,
+// or a full directive such as "SOCKS5 host:port" or "DIRECT".
+func NewPACProxy(target string) (PACProxy, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", errors.New("proxy target is required")
+	}
+
+	if strings.EqualFold(target, string(PACProxyDirect)) {
+		return PACProxyDirect, nil
+	}
+
+	keyword := "PROXY"
+	hostPort := target
+	if fields := strings.Fields(target); len(fields) == 2 { //nolint:mnd // keyword and host:port
+		keyword = strings.ToUpper(fields[0])
+		hostPort = fields[1]
+	}
+
+	if !isPACProxyKeyword(keyword) {
+		return "", fmt.Errorf("unsupported proxy type %q", keyword)
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", fmt.Errorf("invalid proxy address %q: %w", hostPort, err)
+	}
+
+	if host == "" {
+		return "", fmt.Errorf("invalid proxy address %q: missing host", hostPort)
+	}
+
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || portNumber == 0 {
+		return "", fmt.Errorf("invalid proxy port %q", port)
+	}
+
+	return PACProxy(keyword + " " + net.JoinHostPort(host, port)), nil
+}
+
+// isPACProxyKeyword reports whether keyword is a proxy type understood by FindProxyForURL.
+func isPACProxyKeyword(keyword string) bool {
+	switch keyword {
+	case "PROXY", "SOCKS", "SOCKS4", "SOCKS5", "HTTP", "HTTPS":
+		return true
+	default:
+		return false
+	}
+}
+
+// PACFile is a proxy auto-config script routing a network's hosts through a proxy.
+type PACFile struct {
+	NetworkName string
+	Proxy       PACProxy
+	Hosts       []*NetworkHost
+	GeneratedAt time.Time
+}
+
+// String renders the PAC file as JavaScript. Hostnames are matched exactly and
+// by subdomain, IPs and CIDRs are matched against the resolved host address.
+// IPv6 addresses need isInNetEx and dnsResolveEx, browsers without them skip those rules.
+// Exclusions are checked first and always go direct.
+func (p *PACFile) String() string {
+	var excludedRules, includedRules pacRules
+	for _, networkHost := range p.Hosts {
+		if networkHost.IsExclusion() {
+			excludedRules.add(networkHost.Address)
+			continue
+		}
+
+		includedRules.add(networkHost.Address)
+	}
+
+	direct := strconv.Quote(string(PACProxyDirect))
+	proxy := strconv.Quote(string(p.Proxy))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// Proxy auto-config for network %q.\n", p.NetworkName)
+	fmt.Fprintf(&sb, "// Generated by Splitr at %s.\n", p.GeneratedAt.Format(time.RFC3339))
+	sb.WriteString("function FindProxyForURL(url, host) {\n")
+
+	for _, rule := range excludedRules.hostnameRules {
+		fmt.Fprintf(&sb, "%sif (%s) return %s;\n", pacFunctionIndent, rule, direct)
+	}
+	for _, rule := range includedRules.hostnameRules {
+		fmt.Fprintf(&sb, "%sif (%s) return %s;\n", pacFunctionIndent, rule, proxy)
+	}
+
+	if len(excludedRules.netRules)+len(includedRules.netRules) > 0 {
+		fmt.Fprintf(&sb, "%svar ip = dnsResolve(host);\n", pacFunctionIndent)
+		fmt.Fprintf(&sb, "%sif (ip) {\n", pacFunctionIndent)
+		for _, rule := range excludedRules.netRules {
+			fmt.Fprintf(&sb, "%s%sif (%s) return %s;\n", pacFunctionIndent, pacFunctionIndent, rule, direct)
+		}
+		for _, rule := range includedRules.netRules {
+			fmt.Fprintf(&sb, "%s%sif (%s) return %s;\n", pacFunctionIndent, pacFunctionIndent, rule, proxy)
+		}
+		fmt.Fprintf(&sb, "%s}\n", pacFunctionIndent)
+	}
+
+	if len(excludedRules.netExRules)+len(includedRules.netExRules) > 0 {
+		fmt.Fprintf(
+			&sb,
+			"%svar ips = typeof dnsResolveEx === %s ? dnsResolveEx(host) : %s;\n",
+			pacFunctionIndent,
+			strconv.Quote("function"),
+			strconv.Quote(""),
+		)
+		fmt.Fprintf(&sb, "%sif (ips) {\n", pacFunctionIndent)
+		fmt.Fprintf(&sb, "%s%sips = ips.split(\";\");\n", pacFunctionIndent, pacFunctionIndent)
+		writePACNetExRules(&sb, excludedRules.netExRules, direct)
+		writePACNetExRules(&sb, includedRules.netExRules, proxy)
+		fmt.Fprintf(&sb, "%s}\n", pacFunctionIndent)
+	}
+
+	fmt.Fprintf(&sb, "%sreturn %s;\n", pacFunctionIndent, direct)
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// writePACNetExRules checks every address dnsResolveEx returned against IPv6 rules.
+func writePACNetExRules(sb *strings.Builder, rules []string, result string) {
+	if len(rules) == 0 {
+		return
+	}
+
+	indent := strings.Repeat(pacFunctionIndent, 2) //nolint:mnd // inside the function and the ips check
+	fmt.Fprintf(sb, "%sfor (var i = 0; i < ips.length; i++) {\n", indent)
+	for _, rule := range rules {
+		fmt.Fprintf(sb, "%s%sif (%s) return %s;\n", indent, pacFunctionIndent, rule, result)
+	}
+	fmt.Fprintf(sb, "%s}\n", indent)
+}
+
+// pacRules collects FindProxyForURL conditions matching a set of addresses.
+// IPv4 addresses and networks go to netRules, IPv6 ones to netExRules.
+type pacRules struct {
+	hostnameRules []string
+	netRules      []string
+	netExRules    []string
+}
+
+func (r *pacRules) add(address string) {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	if ip, ipNet, err := net.ParseCIDR(address); err == nil {
+		if ip.To4() != nil {
+			r.netRules = append(r.netRules, pacIsInNet(ipNet.IP.String(), net.IP(ipNet.Mask).String()))
+			return
+		}
+
+		r.netExRules = append(r.netExRules, pacIsInNetEx(ipNet.String()))
+		return
+	}
+
+	if ip := net.ParseIP(address); ip != nil {
+		if ip.To4() != nil {
+			r.netRules = append(r.netRules, pacIsInNet(ip.String(), hostSubnetMask))
+			return
+		}
+
+		r.netExRules = append(r.netExRules, pacIsInNetEx(ip.String()+"/128"))
+		return
+	}
+
+	if suffix, ok := WildcardSuffix(address); ok {
+		r.hostnameRules = append(r.hostnameRules, fmt.Sprintf("dnsDomainIs(host, %s)", strconv.Quote("."+suffix)))
+		return
+	}
+
+	r.hostnameRules = append(r.hostnameRules, fmt.Sprintf(
+		"shExpMatch(host, %s) || dnsDomainIs(host, %s)",
+		strconv.Quote(address),
+		strconv.Quote("."+address),
+	))
+}
+
+func pacIsInNet(ip, mask string) string {
+	return fmt.Sprintf("isInNet(ip, %s, %s)", strconv.Quote(ip), strconv.Quote(mask))
+}
+
+func pacIsInNetEx(prefix string) string {
+	return fmt.Sprintf("isInNetEx(ips[i], %s)", strconv.Quote(prefix))
+}
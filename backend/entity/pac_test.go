@@ -0,0 +1,237 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPACProxy(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expected      PACProxy
+		expectedError string
+	}{
+		{
+			name:     "bare host and port defaults to PROXY",
+			target:   "proxy.example.com:3128",
+			expected: "PROXY proxy.example.com:3128",
+		},
+		{
+			name:     "explicit SOCKS5 directive",
+			target:   "socks5 10.0.0.1:1080",
+			expected: "SOCKS5 10.0.0.1:1080",
+		},
+		{
+			name:     "direct",
+			target:   " direct ",
+			expected: PACProxyDirect,
+		},
+		{
+			name:          "empty target",
+			target:        "",
+			expectedError: "proxy target is required",
+		},
+		{
+			name:          "unsupported proxy type",
+			target:        "FTP proxy.example.com:21",
+			expectedError: "unsupported proxy type",
+		},
+		{
+			name:          "missing port",
+			target:        "proxy.example.com",
+			expectedError: "invalid proxy address",
+		},
+		{
+			name:          "invalid port",
+			target:        "proxy.example.com:http",
+			expectedError: "invalid proxy port",
+		},
+		{
+			name:          "zero port",
+			target:        "proxy.example.com:0",
+			expectedError: "invalid proxy port",
+		},
+		{
+			name:          "missing host",
+			target:        ":8080",
+			expectedError: "missing host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewPACProxy(tt.target)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Empty(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPACFile_String(t *testing.T) {
+	generatedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("hostnames and IPs", func(t *testing.T) {
+		pacFile := &PACFile{
+			NetworkName: "Corp VPN",
+			Proxy:       "PROXY proxy.example.com:3128",
+			Hosts: []*NetworkHost{
+				{Address: "git.example.com"},
+				{Address: "10.1.2.3"},
+				{Address: "10.20.0.0/16"},
+			},
+			GeneratedAt: generatedAt,
+		}
+
+		expected := `// Proxy auto-config for network "Corp VPN".
+// Generated by Splitr at 2024-05-01T10:00:00Z.
+function FindProxyForURL(url, host) {
+    if (shExpMatch(host, "git.example.com") || dnsDomainIs(host, ".git.example.com")) return "PROXY proxy.example.com:3128";
+    var ip = dnsResolve(host);
+    if (ip) {
+        if (isInNet(ip, "10.1.2.3", "255.255.255.255")) return "PROXY proxy.example.com:3128";
+        if (isInNet(ip, "10.20.0.0", "255.255.0.0")) return "PROXY proxy.example.com:3128";
+    }
+    return "DIRECT";
+}
+`
+		assert.Equal(t, expected, pacFile.String())
+	})
+
+	t.Run("hostnames only skip DNS resolution", func(t *testing.T) {
+		pacFile := &PACFile{
+			NetworkName: "Corp VPN",
+			Proxy:       "SOCKS5 127.0.0.1:1080",
+			Hosts:       []*NetworkHost{{Address: "Jira.Example.com"}},
+			GeneratedAt: generatedAt,
+		}
+
+		result := pacFile.String()
+		assert.Contains(t, result, `shExpMatch(host, "jira.example.com")`)
+		assert.Contains(t, result, `dnsDomainIs(host, ".jira.example.com")`)
+		assert.NotContains(t, result, "dnsResolve")
+	})
+
+	t.Run("wildcards match subdomains only", func(t *testing.T) {
+		pacFile := &PACFile{
+			NetworkName: "Corp VPN",
+			Proxy:       "PROXY proxy.example.com:3128",
+			Hosts:       []*NetworkHost{{Address: "*.Corp.example.com"}},
+			GeneratedAt: generatedAt,
+		}
+
+		result := pacFile.String()
+		assert.Contains(t, result, `if (dnsDomainIs(host, ".corp.example.com")) return "PROXY proxy.example.com:3128";`)
+		assert.NotContains(t, result, "shExpMatch")
+	})
+
+	t.Run("exclusions go direct before included ranges", func(t *testing.T) {
+		pacFile := &PACFile{
+			NetworkName: "Corp VPN",
+			Proxy:       "PROXY proxy.example.com:3128",
+			Hosts: []*NetworkHost{
+				{Address: "10.0.0.0/8", Kind: NetworkHostKindInclude},
+				{Address: "10.99.0.0/16", Kind: NetworkHostKindExclude},
+				{Address: "printer.example.com", Kind: NetworkHostKindExclude},
+			},
+			GeneratedAt: generatedAt,
+		}
+
+		expected := `// Proxy auto-config for network "Corp VPN".
+// Generated by Splitr at 2024-05-01T10:00:00Z.
+function FindProxyForURL(url, host) {
+    if (shExpMatch(host, "printer.example.com") || dnsDomainIs(host, ".printer.example.com")) return "DIRECT";
+    var ip = dnsResolve(host);
+    if (ip) {
+        if (isInNet(ip, "10.99.0.0", "255.255.0.0")) return "DIRECT";
+        if (isInNet(ip, "10.0.0.0", "255.0.0.0")) return "PROXY proxy.example.com:3128";
+    }
+    return "DIRECT";
+}
+`
+		assert.Equal(t, expected, pacFile.String())
+	})
+
+	t.Run("IPv6 literals match a single resolved address", func(t *testing.T) {
+		pacFile := &PACFile{
+			NetworkName: "Corp VPN",
+			Proxy:       "PROXY proxy.example.com:3128",
+			Hosts: []*NetworkHost{
+				{Address: "10.1.2.3"},
+				{Address: "2001:DB8::1"},
+			},
+			GeneratedAt: generatedAt,
+		}
+
+		expected := `// Proxy auto-config for network "Corp VPN".
+// Generated by Splitr at 2024-05-01T10:00:00Z.
+function FindProxyForURL(url, host) {
+    var ip = dnsResolve(host);
+    if (ip) {
+        if (isInNet(ip, "10.1.2.3", "255.255.255.255")) return "PROXY proxy.example.com:3128";
+    }
+    var ips = typeof dnsResolveEx === "function" ? dnsResolveEx(host) : "";
+    if (ips) {
+        ips = ips.split(";");
+        for (var i = 0; i < ips.length; i++) {
+            if (isInNetEx(ips[i], "2001:db8::1/128")) return "PROXY proxy.example.com:3128";
+        }
+    }
+    return "DIRECT";
+}
+`
+		assert.Equal(t, expected, pacFile.String())
+	})
+
+	t.Run("IPv6 CIDR exclusions go direct before included ranges", func(t *testing.T) {
+		pacFile := &PACFile{
+			NetworkName: "Corp VPN",
+			Proxy:       "PROXY proxy.example.com:3128",
+			Hosts: []*NetworkHost{
+				{Address: "2001:db8::/32", Kind: NetworkHostKindInclude},
+				{Address: "2001:db8:ff::/48", Kind: NetworkHostKindExclude},
+			},
+			GeneratedAt: generatedAt,
+		}
+
+		expected := `// Proxy auto-config for network "Corp VPN".
+// Generated by Splitr at 2024-05-01T10:00:00Z.
+function FindProxyForURL(url, host) {
+    var ips = typeof dnsResolveEx === "function" ? dnsResolveEx(host) : "";
+    if (ips) {
+        ips = ips.split(";");
+        for (var i = 0; i < ips.length; i++) {
+            if (isInNetEx(ips[i], "2001:db8:ff::/48")) return "DIRECT";
+        }
+        for (var i = 0; i < ips.length; i++) {
+            if (isInNetEx(ips[i], "2001:db8::/32")) return "PROXY proxy.example.com:3128";
+        }
+    }
+    return "DIRECT";
+}
+`
+		assert.Equal(t, expected, pacFile.String())
+	})
+
+	t.Run("no hosts", func(t *testing.T) {
+		pacFile := &PACFile{
+			NetworkName: "Empty",
+			Proxy:       "PROXY proxy.example.com:3128",
+			GeneratedAt: generatedAt,
+		}
+
+		result := pacFile.String()
+		assert.Contains(t, result, "function FindProxyForURL(url, host) {\n    return \"DIRECT\";\n}\n")
+	})
+}
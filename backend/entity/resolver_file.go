@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolverFile records a resolver(5) file Splitr created for a domain of a network.
+// Splitr only rewrites and removes files it has a record of.
+type ResolverFile struct {
+	Domain    string    `db:"domain"     json:"Domain"`
+	NetworkID uint64    `db:"network_id" json:"NetworkID"`
+	CreatedAt Timestamp `db:"created_at" json:"CreatedAt"`
+}
+
+// NewResolverFile records the resolver file of a domain of the network.
+func NewResolverFile(network *Network, domain string) *ResolverFile {
+	return &ResolverFile{
+		Domain:    domain,
+		NetworkID: network.ID,
+		CreatedAt: NewTimestamp(),
+	}
+}
+
+// ResolverFileContent renders the resolver(5) file sending queries for domain to the network's nameservers.
+func ResolverFileContent(network *Network, domain string) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Created by Splitr for network %q, removed when the network disconnects.\n", network.Name)
+	fmt.Fprintf(&sb, "domain %s\n", domain)
+	for _, nameserver := range network.DNSNameservers {
+		fmt.Fprintf(&sb, "nameserver %s\n", nameserver)
+	}
+
+	return []byte(sb.String())
+}
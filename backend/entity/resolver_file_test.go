@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResolverFile(t *testing.T) {
+	resolverFile := NewResolverFile(&Network{ID: 3, Name: "Corp"}, "corp.example.com")
+
+	assert.Equal(t, "corp.example.com", resolverFile.Domain)
+	assert.Equal(t, uint64(3), resolverFile.NetworkID)
+	assert.WithinDuration(t, time.Now(), resolverFile.CreatedAt.Time, time.Second)
+}
+
+func TestResolverFileContent(t *testing.T) {
+	network := &Network{Name: "Corp VPN", DNSNameservers: IPList{"10.8.0.1", "fd00::53"}}
+
+	content := ResolverFileContent(network, "corp.example.com")
+
+	assert.Equal(t, `# Created by Splitr for network "Corp VPN", removed when the network disconnects.
+domain corp.example.com
+nameserver 10.8.0.1
+nameserver fd00::53
+`, string(content))
+}
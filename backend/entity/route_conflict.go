@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"fmt"
+)
+
+// RouteConflictKind tells what a network's route collides with.
+type RouteConflictKind string
+
+const (
+	// RouteConflictKindLocalSubnet is a route overlapping the local subnet, which cuts the machine off its LAN.
+	RouteConflictKindLocalSubnet RouteConflictKind = "local_subnet"
+	// RouteConflictKindNetwork is a route overlapping a route of another network.
+	RouteConflictKindNetwork RouteConflictKind = "network"
+	// RouteConflictKindVPNServer is a route covering the VPN server, which sends the tunnel through itself.
+	RouteConflictKindVPNServer RouteConflictKind = "vpn_server"
+)
+
+// RouteConflict is a warning about a route that is likely to break connectivity once applied.
+type RouteConflict struct {
+	Kind           RouteConflictKind `json:"Kind"`
+	NetworkID      uint64            `json:"NetworkID"`
+	NetworkName    string            `json:"NetworkName"`
+	NetworkHostIDs []uint64          `json:"NetworkHostIDs,omitempty"`
+	Route          string            `json:"Route"`
+	// ConflictsWith is the local subnet, the other network's route or the VPN server address.
+	ConflictsWith    string `json:"ConflictsWith"`
+	OtherNetworkID   uint64 `json:"OtherNetworkID,omitempty"`
+	OtherNetworkName string `json:"OtherNetworkName,omitempty"`
+}
+
+func (c *RouteConflict) String() string {
+	switch c.Kind {
+	case RouteConflictKindLocalSubnet:
+		return fmt.Sprintf("route %s of network %q overlaps the local subnet %s",
+			c.Route, c.NetworkName, c.ConflictsWith)
+	case RouteConflictKindNetwork:
+		return fmt.Sprintf("route %s of network %q overlaps route %s of network %q",
+			c.Route, c.NetworkName, c.ConflictsWith, c.OtherNetworkName)
+	case RouteConflictKindVPNServer:
+		return fmt.Sprintf("route %s of network %q covers its VPN server %s",
+			c.Route, c.NetworkName, c.ConflictsWith)
+	default:
+		return fmt.Sprintf("route %s of network %q conflicts with %s", c.Route, c.NetworkName, c.ConflictsWith)
+	}
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteConflict_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		conflict RouteConflict
+		expected string
+	}{
+		{
+			name: "local subnet",
+			conflict: RouteConflict{
+				Kind:          RouteConflictKindLocalSubnet,
+				NetworkName:   "Corp",
+				Route:         "192.168.0.0/16",
+				ConflictsWith: "192.168.1.0/24",
+			},
+			expected: `route 192.168.0.0/16 of network "Corp" overlaps the local subnet 192.168.1.0/24`,
+		},
+		{
+			name: "other network",
+			conflict: RouteConflict{
+				Kind:             RouteConflictKindNetwork,
+				NetworkName:      "Corp",
+				Route:            "10.0.0.0/8",
+				ConflictsWith:    "10.20.0.0/16",
+				OtherNetworkName: "Lab",
+			},
+			expected: `route 10.0.0.0/8 of network "Corp" overlaps route 10.20.0.0/16 of network "Lab"`,
+		},
+		{
+			name: "VPN server",
+			conflict: RouteConflict{
+				Kind:          RouteConflictKindVPNServer,
+				NetworkName:   "Corp",
+				Route:         "203.0.113.0/24",
+				ConflictsWith: "203.0.113.7",
+			},
+			expected: `route 203.0.113.0/24 of network "Corp" covers its VPN server 203.0.113.7`,
+		},
+		{
+			name: "unknown kind",
+			conflict: RouteConflict{
+				NetworkName:   "Corp",
+				Route:         "10.0.0.0/8",
+				ConflictsWith: "something",
+			},
+			expected: `route 10.0.0.0/8 of network "Corp" conflicts with something`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.conflict.String())
+		})
+	}
+}
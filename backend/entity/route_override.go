@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// RouteOverride replaces what sync detects from the default network service: the router routes go through,
+// the subnet mask of host routes and the interface whose network service both are read from.
+// Empty fields are detected as usual.
+type RouteOverride struct {
+	Router           string
+	SubnetMask       string
+	NetworkInterface NetworkInterface
+}
+
+// NewRouteOverride validates a route override, blank values are left to detection.
+func NewRouteOverride(router, subnetMask, networkInterface string) (RouteOverride, error) {
+	var override RouteOverride
+
+	if router = strings.TrimSpace(router); router != "" {
+		routerIP := net.ParseIP(router).To4()
+		if routerIP == nil {
+			return RouteOverride{}, fmt.Errorf("invalid router %q: must be an IPv4 address", router)
+		}
+		override.Router = routerIP.String()
+	}
+
+	if subnetMask = strings.TrimSpace(subnetMask); subnetMask != "" {
+		maskIP := net.ParseIP(subnetMask).To4()
+		if maskIP == nil {
+			return RouteOverride{}, fmt.Errorf("invalid subnet mask %q: must be a dotted IPv4 mask", subnetMask)
+		}
+		if _, bits := net.IPMask(maskIP).Size(); bits == 0 {
+			return RouteOverride{}, fmt.Errorf("invalid subnet mask %q: must be a dotted IPv4 mask", subnetMask)
+		}
+		override.SubnetMask = maskIP.String()
+	}
+
+	if networkInterface = strings.TrimSpace(networkInterface); networkInterface != "" {
+		if !networkInterfaceRegex.MatchString(networkInterface) {
+			return RouteOverride{}, fmt.Errorf("invalid network interface %q, e.g. en0", networkInterface)
+		}
+		override.NetworkInterface = NetworkInterface(networkInterface)
+	}
+
+	return override, nil
+}
+
+// IsEmpty reports whether the override leaves everything to detection.
+func (o RouteOverride) IsEmpty() bool {
+	return o == RouteOverride{}
+}
+
+// HasGateway reports whether the override sets both the router and the subnet mask,
+// so neither has to be read from the network service.
+func (o RouteOverride) HasGateway() bool {
+	return o.Router != "" && o.SubnetMask != ""
+}
+
+// Apply returns a copy of the detected network info with the override's router and subnet mask.
+func (o RouteOverride) Apply(networkInfo *NetworkInfo) *NetworkInfo {
+	res := *networkInfo
+	if o.Router != "" {
+		res.Router = o.Router
+	}
+	if o.SubnetMask != "" {
+		res.SubnetMask = o.SubnetMask
+	}
+
+	return &res
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRouteOverride(t *testing.T) {
+	tests := []struct {
+		name             string
+		router           string
+		subnetMask       string
+		networkInterface string
+		expected         RouteOverride
+		expectedError    string
+	}{
+		{
+			name:     "all blank",
+			router:   " ",
+			expected: RouteOverride{},
+		},
+		{
+			name:             "everything set",
+			router:           " 192.168.50.1 ",
+			subnetMask:       "255.255.255.0",
+			networkInterface: "en7",
+			expected: RouteOverride{
+				Router:           "192.168.50.1",
+				SubnetMask:       "255.255.255.0",
+				NetworkInterface: "en7",
+			},
+		},
+		{
+			name:             "interface only",
+			networkInterface: "bridge100",
+			expected:         RouteOverride{NetworkInterface: "bridge100"},
+		},
+		{
+			name:          "IPv6 router",
+			router:        "fe80::1",
+			expectedError: `invalid router "fe80::1": must be an IPv4 address`,
+		},
+		{
+			name:          "non-contiguous mask",
+			subnetMask:    "255.0.255.0",
+			expectedError: `invalid subnet mask "255.0.255.0"`,
+		},
+		{
+			name:          "mask in CIDR notation",
+			subnetMask:    "/24",
+			expectedError: `invalid subnet mask "/24"`,
+		},
+		{
+			name:             "service name instead of interface",
+			networkInterface: "Wi-Fi",
+			expectedError:    `invalid network interface "Wi-Fi"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			override, err := NewRouteOverride(tt.router, tt.subnetMask, tt.networkInterface)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, override)
+		})
+	}
+}
+
+func TestRouteOverride_IsEmptyHasGateway(t *testing.T) {
+	assert.True(t, RouteOverride{}.IsEmpty())
+	assert.False(t, RouteOverride{NetworkInterface: "en0"}.IsEmpty())
+
+	assert.False(t, RouteOverride{Router: "192.168.1.1"}.HasGateway())
+	assert.True(t, RouteOverride{Router: "192.168.1.1", SubnetMask: "255.255.255.0"}.HasGateway())
+}
+
+func TestRouteOverride_Apply(t *testing.T) {
+	detected := &NetworkInfo{
+		NetworkInterface: "en0",
+		NetworkService:   "Wi-Fi",
+		SubnetMask:       "255.255.255.0",
+		Router:           "192.168.1.1",
+	}
+
+	applied := RouteOverride{Router: "192.168.1.254"}.Apply(detected)
+
+	assert.Equal(t, &NetworkInfo{
+		NetworkInterface: "en0",
+		NetworkService:   "Wi-Fi",
+		SubnetMask:       "255.255.255.0",
+		Router:           "192.168.1.254",
+	}, applied)
+	assert.Equal(t, "192.168.1.1", detected.Router)
+}
@@ -0,0 +1,30 @@
+package entity
+
+// RoutePath tells which way a route sends its traffic.
+type RoutePath string
+
+const (
+	// RoutePathTunnel is a route through the network's VPN service.
+	RoutePathTunnel RoutePath = "tunnel"
+	// RoutePathDirect is a route through the physical gateway, which inverse mode sends the network's hosts to.
+	RoutePathDirect RoutePath = "direct"
+)
+
+// RoutePreview is a route a sync would apply, labelled with the way it sends its traffic.
+type RoutePreview struct {
+	Path  RoutePath         `json:"Path"`
+	Setup *NetworkHostSetup `json:"Setup"`
+}
+
+// NewRoutePreviews labels the tunnel and direct routes of a network, tunnel routes first.
+func NewRoutePreviews(tunnelSetups, directSetups []*NetworkHostSetup) []*RoutePreview {
+	previews := make([]*RoutePreview, 0, len(tunnelSetups)+len(directSetups))
+	for _, setup := range tunnelSetups {
+		previews = append(previews, &RoutePreview{Path: RoutePathTunnel, Setup: setup})
+	}
+	for _, setup := range directSetups {
+		previews = append(previews, &RoutePreview{Path: RoutePathDirect, Setup: setup})
+	}
+
+	return previews
+}
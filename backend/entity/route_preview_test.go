@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRoutePreviews(t *testing.T) {
+	tunnelSetup := &NetworkHostSetup{NetworkHostIP: "0.0.0.0", SubnetMask: "128.0.0.0"}
+	directSetup := &NetworkHostSetup{NetworkHostIP: "203.0.113.10", SubnetMask: "255.255.255.255"}
+
+	assert.Equal(t, []*RoutePreview{
+		{Path: RoutePathTunnel, Setup: tunnelSetup},
+		{Path: RoutePathDirect, Setup: directSetup},
+	}, NewRoutePreviews([]*NetworkHostSetup{tunnelSetup}, []*NetworkHostSetup{directSetup}))
+	assert.Empty(t, NewRoutePreviews(nil, nil))
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type RouteAction string
+
+const (
+	RouteActionAdd    RouteAction = "add"
+	RouteActionDelete RouteAction = "delete"
+)
+
+// RouteScript is a standalone POSIX shell script that applies or removes a network's routes.
+// It can either hand the routes to networksetup, like Splitr does, or manage them with route directly.
+type RouteScript struct {
+	NetworkName string
+	Description string
+	GeneratedAt time.Time
+	// NetworkInfo is the physical network service the routes were planned with, detected or overridden.
+	NetworkInfo         *NetworkInfo
+	NetworkSetupCommand *Command
+	// DirectNetworkSetupCommand handles the routes an inverse mode network sends through the physical service.
+	DirectNetworkSetupCommand *Command
+	RouteCommands             []*Command
+}
+
+// RouteScripts holds the matching apply and teardown scripts for a network.
+type RouteScripts struct {
+	Apply    *RouteScript
+	Teardown *RouteScript
+}
+
+// String renders the script. The first argument selects the mode: "networksetup" (default) or "route".
+func (s *RouteScript) String() string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&sb, "# %s for network %q.\n", s.Description, s.NetworkName)
+	fmt.Fprintf(&sb, "# Generated by Splitr at %s.\n", s.GeneratedAt.Format(time.RFC3339))
+	if s.NetworkInfo != nil {
+		fmt.Fprintf(
+			&sb,
+			"# Planned for %s (%s), router %s, subnet mask %s.\n",
+			s.NetworkInfo.NetworkService,
+			s.NetworkInfo.NetworkInterface,
+			s.NetworkInfo.Router,
+			s.NetworkInfo.SubnetMask,
+		)
+	}
+	sb.WriteString("#\n")
+	sb.WriteString("# Usage: sh $0 [networksetup|route]\n")
+	sb.WriteString("#   networksetup  replace the service's additional routes (default)\n")
+	sb.WriteString("#   route         change the routing table directly, requires root\n")
+	sb.WriteString("\n")
+	sb.WriteString("MODE=\"${1:-networksetup}\"\n")
+	sb.WriteString("\n")
+	sb.WriteString("case \"$MODE\" in\n")
+
+	sb.WriteString("networksetup)\n")
+	if s.NetworkSetupCommand != nil {
+		fmt.Fprintf(&sb, "    %s\n", s.NetworkSetupCommand.ShellString())
+	}
+	if s.DirectNetworkSetupCommand != nil {
+		fmt.Fprintf(&sb, "    %s\n", s.DirectNetworkSetupCommand.ShellString())
+	}
+	sb.WriteString("    ;;\n")
+
+	sb.WriteString("route)\n")
+	for _, command := range s.RouteCommands {
+		fmt.Fprintf(&sb, "    %s\n", command.ShellString())
+	}
+	sb.WriteString("    ;;\n")
+
+	sb.WriteString("*)\n")
+	sb.WriteString("    echo \"usage: $0 [networksetup|route]\" >&2\n")
+	sb.WriteString("    exit 1\n")
+	sb.WriteString("    ;;\n")
+	sb.WriteString("esac\n")
+
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteScript_String(t *testing.T) {
+	script := &RouteScript{
+		NetworkName: "Corp VPN",
+		Description: "Apply Splitr routes",
+		GeneratedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		NetworkSetupCommand: &Command{
+			Executable: "networksetup",
+			Args:       []string{"-setadditionalroutes", "Corp VPN", "10.0.0.1", "255.255.255.0", "192.168.1.1"},
+		},
+		RouteCommands: []*Command{
+			{
+				Executable: "route",
+				Args:       []string{"-n", "add", "-net", "10.0.0.1", "-netmask", "255.255.255.0", "192.168.1.1"},
+			},
+		},
+	}
+
+	expected := `#!/bin/sh
+# Apply Splitr routes for network "Corp VPN".
+# Generated by Splitr at 2024-05-01T10:00:00Z.
+#
+# Usage: sh $0 [networksetup|route]
+#   networksetup  replace the service's additional routes (default)
+#   route         change the routing table directly, requires root
+
+MODE="${1:-networksetup}"
+
+case "$MODE" in
+networksetup)
+    networksetup -setadditionalroutes 'Corp VPN' 10.0.0.1 255.255.255.0 192.168.1.1
+    ;;
+route)
+    route -n add -net 10.0.0.1 -netmask 255.255.255.0 192.168.1.1
+    ;;
+*)
+    echo "usage: $0 [networksetup|route]" >&2
+    exit 1
+    ;;
+esac
+`
+
+	assert.Equal(t, expected, script.String())
+}
+
+func TestRouteScript_String_Empty(t *testing.T) {
+	script := &RouteScript{
+		NetworkName: "Empty",
+		Description: "Remove Splitr routes",
+	}
+
+	result := script.String()
+	assert.Contains(t, result, "networksetup)\n    ;;\n")
+	assert.Contains(t, result, "route)\n    ;;\n")
+}
+
+func TestRouteScript_String_DirectNetworkSetupCommand(t *testing.T) {
+	script := &RouteScript{
+		NetworkName: "Corp VPN",
+		Description: "Apply Splitr routes",
+		NetworkSetupCommand: &Command{
+			Executable: "networksetup",
+			Args:       []string{"-setadditionalroutes", "Corp VPN", "0.0.0.0", "128.0.0.0", "192.168.1.1"},
+		},
+		DirectNetworkSetupCommand: &Command{
+			Executable: "networksetup",
+			Args:       []string{"-setadditionalroutes", "Wi-Fi", "203.0.113.10", "255.255.255.255", "192.168.1.1"},
+		},
+	}
+
+	assert.Contains(t, script.String(), "networksetup)\n"+
+		"    networksetup -setadditionalroutes 'Corp VPN' 0.0.0.0 128.0.0.0 192.168.1.1\n"+
+		"    networksetup -setadditionalroutes Wi-Fi 203.0.113.10 255.255.255.255 192.168.1.1\n"+
+		"    ;;\n")
+}
+
+func TestRouteScript_String_NetworkInfo(t *testing.T) {
+	script := &RouteScript{
+		NetworkName: "Corp VPN",
+		Description: "Apply Splitr routes",
+		NetworkInfo: &NetworkInfo{
+			NetworkInterface: "en7",
+			NetworkService:   "USB LAN",
+			SubnetMask:       "255.255.255.0",
+			Router:           "192.168.50.1",
+		},
+	}
+
+	assert.Contains(
+		t,
+		script.String(),
+		"# Planned for USB LAN (en7), router 192.168.50.1, subnet mask 255.255.255.0.\n",
+	)
+}
@@ -0,0 +1,8 @@
+package entity
+
+// SimulatedVPN is a VPN service of the simulated network stack.
+type SimulatedVPN struct {
+	Service       VPNService `json:"Service"`
+	ServerAddress string     `json:"ServerAddress"`
+	Connected     bool       `json:"Connected"`
+}
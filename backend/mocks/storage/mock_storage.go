@@ -12,6 +12,7 @@ package mock_storage
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/dmitrorlov/splitr/backend/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -70,6 +71,21 @@ func (mr *MockHostMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHost)(nil).Delete), ctx, id)
 }
 
+// Get mocks base method.
+func (m *MockHost) Get(ctx context.Context, id uint64) (*entity.Host, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.Host)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHostMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHost)(nil).Get), ctx, id)
+}
+
 // List mocks base method.
 func (m *MockHost) List(ctx context.Context, filter *entity.ListHostFilter) ([]*entity.Host, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +101,21 @@ func (mr *MockHostMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHost)(nil).List), ctx, filter)
 }
 
+// Update mocks base method.
+func (m *MockHost) Update(ctx context.Context, host *entity.Host) (*entity.Host, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, host)
+	ret0, _ := ret[0].(*entity.Host)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHostMockRecorder) Update(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHost)(nil).Update), ctx, host)
+}
+
 // MockNetwork is a mock of Network interface.
 type MockNetwork struct {
 	ctrl     *gomock.Controller
@@ -168,6 +199,48 @@ func (mr *MockNetworkMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNetwork)(nil).List), ctx, filter)
 }
 
+// SetDNS mocks base method.
+func (m *MockNetwork) SetDNS(ctx context.Context, id uint64, domains entity.DomainList, nameservers entity.IPList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDNS", ctx, id, domains, nameservers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDNS indicates an expected call of SetDNS.
+func (mr *MockNetworkMockRecorder) SetDNS(ctx, id, domains, nameservers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDNS", reflect.TypeOf((*MockNetwork)(nil).SetDNS), ctx, id, domains, nameservers)
+}
+
+// SetRouteOverride mocks base method.
+func (m *MockNetwork) SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRouteOverride", ctx, id, override)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRouteOverride indicates an expected call of SetRouteOverride.
+func (mr *MockNetworkMockRecorder) SetRouteOverride(ctx, id, override any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRouteOverride", reflect.TypeOf((*MockNetwork)(nil).SetRouteOverride), ctx, id, override)
+}
+
+// SetRoutingMode mocks base method.
+func (m *MockNetwork) SetRoutingMode(ctx context.Context, id uint64, mode entity.RoutingMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoutingMode", ctx, id, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRoutingMode indicates an expected call of SetRoutingMode.
+func (mr *MockNetworkMockRecorder) SetRoutingMode(ctx, id, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoutingMode", reflect.TypeOf((*MockNetwork)(nil).SetRoutingMode), ctx, id, mode)
+}
+
 // MockNetworkHost is a mock of NetworkHost interface.
 type MockNetworkHost struct {
 	ctrl     *gomock.Controller
@@ -251,6 +324,358 @@ func (mr *MockNetworkHostMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNetworkHost)(nil).List), ctx, filter)
 }
 
+// SetEnabled mocks base method.
+func (m *MockNetworkHost) SetEnabled(ctx context.Context, id uint64, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", ctx, id, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockNetworkHostMockRecorder) SetEnabled(ctx, id, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockNetworkHost)(nil).SetEnabled), ctx, id, enabled)
+}
+
+// SetExpiresAt mocks base method.
+func (m *MockNetworkHost) SetExpiresAt(ctx context.Context, id uint64, expiresAt *entity.Timestamp) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExpiresAt", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExpiresAt indicates an expected call of SetExpiresAt.
+func (mr *MockNetworkHostMockRecorder) SetExpiresAt(ctx, id, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiresAt", reflect.TypeOf((*MockNetworkHost)(nil).SetExpiresAt), ctx, id, expiresAt)
+}
+
+// SetPinnedIPs mocks base method.
+func (m *MockNetworkHost) SetPinnedIPs(ctx context.Context, id uint64, pinnedIPs entity.IPList, mode entity.NetworkHostPinMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPinnedIPs", ctx, id, pinnedIPs, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPinnedIPs indicates an expected call of SetPinnedIPs.
+func (mr *MockNetworkHostMockRecorder) SetPinnedIPs(ctx, id, pinnedIPs, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPinnedIPs", reflect.TypeOf((*MockNetworkHost)(nil).SetPinnedIPs), ctx, id, pinnedIPs, mode)
+}
+
+// SetRouteOverride mocks base method.
+func (m *MockNetworkHost) SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRouteOverride", ctx, id, override)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRouteOverride indicates an expected call of SetRouteOverride.
+func (mr *MockNetworkHostMockRecorder) SetRouteOverride(ctx, id, override any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRouteOverride", reflect.TypeOf((*MockNetworkHost)(nil).SetRouteOverride), ctx, id, override)
+}
+
+// UpdateByHostID mocks base method.
+func (m *MockNetworkHost) UpdateByHostID(ctx context.Context, host *entity.Host) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByHostID", ctx, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateByHostID indicates an expected call of UpdateByHostID.
+func (mr *MockNetworkHostMockRecorder) UpdateByHostID(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByHostID", reflect.TypeOf((*MockNetworkHost)(nil).UpdateByHostID), ctx, host)
+}
+
+// MockNetworkSubscription is a mock of NetworkSubscription interface.
+type MockNetworkSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkSubscriptionMockRecorder
+	isgomock struct{}
+}
+
+// MockNetworkSubscriptionMockRecorder is the mock recorder for MockNetworkSubscription.
+type MockNetworkSubscriptionMockRecorder struct {
+	mock *MockNetworkSubscription
+}
+
+// NewMockNetworkSubscription creates a new mock instance.
+func NewMockNetworkSubscription(ctrl *gomock.Controller) *MockNetworkSubscription {
+	mock := &MockNetworkSubscription{ctrl: ctrl}
+	mock.recorder = &MockNetworkSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkSubscription) EXPECT() *MockNetworkSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockNetworkSubscription) Add(ctx context.Context, subscription *entity.NetworkSubscription) (*entity.NetworkSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, subscription)
+	ret0, _ := ret[0].(*entity.NetworkSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockNetworkSubscriptionMockRecorder) Add(ctx, subscription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockNetworkSubscription)(nil).Add), ctx, subscription)
+}
+
+// Delete mocks base method.
+func (m *MockNetworkSubscription) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNetworkSubscriptionMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNetworkSubscription)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockNetworkSubscription) Get(ctx context.Context, id uint64) (*entity.NetworkSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.NetworkSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNetworkSubscriptionMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNetworkSubscription)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockNetworkSubscription) List(ctx context.Context, filter *entity.ListNetworkSubscriptionFilter) ([]*entity.NetworkSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.NetworkSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNetworkSubscriptionMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNetworkSubscription)(nil).List), ctx, filter)
+}
+
+// UpdateRefreshState mocks base method.
+func (m *MockNetworkSubscription) UpdateRefreshState(ctx context.Context, subscription *entity.NetworkSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefreshState", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRefreshState indicates an expected call of UpdateRefreshState.
+func (mr *MockNetworkSubscriptionMockRecorder) UpdateRefreshState(ctx, subscription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefreshState", reflect.TypeOf((*MockNetworkSubscription)(nil).UpdateRefreshState), ctx, subscription)
+}
+
+// MockHostGroup is a mock of HostGroup interface.
+type MockHostGroup struct {
+	ctrl     *gomock.Controller
+	recorder *MockHostGroupMockRecorder
+	isgomock struct{}
+}
+
+// MockHostGroupMockRecorder is the mock recorder for MockHostGroup.
+type MockHostGroupMockRecorder struct {
+	mock *MockHostGroup
+}
+
+// NewMockHostGroup creates a new mock instance.
+func NewMockHostGroup(ctrl *gomock.Controller) *MockHostGroup {
+	mock := &MockHostGroup{ctrl: ctrl}
+	mock.recorder = &MockHostGroupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHostGroup) EXPECT() *MockHostGroupMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockHostGroup) Add(ctx context.Context, hostGroup *entity.HostGroup) (*entity.HostGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, hostGroup)
+	ret0, _ := ret[0].(*entity.HostGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockHostGroupMockRecorder) Add(ctx, hostGroup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockHostGroup)(nil).Add), ctx, hostGroup)
+}
+
+// AddHost mocks base method.
+func (m *MockHostGroup) AddHost(ctx context.Context, hostGroupHost *entity.HostGroupHost) (*entity.HostGroupHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHost", ctx, hostGroupHost)
+	ret0, _ := ret[0].(*entity.HostGroupHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHost indicates an expected call of AddHost.
+func (mr *MockHostGroupMockRecorder) AddHost(ctx, hostGroupHost any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHost", reflect.TypeOf((*MockHostGroup)(nil).AddHost), ctx, hostGroupHost)
+}
+
+// AttachToNetwork mocks base method.
+func (m *MockHostGroup) AttachToNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachToNetwork", ctx, hostGroupID, networkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachToNetwork indicates an expected call of AttachToNetwork.
+func (mr *MockHostGroupMockRecorder) AttachToNetwork(ctx, hostGroupID, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachToNetwork", reflect.TypeOf((*MockHostGroup)(nil).AttachToNetwork), ctx, hostGroupID, networkID)
+}
+
+// Delete mocks base method.
+func (m *MockHostGroup) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHostGroupMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHostGroup)(nil).Delete), ctx, id)
+}
+
+// DeleteHost mocks base method.
+func (m *MockHostGroup) DeleteHost(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHost", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHost indicates an expected call of DeleteHost.
+func (mr *MockHostGroupMockRecorder) DeleteHost(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHost", reflect.TypeOf((*MockHostGroup)(nil).DeleteHost), ctx, id)
+}
+
+// DetachFromNetwork mocks base method.
+func (m *MockHostGroup) DetachFromNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachFromNetwork", ctx, hostGroupID, networkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachFromNetwork indicates an expected call of DetachFromNetwork.
+func (mr *MockHostGroupMockRecorder) DetachFromNetwork(ctx, hostGroupID, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachFromNetwork", reflect.TypeOf((*MockHostGroup)(nil).DetachFromNetwork), ctx, hostGroupID, networkID)
+}
+
+// Get mocks base method.
+func (m *MockHostGroup) Get(ctx context.Context, id uint64) (*entity.HostGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.HostGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHostGroupMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHostGroup)(nil).Get), ctx, id)
+}
+
+// GetHost mocks base method.
+func (m *MockHostGroup) GetHost(ctx context.Context, id uint64) (*entity.HostGroupHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHost", ctx, id)
+	ret0, _ := ret[0].(*entity.HostGroupHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHost indicates an expected call of GetHost.
+func (mr *MockHostGroupMockRecorder) GetHost(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHost", reflect.TypeOf((*MockHostGroup)(nil).GetHost), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockHostGroup) List(ctx context.Context, filter *entity.ListHostGroupFilter) ([]*entity.HostGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.HostGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHostGroupMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHostGroup)(nil).List), ctx, filter)
+}
+
+// ListHosts mocks base method.
+func (m *MockHostGroup) ListHosts(ctx context.Context, filter *entity.ListHostGroupHostFilter) ([]*entity.HostGroupHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHosts", ctx, filter)
+	ret0, _ := ret[0].([]*entity.HostGroupHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHosts indicates an expected call of ListHosts.
+func (mr *MockHostGroupMockRecorder) ListHosts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHosts", reflect.TypeOf((*MockHostGroup)(nil).ListHosts), ctx, filter)
+}
+
+// ListNetworkIDs mocks base method.
+func (m *MockHostGroup) ListNetworkIDs(ctx context.Context, hostGroupID uint64) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkIDs", ctx, hostGroupID)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkIDs indicates an expected call of ListNetworkIDs.
+func (mr *MockHostGroupMockRecorder) ListNetworkIDs(ctx, hostGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkIDs", reflect.TypeOf((*MockHostGroup)(nil).ListNetworkIDs), ctx, hostGroupID)
+}
+
 // MockNetworkHostSetup is a mock of NetworkHostSetup interface.
 type MockNetworkHostSetup struct {
 	ctrl     *gomock.Controller
@@ -302,3 +727,288 @@ func (mr *MockNetworkHostSetupMockRecorder) DeleteBatchByNetworkHostIDs(ctx, net
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatchByNetworkHostIDs", reflect.TypeOf((*MockNetworkHostSetup)(nil).DeleteBatchByNetworkHostIDs), ctx, networkHostIDs)
 }
+
+// MockNetworkSetupGeneration is a mock of NetworkSetupGeneration interface.
+type MockNetworkSetupGeneration struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkSetupGenerationMockRecorder
+	isgomock struct{}
+}
+
+// MockNetworkSetupGenerationMockRecorder is the mock recorder for MockNetworkSetupGeneration.
+type MockNetworkSetupGenerationMockRecorder struct {
+	mock *MockNetworkSetupGeneration
+}
+
+// NewMockNetworkSetupGeneration creates a new mock instance.
+func NewMockNetworkSetupGeneration(ctrl *gomock.Controller) *MockNetworkSetupGeneration {
+	mock := &MockNetworkSetupGeneration{ctrl: ctrl}
+	mock.recorder = &MockNetworkSetupGenerationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkSetupGeneration) EXPECT() *MockNetworkSetupGenerationMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockNetworkSetupGeneration) Add(ctx context.Context, generation *entity.NetworkSetupGeneration) (*entity.NetworkSetupGeneration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, generation)
+	ret0, _ := ret[0].(*entity.NetworkSetupGeneration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockNetworkSetupGenerationMockRecorder) Add(ctx, generation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockNetworkSetupGeneration)(nil).Add), ctx, generation)
+}
+
+// GetLastApplied mocks base method.
+func (m *MockNetworkSetupGeneration) GetLastApplied(ctx context.Context, networkID uint64) (*entity.NetworkSetupGeneration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastApplied", ctx, networkID)
+	ret0, _ := ret[0].(*entity.NetworkSetupGeneration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastApplied indicates an expected call of GetLastApplied.
+func (mr *MockNetworkSetupGenerationMockRecorder) GetLastApplied(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastApplied", reflect.TypeOf((*MockNetworkSetupGeneration)(nil).GetLastApplied), ctx, networkID)
+}
+
+// MarkApplied mocks base method.
+func (m *MockNetworkSetupGeneration) MarkApplied(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkApplied", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkApplied indicates an expected call of MarkApplied.
+func (mr *MockNetworkSetupGenerationMockRecorder) MarkApplied(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkApplied", reflect.TypeOf((*MockNetworkSetupGeneration)(nil).MarkApplied), ctx, id)
+}
+
+// MarkFailed mocks base method.
+func (m *MockNetworkSetupGeneration) MarkFailed(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockNetworkSetupGenerationMockRecorder) MarkFailed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockNetworkSetupGeneration)(nil).MarkFailed), ctx, id)
+}
+
+// MockDNSCache is a mock of DNSCache interface.
+type MockDNSCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockDNSCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockDNSCacheMockRecorder is the mock recorder for MockDNSCache.
+type MockDNSCacheMockRecorder struct {
+	mock *MockDNSCache
+}
+
+// NewMockDNSCache creates a new mock instance.
+func NewMockDNSCache(ctrl *gomock.Controller) *MockDNSCache {
+	mock := &MockDNSCache{ctrl: ctrl}
+	mock.recorder = &MockDNSCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDNSCache) EXPECT() *MockDNSCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDNSCache) Get(ctx context.Context, address string) (*entity.DNSCacheEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, address)
+	ret0, _ := ret[0].(*entity.DNSCacheEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDNSCacheMockRecorder) Get(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDNSCache)(nil).Get), ctx, address)
+}
+
+// Upsert mocks base method.
+func (m *MockDNSCache) Upsert(ctx context.Context, entry *entity.DNSCacheEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockDNSCacheMockRecorder) Upsert(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockDNSCache)(nil).Upsert), ctx, entry)
+}
+
+// MockLearnedIP is a mock of LearnedIP interface.
+type MockLearnedIP struct {
+	ctrl     *gomock.Controller
+	recorder *MockLearnedIPMockRecorder
+	isgomock struct{}
+}
+
+// MockLearnedIPMockRecorder is the mock recorder for MockLearnedIP.
+type MockLearnedIPMockRecorder struct {
+	mock *MockLearnedIP
+}
+
+// NewMockLearnedIP creates a new mock instance.
+func NewMockLearnedIP(ctrl *gomock.Controller) *MockLearnedIP {
+	mock := &MockLearnedIP{ctrl: ctrl}
+	mock.recorder = &MockLearnedIPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLearnedIP) EXPECT() *MockLearnedIPMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpired mocks base method.
+func (m *MockLearnedIP) DeleteExpired(ctx context.Context, now time.Time) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockLearnedIPMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockLearnedIP)(nil).DeleteExpired), ctx, now)
+}
+
+// ListActive mocks base method.
+func (m *MockLearnedIP) ListActive(ctx context.Context, networkHostIDs []uint64, now time.Time) ([]*entity.LearnedIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, networkHostIDs, now)
+	ret0, _ := ret[0].([]*entity.LearnedIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockLearnedIPMockRecorder) ListActive(ctx, networkHostIDs, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockLearnedIP)(nil).ListActive), ctx, networkHostIDs, now)
+}
+
+// Upsert mocks base method.
+func (m *MockLearnedIP) Upsert(ctx context.Context, learnedIP *entity.LearnedIP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, learnedIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockLearnedIPMockRecorder) Upsert(ctx, learnedIP any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockLearnedIP)(nil).Upsert), ctx, learnedIP)
+}
+
+// MockResolverFile is a mock of ResolverFile interface.
+type MockResolverFile struct {
+	ctrl     *gomock.Controller
+	recorder *MockResolverFileMockRecorder
+	isgomock struct{}
+}
+
+// MockResolverFileMockRecorder is the mock recorder for MockResolverFile.
+type MockResolverFileMockRecorder struct {
+	mock *MockResolverFile
+}
+
+// NewMockResolverFile creates a new mock instance.
+func NewMockResolverFile(ctrl *gomock.Controller) *MockResolverFile {
+	mock := &MockResolverFile{ctrl: ctrl}
+	mock.recorder = &MockResolverFileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResolverFile) EXPECT() *MockResolverFileMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockResolverFile) Add(ctx context.Context, resolverFile *entity.ResolverFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, resolverFile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockResolverFileMockRecorder) Add(ctx, resolverFile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockResolverFile)(nil).Add), ctx, resolverFile)
+}
+
+// Delete mocks base method.
+func (m *MockResolverFile) Delete(ctx context.Context, domain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, domain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockResolverFileMockRecorder) Delete(ctx, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockResolverFile)(nil).Delete), ctx, domain)
+}
+
+// Get mocks base method.
+func (m *MockResolverFile) Get(ctx context.Context, domain string) (*entity.ResolverFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, domain)
+	ret0, _ := ret[0].(*entity.ResolverFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockResolverFileMockRecorder) Get(ctx, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockResolverFile)(nil).Get), ctx, domain)
+}
+
+// ListByNetworkID mocks base method.
+func (m *MockResolverFile) ListByNetworkID(ctx context.Context, networkID uint64) ([]*entity.ResolverFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByNetworkID", ctx, networkID)
+	ret0, _ := ret[0].([]*entity.ResolverFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByNetworkID indicates an expected call of ListByNetworkID.
+func (mr *MockResolverFileMockRecorder) ListByNetworkID(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByNetworkID", reflect.TypeOf((*MockResolverFile)(nil).ListByNetworkID), ctx, networkID)
+}
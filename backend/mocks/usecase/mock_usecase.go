@@ -71,6 +71,21 @@ func (mr *MockCommandExecutorMockRecorder) GetDefaultNetworkInterface(ctx any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultNetworkInterface", reflect.TypeOf((*MockCommandExecutor)(nil).GetDefaultNetworkInterface), ctx)
 }
 
+// GetNetworkAdditionalRoutes mocks base method.
+func (m *MockCommandExecutor) GetNetworkAdditionalRoutes(ctx context.Context, network *entity.Network) ([]*entity.AdditionalRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkAdditionalRoutes", ctx, network)
+	ret0, _ := ret[0].([]*entity.AdditionalRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkAdditionalRoutes indicates an expected call of GetNetworkAdditionalRoutes.
+func (mr *MockCommandExecutorMockRecorder) GetNetworkAdditionalRoutes(ctx, network any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkAdditionalRoutes", reflect.TypeOf((*MockCommandExecutor)(nil).GetNetworkAdditionalRoutes), ctx, network)
+}
+
 // GetNetworkInfoByNetworkService mocks base method.
 func (m *MockCommandExecutor) GetNetworkInfoByNetworkService(ctx context.Context, networkService entity.NetworkService) (*entity.NetworkInfo, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,36 @@ func (mr *MockCommandExecutorMockRecorder) GetNetworkServiceByNetworkInterface(c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkServiceByNetworkInterface", reflect.TypeOf((*MockCommandExecutor)(nil).GetNetworkServiceByNetworkInterface), ctx, networkInterface)
 }
 
+// GetServiceAdditionalRoutes mocks base method.
+func (m *MockCommandExecutor) GetServiceAdditionalRoutes(ctx context.Context, networkService entity.NetworkService) ([]*entity.AdditionalRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceAdditionalRoutes", ctx, networkService)
+	ret0, _ := ret[0].([]*entity.AdditionalRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceAdditionalRoutes indicates an expected call of GetServiceAdditionalRoutes.
+func (mr *MockCommandExecutorMockRecorder) GetServiceAdditionalRoutes(ctx, networkService any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAdditionalRoutes", reflect.TypeOf((*MockCommandExecutor)(nil).GetServiceAdditionalRoutes), ctx, networkService)
+}
+
+// GetVPNServerAddress mocks base method.
+func (m *MockCommandExecutor) GetVPNServerAddress(ctx context.Context, vpnService entity.VPNService) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVPNServerAddress", ctx, vpnService)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVPNServerAddress indicates an expected call of GetVPNServerAddress.
+func (mr *MockCommandExecutorMockRecorder) GetVPNServerAddress(ctx, vpnService any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVPNServerAddress", reflect.TypeOf((*MockCommandExecutor)(nil).GetVPNServerAddress), ctx, vpnService)
+}
+
 // ListVPN mocks base method.
 func (m *MockCommandExecutor) ListVPN(ctx context.Context) ([]entity.VPNService, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +175,20 @@ func (mr *MockCommandExecutorMockRecorder) OpenInFinder(ctx, path any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenInFinder", reflect.TypeOf((*MockCommandExecutor)(nil).OpenInFinder), ctx, path)
 }
 
+// RouteCommands mocks base method.
+func (m *MockCommandExecutor) RouteCommands(action entity.RouteAction, networkHostSetupList []*entity.NetworkHostSetup) []*entity.Command {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RouteCommands", action, networkHostSetupList)
+	ret0, _ := ret[0].([]*entity.Command)
+	return ret0
+}
+
+// RouteCommands indicates an expected call of RouteCommands.
+func (mr *MockCommandExecutorMockRecorder) RouteCommands(action, networkHostSetupList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RouteCommands", reflect.TypeOf((*MockCommandExecutor)(nil).RouteCommands), action, networkHostSetupList)
+}
+
 // SetNetworkAdditionalRoutes mocks base method.
 func (m *MockCommandExecutor) SetNetworkAdditionalRoutes(ctx context.Context, network *entity.Network, networkHostSetupList []*entity.NetworkHostSetup) error {
 	m.ctrl.T.Helper()
@@ -144,6 +203,115 @@ func (mr *MockCommandExecutorMockRecorder) SetNetworkAdditionalRoutes(ctx, netwo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetworkAdditionalRoutes", reflect.TypeOf((*MockCommandExecutor)(nil).SetNetworkAdditionalRoutes), ctx, network, networkHostSetupList)
 }
 
+// SetNetworkAdditionalRoutesCommand mocks base method.
+func (m *MockCommandExecutor) SetNetworkAdditionalRoutesCommand(network *entity.Network, networkHostSetupList []*entity.NetworkHostSetup) *entity.Command {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNetworkAdditionalRoutesCommand", network, networkHostSetupList)
+	ret0, _ := ret[0].(*entity.Command)
+	return ret0
+}
+
+// SetNetworkAdditionalRoutesCommand indicates an expected call of SetNetworkAdditionalRoutesCommand.
+func (mr *MockCommandExecutorMockRecorder) SetNetworkAdditionalRoutesCommand(network, networkHostSetupList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNetworkAdditionalRoutesCommand", reflect.TypeOf((*MockCommandExecutor)(nil).SetNetworkAdditionalRoutesCommand), network, networkHostSetupList)
+}
+
+// SetServiceAdditionalRoutes mocks base method.
+func (m *MockCommandExecutor) SetServiceAdditionalRoutes(ctx context.Context, networkService entity.NetworkService, networkHostSetupList []*entity.NetworkHostSetup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetServiceAdditionalRoutes", ctx, networkService, networkHostSetupList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetServiceAdditionalRoutes indicates an expected call of SetServiceAdditionalRoutes.
+func (mr *MockCommandExecutorMockRecorder) SetServiceAdditionalRoutes(ctx, networkService, networkHostSetupList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServiceAdditionalRoutes", reflect.TypeOf((*MockCommandExecutor)(nil).SetServiceAdditionalRoutes), ctx, networkService, networkHostSetupList)
+}
+
+// SetServiceAdditionalRoutesCommand mocks base method.
+func (m *MockCommandExecutor) SetServiceAdditionalRoutesCommand(networkService entity.NetworkService, networkHostSetupList []*entity.NetworkHostSetup) *entity.Command {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetServiceAdditionalRoutesCommand", networkService, networkHostSetupList)
+	ret0, _ := ret[0].(*entity.Command)
+	return ret0
+}
+
+// SetServiceAdditionalRoutesCommand indicates an expected call of SetServiceAdditionalRoutesCommand.
+func (mr *MockCommandExecutorMockRecorder) SetServiceAdditionalRoutesCommand(networkService, networkHostSetupList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServiceAdditionalRoutesCommand", reflect.TypeOf((*MockCommandExecutor)(nil).SetServiceAdditionalRoutesCommand), networkService, networkHostSetupList)
+}
+
+// MockSimulation is a mock of Simulation interface.
+type MockSimulation struct {
+	ctrl     *gomock.Controller
+	recorder *MockSimulationMockRecorder
+	isgomock struct{}
+}
+
+// MockSimulationMockRecorder is the mock recorder for MockSimulation.
+type MockSimulationMockRecorder struct {
+	mock *MockSimulation
+}
+
+// NewMockSimulation creates a new mock instance.
+func NewMockSimulation(ctrl *gomock.Controller) *MockSimulation {
+	mock := &MockSimulation{ctrl: ctrl}
+	mock.recorder = &MockSimulationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSimulation) EXPECT() *MockSimulationMockRecorder {
+	return m.recorder
+}
+
+// ConnectVPN mocks base method.
+func (m *MockSimulation) ConnectVPN(ctx context.Context, vpnService entity.VPNService) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectVPN", ctx, vpnService)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectVPN indicates an expected call of ConnectVPN.
+func (mr *MockSimulationMockRecorder) ConnectVPN(ctx, vpnService any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectVPN", reflect.TypeOf((*MockSimulation)(nil).ConnectVPN), ctx, vpnService)
+}
+
+// DisconnectVPN mocks base method.
+func (m *MockSimulation) DisconnectVPN(ctx context.Context, vpnService entity.VPNService) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisconnectVPN", ctx, vpnService)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisconnectVPN indicates an expected call of DisconnectVPN.
+func (mr *MockSimulationMockRecorder) DisconnectVPN(ctx, vpnService any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectVPN", reflect.TypeOf((*MockSimulation)(nil).DisconnectVPN), ctx, vpnService)
+}
+
+// ListSimulatedVPN mocks base method.
+func (m *MockSimulation) ListSimulatedVPN(ctx context.Context) ([]*entity.SimulatedVPN, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSimulatedVPN", ctx)
+	ret0, _ := ret[0].([]*entity.SimulatedVPN)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSimulatedVPN indicates an expected call of ListSimulatedVPN.
+func (mr *MockSimulationMockRecorder) ListSimulatedVPN(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSimulatedVPN", reflect.TypeOf((*MockSimulation)(nil).ListSimulatedVPN), ctx)
+}
+
 // MockCommandRunner is a mock of CommandRunner interface.
 type MockCommandRunner struct {
 	ctrl     *gomock.Controller
@@ -169,14 +337,14 @@ func (m *MockCommandRunner) EXPECT() *MockCommandRunnerMockRecorder {
 }
 
 // Run mocks base method.
-func (m *MockCommandRunner) Run(ctx context.Context, name string, args ...string) ([]string, error) {
+func (m *MockCommandRunner) Run(ctx context.Context, name string, args ...string) (*entity.CommandResult, error) {
 	m.ctrl.T.Helper()
 	varargs := []any{ctx, name}
 	for _, a := range args {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "Run", varargs...)
-	ret0, _ := ret[0].([]string)
+	ret0, _ := ret[0].(*entity.CommandResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -227,6 +395,21 @@ func (mr *MockHostMockRecorder) Add(ctx, host any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockHost)(nil).Add), ctx, host)
 }
 
+// AttachToNetwork mocks base method.
+func (m *MockHost) AttachToNetwork(ctx context.Context, hostID, networkID uint64) (*entity.NetworkHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachToNetwork", ctx, hostID, networkID)
+	ret0, _ := ret[0].(*entity.NetworkHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachToNetwork indicates an expected call of AttachToNetwork.
+func (mr *MockHostMockRecorder) AttachToNetwork(ctx, hostID, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachToNetwork", reflect.TypeOf((*MockHost)(nil).AttachToNetwork), ctx, hostID, networkID)
+}
+
 // Delete mocks base method.
 func (m *MockHost) Delete(ctx context.Context, id uint64) error {
 	m.ctrl.T.Helper()
@@ -241,6 +424,20 @@ func (mr *MockHostMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHost)(nil).Delete), ctx, id)
 }
 
+// DetachFromNetwork mocks base method.
+func (m *MockHost) DetachFromNetwork(ctx context.Context, hostID, networkID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachFromNetwork", ctx, hostID, networkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachFromNetwork indicates an expected call of DetachFromNetwork.
+func (mr *MockHostMockRecorder) DetachFromNetwork(ctx, hostID, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachFromNetwork", reflect.TypeOf((*MockHost)(nil).DetachFromNetwork), ctx, hostID, networkID)
+}
+
 // List mocks base method.
 func (m *MockHost) List(ctx context.Context, filter *entity.ListHostFilter) ([]*entity.Host, error) {
 	m.ctrl.T.Helper()
@@ -256,6 +453,176 @@ func (mr *MockHostMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHost)(nil).List), ctx, filter)
 }
 
+// ListWithUsage mocks base method.
+func (m *MockHost) ListWithUsage(ctx context.Context, filter *entity.ListHostFilter) ([]*entity.HostWithUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithUsage", ctx, filter)
+	ret0, _ := ret[0].([]*entity.HostWithUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWithUsage indicates an expected call of ListWithUsage.
+func (mr *MockHostMockRecorder) ListWithUsage(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithUsage", reflect.TypeOf((*MockHost)(nil).ListWithUsage), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockHost) Update(ctx context.Context, host *entity.Host) (*entity.Host, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, host)
+	ret0, _ := ret[0].(*entity.Host)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHostMockRecorder) Update(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHost)(nil).Update), ctx, host)
+}
+
+// MockHostGroup is a mock of HostGroup interface.
+type MockHostGroup struct {
+	ctrl     *gomock.Controller
+	recorder *MockHostGroupMockRecorder
+	isgomock struct{}
+}
+
+// MockHostGroupMockRecorder is the mock recorder for MockHostGroup.
+type MockHostGroupMockRecorder struct {
+	mock *MockHostGroup
+}
+
+// NewMockHostGroup creates a new mock instance.
+func NewMockHostGroup(ctrl *gomock.Controller) *MockHostGroup {
+	mock := &MockHostGroup{ctrl: ctrl}
+	mock.recorder = &MockHostGroupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHostGroup) EXPECT() *MockHostGroupMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockHostGroup) Add(ctx context.Context, hostGroup *entity.HostGroup) (*entity.HostGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, hostGroup)
+	ret0, _ := ret[0].(*entity.HostGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockHostGroupMockRecorder) Add(ctx, hostGroup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockHostGroup)(nil).Add), ctx, hostGroup)
+}
+
+// AddHost mocks base method.
+func (m *MockHostGroup) AddHost(ctx context.Context, hostGroupHost *entity.HostGroupHost) (*entity.HostGroupHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHost", ctx, hostGroupHost)
+	ret0, _ := ret[0].(*entity.HostGroupHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHost indicates an expected call of AddHost.
+func (mr *MockHostGroupMockRecorder) AddHost(ctx, hostGroupHost any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHost", reflect.TypeOf((*MockHostGroup)(nil).AddHost), ctx, hostGroupHost)
+}
+
+// AttachToNetwork mocks base method.
+func (m *MockHostGroup) AttachToNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachToNetwork", ctx, hostGroupID, networkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachToNetwork indicates an expected call of AttachToNetwork.
+func (mr *MockHostGroupMockRecorder) AttachToNetwork(ctx, hostGroupID, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachToNetwork", reflect.TypeOf((*MockHostGroup)(nil).AttachToNetwork), ctx, hostGroupID, networkID)
+}
+
+// Delete mocks base method.
+func (m *MockHostGroup) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHostGroupMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHostGroup)(nil).Delete), ctx, id)
+}
+
+// DeleteHost mocks base method.
+func (m *MockHostGroup) DeleteHost(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHost", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHost indicates an expected call of DeleteHost.
+func (mr *MockHostGroupMockRecorder) DeleteHost(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHost", reflect.TypeOf((*MockHostGroup)(nil).DeleteHost), ctx, id)
+}
+
+// DetachFromNetwork mocks base method.
+func (m *MockHostGroup) DetachFromNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachFromNetwork", ctx, hostGroupID, networkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachFromNetwork indicates an expected call of DetachFromNetwork.
+func (mr *MockHostGroupMockRecorder) DetachFromNetwork(ctx, hostGroupID, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachFromNetwork", reflect.TypeOf((*MockHostGroup)(nil).DetachFromNetwork), ctx, hostGroupID, networkID)
+}
+
+// List mocks base method.
+func (m *MockHostGroup) List(ctx context.Context, filter *entity.ListHostGroupFilter) ([]*entity.HostGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.HostGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHostGroupMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHostGroup)(nil).List), ctx, filter)
+}
+
+// ListHosts mocks base method.
+func (m *MockHostGroup) ListHosts(ctx context.Context, hostGroupID uint64) ([]*entity.HostGroupHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHosts", ctx, hostGroupID)
+	ret0, _ := ret[0].([]*entity.HostGroupHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHosts indicates an expected call of ListHosts.
+func (mr *MockHostGroupMockRecorder) ListHosts(ctx, hostGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHosts", reflect.TypeOf((*MockHostGroup)(nil).ListHosts), ctx, hostGroupID)
+}
+
 // MockNetwork is a mock of Network interface.
 type MockNetwork struct {
 	ctrl     *gomock.Controller
@@ -339,6 +706,51 @@ func (mr *MockNetworkMockRecorder) ListVPNServices(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPNServices", reflect.TypeOf((*MockNetwork)(nil).ListVPNServices), ctx)
 }
 
+// SetDNS mocks base method.
+func (m *MockNetwork) SetDNS(ctx context.Context, id uint64, domains, nameservers []string) (*entity.Network, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDNS", ctx, id, domains, nameservers)
+	ret0, _ := ret[0].(*entity.Network)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDNS indicates an expected call of SetDNS.
+func (mr *MockNetworkMockRecorder) SetDNS(ctx, id, domains, nameservers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDNS", reflect.TypeOf((*MockNetwork)(nil).SetDNS), ctx, id, domains, nameservers)
+}
+
+// SetRouteOverride mocks base method.
+func (m *MockNetwork) SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) (*entity.Network, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRouteOverride", ctx, id, override)
+	ret0, _ := ret[0].(*entity.Network)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRouteOverride indicates an expected call of SetRouteOverride.
+func (mr *MockNetworkMockRecorder) SetRouteOverride(ctx, id, override any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRouteOverride", reflect.TypeOf((*MockNetwork)(nil).SetRouteOverride), ctx, id, override)
+}
+
+// SetRoutingMode mocks base method.
+func (m *MockNetwork) SetRoutingMode(ctx context.Context, id uint64, mode entity.RoutingMode) (*entity.Network, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoutingMode", ctx, id, mode)
+	ret0, _ := ret[0].(*entity.Network)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRoutingMode indicates an expected call of SetRoutingMode.
+func (mr *MockNetworkMockRecorder) SetRoutingMode(ctx, id, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoutingMode", reflect.TypeOf((*MockNetwork)(nil).SetRoutingMode), ctx, id, mode)
+}
+
 // MockNetworkHost is a mock of NetworkHost interface.
 type MockNetworkHost struct {
 	ctrl     *gomock.Controller
@@ -407,6 +819,35 @@ func (mr *MockNetworkHostMockRecorder) ExportByNetworkIDForContext(ctx, networkI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportByNetworkIDForContext", reflect.TypeOf((*MockNetworkHost)(nil).ExportByNetworkIDForContext), ctx, networkID)
 }
 
+// ExportPACByNetworkID mocks base method.
+func (m *MockNetworkHost) ExportPACByNetworkID(ctx context.Context, networkID uint64, proxy entity.PACProxy) (*entity.PACFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPACByNetworkID", ctx, networkID, proxy)
+	ret0, _ := ret[0].(*entity.PACFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportPACByNetworkID indicates an expected call of ExportPACByNetworkID.
+func (mr *MockNetworkHostMockRecorder) ExportPACByNetworkID(ctx, networkID, proxy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPACByNetworkID", reflect.TypeOf((*MockNetworkHost)(nil).ExportPACByNetworkID), ctx, networkID, proxy)
+}
+
+// ImportByNetworkID mocks base method.
+func (m *MockNetworkHost) ImportByNetworkID(ctx context.Context, networkID uint64, hostDTOs []entity.NetworkHostDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportByNetworkID", ctx, networkID, hostDTOs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportByNetworkID indicates an expected call of ImportByNetworkID.
+func (mr *MockNetworkHostMockRecorder) ImportByNetworkID(ctx, networkID, hostDTOs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportByNetworkID", reflect.TypeOf((*MockNetworkHost)(nil).ImportByNetworkID), ctx, networkID, hostDTOs)
+}
+
 // ImportByNetworkIDFromJSON mocks base method.
 func (m *MockNetworkHost) ImportByNetworkIDFromJSON(ctx context.Context, networkID uint64, jsonData string) error {
 	m.ctrl.T.Helper()
@@ -436,6 +877,201 @@ func (mr *MockNetworkHostMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNetworkHost)(nil).List), ctx, filter)
 }
 
+// SetEnabled mocks base method.
+func (m *MockNetworkHost) SetEnabled(ctx context.Context, id uint64, enabled bool) (*entity.NetworkHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", ctx, id, enabled)
+	ret0, _ := ret[0].(*entity.NetworkHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockNetworkHostMockRecorder) SetEnabled(ctx, id, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockNetworkHost)(nil).SetEnabled), ctx, id, enabled)
+}
+
+// SetPinnedIPs mocks base method.
+func (m *MockNetworkHost) SetPinnedIPs(ctx context.Context, id uint64, pinnedIPs []string, mode entity.NetworkHostPinMode) (*entity.NetworkHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPinnedIPs", ctx, id, pinnedIPs, mode)
+	ret0, _ := ret[0].(*entity.NetworkHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPinnedIPs indicates an expected call of SetPinnedIPs.
+func (mr *MockNetworkHostMockRecorder) SetPinnedIPs(ctx, id, pinnedIPs, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPinnedIPs", reflect.TypeOf((*MockNetworkHost)(nil).SetPinnedIPs), ctx, id, pinnedIPs, mode)
+}
+
+// SetRouteOverride mocks base method.
+func (m *MockNetworkHost) SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) (*entity.NetworkHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRouteOverride", ctx, id, override)
+	ret0, _ := ret[0].(*entity.NetworkHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRouteOverride indicates an expected call of SetRouteOverride.
+func (mr *MockNetworkHostMockRecorder) SetRouteOverride(ctx, id, override any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRouteOverride", reflect.TypeOf((*MockNetworkHost)(nil).SetRouteOverride), ctx, id, override)
+}
+
+// MockNetworkHostImport is a mock of NetworkHostImport interface.
+type MockNetworkHostImport struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkHostImportMockRecorder
+	isgomock struct{}
+}
+
+// MockNetworkHostImportMockRecorder is the mock recorder for MockNetworkHostImport.
+type MockNetworkHostImportMockRecorder struct {
+	mock *MockNetworkHostImport
+}
+
+// NewMockNetworkHostImport creates a new mock instance.
+func NewMockNetworkHostImport(ctrl *gomock.Controller) *MockNetworkHostImport {
+	mock := &MockNetworkHostImport{ctrl: ctrl}
+	mock.recorder = &MockNetworkHostImportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkHostImport) EXPECT() *MockNetworkHostImportMockRecorder {
+	return m.recorder
+}
+
+// PreviewFromSSHConfig mocks base method.
+func (m *MockNetworkHostImport) PreviewFromSSHConfig(ctx context.Context, networkID uint64, includeKnownHosts bool) (*entity.NetworkHostImportPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewFromSSHConfig", ctx, networkID, includeKnownHosts)
+	ret0, _ := ret[0].(*entity.NetworkHostImportPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewFromSSHConfig indicates an expected call of PreviewFromSSHConfig.
+func (mr *MockNetworkHostImportMockRecorder) PreviewFromSSHConfig(ctx, networkID, includeKnownHosts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewFromSSHConfig", reflect.TypeOf((*MockNetworkHostImport)(nil).PreviewFromSSHConfig), ctx, networkID, includeKnownHosts)
+}
+
+// PreviewFromSystemRoutes mocks base method.
+func (m *MockNetworkHostImport) PreviewFromSystemRoutes(ctx context.Context, networkID uint64, reverseResolve bool) (*entity.NetworkHostImportPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewFromSystemRoutes", ctx, networkID, reverseResolve)
+	ret0, _ := ret[0].(*entity.NetworkHostImportPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewFromSystemRoutes indicates an expected call of PreviewFromSystemRoutes.
+func (mr *MockNetworkHostImportMockRecorder) PreviewFromSystemRoutes(ctx, networkID, reverseResolve any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewFromSystemRoutes", reflect.TypeOf((*MockNetworkHostImport)(nil).PreviewFromSystemRoutes), ctx, networkID, reverseResolve)
+}
+
+// MockNetworkSubscription is a mock of NetworkSubscription interface.
+type MockNetworkSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkSubscriptionMockRecorder
+	isgomock struct{}
+}
+
+// MockNetworkSubscriptionMockRecorder is the mock recorder for MockNetworkSubscription.
+type MockNetworkSubscriptionMockRecorder struct {
+	mock *MockNetworkSubscription
+}
+
+// NewMockNetworkSubscription creates a new mock instance.
+func NewMockNetworkSubscription(ctrl *gomock.Controller) *MockNetworkSubscription {
+	mock := &MockNetworkSubscription{ctrl: ctrl}
+	mock.recorder = &MockNetworkSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkSubscription) EXPECT() *MockNetworkSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockNetworkSubscription) Add(ctx context.Context, networkID uint64, url string) (*entity.NetworkSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, networkID, url)
+	ret0, _ := ret[0].(*entity.NetworkSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockNetworkSubscriptionMockRecorder) Add(ctx, networkID, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockNetworkSubscription)(nil).Add), ctx, networkID, url)
+}
+
+// Delete mocks base method.
+func (m *MockNetworkSubscription) Delete(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNetworkSubscriptionMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNetworkSubscription)(nil).Delete), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockNetworkSubscription) List(ctx context.Context, networkID uint64) ([]*entity.NetworkSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, networkID)
+	ret0, _ := ret[0].([]*entity.NetworkSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNetworkSubscriptionMockRecorder) List(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNetworkSubscription)(nil).List), ctx, networkID)
+}
+
+// Refresh mocks base method.
+func (m *MockNetworkSubscription) Refresh(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockNetworkSubscriptionMockRecorder) Refresh(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockNetworkSubscription)(nil).Refresh), ctx, id)
+}
+
+// RefreshAll mocks base method.
+func (m *MockNetworkSubscription) RefreshAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshAll indicates an expected call of RefreshAll.
+func (mr *MockNetworkSubscriptionMockRecorder) RefreshAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAll", reflect.TypeOf((*MockNetworkSubscription)(nil).RefreshAll), ctx)
+}
+
 // MockNetworkHostSetup is a mock of NetworkHostSetup interface.
 type MockNetworkHostSetup struct {
 	ctrl     *gomock.Controller
@@ -460,6 +1096,51 @@ func (m *MockNetworkHostSetup) EXPECT() *MockNetworkHostSetupMockRecorder {
 	return m.recorder
 }
 
+// ExportScriptsByNetworkID mocks base method.
+func (m *MockNetworkHostSetup) ExportScriptsByNetworkID(ctx context.Context, networkID uint64) (*entity.RouteScripts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportScriptsByNetworkID", ctx, networkID)
+	ret0, _ := ret[0].(*entity.RouteScripts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportScriptsByNetworkID indicates an expected call of ExportScriptsByNetworkID.
+func (mr *MockNetworkHostSetupMockRecorder) ExportScriptsByNetworkID(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportScriptsByNetworkID", reflect.TypeOf((*MockNetworkHostSetup)(nil).ExportScriptsByNetworkID), ctx, networkID)
+}
+
+// PreviewByAddress mocks base method.
+func (m *MockNetworkHostSetup) PreviewByAddress(ctx context.Context, networkID uint64, address string) ([]*entity.NetworkHostSetup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewByAddress", ctx, networkID, address)
+	ret0, _ := ret[0].([]*entity.NetworkHostSetup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewByAddress indicates an expected call of PreviewByAddress.
+func (mr *MockNetworkHostSetupMockRecorder) PreviewByAddress(ctx, networkID, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewByAddress", reflect.TypeOf((*MockNetworkHostSetup)(nil).PreviewByAddress), ctx, networkID, address)
+}
+
+// PreviewByNetworkID mocks base method.
+func (m *MockNetworkHostSetup) PreviewByNetworkID(ctx context.Context, networkID uint64) ([]*entity.RoutePreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewByNetworkID", ctx, networkID)
+	ret0, _ := ret[0].([]*entity.RoutePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewByNetworkID indicates an expected call of PreviewByNetworkID.
+func (mr *MockNetworkHostSetupMockRecorder) PreviewByNetworkID(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewByNetworkID", reflect.TypeOf((*MockNetworkHostSetup)(nil).PreviewByNetworkID), ctx, networkID)
+}
+
 // ResetByNetworkID mocks base method.
 func (m *MockNetworkHostSetup) ResetByNetworkID(ctx context.Context, networkID uint64) error {
 	m.ctrl.T.Helper()
@@ -488,6 +1169,157 @@ func (mr *MockNetworkHostSetupMockRecorder) SyncByNetworkID(ctx, network any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncByNetworkID", reflect.TypeOf((*MockNetworkHostSetup)(nil).SyncByNetworkID), ctx, network)
 }
 
+// SyncByNetworkIDWithResult mocks base method.
+func (m *MockNetworkHostSetup) SyncByNetworkIDWithResult(ctx context.Context, networkID uint64) (*entity.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncByNetworkIDWithResult", ctx, networkID)
+	ret0, _ := ret[0].(*entity.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncByNetworkIDWithResult indicates an expected call of SyncByNetworkIDWithResult.
+func (mr *MockNetworkHostSetupMockRecorder) SyncByNetworkIDWithResult(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncByNetworkIDWithResult", reflect.TypeOf((*MockNetworkHostSetup)(nil).SyncByNetworkIDWithResult), ctx, networkID)
+}
+
+// ValidateByNetworkID mocks base method.
+func (m *MockNetworkHostSetup) ValidateByNetworkID(ctx context.Context, networkID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateByNetworkID", ctx, networkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateByNetworkID indicates an expected call of ValidateByNetworkID.
+func (mr *MockNetworkHostSetupMockRecorder) ValidateByNetworkID(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateByNetworkID", reflect.TypeOf((*MockNetworkHostSetup)(nil).ValidateByNetworkID), ctx, networkID)
+}
+
+// MockScopedDNS is a mock of ScopedDNS interface.
+type MockScopedDNS struct {
+	ctrl     *gomock.Controller
+	recorder *MockScopedDNSMockRecorder
+	isgomock struct{}
+}
+
+// MockScopedDNSMockRecorder is the mock recorder for MockScopedDNS.
+type MockScopedDNSMockRecorder struct {
+	mock *MockScopedDNS
+}
+
+// NewMockScopedDNS creates a new mock instance.
+func NewMockScopedDNS(ctrl *gomock.Controller) *MockScopedDNS {
+	mock := &MockScopedDNS{ctrl: ctrl}
+	mock.recorder = &MockScopedDNSMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScopedDNS) EXPECT() *MockScopedDNSMockRecorder {
+	return m.recorder
+}
+
+// ApplyByNetwork mocks base method.
+func (m *MockScopedDNS) ApplyByNetwork(ctx context.Context, network *entity.Network) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyByNetwork", ctx, network)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyByNetwork indicates an expected call of ApplyByNetwork.
+func (mr *MockScopedDNSMockRecorder) ApplyByNetwork(ctx, network any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyByNetwork", reflect.TypeOf((*MockScopedDNS)(nil).ApplyByNetwork), ctx, network)
+}
+
+// RemoveByNetworkID mocks base method.
+func (m *MockScopedDNS) RemoveByNetworkID(ctx context.Context, networkID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveByNetworkID", ctx, networkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveByNetworkID indicates an expected call of RemoveByNetworkID.
+func (mr *MockScopedDNSMockRecorder) RemoveByNetworkID(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveByNetworkID", reflect.TypeOf((*MockScopedDNS)(nil).RemoveByNetworkID), ctx, networkID)
+}
+
+// MockRouteConflict is a mock of RouteConflict interface.
+type MockRouteConflict struct {
+	ctrl     *gomock.Controller
+	recorder *MockRouteConflictMockRecorder
+	isgomock struct{}
+}
+
+// MockRouteConflictMockRecorder is the mock recorder for MockRouteConflict.
+type MockRouteConflictMockRecorder struct {
+	mock *MockRouteConflict
+}
+
+// NewMockRouteConflict creates a new mock instance.
+func NewMockRouteConflict(ctrl *gomock.Controller) *MockRouteConflict {
+	mock := &MockRouteConflict{ctrl: ctrl}
+	mock.recorder = &MockRouteConflictMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRouteConflict) EXPECT() *MockRouteConflictMockRecorder {
+	return m.recorder
+}
+
+// Analyze mocks base method.
+func (m *MockRouteConflict) Analyze(ctx context.Context) ([]*entity.RouteConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analyze", ctx)
+	ret0, _ := ret[0].([]*entity.RouteConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analyze indicates an expected call of Analyze.
+func (mr *MockRouteConflictMockRecorder) Analyze(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analyze", reflect.TypeOf((*MockRouteConflict)(nil).Analyze), ctx)
+}
+
+// AnalyzeByNetworkID mocks base method.
+func (m *MockRouteConflict) AnalyzeByNetworkID(ctx context.Context, networkID uint64) ([]*entity.RouteConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnalyzeByNetworkID", ctx, networkID)
+	ret0, _ := ret[0].([]*entity.RouteConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeByNetworkID indicates an expected call of AnalyzeByNetworkID.
+func (mr *MockRouteConflictMockRecorder) AnalyzeByNetworkID(ctx, networkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeByNetworkID", reflect.TypeOf((*MockRouteConflict)(nil).AnalyzeByNetworkID), ctx, networkID)
+}
+
+// CheckAddress mocks base method.
+func (m *MockRouteConflict) CheckAddress(ctx context.Context, networkID uint64, address string) ([]*entity.RouteConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAddress", ctx, networkID, address)
+	ret0, _ := ret[0].([]*entity.RouteConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAddress indicates an expected call of CheckAddress.
+func (mr *MockRouteConflictMockRecorder) CheckAddress(ctx, networkID, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAddress", reflect.TypeOf((*MockRouteConflict)(nil).CheckAddress), ctx, networkID, address)
+}
+
 // MockUpdate is a mock of Update interface.
 type MockUpdate struct {
 	ctrl     *gomock.Controller
@@ -513,16 +1345,16 @@ func (m *MockUpdate) EXPECT() *MockUpdateMockRecorder {
 }
 
 // CheckForUpdates mocks base method.
-func (m *MockUpdate) CheckForUpdates() (*entity.UpdateInfo, error) {
+func (m *MockUpdate) CheckForUpdates(ctx context.Context) (*entity.UpdateInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckForUpdates")
+	ret := m.ctrl.Call(m, "CheckForUpdates", ctx)
 	ret0, _ := ret[0].(*entity.UpdateInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CheckForUpdates indicates an expected call of CheckForUpdates.
-func (mr *MockUpdateMockRecorder) CheckForUpdates() *gomock.Call {
+func (mr *MockUpdateMockRecorder) CheckForUpdates(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckForUpdates", reflect.TypeOf((*MockUpdate)(nil).CheckForUpdates))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckForUpdates", reflect.TypeOf((*MockUpdate)(nil).CheckForUpdates), ctx)
 }
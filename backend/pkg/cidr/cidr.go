@@ -0,0 +1,163 @@
+// Package cidr implements prefix arithmetic used to punch holes into routed ranges.
+package cidr
+
+import (
+	"net/netip"
+	"slices"
+)
+
+// Subtract removes every excluded prefix from prefix. The remainder is returned as the smallest set
+// of prefixes covering it, obtained by repeatedly splitting prefix in halves around each exclusion.
+// Prefixes of a different address family than prefix are ignored.
+func Subtract(prefix netip.Prefix, excluded []netip.Prefix) []netip.Prefix {
+	remaining := []netip.Prefix{prefix.Masked()}
+	for _, exclusion := range excluded {
+		exclusion = exclusion.Masked()
+		if exclusion.Addr().Is4() != prefix.Addr().Is4() {
+			continue
+		}
+
+		next := make([]netip.Prefix, 0, len(remaining))
+		for _, current := range remaining {
+			next = append(next, subtractOne(current, exclusion)...)
+		}
+		remaining = next
+	}
+
+	return remaining
+}
+
+// Overlaps reports whether prefix overlaps any of the other prefixes.
+func Overlaps(prefix netip.Prefix, others []netip.Prefix) bool {
+	for _, other := range others {
+		if prefix.Overlaps(other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Aggregate returns a minimal set of prefixes covering the given ones. Duplicates and prefixes contained
+// in others are dropped and two halves of the same parent are merged into it, which keeps the covered
+// addresses exactly the same.
+//
+// Prefixes longer than maxBits that fall into the same /maxBits block are additionally collapsed into
+// the smallest prefix covering all of them, which may cover addresses that weren't listed. A maxBits
+// equal to or above the address length disables collapsing. The result is sorted.
+func Aggregate(prefixes []netip.Prefix, maxBits int) []netip.Prefix {
+	res := make([]netip.Prefix, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		res = append(res, prefix.Masked())
+	}
+
+	res = collapse(res, maxBits)
+	for {
+		merged := mergeHalves(removeContained(res))
+		if len(merged) == len(res) {
+			return merged
+		}
+		res = merged
+	}
+}
+
+// collapse replaces the prefixes longer than maxBits sharing a /maxBits block with the smallest prefix
+// covering them.
+func collapse(prefixes []netip.Prefix, maxBits int) []netip.Prefix {
+	covers := make(map[netip.Prefix]netip.Prefix)
+	blocks := make([]netip.Prefix, 0)
+	res := make([]netip.Prefix, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if maxBits < 0 || prefix.Bits() <= maxBits || maxBits >= prefix.Addr().BitLen() {
+			res = append(res, prefix)
+			continue
+		}
+
+		block := netip.PrefixFrom(prefix.Addr(), maxBits).Masked()
+		cover, ok := covers[block]
+		if !ok {
+			covers[block] = prefix
+			blocks = append(blocks, block)
+			continue
+		}
+
+		for !cover.Contains(prefix.Addr()) || cover.Bits() > prefix.Bits() {
+			cover = netip.PrefixFrom(cover.Addr(), cover.Bits()-1).Masked()
+		}
+		covers[block] = cover
+	}
+
+	for _, block := range blocks {
+		res = append(res, covers[block])
+	}
+
+	return res
+}
+
+// removeContained sorts the prefixes and drops duplicates and prefixes contained in another one.
+func removeContained(prefixes []netip.Prefix) []netip.Prefix {
+	sorted := slices.Clone(prefixes)
+	slices.SortFunc(sorted, comparePrefixes)
+
+	res := make([]netip.Prefix, 0, len(sorted))
+	for _, prefix := range sorted {
+		// Sorted by address and then by length, a prefix follows the prefix containing it.
+		if len(res) > 0 && res[len(res)-1].Bits() <= prefix.Bits() && res[len(res)-1].Contains(prefix.Addr()) {
+			continue
+		}
+		res = append(res, prefix)
+	}
+
+	return res
+}
+
+// mergeHalves merges neighbouring sorted prefixes that are the two halves of the same parent.
+func mergeHalves(prefixes []netip.Prefix) []netip.Prefix {
+	res := make([]netip.Prefix, 0, len(prefixes))
+	for i := 0; i < len(prefixes); i++ {
+		current := prefixes[i]
+		if i+1 < len(prefixes) && current.Bits() > 0 && current.Bits() == prefixes[i+1].Bits() {
+			parent := netip.PrefixFrom(current.Addr(), current.Bits()-1).Masked()
+			if parent.Addr() == current.Addr() && parent.Contains(prefixes[i+1].Addr()) {
+				res = append(res, parent)
+				i++
+				continue
+			}
+		}
+		res = append(res, current)
+	}
+
+	return res
+}
+
+func comparePrefixes(a, b netip.Prefix) int {
+	if c := a.Addr().Compare(b.Addr()); c != 0 {
+		return c
+	}
+
+	return a.Bits() - b.Bits()
+}
+
+func subtractOne(prefix, exclusion netip.Prefix) []netip.Prefix {
+	switch {
+	case !prefix.Overlaps(exclusion):
+		return []netip.Prefix{prefix}
+	case exclusion.Bits() <= prefix.Bits():
+		// The exclusion covers the whole prefix.
+		return nil
+	}
+
+	lower, upper := split(prefix)
+
+	return append(subtractOne(lower, exclusion), subtractOne(upper, exclusion)...)
+}
+
+// split halves a prefix into its two subprefixes one bit longer.
+func split(prefix netip.Prefix) (netip.Prefix, netip.Prefix) {
+	bits := prefix.Bits()
+	addr := prefix.Addr().AsSlice()
+	addr[bits/8] |= 0x80 >> (bits % 8) //nolint:mnd // bits per byte
+	upper, _ := netip.AddrFromSlice(addr)
+
+	return netip.PrefixFrom(prefix.Addr(), bits+1), netip.PrefixFrom(upper, bits+1)
+}
@@ -0,0 +1,190 @@
+package cidr
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		excluded []string
+		expected []string
+	}{
+		{
+			name:     "no exclusions",
+			prefix:   "10.0.0.0/8",
+			expected: []string{"10.0.0.0/8"},
+		},
+		{
+			name:     "exclusion outside of the prefix",
+			prefix:   "10.0.0.0/8",
+			excluded: []string{"192.168.0.0/16"},
+			expected: []string{"10.0.0.0/8"},
+		},
+		{
+			name:     "exclusion covering the prefix",
+			prefix:   "10.20.0.0/16",
+			excluded: []string{"10.0.0.0/8"},
+			expected: nil,
+		},
+		{
+			name:     "exclusion equal to the prefix",
+			prefix:   "10.20.0.0/16",
+			excluded: []string{"10.20.0.0/16"},
+			expected: nil,
+		},
+		{
+			name:     "hole in the middle",
+			prefix:   "10.0.0.0/8",
+			excluded: []string{"10.99.0.0/16"},
+			expected: []string{
+				"10.0.0.0/10",
+				"10.64.0.0/11",
+				"10.96.0.0/15",
+				"10.98.0.0/16",
+				"10.100.0.0/14",
+				"10.104.0.0/13",
+				"10.112.0.0/12",
+				"10.128.0.0/9",
+			},
+		},
+		{
+			name:     "single address",
+			prefix:   "192.168.1.0/30",
+			excluded: []string{"192.168.1.2/32"},
+			expected: []string{"192.168.1.0/31", "192.168.1.3/32"},
+		},
+		{
+			name:     "several exclusions",
+			prefix:   "192.168.1.0/29",
+			excluded: []string{"192.168.1.0/31", "192.168.1.4/30"},
+			expected: []string{"192.168.1.2/31"},
+		},
+		{
+			name:     "prefix with host bits is masked",
+			prefix:   "192.168.1.5/30",
+			excluded: []string{"192.168.1.4/31"},
+			expected: []string{"192.168.1.6/31"},
+		},
+		{
+			name:     "different address family is ignored",
+			prefix:   "10.0.0.0/8",
+			excluded: []string{"fd00::/8"},
+			expected: []string{"10.0.0.0/8"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			excluded := make([]netip.Prefix, 0, len(tt.excluded))
+			for _, exclusion := range tt.excluded {
+				excluded = append(excluded, netip.MustParsePrefix(exclusion))
+			}
+
+			result := Subtract(netip.MustParsePrefix(tt.prefix), excluded)
+
+			var actual []string
+			for _, prefix := range result {
+				actual = append(actual, prefix.String())
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	others := []netip.Prefix{
+		netip.MustParsePrefix("10.99.0.0/16"),
+		netip.MustParsePrefix("192.168.1.10/32"),
+	}
+
+	assert.True(t, Overlaps(netip.MustParsePrefix("10.0.0.0/8"), others))
+	assert.True(t, Overlaps(netip.MustParsePrefix("10.99.1.0/24"), others))
+	assert.True(t, Overlaps(netip.MustParsePrefix("192.168.1.10/32"), others))
+	assert.False(t, Overlaps(netip.MustParsePrefix("172.16.0.0/12"), others))
+	assert.False(t, Overlaps(netip.MustParsePrefix("10.0.0.0/8"), nil))
+}
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		maxBits  int
+		expected []string
+	}{
+		{
+			name:     "empty",
+			maxBits:  32,
+			expected: nil,
+		},
+		{
+			name:     "duplicates are dropped",
+			prefixes: []string{"10.0.0.1/32", "10.0.0.1/32", "10.0.0.9/32"},
+			maxBits:  32,
+			expected: []string{"10.0.0.1/32", "10.0.0.9/32"},
+		},
+		{
+			name:     "contained prefixes are dropped",
+			prefixes: []string{"10.1.2.3/32", "10.0.0.0/8", "10.20.0.0/16"},
+			maxBits:  32,
+			expected: []string{"10.0.0.0/8"},
+		},
+		{
+			name:     "halves are merged repeatedly",
+			prefixes: []string{"10.0.0.3/32", "10.0.0.0/32", "10.0.0.2/32", "10.0.0.1/32"},
+			maxBits:  32,
+			expected: []string{"10.0.0.0/30"},
+		},
+		{
+			name:     "adjacent prefixes of different parents are kept",
+			prefixes: []string{"10.0.0.1/32", "10.0.0.2/32"},
+			maxBits:  32,
+			expected: []string{"10.0.0.1/32", "10.0.0.2/32"},
+		},
+		{
+			name:     "prefixes in the same block are collapsed",
+			prefixes: []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.200/32", "10.0.1.5/32"},
+			maxBits:  24,
+			expected: []string{"10.0.0.0/24", "10.0.1.5/32"},
+		},
+		{
+			name:     "collapse covers only what is needed",
+			prefixes: []string{"10.0.0.17/32", "10.0.0.30/32"},
+			maxBits:  24,
+			expected: []string{"10.0.0.16/28"},
+		},
+		{
+			name:     "prefixes wider than the limit are kept",
+			prefixes: []string{"10.0.0.0/16", "10.1.0.0/16", "172.16.0.0/12"},
+			maxBits:  24,
+			expected: []string{"10.0.0.0/15", "172.16.0.0/12"},
+		},
+		{
+			name:     "host bits are masked",
+			prefixes: []string{"192.168.1.5/24"},
+			maxBits:  32,
+			expected: []string{"192.168.1.0/24"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefixes := make([]netip.Prefix, 0, len(tt.prefixes))
+			for _, prefix := range tt.prefixes {
+				prefixes = append(prefixes, netip.MustParsePrefix(prefix))
+			}
+
+			result := Aggregate(prefixes, tt.maxBits)
+
+			var actual []string
+			for _, prefix := range result {
+				actual = append(actual, prefix.String())
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
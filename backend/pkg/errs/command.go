@@ -0,0 +1,28 @@
+package errs
+
+import (
+	"fmt"
+	"time"
+)
+
+// CommandError is a command that ran and failed. Err is one of the command errors above when the command printed
+// a known message, the error the command exited with otherwise.
+type CommandError struct {
+	Command  string
+	ExitCode int
+	Stderr   string
+	Duration time.Duration
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%v: %s", e.Err, e.Stderr)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
@@ -13,6 +13,32 @@ var (
 
 	ErrNetworkHostNotFound      = errors.New("network host not found")
 	ErrNetworkHostAlreadyExists = errors.New("network host already exists")
+	ErrNetworkHostManaged       = errors.New("network host is managed by a subscription")
+
+	ErrNetworkSubscriptionNotFound      = errors.New("network subscription not found")
+	ErrNetworkSubscriptionAlreadyExists = errors.New("network subscription already exists")
+
+	ErrHostGroupNotFound          = errors.New("host group not found")
+	ErrHostGroupAlreadyExists     = errors.New("host group already exists")
+	ErrHostGroupHostNotFound      = errors.New("host group host not found")
+	ErrHostGroupHostAlreadyExists = errors.New("host group host already exists")
 
 	ErrVPNServiceNotFound = errors.New("vpn service not found")
+
+	ErrCommandRequiresAdmin        = errors.New("command requires admin privileges")
+	ErrCommandInvalidParameters    = errors.New("command parameters were not valid")
+	ErrNetworkServiceNotRecognized = errors.New("network service is not recognized")
+	ErrCommandNotRecorded          = errors.New("command not recorded in transcript")
+
+	ErrDNSCacheEntryNotFound = errors.New("dns cache entry not found")
+	ErrResolverFileNotFound  = errors.New("resolver file not found")
+
+	ErrNetworkSetupGenerationNotFound = errors.New("network setup generation not found")
+	ErrSyncInTransaction              = errors.New("network sync can't run inside a transaction")
+
+	ErrOperationNotFound  = errors.New("operation not found")
+	ErrOperationCancelled = errors.New("operation cancelled")
+	ErrOperationTimedOut  = errors.New("operation timed out")
+
+	ErrSimulationDisabled = errors.New("simulation mode is disabled")
 )
@@ -0,0 +1,173 @@
+package sshconfig
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxIncludeDepth mirrors the recursion limit of OpenSSH's config reader.
+const maxIncludeDepth = 16
+
+// Host is a Host block of an ssh config file.
+type Host struct {
+	Patterns []string
+	HostName string
+}
+
+// ParseConfigFile reads an ssh config file and the files it includes.
+// Relative Include paths are resolved against homeDir/.ssh, like ssh does for user config files.
+// Match blocks are ignored since their conditions can't be evaluated statically.
+func ParseConfigFile(path, homeDir string) ([]*Host, error) {
+	p := &configParser{
+		sshDir:  filepath.Join(homeDir, ".ssh"),
+		homeDir: homeDir,
+	}
+
+	err := p.parseFile(path, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.hosts, nil
+}
+
+type configParser struct {
+	sshDir  string
+	homeDir string
+	hosts   []*Host
+}
+
+func (p *configParser) parseFile(path string, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf("too many nested includes in %s", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open ssh config %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var current *Host
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		keyword, args := splitConfigLine(scanner.Text())
+		if keyword == "" {
+			continue
+		}
+
+		switch keyword {
+		case "host":
+			current = &Host{Patterns: args}
+			p.hosts = append(p.hosts, current)
+		case "match":
+			current = nil
+		case "hostname":
+			// The first obtained value wins, as in ssh.
+			if current != nil && current.HostName == "" && len(args) > 0 {
+				current.HostName = args[0]
+			}
+		case "include":
+			err = p.parseIncludes(args, depth)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read ssh config %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (p *configParser) parseIncludes(patterns []string, depth int) error {
+	for _, pattern := range patterns {
+		pattern = p.expandPath(pattern)
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid include pattern %s: %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			err = p.parseFile(match, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *configParser) expandPath(path string) string {
+	if path == "~" {
+		return p.homeDir
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(p.homeDir, path[2:])
+	}
+	if !filepath.IsAbs(path) {
+		return filepath.Join(p.sshDir, path)
+	}
+
+	return path
+}
+
+// splitConfigLine returns the lower-cased keyword of a config line and its arguments.
+// Keyword and arguments may be separated by whitespace or a single "=".
+func splitConfigLine(line string) (string, []string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", nil
+	}
+
+	end := strings.IndexAny(line, " \t=")
+	if end == -1 {
+		return strings.ToLower(line), nil
+	}
+
+	keyword := strings.ToLower(line[:end])
+	rest := strings.TrimLeft(line[end:], " \t")
+	rest = strings.TrimPrefix(rest, "=")
+
+	return keyword, splitArgs(rest)
+}
+
+// splitArgs splits whitespace separated arguments, keeping double-quoted ones together.
+func splitArgs(s string) []string {
+	var (
+		args    []string
+		current strings.Builder
+		quoted  bool
+		inArg   bool
+	)
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case (r == ' ' || r == '\t') && !quoted:
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
@@ -0,0 +1,124 @@
+package sshconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestParseConfigFile(t *testing.T) {
+	homeDir := t.TempDir()
+	configPath := filepath.Join(homeDir, ".ssh", "config")
+
+	writeFile(t, configPath, `# Corporate hosts
+Include config.d/*
+Include ~/.ssh/extra missing/*
+
+Host gitlab gl
+    HostName gitlab.corp.example
+    HostName ignored.example
+    User git
+
+Host=jump.corp.example
+  Port 2222
+
+Match host *.corp.example exec "true"
+    HostName not-a-host-block.example
+
+Host "quoted host" *.internal
+	hostname=%h.corp.example
+`)
+	writeFile(t, filepath.Join(homeDir, ".ssh", "config.d", "db"), "Host db\n  HostName 10.1.2.3\n")
+	writeFile(t, filepath.Join(homeDir, ".ssh", "extra"), "Host wiki.corp.example\n")
+
+	hosts, err := ParseConfigFile(configPath, homeDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*Host{
+		{Patterns: []string{"db"}, HostName: "10.1.2.3"},
+		{Patterns: []string{"wiki.corp.example"}},
+		{Patterns: []string{"gitlab", "gl"}, HostName: "gitlab.corp.example"},
+		{Patterns: []string{"jump.corp.example"}},
+		{Patterns: []string{"quoted host", "*.internal"}, HostName: "%h.corp.example"},
+	}, hosts)
+}
+
+func TestParseConfigFile_Errors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		homeDir := t.TempDir()
+
+		hosts, err := ParseConfigFile(filepath.Join(homeDir, ".ssh", "config"), homeDir)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		assert.Nil(t, hosts)
+	})
+
+	t.Run("include loop", func(t *testing.T) {
+		homeDir := t.TempDir()
+		configPath := filepath.Join(homeDir, ".ssh", "config")
+		writeFile(t, configPath, "Include config\n")
+
+		hosts, err := ParseConfigFile(configPath, homeDir)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too many nested includes")
+		assert.Nil(t, hosts)
+	})
+}
+
+func TestSplitConfigLine(t *testing.T) {
+	tests := []struct {
+		name            string
+		line            string
+		expectedKeyword string
+		expectedArgs    []string
+	}{
+		{
+			name:            "whitespace separated",
+			line:            "  HostName example.com",
+			expectedKeyword: "hostname",
+			expectedArgs:    []string{"example.com"},
+		},
+		{
+			name:            "equals separated",
+			line:            "Host=a b",
+			expectedKeyword: "host",
+			expectedArgs:    []string{"a", "b"},
+		},
+		{
+			name:            "equals with spaces",
+			line:            "Host = a",
+			expectedKeyword: "host",
+			expectedArgs:    []string{"a"},
+		},
+		{
+			name:            "quoted argument",
+			line:            `Include "my configs/*"`,
+			expectedKeyword: "include",
+			expectedArgs:    []string{"my configs/*"},
+		},
+		{name: "keyword only", line: "Host", expectedKeyword: "host"},
+		{name: "comment", line: "# Host example.com"},
+		{name: "empty", line: "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyword, args := splitConfigLine(tt.line)
+
+			assert.Equal(t, tt.expectedKeyword, keyword)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
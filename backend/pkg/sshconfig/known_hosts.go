@@ -0,0 +1,62 @@
+package sshconfig
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ParseKnownHostsFile returns the host patterns of a known_hosts file in the order they appear.
+// Non-standard ports are dropped ("[host]:2222" becomes "host"), hashed entries are returned as is.
+func ParseKnownHostsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open known_hosts %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		// Skip @cert-authority and @revoked markers.
+		if strings.HasPrefix(fields[0], "@") {
+			fields = fields[1:]
+			if len(fields) == 0 {
+				continue
+			}
+		}
+
+		for _, host := range strings.Split(fields[0], ",") {
+			if host == "" {
+				continue
+			}
+
+			hosts = append(hosts, stripPort(host))
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read known_hosts %s: %w", path, err)
+	}
+
+	return hosts, nil
+}
+
+func stripPort(host string) string {
+	if !strings.HasPrefix(host, "[") {
+		return host
+	}
+
+	end := strings.Index(host, "]")
+	if end == -1 {
+		return host
+	}
+
+	return host[1:end]
+}
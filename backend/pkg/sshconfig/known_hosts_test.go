@@ -0,0 +1,41 @@
+package sshconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKnownHostsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	writeFile(t, path, `# comment
+git.corp.example,10.1.2.3 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAA
+[jump.corp.example]:2222 ssh-rsa AAAAB3NzaC1yc2EAAAA
+
+|1|c2FsdA==|aGFzaA== ecdsa-sha2-nistp256 AAAAE2VjZHNh
+@cert-authority *.corp.example ssh-rsa AAAAB3NzaC1yc2EAAAA
+@revoked
+`)
+
+	hosts, err := ParseKnownHostsFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"git.corp.example",
+		"10.1.2.3",
+		"jump.corp.example",
+		"|1|c2FsdA==|aGFzaA==",
+		"*.corp.example",
+	}, hosts)
+}
+
+func TestParseKnownHostsFile_MissingFile(t *testing.T) {
+	hosts, err := ParseKnownHostsFile(filepath.Join(t.TempDir(), "known_hosts"))
+
+	require.Error(t, err)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	assert.Nil(t, hosts)
+}
@@ -0,0 +1,72 @@
+package dnscache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type Storage struct {
+	db *database.Database
+}
+
+func New(db *database.Database) *Storage {
+	return &Storage{
+		db: db,
+	}
+}
+
+// Upsert stores the latest resolution of a hostname, replacing the one cached before.
+func (s *Storage) Upsert(ctx context.Context, entry *entity.DNSCacheEntry) error {
+	queryBuilder := sq.Insert("dns_cache").
+		Columns("address", "ips", "resolved_at", "ttl").
+		Values(entry.Address, &entry.IPs, entry.ResolvedAt.Time, entry.TTL).
+		Suffix(
+			"ON CONFLICT (address) DO UPDATE SET " +
+				"ips = excluded.ips, resolved_at = excluded.resolved_at, ttl = excluded.ttl",
+		)
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Get(ctx context.Context, address string) (*entity.DNSCacheEntry, error) {
+	queryBuilder := sq.Select("address", "ips", "resolved_at", "ttl").
+		From("dns_cache").
+		Where(sq.Eq{"address": address})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	entry := new(entity.DNSCacheEntry)
+	err = row.StructScan(entry)
+
+	switch {
+	case err == nil:
+		return entry, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrDNSCacheEntryNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
@@ -0,0 +1,113 @@
+package dnscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	db := &database.Database{}
+	storage := New(db)
+
+	assert.NotNil(t, storage)
+	assert.Equal(t, db, storage.db)
+}
+
+func TestStorage_UpsertAndGet(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	resolvedAt := entity.TimestampFromTime(time.Now().Add(-time.Hour).UTC())
+	err = storage.Upsert(ctx, &entity.DNSCacheEntry{
+		Address:    "git.corp.example",
+		IPs:        entity.IPList{"10.0.0.5", "10.0.0.6"},
+		ResolvedAt: resolvedAt,
+		TTL:        3600,
+	})
+	require.NoError(t, err)
+
+	entry, err := storage.Get(ctx, "git.corp.example")
+	require.NoError(t, err)
+	assert.Equal(t, "git.corp.example", entry.Address)
+	assert.Equal(t, entity.IPList{"10.0.0.5", "10.0.0.6"}, entry.IPs)
+	assert.WithinDuration(t, resolvedAt.Time, entry.ResolvedAt.Time, time.Second)
+	assert.Equal(t, int64(3600), entry.TTL)
+
+	err = storage.Upsert(ctx, entity.NewDNSCacheEntry("git.corp.example", []string{"10.0.0.7"}, time.Minute))
+	require.NoError(t, err)
+
+	entry, err = storage.Get(ctx, "git.corp.example")
+	require.NoError(t, err)
+	assert.Equal(t, entity.IPList{"10.0.0.7"}, entry.IPs)
+	assert.Equal(t, int64(60), entry.TTL)
+	assert.False(t, entry.IsExpired(time.Now()))
+}
+
+func TestStorage_Get_NotFound(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+
+	entry, err := storage.Get(context.Background(), "missing.example")
+
+	require.ErrorIs(t, err, errs.ErrDNSCacheEntryNotFound)
+	assert.Nil(t, entry)
+}
+
+func TestStorage_DatabaseError(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+
+	storage := New(db)
+	ctx := context.Background()
+	db.Close()
+
+	err = storage.Upsert(ctx, entity.NewDNSCacheEntry("git.corp.example", []string{"10.0.0.5"}, time.Minute))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute query")
+
+	_, err = storage.Get(ctx, "git.corp.example")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to scan row")
+}
+
+func createTestDatabase(t *testing.T) (*database.Database, error) {
+	t.Helper()
+
+	db, err := database.NewForTesting("dnscache_test")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	_, err = db.GetDB(ctx).ExecContext(ctx, `
+		DROP TABLE IF EXISTS dns_cache;
+
+		CREATE TABLE dns_cache (
+			address TEXT PRIMARY KEY,
+			ips TEXT NOT NULL,
+			resolved_at DATETIME NOT NULL,
+			ttl INTEGER NOT NULL
+		);
+	`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -55,6 +55,31 @@ func (s *Storage) Add(ctx context.Context, host *entity.Host) (*entity.Host, err
 	}
 }
 
+func (s *Storage) Get(ctx context.Context, id uint64) (*entity.Host, error) {
+	queryBuilder := sq.Select("id", "address", "description", "created_at").
+		From("hosts").
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	host := new(entity.Host)
+	err = row.StructScan(host)
+
+	switch {
+	case err == nil:
+		return host, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrHostNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
 func (s *Storage) List(ctx context.Context, filter *entity.ListHostFilter) ([]*entity.Host, error) {
 	queryBuilder := sq.Select("id", "address", "description", "created_at").
 		From("hosts").
@@ -110,6 +135,35 @@ func (s *Storage) List(ctx context.Context, filter *entity.ListHostFilter) ([]*e
 	return hosts, nil
 }
 
+func (s *Storage) Update(ctx context.Context, host *entity.Host) (*entity.Host, error) {
+	queryBuilder := sq.Update("hosts").
+		Set("address", host.Address).
+		Set("description", host.Description).
+		Where(sq.Eq{"id": host.ID}).
+		Suffix("RETURNING id, address, description, created_at")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	updatedHost := new(entity.Host)
+	err = row.StructScan(updatedHost)
+
+	switch {
+	case err == nil:
+		return updatedHost, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrHostNotFound
+	case strings.Contains(err.Error(), storage.ErrPrefixUniqueViolation):
+		return nil, errs.ErrHostAlreadyExists
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
 func (s *Storage) Delete(ctx context.Context, id uint64) error {
 	queryBuilder := sq.Delete("hosts").
 		Where(sq.Eq{"id": id})
@@ -236,6 +236,57 @@ func TestStorage_List_Success(t *testing.T) {
 	}
 }
 
+func TestStorage_Get(t *testing.T) {
+	db := setupTestDB(t)
+	storage := New(db)
+	ctx := context.Background()
+
+	added, err := storage.Add(ctx, &entity.Host{
+		Address:     "git.example.com",
+		Description: stringPtr("Git server"),
+	})
+	require.NoError(t, err)
+
+	result, err := storage.Get(ctx, added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, added.ID, result.ID)
+	assert.Equal(t, "git.example.com", result.Address)
+	require.NotNil(t, result.Description)
+	assert.Equal(t, "Git server", *result.Description)
+
+	_, err = storage.Get(ctx, 99999)
+	require.ErrorIs(t, err, errs.ErrHostNotFound)
+}
+
+func TestStorage_Update(t *testing.T) {
+	db := setupTestDB(t)
+	storage := New(db)
+	ctx := context.Background()
+
+	added, err := storage.Add(ctx, &entity.Host{Address: "git.example.com"})
+	require.NoError(t, err)
+
+	_, err = storage.Add(ctx, &entity.Host{Address: "wiki.example.com"})
+	require.NoError(t, err)
+
+	updated, err := storage.Update(ctx, &entity.Host{
+		ID:          added.ID,
+		Address:     "git2.example.com",
+		Description: stringPtr("Git server"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, added.ID, updated.ID)
+	assert.Equal(t, "git2.example.com", updated.Address)
+	require.NotNil(t, updated.Description)
+	assert.Equal(t, "Git server", *updated.Description)
+
+	_, err = storage.Update(ctx, &entity.Host{ID: added.ID, Address: "wiki.example.com"})
+	require.ErrorIs(t, err, errs.ErrHostAlreadyExists)
+
+	_, err = storage.Update(ctx, &entity.Host{ID: 99999, Address: "missing.example.com"})
+	require.ErrorIs(t, err, errs.ErrHostNotFound)
+}
+
 func TestStorage_Delete_Success(t *testing.T) {
 	db := setupTestDB(t)
 	storage := New(db)
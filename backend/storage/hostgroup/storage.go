@@ -0,0 +1,354 @@
+package hostgroup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/storage"
+)
+
+const (
+	columnNetworkID   = "network_id"
+	columnHostGroupID = "host_group_id"
+)
+
+type Storage struct {
+	db *database.Database
+}
+
+func New(db *database.Database) *Storage {
+	return &Storage{
+		db: db,
+	}
+}
+
+func (s *Storage) Add(ctx context.Context, hostGroup *entity.HostGroup) (*entity.HostGroup, error) {
+	queryBuilder := sq.Insert("host_groups").
+		Columns("name", "description", "created_at").
+		Values(hostGroup.Name, hostGroup.Description, time.Now()).
+		Suffix("RETURNING id, name, description, created_at")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	newHostGroup := new(entity.HostGroup)
+	err = row.StructScan(newHostGroup)
+
+	switch {
+	case err == nil:
+		return newHostGroup, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrHostGroupNotFound
+	case strings.Contains(err.Error(), storage.ErrPrefixUniqueViolation):
+		return nil, errs.ErrHostGroupAlreadyExists
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+func (s *Storage) Get(ctx context.Context, id uint64) (*entity.HostGroup, error) {
+	queryBuilder := sq.Select("id", "name", "description", "created_at").
+		From("host_groups").
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	hostGroup := new(entity.HostGroup)
+	err = row.StructScan(hostGroup)
+
+	switch {
+	case err == nil:
+		return hostGroup, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrHostGroupNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+func (s *Storage) List(ctx context.Context, filter *entity.ListHostGroupFilter) ([]*entity.HostGroup, error) {
+	queryBuilder := sq.Select("id", "name", "description", "created_at").
+		From("host_groups").
+		OrderBy("name ASC")
+
+	if filter != nil {
+		if filter.ID != nil {
+			queryBuilder = queryBuilder.Where(sq.Eq{"id": filter.ID})
+		}
+
+		if filter.NetworkID != nil {
+			queryBuilder = queryBuilder.Where(
+				sq.Expr("id IN (SELECT host_group_id FROM network_host_groups WHERE ?)",
+					sq.Eq{columnNetworkID: filter.NetworkID}),
+			)
+		}
+
+		if filter.Search != "" {
+			queryBuilder = queryBuilder.Where(sq.Or{
+				sq.Like{"name": "%" + filter.Search + "%"},
+				sq.Like{"description": "%" + filter.Search + "%"},
+			})
+		}
+	}
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.GetDB(ctx).QueryxContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Error("failed to close rows", "error", closeErr)
+		}
+	}()
+
+	var hostGroups []*entity.HostGroup
+	for rows.Next() {
+		hostGroup := new(entity.HostGroup)
+		err = rows.StructScan(hostGroup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		hostGroups = append(hostGroups, hostGroup)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("got rows error: %w", err)
+	}
+
+	return hostGroups, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, id uint64) error {
+	queryBuilder := sq.Delete("host_groups").
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) AddHost(ctx context.Context, hostGroupHost *entity.HostGroupHost) (*entity.HostGroupHost, error) {
+	queryBuilder := sq.Insert("host_group_hosts").
+		Columns("host_group_id", "address", "description", "created_at").
+		Values(hostGroupHost.HostGroupID, hostGroupHost.Address, hostGroupHost.Description, time.Now()).
+		Suffix("RETURNING id, host_group_id, address, description, created_at")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	newHostGroupHost := new(entity.HostGroupHost)
+	err = row.StructScan(newHostGroupHost)
+
+	switch {
+	case err == nil:
+		return newHostGroupHost, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrHostGroupHostNotFound
+	case strings.Contains(err.Error(), storage.ErrPrefixUniqueViolation):
+		return nil, errs.ErrHostGroupHostAlreadyExists
+	case strings.Contains(err.Error(), storage.ErrPrefixForeignKeyViolation):
+		return nil, errs.ErrHostGroupNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+func (s *Storage) GetHost(ctx context.Context, id uint64) (*entity.HostGroupHost, error) {
+	queryBuilder := sq.Select("id", "host_group_id", "address", "description", "created_at").
+		From("host_group_hosts").
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	hostGroupHost := new(entity.HostGroupHost)
+	err = row.StructScan(hostGroupHost)
+
+	switch {
+	case err == nil:
+		return hostGroupHost, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrHostGroupHostNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+func (s *Storage) ListHosts(
+	ctx context.Context,
+	filter *entity.ListHostGroupHostFilter,
+) ([]*entity.HostGroupHost, error) {
+	queryBuilder := sq.Select("id", "host_group_id", "address", "description", "created_at").
+		From("host_group_hosts").
+		OrderBy("host_group_id ASC", "id ASC")
+
+	if filter != nil {
+		if filter.ID != nil {
+			queryBuilder = queryBuilder.Where(sq.Eq{"id": filter.ID})
+		}
+
+		if filter.HostGroupID != nil {
+			queryBuilder = queryBuilder.Where(sq.Eq{columnHostGroupID: filter.HostGroupID})
+		}
+
+		if filter.NetworkID != nil {
+			queryBuilder = queryBuilder.Where(
+				sq.Expr("host_group_id IN (SELECT host_group_id FROM network_host_groups WHERE ?)",
+					sq.Eq{columnNetworkID: filter.NetworkID}),
+			)
+		}
+	}
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.GetDB(ctx).QueryxContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Error("failed to close rows", "error", closeErr)
+		}
+	}()
+
+	var hostGroupHosts []*entity.HostGroupHost
+	for rows.Next() {
+		hostGroupHost := new(entity.HostGroupHost)
+		err = rows.StructScan(hostGroupHost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		hostGroupHosts = append(hostGroupHosts, hostGroupHost)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("got rows error: %w", err)
+	}
+
+	return hostGroupHosts, nil
+}
+
+func (s *Storage) DeleteHost(ctx context.Context, id uint64) error {
+	queryBuilder := sq.Delete("host_group_hosts").
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// AttachToNetwork links a host group to a network. Attaching an already attached group is a no-op.
+func (s *Storage) AttachToNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	queryBuilder := sq.Insert("network_host_groups").
+		Options("OR IGNORE").
+		Columns("network_id", "host_group_id", "created_at").
+		Values(networkID, hostGroupID, time.Now())
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		if strings.Contains(err.Error(), storage.ErrPrefixForeignKeyViolation) {
+			return fmt.Errorf("host group or network not found: %w", err)
+		}
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DetachFromNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	queryBuilder := sq.Delete("network_host_groups").
+		Where(sq.Eq{columnNetworkID: networkID, columnHostGroupID: hostGroupID})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// ListNetworkIDs returns the IDs of the networks a host group is attached to.
+func (s *Storage) ListNetworkIDs(ctx context.Context, hostGroupID uint64) ([]uint64, error) {
+	queryBuilder := sq.Select("network_id").
+		From("network_host_groups").
+		Where(sq.Eq{columnHostGroupID: hostGroupID}).
+		OrderBy("network_id ASC")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var networkIDs []uint64
+	err = s.db.GetDB(ctx).SelectContext(ctx, &networkIDs, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return networkIDs, nil
+}
@@ -0,0 +1,366 @@
+package hostgroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	db := &database.Database{}
+	storage := New(db)
+
+	assert.NotNil(t, storage)
+	assert.Equal(t, db, storage.db)
+}
+
+func TestStorage_Add_Success(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	description := "Code hosting"
+	result, err := storage.Add(ctx, &entity.HostGroup{Name: "GitHub Enterprise", Description: &description})
+	require.NoError(t, err)
+	assert.NotZero(t, result.ID)
+	assert.Equal(t, "GitHub Enterprise", result.Name)
+	require.NotNil(t, result.Description)
+	assert.Equal(t, description, *result.Description)
+	assert.False(t, result.CreatedAt.Time.IsZero())
+}
+
+func TestStorage_Add_UniqueConstraintViolation(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	_, err = storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.NoError(t, err)
+
+	result, err := storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.ErrorIs(t, err, errs.ErrHostGroupAlreadyExists)
+	assert.Nil(t, result)
+}
+
+func TestStorage_Get(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	added, err := storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.NoError(t, err)
+
+	result, err := storage.Get(ctx, added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, added.ID, result.ID)
+	assert.Equal(t, "Build farm", result.Name)
+
+	result, err = storage.Get(ctx, 999)
+	require.ErrorIs(t, err, errs.ErrHostGroupNotFound)
+	assert.Nil(t, result)
+}
+
+func TestStorage_List(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Corp VPN")
+	require.NoError(t, err)
+
+	jiraDescription := "Jira and Confluence"
+	github, err := storage.Add(ctx, &entity.HostGroup{Name: "GitHub Enterprise"})
+	require.NoError(t, err)
+	jira, err := storage.Add(ctx, &entity.HostGroup{Name: "Atlassian", Description: &jiraDescription})
+	require.NoError(t, err)
+
+	err = storage.AttachToNetwork(ctx, github.ID, 1)
+	require.NoError(t, err)
+
+	t.Run("no filter orders by name", func(t *testing.T) {
+		result, listErr := storage.List(ctx, nil)
+		require.NoError(t, listErr)
+		require.Len(t, result, 2)
+		assert.Equal(t, jira.ID, result[0].ID)
+		assert.Equal(t, github.ID, result[1].ID)
+	})
+
+	t.Run("filter by ID", func(t *testing.T) {
+		result, listErr := storage.List(ctx, &entity.ListHostGroupFilter{ID: []uint64{jira.ID}})
+		require.NoError(t, listErr)
+		require.Len(t, result, 1)
+		assert.Equal(t, "Atlassian", result[0].Name)
+	})
+
+	t.Run("filter by network", func(t *testing.T) {
+		result, listErr := storage.List(ctx, &entity.ListHostGroupFilter{NetworkID: []uint64{1}})
+		require.NoError(t, listErr)
+		require.Len(t, result, 1)
+		assert.Equal(t, "GitHub Enterprise", result[0].Name)
+	})
+
+	t.Run("search matches description", func(t *testing.T) {
+		result, listErr := storage.List(ctx, &entity.ListHostGroupFilter{Search: "confluence"})
+		require.NoError(t, listErr)
+		require.Len(t, result, 1)
+		assert.Equal(t, "Atlassian", result[0].Name)
+	})
+}
+
+func TestStorage_Delete_CascadesToHostsAndAttachments(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Corp VPN")
+	require.NoError(t, err)
+
+	hostGroup, err := storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.NoError(t, err)
+	_, err = storage.AddHost(ctx, &entity.HostGroupHost{HostGroupID: hostGroup.ID, Address: "ci.example.com"})
+	require.NoError(t, err)
+	err = storage.AttachToNetwork(ctx, hostGroup.ID, 1)
+	require.NoError(t, err)
+
+	err = storage.Delete(ctx, hostGroup.ID)
+	require.NoError(t, err)
+
+	hosts, err := storage.ListHosts(ctx, &entity.ListHostGroupHostFilter{HostGroupID: []uint64{hostGroup.ID}})
+	require.NoError(t, err)
+	assert.Empty(t, hosts)
+
+	networkIDs, err := storage.ListNetworkIDs(ctx, hostGroup.ID)
+	require.NoError(t, err)
+	assert.Empty(t, networkIDs)
+}
+
+func TestStorage_AddHost(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	hostGroup, err := storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.NoError(t, err)
+
+	description := "CI"
+	result, err := storage.AddHost(ctx, &entity.HostGroupHost{
+		HostGroupID: hostGroup.ID,
+		Address:     "ci.example.com",
+		Description: &description,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, result.ID)
+	assert.Equal(t, hostGroup.ID, result.HostGroupID)
+	assert.Equal(t, "ci.example.com", result.Address)
+
+	fetched, err := storage.GetHost(ctx, result.ID)
+	require.NoError(t, err)
+	assert.Equal(t, result.ID, fetched.ID)
+
+	_, err = storage.AddHost(ctx, &entity.HostGroupHost{HostGroupID: hostGroup.ID, Address: "ci.example.com"})
+	require.ErrorIs(t, err, errs.ErrHostGroupHostAlreadyExists)
+
+	_, err = storage.AddHost(ctx, &entity.HostGroupHost{HostGroupID: 999, Address: "ci.example.com"})
+	require.ErrorIs(t, err, errs.ErrHostGroupNotFound)
+
+	_, err = storage.GetHost(ctx, 999)
+	require.ErrorIs(t, err, errs.ErrHostGroupHostNotFound)
+}
+
+func TestStorage_ListHosts(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Corp VPN")
+	require.NoError(t, err)
+	err = createTestNetwork(ctx, db, 2, "Lab VPN")
+	require.NoError(t, err)
+
+	github, err := storage.Add(ctx, &entity.HostGroup{Name: "GitHub Enterprise"})
+	require.NoError(t, err)
+	buildFarm, err := storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.NoError(t, err)
+
+	_, err = storage.AddHost(ctx, &entity.HostGroupHost{HostGroupID: github.ID, Address: "github.example.com"})
+	require.NoError(t, err)
+	_, err = storage.AddHost(ctx, &entity.HostGroupHost{HostGroupID: buildFarm.ID, Address: "10.30.0.0/16"})
+	require.NoError(t, err)
+
+	err = storage.AttachToNetwork(ctx, github.ID, 1)
+	require.NoError(t, err)
+	err = storage.AttachToNetwork(ctx, buildFarm.ID, 2)
+	require.NoError(t, err)
+
+	t.Run("filter by group", func(t *testing.T) {
+		result, listErr := storage.ListHosts(ctx, &entity.ListHostGroupHostFilter{HostGroupID: []uint64{buildFarm.ID}})
+		require.NoError(t, listErr)
+		require.Len(t, result, 1)
+		assert.Equal(t, "10.30.0.0/16", result[0].Address)
+	})
+
+	t.Run("filter by attached network", func(t *testing.T) {
+		result, listErr := storage.ListHosts(ctx, &entity.ListHostGroupHostFilter{NetworkID: []uint64{1}})
+		require.NoError(t, listErr)
+		require.Len(t, result, 1)
+		assert.Equal(t, "github.example.com", result[0].Address)
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		result, listErr := storage.ListHosts(ctx, nil)
+		require.NoError(t, listErr)
+		assert.Len(t, result, 2)
+	})
+}
+
+func TestStorage_DeleteHost(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	hostGroup, err := storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.NoError(t, err)
+	hostGroupHost, err := storage.AddHost(ctx, &entity.HostGroupHost{
+		HostGroupID: hostGroup.ID,
+		Address:     "ci.example.com",
+	})
+	require.NoError(t, err)
+
+	err = storage.DeleteHost(ctx, hostGroupHost.ID)
+	require.NoError(t, err)
+
+	_, err = storage.GetHost(ctx, hostGroupHost.ID)
+	require.ErrorIs(t, err, errs.ErrHostGroupHostNotFound)
+}
+
+func TestStorage_AttachDetachNetwork(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Corp VPN")
+	require.NoError(t, err)
+	err = createTestNetwork(ctx, db, 2, "Lab VPN")
+	require.NoError(t, err)
+
+	hostGroup, err := storage.Add(ctx, &entity.HostGroup{Name: "Build farm"})
+	require.NoError(t, err)
+
+	err = storage.AttachToNetwork(ctx, hostGroup.ID, 2)
+	require.NoError(t, err)
+	err = storage.AttachToNetwork(ctx, hostGroup.ID, 1)
+	require.NoError(t, err)
+	// Attaching twice is a no-op
+	err = storage.AttachToNetwork(ctx, hostGroup.ID, 1)
+	require.NoError(t, err)
+
+	networkIDs, err := storage.ListNetworkIDs(ctx, hostGroup.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, networkIDs)
+
+	err = storage.DetachFromNetwork(ctx, hostGroup.ID, 1)
+	require.NoError(t, err)
+
+	networkIDs, err = storage.ListNetworkIDs(ctx, hostGroup.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, networkIDs)
+
+	err = storage.AttachToNetwork(ctx, hostGroup.ID, 999)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "host group or network not found")
+}
+
+func createTestDatabase(t *testing.T) (*database.Database, error) {
+	t.Helper()
+
+	db, err := database.NewForTesting("hostgroup_test")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	_, err = db.GetDB(ctx).ExecContext(ctx, `
+		DROP TABLE IF EXISTS network_host_groups;
+		DROP TABLE IF EXISTS host_group_hosts;
+		DROP TABLE IF EXISTS host_groups;
+		DROP TABLE IF EXISTS networks;
+
+		CREATE TABLE networks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE host_groups (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE host_group_hosts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			host_group_id INTEGER NOT NULL,
+			address TEXT NOT NULL,
+			description TEXT,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (host_group_id, address),
+			FOREIGN KEY (host_group_id) REFERENCES host_groups(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE network_host_groups (
+			network_id INTEGER NOT NULL,
+			host_group_id INTEGER NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (network_id, host_group_id),
+			FOREIGN KEY (network_id) REFERENCES networks(id) ON DELETE CASCADE,
+			FOREIGN KEY (host_group_id) REFERENCES host_groups(id) ON DELETE CASCADE
+		);
+	`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func createTestNetwork(ctx context.Context, db *database.Database, id uint64, name string) error {
+	_, err := db.GetDB(ctx).ExecContext(ctx,
+		"INSERT INTO networks (id, name) VALUES (?, ?)",
+		id, name)
+	return err
+}
@@ -2,13 +2,16 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/dmitrorlov/splitr/backend/entity"
 )
 
 type Host interface {
 	Add(ctx context.Context, host *entity.Host) (*entity.Host, error)
+	Get(ctx context.Context, id uint64) (*entity.Host, error)
 	List(ctx context.Context, filter *entity.ListHostFilter) ([]*entity.Host, error)
+	Update(ctx context.Context, host *entity.Host) (*entity.Host, error)
 	Delete(ctx context.Context, id uint64) error
 }
 
@@ -16,6 +19,9 @@ type Network interface {
 	Add(ctx context.Context, network *entity.Network) (*entity.Network, error)
 	Get(ctx context.Context, id uint64) (*entity.Network, error)
 	List(ctx context.Context, filter *entity.ListNetworkFilter) ([]*entity.Network, error)
+	SetRoutingMode(ctx context.Context, id uint64, mode entity.RoutingMode) error
+	SetDNS(ctx context.Context, id uint64, domains entity.DomainList, nameservers entity.IPList) error
+	SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) error
 	Delete(ctx context.Context, id uint64) error
 }
 
@@ -23,10 +29,63 @@ type NetworkHost interface {
 	Add(ctx context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error)
 	Get(ctx context.Context, id uint64) (*entity.NetworkHost, error)
 	List(ctx context.Context, filter *entity.ListNetworkHostFilter) ([]*entity.NetworkHost, error)
+	UpdateByHostID(ctx context.Context, host *entity.Host) error
+	SetEnabled(ctx context.Context, id uint64, enabled bool) error
+	SetExpiresAt(ctx context.Context, id uint64, expiresAt *entity.Timestamp) error
+	SetPinnedIPs(ctx context.Context, id uint64, pinnedIPs entity.IPList, mode entity.NetworkHostPinMode) error
+	SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) error
 	Delete(ctx context.Context, id uint64) error
 }
 
+type NetworkSubscription interface {
+	Add(ctx context.Context, subscription *entity.NetworkSubscription) (*entity.NetworkSubscription, error)
+	Get(ctx context.Context, id uint64) (*entity.NetworkSubscription, error)
+	List(ctx context.Context, filter *entity.ListNetworkSubscriptionFilter) ([]*entity.NetworkSubscription, error)
+	UpdateRefreshState(ctx context.Context, subscription *entity.NetworkSubscription) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+type HostGroup interface {
+	Add(ctx context.Context, hostGroup *entity.HostGroup) (*entity.HostGroup, error)
+	Get(ctx context.Context, id uint64) (*entity.HostGroup, error)
+	List(ctx context.Context, filter *entity.ListHostGroupFilter) ([]*entity.HostGroup, error)
+	Delete(ctx context.Context, id uint64) error
+	AddHost(ctx context.Context, hostGroupHost *entity.HostGroupHost) (*entity.HostGroupHost, error)
+	GetHost(ctx context.Context, id uint64) (*entity.HostGroupHost, error)
+	ListHosts(ctx context.Context, filter *entity.ListHostGroupHostFilter) ([]*entity.HostGroupHost, error)
+	DeleteHost(ctx context.Context, id uint64) error
+	AttachToNetwork(ctx context.Context, hostGroupID, networkID uint64) error
+	DetachFromNetwork(ctx context.Context, hostGroupID, networkID uint64) error
+	ListNetworkIDs(ctx context.Context, hostGroupID uint64) ([]uint64, error)
+}
+
 type NetworkHostSetup interface {
 	AddBatch(ctx context.Context, batch []*entity.NetworkHostSetup) error
 	DeleteBatchByNetworkHostIDs(ctx context.Context, networkHostIDs []uint64) error
 }
+
+type NetworkSetupGeneration interface {
+	Add(ctx context.Context, generation *entity.NetworkSetupGeneration) (*entity.NetworkSetupGeneration, error)
+	GetLastApplied(ctx context.Context, networkID uint64) (*entity.NetworkSetupGeneration, error)
+	// MarkApplied marks the generation applied and deletes the other generations of its network.
+	MarkApplied(ctx context.Context, id uint64) error
+	MarkFailed(ctx context.Context, id uint64) error
+}
+
+type DNSCache interface {
+	Upsert(ctx context.Context, entry *entity.DNSCacheEntry) error
+	Get(ctx context.Context, address string) (*entity.DNSCacheEntry, error)
+}
+
+type LearnedIP interface {
+	Upsert(ctx context.Context, learnedIP *entity.LearnedIP) error
+	ListActive(ctx context.Context, networkHostIDs []uint64, now time.Time) ([]*entity.LearnedIP, error)
+	DeleteExpired(ctx context.Context, now time.Time) ([]uint64, error)
+}
+
+type ResolverFile interface {
+	Add(ctx context.Context, resolverFile *entity.ResolverFile) error
+	Get(ctx context.Context, domain string) (*entity.ResolverFile, error)
+	ListByNetworkID(ctx context.Context, networkID uint64) ([]*entity.ResolverFile, error)
+	Delete(ctx context.Context, domain string) error
+}
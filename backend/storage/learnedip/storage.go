@@ -0,0 +1,143 @@
+package learnedip
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+)
+
+type Storage struct {
+	db *database.Database
+}
+
+func New(db *database.Database) *Storage {
+	return &Storage{
+		db: db,
+	}
+}
+
+// Upsert stores an IP learned for a wildcard network host. Learning an IP again extends it to the new expiry.
+func (s *Storage) Upsert(ctx context.Context, learnedIP *entity.LearnedIP) error {
+	queryBuilder := sq.Insert("network_host_learned_ips").
+		Columns("network_host_id", "network_id", "ip", "name", "expires_at").
+		Values(
+			learnedIP.NetworkHostID,
+			learnedIP.NetworkID,
+			learnedIP.IP,
+			learnedIP.Name,
+			learnedIP.ExpiresAt.UTC(),
+		).
+		Suffix(
+			"ON CONFLICT (network_host_id, ip) DO UPDATE SET " +
+				"name = excluded.name, expires_at = excluded.expires_at",
+		)
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+// ListActive returns the IPs learned for the network hosts that haven't expired by now.
+func (s *Storage) ListActive(
+	ctx context.Context,
+	networkHostIDs []uint64,
+	now time.Time,
+) ([]*entity.LearnedIP, error) {
+	queryBuilder := sq.Select("network_host_id", "network_id", "ip", "name", "expires_at").
+		From("network_host_learned_ips").
+		Where(sq.Eq{"network_host_id": networkHostIDs}).
+		Where(sq.Gt{"expires_at": now.UTC()}).
+		OrderBy("network_host_id ASC", "ip ASC")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.GetDB(ctx).QueryxContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Error("failed to close rows", "error", closeErr)
+		}
+	}()
+
+	var learnedIPs []*entity.LearnedIP
+	for rows.Next() {
+		learnedIP := new(entity.LearnedIP)
+		err = rows.StructScan(learnedIP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		learnedIPs = append(learnedIPs, learnedIP)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("got rows error: %w", err)
+	}
+
+	return learnedIPs, nil
+}
+
+// DeleteExpired removes the IPs that have expired by now and returns the networks they were routed in.
+func (s *Storage) DeleteExpired(ctx context.Context, now time.Time) ([]uint64, error) {
+	queryBuilder := sq.Delete("network_host_learned_ips").
+		Where(sq.LtOrEq{"expires_at": now.UTC()}).
+		Suffix("RETURNING network_id")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.GetDB(ctx).QueryxContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Error("failed to close rows", "error", closeErr)
+		}
+	}()
+
+	var networkIDs []uint64
+	for rows.Next() {
+		var networkID uint64
+		err = rows.Scan(&networkID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if !slices.Contains(networkIDs, networkID) {
+			networkIDs = append(networkIDs, networkID)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("got rows error: %w", err)
+	}
+
+	slices.Sort(networkIDs)
+
+	return networkIDs, nil
+}
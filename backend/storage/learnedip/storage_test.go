@@ -0,0 +1,144 @@
+package learnedip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+)
+
+func TestNew(t *testing.T) {
+	db := &database.Database{}
+	storage := New(db)
+
+	assert.NotNil(t, storage)
+	assert.Equal(t, db, storage.db)
+}
+
+func TestStorage_UpsertAndListActive(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	err = storage.Upsert(ctx, learnedIP(1, 10, "10.0.0.5", now.Add(time.Minute)))
+	require.NoError(t, err)
+	err = storage.Upsert(ctx, learnedIP(1, 10, "10.0.0.6", now.Add(-time.Minute)))
+	require.NoError(t, err)
+	err = storage.Upsert(ctx, learnedIP(2, 20, "10.0.1.5", now.Add(time.Minute)))
+	require.NoError(t, err)
+
+	learnedIPs, err := storage.ListActive(ctx, []uint64{1}, now)
+	require.NoError(t, err)
+	require.Len(t, learnedIPs, 1)
+	assert.Equal(t, uint64(1), learnedIPs[0].NetworkHostID)
+	assert.Equal(t, uint64(10), learnedIPs[0].NetworkID)
+	assert.Equal(t, "10.0.0.5", learnedIPs[0].IP)
+	assert.Equal(t, "git.corp.example.com", learnedIPs[0].Name)
+
+	// Learning the expired IP again brings it back.
+	err = storage.Upsert(ctx, learnedIP(1, 10, "10.0.0.6", now.Add(time.Hour)))
+	require.NoError(t, err)
+
+	learnedIPs, err = storage.ListActive(ctx, []uint64{1, 2}, now)
+	require.NoError(t, err)
+	require.Len(t, learnedIPs, 3)
+	assert.Equal(t, "10.0.0.6", learnedIPs[1].IP)
+	assert.WithinDuration(t, now.Add(time.Hour), learnedIPs[1].ExpiresAt.Time, time.Second)
+}
+
+func TestStorage_DeleteExpired(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, storage.Upsert(ctx, learnedIP(1, 20, "10.0.0.5", now.Add(-time.Minute))))
+	require.NoError(t, storage.Upsert(ctx, learnedIP(2, 20, "10.0.0.6", now.Add(-time.Second))))
+	require.NoError(t, storage.Upsert(ctx, learnedIP(3, 10, "10.0.0.7", now.Add(-time.Hour))))
+	require.NoError(t, storage.Upsert(ctx, learnedIP(4, 30, "10.0.0.8", now.Add(time.Minute))))
+
+	networkIDs, err := storage.DeleteExpired(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 20}, networkIDs)
+
+	networkIDs, err = storage.DeleteExpired(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, networkIDs)
+
+	learnedIPs, err := storage.ListActive(ctx, []uint64{1, 2, 3, 4}, now)
+	require.NoError(t, err)
+	require.Len(t, learnedIPs, 1)
+	assert.Equal(t, "10.0.0.8", learnedIPs[0].IP)
+}
+
+func TestStorage_DatabaseError(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+
+	storage := New(db)
+	ctx := context.Background()
+	db.Close()
+
+	err = storage.Upsert(ctx, learnedIP(1, 10, "10.0.0.5", time.Now()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute query")
+
+	_, err = storage.ListActive(ctx, []uint64{1}, time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute query")
+
+	_, err = storage.DeleteExpired(ctx, time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute query")
+}
+
+func learnedIP(networkHostID, networkID uint64, ip string, expiresAt time.Time) *entity.LearnedIP {
+	return &entity.LearnedIP{
+		NetworkHostID: networkHostID,
+		NetworkID:     networkID,
+		IP:            ip,
+		Name:          "git.corp.example.com",
+		ExpiresAt:     entity.TimestampFromTime(expiresAt),
+	}
+}
+
+func createTestDatabase(t *testing.T) (*database.Database, error) {
+	t.Helper()
+
+	db, err := database.NewForTesting("learnedip_test")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	_, err = db.GetDB(ctx).ExecContext(ctx, `
+		DROP TABLE IF EXISTS network_host_learned_ips;
+
+		CREATE TABLE network_host_learned_ips (
+			network_host_id INTEGER NOT NULL,
+			network_id INTEGER NOT NULL,
+			ip TEXT NOT NULL,
+			name TEXT NOT NULL,
+			expires_at DATETIME NOT NULL,
+			PRIMARY KEY (network_host_id, ip)
+		);
+	`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
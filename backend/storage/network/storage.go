@@ -29,9 +29,12 @@ func New(db *database.Database) *Storage {
 
 func (s *Storage) Add(ctx context.Context, network *entity.Network) (*entity.Network, error) {
 	queryBuilder := sq.Insert("networks").
-		Columns("name", "created_at").
-		Values(network.Name, time.Now()).
-		Suffix("RETURNING id, name, created_at")
+		Columns("name", "routing_mode", "created_at").
+		Values(network.Name, routingMode(network.RoutingMode), time.Now()).
+		Suffix(
+			"RETURNING id, name, routing_mode, dns_domains, dns_nameservers, " +
+				"router, subnet_mask, network_interface, created_at",
+		)
 
 	query, params, err := queryBuilder.ToSql()
 	if err != nil {
@@ -56,7 +59,10 @@ func (s *Storage) Add(ctx context.Context, network *entity.Network) (*entity.Net
 }
 
 func (s *Storage) Get(ctx context.Context, id uint64) (*entity.Network, error) {
-	queryBuilder := sq.Select("id", "name", "created_at").
+	queryBuilder := sq.Select(
+		"id", "name", "routing_mode", "dns_domains", "dns_nameservers",
+		"router", "subnet_mask", "network_interface", "created_at",
+	).
 		From("networks").
 		Where(sq.Eq{"id": id}).
 		OrderBy("UPPER(name) ASC")
@@ -82,7 +88,10 @@ func (s *Storage) Get(ctx context.Context, id uint64) (*entity.Network, error) {
 }
 
 func (s *Storage) List(ctx context.Context, filter *entity.ListNetworkFilter) ([]*entity.Network, error) {
-	queryBuilder := sq.Select("id", "name", "created_at").
+	queryBuilder := sq.Select(
+		"id", "name", "routing_mode", "dns_domains", "dns_nameservers",
+		"router", "subnet_mask", "network_interface", "created_at",
+	).
 		From("networks").
 		OrderBy("id DESC")
 
@@ -134,6 +143,95 @@ func (s *Storage) List(ctx context.Context, filter *entity.ListNetworkFilter) ([
 	return networks, nil
 }
 
+// SetRoutingMode changes which traffic of a network goes through the VPN.
+func (s *Storage) SetRoutingMode(ctx context.Context, id uint64, mode entity.RoutingMode) error {
+	queryBuilder := sq.Update("networks").
+		Set("routing_mode", routingMode(mode)).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkNotFound
+	}
+
+	return nil
+}
+
+// SetDNS changes the domains resolved through a network's nameservers.
+func (s *Storage) SetDNS(
+	ctx context.Context,
+	id uint64,
+	domains entity.DomainList,
+	nameservers entity.IPList,
+) error {
+	queryBuilder := sq.Update("networks").
+		Set("dns_domains", &domains).
+		Set("dns_nameservers", &nameservers).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkNotFound
+	}
+
+	return nil
+}
+
+// SetRouteOverride changes what a network overrides of the network info sync detects.
+func (s *Storage) SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) error {
+	queryBuilder := sq.Update("networks").
+		Set("router", override.Router).
+		Set("subnet_mask", override.SubnetMask).
+		Set("network_interface", override.NetworkInterface).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkNotFound
+	}
+
+	return nil
+}
+
 func (s *Storage) Delete(ctx context.Context, id uint64) error {
 	queryBuilder := sq.Delete("networks").
 		Where(sq.Eq{"id": id})
@@ -150,3 +248,12 @@ func (s *Storage) Delete(ctx context.Context, id uint64) error {
 
 	return nil
 }
+
+// routingMode defaults an unset routing mode to split.
+func routingMode(mode entity.RoutingMode) entity.RoutingMode {
+	if mode == "" {
+		return entity.RoutingModeSplit
+	}
+
+	return mode
+}
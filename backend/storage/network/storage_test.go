@@ -28,6 +28,12 @@ func setupInMemoryDB(t *testing.T) *database.Database {
 		CREATE TABLE networks (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name TEXT UNIQUE NOT NULL,
+			routing_mode TEXT DEFAULT 'split' NOT NULL,
+			dns_domains TEXT DEFAULT '' NOT NULL,
+			dns_nameservers TEXT DEFAULT '' NOT NULL,
+			router TEXT DEFAULT '' NOT NULL,
+			subnet_mask TEXT DEFAULT '' NOT NULL,
+			network_interface TEXT DEFAULT '' NOT NULL,
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		)
 	`)
@@ -326,6 +332,96 @@ func TestStorage_Delete(t *testing.T) {
 	}
 }
 
+func TestStorage_SetRoutingMode(t *testing.T) {
+	db := setupInMemoryDB(t)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	network, err := storage.Add(ctx, &entity.Network{Name: "RoutingModeTest"})
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoutingModeSplit, network.RoutingMode)
+
+	err = storage.SetRoutingMode(ctx, network.ID, entity.RoutingModeInverse)
+	require.NoError(t, err)
+
+	updated, err := storage.Get(ctx, network.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoutingModeInverse, updated.RoutingMode)
+
+	err = storage.SetRoutingMode(ctx, 999, entity.RoutingModeInverse)
+	require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+}
+
+func TestStorage_SetDNS(t *testing.T) {
+	db := setupInMemoryDB(t)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	network, err := storage.Add(ctx, &entity.Network{Name: "DNSTest"})
+	require.NoError(t, err)
+	assert.Empty(t, network.DNSDomains)
+	assert.Empty(t, network.DNSNameservers)
+
+	err = storage.SetDNS(
+		ctx,
+		network.ID,
+		entity.DomainList{"corp.example.com", "lab.example.com"},
+		entity.IPList{"10.8.0.1"},
+	)
+	require.NoError(t, err)
+
+	updated, err := storage.Get(ctx, network.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DomainList{"corp.example.com", "lab.example.com"}, updated.DNSDomains)
+	assert.Equal(t, entity.IPList{"10.8.0.1"}, updated.DNSNameservers)
+
+	err = storage.SetDNS(ctx, network.ID, nil, nil)
+	require.NoError(t, err)
+
+	networks, err := storage.List(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+	assert.Empty(t, networks[0].DNSDomains)
+
+	err = storage.SetDNS(ctx, 999, nil, nil)
+	require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+}
+
+func TestStorage_SetRouteOverride(t *testing.T) {
+	db := setupInMemoryDB(t)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	network, err := storage.Add(ctx, &entity.Network{Name: "OverrideTest"})
+	require.NoError(t, err)
+	assert.True(t, network.RouteOverride().IsEmpty())
+
+	override := entity.RouteOverride{Router: "192.168.50.1", SubnetMask: "255.255.255.0", NetworkInterface: "en7"}
+	err = storage.SetRouteOverride(ctx, network.ID, override)
+	require.NoError(t, err)
+
+	updated, err := storage.Get(ctx, network.ID)
+	require.NoError(t, err)
+	assert.Equal(t, override, updated.RouteOverride())
+
+	err = storage.SetRouteOverride(ctx, network.ID, entity.RouteOverride{})
+	require.NoError(t, err)
+
+	networks, err := storage.List(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+	assert.True(t, networks[0].RouteOverride().IsEmpty())
+
+	err = storage.SetRouteOverride(ctx, 999, entity.RouteOverride{})
+	require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+}
+
 func TestStorage_Delete_Error(t *testing.T) {
 	t.Run("database execution error", func(t *testing.T) {
 		db := setupInMemoryDB(t)
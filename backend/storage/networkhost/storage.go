@@ -29,9 +29,32 @@ func New(db *database.Database) *Storage {
 
 func (s *Storage) Add(ctx context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error) {
 	queryBuilder := sq.Insert("network_hosts").
-		Columns("network_id", "address", "description", "created_at").
-		Values(networkHost.NetworkID, networkHost.Address, networkHost.Description, time.Now()).
-		Suffix("RETURNING id, network_id, address, description, created_at")
+		Columns(
+			"network_id", "address", "description", "subscription_id",
+			"host_id", "enabled", "expires_at", "kind", "pinned_ips", "pin_mode",
+			"router", "subnet_mask", "network_interface", "created_at",
+		).
+		Values(
+			networkHost.NetworkID,
+			networkHost.Address,
+			networkHost.Description,
+			networkHost.SubscriptionID,
+			networkHost.HostID,
+			networkHost.Enabled,
+			expiresAtParam(networkHost.ExpiresAt),
+			networkHostKind(networkHost.Kind),
+			&networkHost.PinnedIPs,
+			pinMode(networkHost.PinMode),
+			networkHost.Router,
+			networkHost.SubnetMask,
+			networkHost.NetworkInterface,
+			time.Now(),
+		).
+		Suffix(
+			"RETURNING id, network_id, address, description, subscription_id, " +
+				"host_id, enabled, expires_at, kind, pinned_ips, pin_mode, " +
+				"router, subnet_mask, network_interface, created_at",
+		)
 
 	query, params, err := queryBuilder.ToSql()
 	if err != nil {
@@ -58,7 +81,11 @@ func (s *Storage) Add(ctx context.Context, networkHost *entity.NetworkHost) (*en
 }
 
 func (s *Storage) Get(ctx context.Context, id uint64) (*entity.NetworkHost, error) {
-	queryBuilder := sq.Select("id", "network_id", "address", "description", "created_at").
+	queryBuilder := sq.Select(
+		"id", "network_id", "address", "description", "subscription_id",
+		"host_id", "enabled", "expires_at", "kind", "pinned_ips", "pin_mode",
+		"router", "subnet_mask", "network_interface", "created_at",
+	).
 		From("network_hosts").
 		Where(sq.Eq{"id": id})
 
@@ -83,33 +110,15 @@ func (s *Storage) Get(ctx context.Context, id uint64) (*entity.NetworkHost, erro
 }
 
 func (s *Storage) List(ctx context.Context, filter *entity.ListNetworkHostFilter) ([]*entity.NetworkHost, error) {
-	queryBuilder := sq.Select("id", "network_id", "address", "description", "created_at").
+	queryBuilder := sq.Select(
+		"id", "network_id", "address", "description", "subscription_id",
+		"host_id", "enabled", "expires_at", "kind", "pinned_ips", "pin_mode",
+		"router", "subnet_mask", "network_interface", "created_at",
+	).
 		From("network_hosts").
 		OrderBy("UPPER(coalesce(description, address)) ASC")
 
-	if filter != nil {
-		if filter.ID != nil {
-			queryBuilder = queryBuilder.Where(sq.Eq{"id": filter.ID})
-		}
-
-		if filter.NetworkID != nil {
-			queryBuilder = queryBuilder.Where(sq.Eq{"network_id": filter.NetworkID})
-		}
-
-		if filter.Address != nil {
-			queryBuilder = queryBuilder.Where(sq.Eq{"address": filter.Address})
-		}
-
-		if filter.Search != "" {
-			searchTerm := strings.ToUpper(fmt.Sprintf("%%%s%%", filter.Search))
-			queryBuilder = queryBuilder.Where(
-				sq.Or{
-					sq.Like{"UPPER(address)": searchTerm},
-					sq.Like{"UPPER(description)": searchTerm},
-				},
-			)
-		}
-	}
+	queryBuilder = applyListFilter(queryBuilder, filter)
 
 	query, params, err := queryBuilder.ToSql()
 	if err != nil {
@@ -145,6 +154,144 @@ func (s *Storage) List(ctx context.Context, filter *entity.ListNetworkHostFilter
 	return networkHosts, nil
 }
 
+// UpdateByHostID copies the address and description of a library host to every network host attached to it.
+func (s *Storage) UpdateByHostID(ctx context.Context, host *entity.Host) error {
+	queryBuilder := sq.Update("network_hosts").
+		Set("address", host.Address).
+		Set("description", host.Description).
+		Where(sq.Eq{"host_id": host.ID})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		if strings.Contains(err.Error(), storage.ErrPrefixUniqueViolation) {
+			return errs.ErrNetworkHostAlreadyExists
+		}
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SetEnabled(ctx context.Context, id uint64, enabled bool) error {
+	queryBuilder := sq.Update("network_hosts").
+		Set("enabled", enabled).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkHostNotFound
+	}
+
+	return nil
+}
+
+// SetExpiresAt changes when a network host expires, nil makes it permanent.
+func (s *Storage) SetExpiresAt(ctx context.Context, id uint64, expiresAt *entity.Timestamp) error {
+	queryBuilder := sq.Update("network_hosts").
+		Set("expires_at", expiresAtParam(expiresAt)).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkHostNotFound
+	}
+
+	return nil
+}
+
+// SetPinnedIPs changes the IPs pinned to a network host and how they combine with DNS results.
+func (s *Storage) SetPinnedIPs(
+	ctx context.Context,
+	id uint64,
+	pinnedIPs entity.IPList,
+	mode entity.NetworkHostPinMode,
+) error {
+	queryBuilder := sq.Update("network_hosts").
+		Set("pinned_ips", &pinnedIPs).
+		Set("pin_mode", pinMode(mode)).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkHostNotFound
+	}
+
+	return nil
+}
+
+// SetRouteOverride changes what a network host overrides of the network info its routes are built with.
+func (s *Storage) SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) error {
+	queryBuilder := sq.Update("network_hosts").
+		Set("router", override.Router).
+		Set("subnet_mask", override.SubnetMask).
+		Set("network_interface", override.NetworkInterface).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkHostNotFound
+	}
+
+	return nil
+}
+
 func (s *Storage) Delete(ctx context.Context, id uint64) error {
 	queryBuilder := sq.Delete("network_hosts").
 		Where(sq.Eq{"id": id})
@@ -161,3 +308,77 @@ func (s *Storage) Delete(ctx context.Context, id uint64) error {
 
 	return nil
 }
+
+// expiresAtParam converts an optional expiry into a query parameter, stored in UTC so expiries compare as text.
+func expiresAtParam(expiresAt *entity.Timestamp) *time.Time {
+	if expiresAt == nil {
+		return nil
+	}
+
+	utc := expiresAt.UTC()
+	return &utc
+}
+
+// networkHostKind defaults hosts built without a kind to includes.
+func networkHostKind(kind entity.NetworkHostKind) entity.NetworkHostKind {
+	if kind == "" {
+		return entity.NetworkHostKindInclude
+	}
+
+	return kind
+}
+
+// pinMode defaults hosts built without a pin mode to replacing DNS results.
+func pinMode(mode entity.NetworkHostPinMode) entity.NetworkHostPinMode {
+	if mode == "" {
+		return entity.NetworkHostPinModeReplace
+	}
+
+	return mode
+}
+
+func applyListFilter(queryBuilder sq.SelectBuilder, filter *entity.ListNetworkHostFilter) sq.SelectBuilder {
+	if filter == nil {
+		return queryBuilder
+	}
+
+	if filter.ID != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"id": filter.ID})
+	}
+
+	if filter.NetworkID != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"network_id": filter.NetworkID})
+	}
+
+	if filter.SubscriptionID != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"subscription_id": filter.SubscriptionID})
+	}
+
+	if filter.HostID != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"host_id": filter.HostID})
+	}
+
+	if filter.Address != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"address": filter.Address})
+	}
+
+	if filter.Enabled != nil {
+		queryBuilder = queryBuilder.Where(sq.Eq{"enabled": *filter.Enabled})
+	}
+
+	if filter.ExpiredAt != nil {
+		queryBuilder = queryBuilder.Where(sq.LtOrEq{"expires_at": filter.ExpiredAt.UTC()})
+	}
+
+	if filter.Search != "" {
+		searchTerm := strings.ToUpper(fmt.Sprintf("%%%s%%", filter.Search))
+		queryBuilder = queryBuilder.Where(
+			sq.Or{
+				sq.Like{"UPPER(address)": searchTerm},
+				sq.Like{"UPPER(description)": searchTerm},
+			},
+		)
+	}
+
+	return queryBuilder
+}
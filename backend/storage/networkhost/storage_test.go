@@ -3,6 +3,7 @@ package networkhost
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -364,6 +365,359 @@ func TestStorage_List_FilterByAddress(t *testing.T) {
 	assert.Equal(t, "example.com", result[0].Address)
 }
 
+func TestStorage_List_FilterBySubscriptionID(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	// Create a test network
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	// Add a manual and a managed network host
+	_, err = storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "192.168.1.10",
+	})
+	require.NoError(t, err)
+
+	subscriptionID := uint64(7)
+	managedHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID:      1,
+		Address:        "example.com",
+		SubscriptionID: &subscriptionID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, managedHost.SubscriptionID)
+	assert.Equal(t, subscriptionID, *managedHost.SubscriptionID)
+
+	// Filter by SubscriptionID
+	filter := &entity.ListNetworkHostFilter{
+		SubscriptionID: []uint64{subscriptionID},
+	}
+
+	result, err := storage.List(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "example.com", result[0].Address)
+	assert.True(t, result[0].IsManaged())
+}
+
+func TestStorage_List_FilterByHostID(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	_, err = storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "192.168.1.10",
+	})
+	require.NoError(t, err)
+
+	hostID := uint64(3)
+	linkedHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "git.example.com",
+		HostID:    &hostID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, linkedHost.HostID)
+	assert.Equal(t, hostID, *linkedHost.HostID)
+
+	result, err := storage.List(ctx, &entity.ListNetworkHostFilter{
+		HostID: []uint64{hostID},
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "git.example.com", result[0].Address)
+}
+
+func TestStorage_UpdateByHostID(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	hostID := uint64(3)
+	linkedHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "git.example.com",
+		HostID:    &hostID,
+	})
+	require.NoError(t, err)
+
+	otherHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "wiki.example.com",
+	})
+	require.NoError(t, err)
+
+	description := "Git server"
+	err = storage.UpdateByHostID(ctx, &entity.Host{
+		ID:          hostID,
+		Address:     "git2.example.com",
+		Description: &description,
+	})
+	require.NoError(t, err)
+
+	updated, err := storage.Get(ctx, linkedHost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "git2.example.com", updated.Address)
+	require.NotNil(t, updated.Description)
+	assert.Equal(t, description, *updated.Description)
+
+	untouched, err := storage.Get(ctx, otherHost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "wiki.example.com", untouched.Address)
+
+	err = storage.UpdateByHostID(ctx, &entity.Host{ID: hostID, Address: "wiki.example.com"})
+	require.ErrorIs(t, err, errs.ErrNetworkHostAlreadyExists)
+}
+
+func TestStorage_SetEnabled(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	disabledHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "192.168.1.10",
+		Enabled:   true,
+	})
+	require.NoError(t, err)
+	assert.True(t, disabledHost.Enabled)
+
+	_, err = storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "192.168.1.20",
+		Enabled:   true,
+	})
+	require.NoError(t, err)
+
+	err = storage.SetEnabled(ctx, disabledHost.ID, false)
+	require.NoError(t, err)
+
+	updated, err := storage.Get(ctx, disabledHost.ID)
+	require.NoError(t, err)
+	assert.False(t, updated.Enabled)
+
+	enabled := true
+	result, err := storage.List(ctx, &entity.ListNetworkHostFilter{Enabled: &enabled})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "192.168.1.20", result[0].Address)
+
+	enabled = false
+	result, err = storage.List(ctx, &entity.ListNetworkHostFilter{Enabled: &enabled})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "192.168.1.10", result[0].Address)
+
+	err = storage.SetEnabled(ctx, 999, true)
+	require.ErrorIs(t, err, errs.ErrNetworkHostNotFound)
+}
+
+func TestStorage_List_FilterByExpiredAt(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	now := time.Now()
+	expired := entity.TimestampFromTime(now.Add(-time.Minute))
+	expiredHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "vendor.example.com",
+		Enabled:   true,
+		ExpiresAt: &expired,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, expiredHost.ExpiresAt)
+	assert.WithinDuration(t, expired.Time, expiredHost.ExpiresAt.Time, time.Second)
+
+	later := entity.TimestampFromTime(now.Add(time.Hour))
+	_, err = storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "demo.example.com",
+		Enabled:   true,
+		ExpiresAt: &later,
+	})
+	require.NoError(t, err)
+
+	permanentHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "git.example.com",
+		Enabled:   true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, permanentHost.ExpiresAt)
+
+	result, err := storage.List(ctx, &entity.ListNetworkHostFilter{ExpiredAt: &now})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "vendor.example.com", result[0].Address)
+
+	err = storage.SetExpiresAt(ctx, expiredHost.ID, nil)
+	require.NoError(t, err)
+
+	result, err = storage.List(ctx, &entity.ListNetworkHostFilter{ExpiredAt: &now})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	err = storage.SetExpiresAt(ctx, 999, nil)
+	require.ErrorIs(t, err, errs.ErrNetworkHostNotFound)
+}
+
+func TestStorage_Add_Kind(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	exclusion, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "10.99.0.0/16",
+		Enabled:   true,
+		Kind:      entity.NetworkHostKindExclude,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.NetworkHostKindExclude, exclusion.Kind)
+
+	withoutKind, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "10.0.0.0/8",
+		Enabled:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.NetworkHostKindInclude, withoutKind.Kind)
+
+	retrieved, err := storage.Get(ctx, exclusion.ID)
+	require.NoError(t, err)
+	assert.True(t, retrieved.IsExclusion())
+}
+
+func TestStorage_SetPinnedIPs(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	pinnedHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "git.corp.example",
+		Enabled:   true,
+		PinnedIPs: entity.IPList{"10.0.0.5", "10.0.0.6"},
+		PinMode:   entity.NetworkHostPinModeAppend,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.IPList{"10.0.0.5", "10.0.0.6"}, pinnedHost.PinnedIPs)
+	assert.Equal(t, entity.NetworkHostPinModeAppend, pinnedHost.PinMode)
+
+	plainHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID: 1,
+		Address:   "jira.corp.example",
+		Enabled:   true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, plainHost.PinnedIPs)
+	assert.Equal(t, entity.NetworkHostPinModeReplace, plainHost.PinMode)
+
+	err = storage.SetPinnedIPs(ctx, plainHost.ID, entity.IPList{"10.0.0.7"}, "")
+	require.NoError(t, err)
+
+	updated, err := storage.Get(ctx, plainHost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.IPList{"10.0.0.7"}, updated.PinnedIPs)
+	assert.Equal(t, entity.NetworkHostPinModeReplace, updated.PinMode)
+
+	err = storage.SetPinnedIPs(ctx, pinnedHost.ID, nil, entity.NetworkHostPinModeReplace)
+	require.NoError(t, err)
+
+	updated, err = storage.Get(ctx, pinnedHost.ID)
+	require.NoError(t, err)
+	assert.False(t, updated.HasPinnedIPs())
+
+	err = storage.SetPinnedIPs(ctx, 999, nil, entity.NetworkHostPinModeReplace)
+	require.ErrorIs(t, err, errs.ErrNetworkHostNotFound)
+}
+
+func TestStorage_SetRouteOverride(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	overriddenHost, err := storage.Add(ctx, &entity.NetworkHost{
+		NetworkID:        1,
+		Address:          "git.corp.example",
+		Enabled:          true,
+		Router:           "192.168.50.1",
+		NetworkInterface: "en7",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.50.1", overriddenHost.Router)
+	assert.Equal(t, entity.NetworkInterface("en7"), overriddenHost.NetworkInterface)
+
+	override := entity.RouteOverride{Router: "10.0.0.1", SubnetMask: "255.255.255.255"}
+	err = storage.SetRouteOverride(ctx, overriddenHost.ID, override)
+	require.NoError(t, err)
+
+	updated, err := storage.Get(ctx, overriddenHost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, override, updated.RouteOverride())
+
+	err = storage.SetRouteOverride(ctx, overriddenHost.ID, entity.RouteOverride{})
+	require.NoError(t, err)
+
+	hosts, err := storage.List(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+	assert.True(t, hosts[0].RouteOverride().IsEmpty())
+
+	err = storage.SetRouteOverride(ctx, 999, entity.RouteOverride{})
+	require.ErrorIs(t, err, errs.ErrNetworkHostNotFound)
+}
+
 func TestStorage_List_FilterBySearch(t *testing.T) {
 	db, err := createTestDatabase(t)
 	require.NoError(t, err)
@@ -579,6 +933,16 @@ func createTestDatabase(t *testing.T) (*database.Database, error) {
 			network_id INTEGER NOT NULL,
 			address TEXT NOT NULL UNIQUE,
 			description TEXT,
+			subscription_id INTEGER,
+			host_id INTEGER,
+			enabled BOOLEAN DEFAULT 1 NOT NULL,
+			expires_at DATETIME,
+			kind TEXT DEFAULT 'include' NOT NULL,
+			pinned_ips TEXT DEFAULT '' NOT NULL,
+			pin_mode TEXT DEFAULT 'replace' NOT NULL,
+			router TEXT DEFAULT '' NOT NULL,
+			subnet_mask TEXT DEFAULT '' NOT NULL,
+			network_interface TEXT DEFAULT '' NOT NULL,
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (network_id) REFERENCES networks(id)
 		);
@@ -0,0 +1,146 @@
+package networksetupgeneration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type Storage struct {
+	db *database.Database
+}
+
+func New(db *database.Database) *Storage {
+	return &Storage{
+		db: db,
+	}
+}
+
+func (s *Storage) Add(
+	ctx context.Context,
+	generation *entity.NetworkSetupGeneration,
+) (*entity.NetworkSetupGeneration, error) {
+	queryBuilder := sq.Insert("network_setup_generations").
+		Columns("network_id", "status", "network_service", "tunnel_routes", "direct_routes", "created_at").
+		Values(
+			generation.NetworkID,
+			generation.Status,
+			generation.NetworkService,
+			&generation.TunnelRoutes,
+			&generation.DirectRoutes,
+			generation.CreatedAt.Time,
+		).
+		Suffix("RETURNING id, network_id, status, network_service, tunnel_routes, direct_routes, created_at")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	newGeneration := new(entity.NetworkSetupGeneration)
+	err = row.StructScan(newGeneration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return newGeneration, nil
+}
+
+// GetLastApplied returns the generation of routes last applied to the network.
+func (s *Storage) GetLastApplied(ctx context.Context, networkID uint64) (*entity.NetworkSetupGeneration, error) {
+	queryBuilder := sq.Select(
+		"id", "network_id", "status", "network_service", "tunnel_routes", "direct_routes", "created_at",
+	).
+		From("network_setup_generations").
+		Where(sq.Eq{
+			"network_id": networkID,
+			"status":     entity.NetworkSetupGenerationStatusApplied,
+		}).
+		OrderBy("id DESC").
+		Limit(1)
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	generation := new(entity.NetworkSetupGeneration)
+	err = row.StructScan(generation)
+
+	switch {
+	case err == nil:
+		return generation, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrNetworkSetupGenerationNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+// MarkApplied marks the generation applied and deletes the other generations of its network,
+// the applied one is all a later sync can restore.
+func (s *Storage) MarkApplied(ctx context.Context, id uint64) error {
+	err := s.setStatus(ctx, id, entity.NetworkSetupGenerationStatusApplied)
+	if err != nil {
+		return err
+	}
+
+	queryBuilder := sq.Delete("network_setup_generations").
+		Where(sq.And{
+			sq.Expr("network_id = (SELECT network_id FROM network_setup_generations WHERE id = ?)", id),
+			sq.NotEq{"id": id},
+		})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) MarkFailed(ctx context.Context, id uint64) error {
+	return s.setStatus(ctx, id, entity.NetworkSetupGenerationStatusFailed)
+}
+
+func (s *Storage) setStatus(ctx context.Context, id uint64, status entity.NetworkSetupGenerationStatus) error {
+	queryBuilder := sq.Update("network_setup_generations").
+		Set("status", status).
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	res, err := s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNetworkSetupGenerationNotFound
+	}
+
+	return nil
+}
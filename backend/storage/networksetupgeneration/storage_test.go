@@ -0,0 +1,133 @@
+package networksetupgeneration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	db := &database.Database{}
+	storage := New(db)
+
+	assert.NotNil(t, storage)
+	assert.Equal(t, db, storage.db)
+}
+
+func TestStorage_AddMarkGetLastApplied(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	_, err = storage.GetLastApplied(ctx, 1)
+	require.ErrorIs(t, err, errs.ErrNetworkSetupGenerationNotFound)
+
+	first, err := storage.Add(ctx, entity.NewNetworkSetupGeneration(1, "", []*entity.NetworkHostSetup{
+		{NetworkHostIP: "10.0.0.5", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+	}, nil))
+	require.NoError(t, err)
+	assert.NotZero(t, first.ID)
+	assert.Equal(t, entity.NetworkSetupGenerationStatusPending, first.Status)
+	require.Len(t, first.TunnelRoutes, 1)
+	assert.Equal(t, "10.0.0.5", first.TunnelRoutes[0].NetworkHostIP)
+	assert.Empty(t, first.DirectRoutes)
+
+	// Pending generations aren't restorable.
+	_, err = storage.GetLastApplied(ctx, 1)
+	require.ErrorIs(t, err, errs.ErrNetworkSetupGenerationNotFound)
+
+	require.NoError(t, storage.MarkApplied(ctx, first.ID))
+	applied, err := storage.GetLastApplied(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, applied.ID)
+	assert.Equal(t, entity.NetworkSetupGenerationStatusApplied, applied.Status)
+	assert.Equal(t, first.TunnelRoutes, applied.TunnelRoutes)
+
+	second, err := storage.Add(ctx, entity.NewNetworkSetupGeneration(1, "Wi-Fi", nil, []*entity.NetworkHostSetup{
+		{NetworkHostIP: "10.0.0.6", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, storage.MarkFailed(ctx, second.ID))
+
+	applied, err = storage.GetLastApplied(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, applied.ID)
+
+	other, err := storage.Add(ctx, entity.NewNetworkSetupGeneration(2, "", nil, nil))
+	require.NoError(t, err)
+	require.NoError(t, storage.MarkApplied(ctx, other.ID))
+
+	third, err := storage.Add(ctx, entity.NewNetworkSetupGeneration(1, "Wi-Fi", nil, []*entity.NetworkHostSetup{
+		{NetworkHostIP: "10.0.0.7", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, storage.MarkApplied(ctx, third.ID))
+
+	applied, err = storage.GetLastApplied(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, third.ID, applied.ID)
+	assert.Equal(t, entity.NetworkService("Wi-Fi"), applied.NetworkService)
+	require.Len(t, applied.DirectRoutes, 1)
+	assert.Equal(t, "10.0.0.7", applied.DirectRoutes[0].NetworkHostIP)
+
+	// Marking a generation applied drops the other generations of its network only.
+	var count int
+	require.NoError(t, db.GetDB(ctx).GetContext(ctx, &count,
+		"SELECT COUNT(*) FROM network_setup_generations WHERE network_id = 1"))
+	assert.Equal(t, 1, count)
+
+	applied, err = storage.GetLastApplied(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, applied.ID)
+}
+
+func TestStorage_Mark_NotFound(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	require.ErrorIs(t, storage.MarkApplied(ctx, 42), errs.ErrNetworkSetupGenerationNotFound)
+	require.ErrorIs(t, storage.MarkFailed(ctx, 42), errs.ErrNetworkSetupGenerationNotFound)
+}
+
+func createTestDatabase(t *testing.T) (*database.Database, error) {
+	t.Helper()
+
+	db, err := database.NewForTesting("networksetupgeneration_test")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	_, err = db.GetDB(ctx).ExecContext(ctx, `
+		DROP TABLE IF EXISTS network_setup_generations;
+
+		CREATE TABLE network_setup_generations (
+			id INTEGER PRIMARY KEY,
+			network_id INTEGER NOT NULL,
+			status VARCHAR(16) NOT NULL,
+			network_service VARCHAR(255) DEFAULT '' NOT NULL,
+			tunnel_routes TEXT DEFAULT '[]' NOT NULL,
+			direct_routes TEXT DEFAULT '[]' NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+		);
+	`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
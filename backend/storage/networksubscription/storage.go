@@ -0,0 +1,186 @@
+package networksubscription
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/storage"
+)
+
+type Storage struct {
+	db *database.Database
+}
+
+func New(db *database.Database) *Storage {
+	return &Storage{
+		db: db,
+	}
+}
+
+func (s *Storage) Add(
+	ctx context.Context,
+	subscription *entity.NetworkSubscription,
+) (*entity.NetworkSubscription, error) {
+	queryBuilder := sq.Insert("network_subscriptions").
+		Columns("network_id", "url", "created_at").
+		Values(subscription.NetworkID, subscription.URL, time.Now()).
+		Suffix("RETURNING id, network_id, url, etag, last_refreshed_at, last_error, host_list, created_at")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	newSubscription := new(entity.NetworkSubscription)
+	err = row.StructScan(newSubscription)
+
+	switch {
+	case err == nil:
+		return newSubscription, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrNetworkSubscriptionNotFound
+	case strings.Contains(err.Error(), storage.ErrPrefixUniqueViolation):
+		return nil, errs.ErrNetworkSubscriptionAlreadyExists
+	case strings.Contains(err.Error(), storage.ErrPrefixForeignKeyViolation):
+		return nil, errs.ErrNetworkNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+func (s *Storage) Get(ctx context.Context, id uint64) (*entity.NetworkSubscription, error) {
+	queryBuilder := sq.Select(
+		"id", "network_id", "url", "etag", "last_refreshed_at", "last_error", "host_list", "created_at",
+	).
+		From("network_subscriptions").
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	subscription := new(entity.NetworkSubscription)
+	err = row.StructScan(subscription)
+
+	switch {
+	case err == nil:
+		return subscription, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrNetworkSubscriptionNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+func (s *Storage) List(
+	ctx context.Context,
+	filter *entity.ListNetworkSubscriptionFilter,
+) ([]*entity.NetworkSubscription, error) {
+	queryBuilder := sq.Select(
+		"id", "network_id", "url", "etag", "last_refreshed_at", "last_error", "host_list", "created_at",
+	).
+		From("network_subscriptions").
+		OrderBy("id ASC")
+
+	if filter != nil {
+		if filter.ID != nil {
+			queryBuilder = queryBuilder.Where(sq.Eq{"id": filter.ID})
+		}
+
+		if filter.NetworkID != nil {
+			queryBuilder = queryBuilder.Where(sq.Eq{"network_id": filter.NetworkID})
+		}
+	}
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.GetDB(ctx).QueryxContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Error("failed to close rows", "error", closeErr)
+		}
+	}()
+
+	var subscriptions []*entity.NetworkSubscription
+	for rows.Next() {
+		subscription := new(entity.NetworkSubscription)
+		err = rows.StructScan(subscription)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("got rows error: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateRefreshState stores the outcome of the latest refresh of a subscription.
+func (s *Storage) UpdateRefreshState(ctx context.Context, subscription *entity.NetworkSubscription) error {
+	var lastRefreshedAt *time.Time
+	if subscription.LastRefreshedAt != nil {
+		lastRefreshedAt = &subscription.LastRefreshedAt.Time
+	}
+
+	queryBuilder := sq.Update("network_subscriptions").
+		Set("etag", subscription.ETag).
+		Set("last_refreshed_at", lastRefreshedAt).
+		Set("last_error", subscription.LastError).
+		Set("host_list", subscription.HostList).
+		Where(sq.Eq{"id": subscription.ID})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Delete(ctx context.Context, id uint64) error {
+	queryBuilder := sq.Delete("network_subscriptions").
+		Where(sq.Eq{"id": id})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package networksubscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	db := &database.Database{}
+	storage := New(db)
+
+	assert.NotNil(t, storage)
+	assert.Equal(t, db, storage.db)
+}
+
+func TestStorage_Add_Success(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	result, err := storage.Add(ctx, &entity.NetworkSubscription{
+		NetworkID: 1,
+		URL:       "https://it.corp.example/hosts.json",
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, result.ID)
+	assert.Equal(t, uint64(1), result.NetworkID)
+	assert.Equal(t, "https://it.corp.example/hosts.json", result.URL)
+	assert.Nil(t, result.ETag)
+	assert.Nil(t, result.LastRefreshedAt)
+	assert.Nil(t, result.LastError)
+	assert.False(t, result.CreatedAt.Time.IsZero())
+}
+
+func TestStorage_Add_UniqueConstraintViolation(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	subscription := &entity.NetworkSubscription{NetworkID: 1, URL: "https://it.corp.example/hosts.json"}
+	_, err = storage.Add(ctx, subscription)
+	require.NoError(t, err)
+
+	result, err := storage.Add(ctx, subscription)
+	require.ErrorIs(t, err, errs.ErrNetworkSubscriptionAlreadyExists)
+	assert.Nil(t, result)
+}
+
+func TestStorage_Add_ForeignKeyViolation(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	result, err := storage.Add(ctx, &entity.NetworkSubscription{
+		NetworkID: 999,
+		URL:       "https://it.corp.example/hosts.json",
+	})
+	require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+	assert.Nil(t, result)
+}
+
+func TestStorage_Get_NotFound(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+
+	result, err := storage.Get(context.Background(), 999)
+	require.ErrorIs(t, err, errs.ErrNetworkSubscriptionNotFound)
+	assert.Nil(t, result)
+}
+
+func TestStorage_List_FilterByNetworkID(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Network 1")
+	require.NoError(t, err)
+	err = createTestNetwork(ctx, db, 2, "Network 2")
+	require.NoError(t, err)
+
+	_, err = storage.Add(ctx, &entity.NetworkSubscription{NetworkID: 1, URL: "https://a.example/hosts"})
+	require.NoError(t, err)
+	_, err = storage.Add(ctx, &entity.NetworkSubscription{NetworkID: 2, URL: "https://b.example/hosts"})
+	require.NoError(t, err)
+
+	all, err := storage.List(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	result, err := storage.List(ctx, &entity.ListNetworkSubscriptionFilter{NetworkID: []uint64{2}})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "https://b.example/hosts", result[0].URL)
+}
+
+func TestStorage_UpdateRefreshState(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	subscription, err := storage.Add(ctx, &entity.NetworkSubscription{NetworkID: 1, URL: "https://a.example/hosts"})
+	require.NoError(t, err)
+
+	etag := `"v1"`
+	refreshedAt := entity.TimestampFromTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	hostList := "a.example\nb.example\n"
+	subscription.ETag = &etag
+	subscription.LastRefreshedAt = &refreshedAt
+	subscription.HostList = &hostList
+
+	err = storage.UpdateRefreshState(ctx, subscription)
+	require.NoError(t, err)
+
+	result, err := storage.Get(ctx, subscription.ID)
+	require.NoError(t, err)
+	require.NotNil(t, result.ETag)
+	assert.Equal(t, etag, *result.ETag)
+	require.NotNil(t, result.LastRefreshedAt)
+	assert.True(t, refreshedAt.Time.Equal(result.LastRefreshedAt.Time))
+	assert.Nil(t, result.LastError)
+	require.NotNil(t, result.HostList)
+	assert.Equal(t, hostList, *result.HostList)
+
+	lastError := "unexpected status code 500"
+	result.LastError = &lastError
+
+	err = storage.UpdateRefreshState(ctx, result)
+	require.NoError(t, err)
+
+	result, err = storage.Get(ctx, subscription.ID)
+	require.NoError(t, err)
+	require.NotNil(t, result.LastError)
+	assert.Equal(t, lastError, *result.LastError)
+	assert.Equal(t, etag, *result.ETag)
+}
+
+func TestStorage_Delete(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+
+	err = createTestNetwork(ctx, db, 1, "Test Network")
+	require.NoError(t, err)
+
+	subscription, err := storage.Add(ctx, &entity.NetworkSubscription{NetworkID: 1, URL: "https://a.example/hosts"})
+	require.NoError(t, err)
+
+	err = storage.Delete(ctx, subscription.ID)
+	require.NoError(t, err)
+
+	_, err = storage.Get(ctx, subscription.ID)
+	require.ErrorIs(t, err, errs.ErrNetworkSubscriptionNotFound)
+
+	// Deleting a missing subscription is not an error
+	err = storage.Delete(ctx, subscription.ID)
+	assert.NoError(t, err)
+}
+
+func createTestDatabase(t *testing.T) (*database.Database, error) {
+	t.Helper()
+
+	db, err := database.NewForTesting("networksubscription_test")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	_, err = db.GetDB(ctx).ExecContext(ctx, `
+		DROP TABLE IF EXISTS network_subscriptions;
+		DROP TABLE IF EXISTS networks;
+
+		CREATE TABLE networks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE network_subscriptions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			network_id INTEGER NOT NULL,
+			url TEXT NOT NULL,
+			etag TEXT,
+			last_refreshed_at DATETIME,
+			last_error TEXT,
+			host_list TEXT,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (network_id, url),
+			FOREIGN KEY (network_id) REFERENCES networks(id) ON DELETE CASCADE
+		);
+	`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func createTestNetwork(ctx context.Context, db *database.Database, id uint64, name string) error {
+	_, err := db.GetDB(ctx).ExecContext(ctx,
+		"INSERT INTO networks (id, name) VALUES (?, ?)",
+		id, name)
+	return err
+}
@@ -0,0 +1,127 @@
+package resolverfile
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type Storage struct {
+	db *database.Database
+}
+
+func New(db *database.Database) *Storage {
+	return &Storage{
+		db: db,
+	}
+}
+
+// Add records a resolver file Splitr created, taking it over for another network if it was recorded before.
+func (s *Storage) Add(ctx context.Context, resolverFile *entity.ResolverFile) error {
+	queryBuilder := sq.Insert("resolver_files").
+		Columns("domain", "network_id", "created_at").
+		Values(resolverFile.Domain, resolverFile.NetworkID, resolverFile.CreatedAt.Time).
+		Suffix("ON CONFLICT (domain) DO UPDATE SET network_id = excluded.network_id")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Get(ctx context.Context, domain string) (*entity.ResolverFile, error) {
+	queryBuilder := sq.Select("domain", "network_id", "created_at").
+		From("resolver_files").
+		Where(sq.Eq{"domain": domain})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	row := s.db.GetDB(ctx).QueryRowxContext(ctx, query, params...)
+
+	resolverFile := new(entity.ResolverFile)
+	err = row.StructScan(resolverFile)
+
+	switch {
+	case err == nil:
+		return resolverFile, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, errs.ErrResolverFileNotFound
+	default:
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+}
+
+func (s *Storage) ListByNetworkID(ctx context.Context, networkID uint64) ([]*entity.ResolverFile, error) {
+	queryBuilder := sq.Select("domain", "network_id", "created_at").
+		From("resolver_files").
+		Where(sq.Eq{"network_id": networkID}).
+		OrderBy("domain ASC")
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := s.db.GetDB(ctx).QueryxContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			slog.Error("failed to close rows", "error", closeErr)
+		}
+	}()
+
+	var resolverFiles []*entity.ResolverFile
+	for rows.Next() {
+		resolverFile := new(entity.ResolverFile)
+		err = rows.StructScan(resolverFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		resolverFiles = append(resolverFiles, resolverFile)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("got rows error: %w", err)
+	}
+
+	return resolverFiles, nil
+}
+
+func (s *Storage) Delete(ctx context.Context, domain string) error {
+	queryBuilder := sq.Delete("resolver_files").
+		Where(sq.Eq{"domain": domain})
+
+	query, params, err := queryBuilder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = s.db.GetDB(ctx).ExecContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+
+	return nil
+}
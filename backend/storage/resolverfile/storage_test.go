@@ -0,0 +1,113 @@
+package resolverfile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	db := &database.Database{}
+	storage := New(db)
+
+	assert.NotNil(t, storage)
+	assert.Equal(t, db, storage.db)
+}
+
+func TestStorage_AddGetListDelete(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+	defer db.Close()
+
+	storage := New(db)
+	ctx := context.Background()
+	corp := &entity.Network{ID: 1}
+	lab := &entity.Network{ID: 2}
+
+	require.NoError(t, storage.Add(ctx, entity.NewResolverFile(corp, "corp.example.com")))
+	require.NoError(t, storage.Add(ctx, entity.NewResolverFile(corp, "a.example.com")))
+	require.NoError(t, storage.Add(ctx, entity.NewResolverFile(lab, "lab.example.com")))
+
+	resolverFile, err := storage.Get(ctx, "corp.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "corp.example.com", resolverFile.Domain)
+	assert.Equal(t, uint64(1), resolverFile.NetworkID)
+	assert.False(t, resolverFile.CreatedAt.IsZero())
+
+	resolverFiles, err := storage.ListByNetworkID(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, resolverFiles, 2)
+	assert.Equal(t, "a.example.com", resolverFiles[0].Domain)
+	assert.Equal(t, "corp.example.com", resolverFiles[1].Domain)
+
+	// Another network taking the domain over.
+	require.NoError(t, storage.Add(ctx, entity.NewResolverFile(lab, "corp.example.com")))
+	resolverFile, err = storage.Get(ctx, "corp.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), resolverFile.NetworkID)
+
+	require.NoError(t, storage.Delete(ctx, "corp.example.com"))
+	_, err = storage.Get(ctx, "corp.example.com")
+	require.ErrorIs(t, err, errs.ErrResolverFileNotFound)
+
+	resolverFiles, err = storage.ListByNetworkID(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, resolverFiles)
+}
+
+func TestStorage_DatabaseError(t *testing.T) {
+	db, err := createTestDatabase(t)
+	require.NoError(t, err)
+
+	storage := New(db)
+	ctx := context.Background()
+	db.Close()
+
+	err = storage.Add(ctx, entity.NewResolverFile(&entity.Network{ID: 1}, "corp.example.com"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute query")
+
+	_, err = storage.Get(ctx, "corp.example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to scan row")
+
+	_, err = storage.ListByNetworkID(ctx, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute query")
+
+	err = storage.Delete(ctx, "corp.example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute query")
+}
+
+func createTestDatabase(t *testing.T) (*database.Database, error) {
+	t.Helper()
+
+	db, err := database.NewForTesting("resolverfile_test")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	_, err = db.GetDB(ctx).ExecContext(ctx, `
+		DROP TABLE IF EXISTS resolver_files;
+
+		CREATE TABLE resolver_files (
+			domain TEXT PRIMARY KEY,
+			network_id INTEGER NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+		);
+	`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
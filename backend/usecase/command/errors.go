@@ -0,0 +1,55 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+// knownCommandError is a message a command prints when it fails for a reason the UI can explain.
+type knownCommandError struct {
+	message string
+	err     error
+}
+
+// knownCommandErrors returns the known failure messages of a command, most specific first:
+// networksetup follows most of its errors with "The parameters were not valid".
+func knownCommandErrors(name string) []knownCommandError {
+	switch name {
+	case cmdNetworkSetup:
+		return []knownCommandError{
+			{message: "requires admin privileges", err: errs.ErrCommandRequiresAdmin},
+			{message: "is not a recognized network service", err: errs.ErrNetworkServiceNotRecognized},
+			{message: "the parameters were not valid", err: errs.ErrCommandInvalidParameters},
+		}
+	case cmdSCUtil:
+		return []knownCommandError{
+			{message: "no service", err: errs.ErrVPNServiceNotFound},
+		}
+	default:
+		return nil
+	}
+}
+
+// newCommandError describes a command that ran and failed with err. networksetup prints its errors to stdout,
+// so both outputs are matched against the command's known messages.
+func newCommandError(name string, args []string, result *entity.CommandResult, err error) *errs.CommandError {
+	commandErr := &errs.CommandError{
+		Command:  (&entity.Command{Executable: name, Args: args}).String(),
+		ExitCode: result.ExitCode,
+		Stderr:   result.Stderr,
+		Duration: result.Duration,
+		Err:      err,
+	}
+
+	output := strings.ToLower(strings.Join(result.Stdout, "\n") + "\n" + result.Stderr)
+	for _, known := range knownCommandErrors(name) {
+		if strings.Contains(output, known.message) {
+			commandErr.Err = known.err
+			break
+		}
+	}
+
+	return commandErr
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestNewCommandError(t *testing.T) {
+	exitErr := errors.New("exit status 4")
+
+	tests := []struct {
+		name        string
+		command     string
+		result      *entity.CommandResult
+		expectedErr error
+	}{
+		{
+			name:    "networksetup without admin privileges",
+			command: cmdNetworkSetup,
+			result: &entity.CommandResult{
+				Stdout:   []string{"** Error: Command requires admin privileges.", ""},
+				ExitCode: 14,
+			},
+			expectedErr: errs.ErrCommandRequiresAdmin,
+		},
+		{
+			name:    "networksetup with an unknown network service",
+			command: cmdNetworkSetup,
+			result: &entity.CommandResult{
+				Stdout: []string{
+					"Office VPN is not a recognized network service.",
+					"** Error: The parameters were not valid.",
+				},
+				ExitCode: 4,
+			},
+			expectedErr: errs.ErrNetworkServiceNotRecognized,
+		},
+		{
+			name:    "networksetup with invalid parameters",
+			command: cmdNetworkSetup,
+			result: &entity.CommandResult{
+				Stderr:   "** Error: The parameters were not valid.",
+				ExitCode: 4,
+			},
+			expectedErr: errs.ErrCommandInvalidParameters,
+		},
+		{
+			name:    "scutil with an unknown service",
+			command: cmdSCUtil,
+			result: &entity.CommandResult{
+				Stdout:   []string{"No service", ""},
+				ExitCode: 1,
+			},
+			expectedErr: errs.ErrVPNServiceNotFound,
+		},
+		{
+			name:    "messages of another command are not matched",
+			command: cmdRoute,
+			result: &entity.CommandResult{
+				Stderr:   "route: writing to routing socket: requires admin privileges",
+				ExitCode: 1,
+			},
+			expectedErr: exitErr,
+		},
+		{
+			name:    "unknown message",
+			command: cmdNetworkSetup,
+			result: &entity.CommandResult{
+				Stderr:   "something else went wrong",
+				ExitCode: 1,
+			},
+			expectedErr: exitErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newCommandError(tt.command, []string{"-getinfo", "Office VPN"}, tt.result, exitErr)
+
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.command+" -getinfo Office VPN", err.Command)
+			assert.Equal(t, tt.result.ExitCode, err.ExitCode)
+			assert.Equal(t, tt.result.Stderr, err.Stderr)
+		})
+	}
+}
+
+func TestExecutor_run_CommandErrors(t *testing.T) {
+	t.Run("failed command is a command error with stderr", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+		executor := NewExecutorWithRunner(mockRunner)
+		ctx := context.Background()
+
+		mockRunner.EXPECT().
+			Run(ctx, cmdNetworkSetup, "-setadditionalroutes", "Wi-Fi").
+			Return(&entity.CommandResult{
+				Stdout:   []string{"** Error: Command requires admin privileges."},
+				Stderr:   "denied",
+				ExitCode: 14,
+				Duration: time.Millisecond,
+			}, errors.New("failed to execute command: exit status 14"))
+
+		err := executor.SetServiceAdditionalRoutes(ctx, "Wi-Fi", nil)
+
+		require.ErrorIs(t, err, errs.ErrCommandRequiresAdmin)
+		var commandErr *errs.CommandError
+		require.ErrorAs(t, err, &commandErr)
+		assert.Equal(t, 14, commandErr.ExitCode)
+		assert.Equal(t, time.Millisecond, commandErr.Duration)
+		assert.Equal(t, "failed to sync execute command: command requires admin privileges: denied", err.Error())
+	})
+
+	t.Run("cancelled command keeps the context error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+		executor := NewExecutorWithRunner(mockRunner)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mockRunner.EXPECT().
+			Run(ctx, cmdSCUtil, "--nc", "list").
+			Return(&entity.CommandResult{ExitCode: -1}, context.Canceled)
+
+		_, err := executor.ListVPN(ctx)
+
+		require.ErrorIs(t, err, context.Canceled)
+		var commandErr *errs.CommandError
+		assert.NotErrorAs(t, err, &commandErr)
+	})
+}
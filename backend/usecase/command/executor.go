@@ -29,11 +29,14 @@ type Executor struct {
 	cmdRunner    usecase.CommandRunner
 
 	cmdListVPNArgs                    []string
+	cmdShowVPNArgs                    []string
 	cmdGetDefaultInterfaceArgs        []string
 	cmdListNetworkServiceArgs         []string
 	cmdGetNetworkServiceInfoArgs      []string
 	cmdSetNetworkAdditionalRoutesArgs []string
+	cmdGetNetworkAdditionalRoutesArgs []string
 	cmdOpenInFinderArgs               []string
+	cmdRouteArgs                      []string
 }
 
 func NewExecutor() *Executor {
@@ -45,16 +48,33 @@ func NewExecutorWithRunner(cmdRunner usecase.CommandRunner) *Executor {
 		outputParser:                      newOutputParser(),
 		cmdRunner:                         cmdRunner,
 		cmdListVPNArgs:                    []string{"--nc", "list"},
+		cmdShowVPNArgs:                    []string{"--nc", "show"},
 		cmdGetDefaultInterfaceArgs:        []string{"get", "default"},
 		cmdListNetworkServiceArgs:         []string{"-listnetworkserviceorder"},
 		cmdGetNetworkServiceInfoArgs:      []string{"-getinfo"},
 		cmdSetNetworkAdditionalRoutesArgs: []string{"-setadditionalroutes"},
+		cmdGetNetworkAdditionalRoutesArgs: []string{"-getadditionalroutes"},
 		cmdOpenInFinderArgs:               []string{"-R"},
+		cmdRouteArgs:                      []string{"-n"},
 	}
 }
 
+// run executes the command and returns its output lines. Errors of a command that ran and failed are
+// *errs.CommandError values, with a typed error for the messages networksetup and scutil are known to print.
+func (e *Executor) run(ctx context.Context, name string, args ...string) ([]string, error) {
+	result, err := e.cmdRunner.Run(ctx, name, args...)
+	if err != nil {
+		if result == nil || ctx.Err() != nil {
+			return nil, err
+		}
+		return nil, newCommandError(name, args, result, err)
+	}
+
+	return result.Stdout, nil
+}
+
 func (e *Executor) GetDefaultNetworkInterface(ctx context.Context) (entity.NetworkInterface, error) {
-	output, err := e.cmdRunner.Run(ctx, cmdRoute, e.cmdGetDefaultInterfaceArgs...)
+	output, err := e.run(ctx, cmdRoute, e.cmdGetDefaultInterfaceArgs...)
 	if err != nil {
 		return "", fmt.Errorf("failed to sync execute command: %w", err)
 	}
@@ -84,7 +104,7 @@ func (e *Executor) GetNetworkServiceByNetworkInterface(
 	ctx context.Context,
 	networkInterface entity.NetworkInterface,
 ) (entity.NetworkService, error) {
-	commandOutput, err := e.cmdRunner.Run(ctx, cmdNetworkSetup, e.cmdListNetworkServiceArgs...)
+	commandOutput, err := e.run(ctx, cmdNetworkSetup, e.cmdListNetworkServiceArgs...)
 	if err != nil {
 		return "", fmt.Errorf("failed to sync execute command: %w", err)
 	}
@@ -126,12 +146,12 @@ func (e *Executor) GetNetworkInfoByNetworkService(
 	args := make([]string, 0, len(e.cmdGetNetworkServiceInfoArgs)+1)
 	args = append(args, e.cmdGetNetworkServiceInfoArgs...)
 	args = append(args, string(networkService))
-	commandOutput, err := e.cmdRunner.Run(ctx, cmdNetworkSetup, args...)
+	commandOutput, err := e.run(ctx, cmdNetworkSetup, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sync execute command: %w", err)
 	}
 
-	var networkInfo entity.NetworkInfo
+	networkInfo := entity.NetworkInfo{NetworkService: networkService}
 	for _, line := range commandOutput {
 		subnetMaskFromLine := e.outputParser.parseSubnetMask(line)
 		routerFromLine := e.outputParser.parseRouter(line)
@@ -157,8 +177,86 @@ func (e *Executor) SetNetworkAdditionalRoutes(
 	network *entity.Network,
 	networkHostSetupList []*entity.NetworkHostSetup,
 ) error {
+	command := e.SetNetworkAdditionalRoutesCommand(network, networkHostSetupList)
+
+	_, err := e.run(ctx, command.Executable, command.Args...)
+	if err != nil {
+		return fmt.Errorf("failed to sync execute command: %w", err)
+	}
+
+	return nil
+}
+
+// GetNetworkAdditionalRoutes returns the additional routes currently configured on the network's service.
+func (e *Executor) GetNetworkAdditionalRoutes(
+	ctx context.Context,
+	network *entity.Network,
+) ([]*entity.AdditionalRoute, error) {
+	return e.GetServiceAdditionalRoutes(ctx, entity.NetworkService(network.Name))
+}
+
+// GetServiceAdditionalRoutes returns the additional routes currently configured on any network service,
+// e.g. the physical one inverse mode routes listed hosts through.
+func (e *Executor) GetServiceAdditionalRoutes(
+	ctx context.Context,
+	networkService entity.NetworkService,
+) ([]*entity.AdditionalRoute, error) {
+	args := make([]string, 0, len(e.cmdGetNetworkAdditionalRoutesArgs)+1)
+	args = append(args, e.cmdGetNetworkAdditionalRoutesArgs...)
+	args = append(args, string(networkService))
+
+	commandOutput, err := e.run(ctx, cmdNetworkSetup, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync execute command: %w", err)
+	}
+
+	additionalRoutes := make([]*entity.AdditionalRoute, 0, len(commandOutput))
+	for _, line := range commandOutput {
+		additionalRoute := e.outputParser.parseAdditionalRoute(line)
+		if additionalRoute == nil {
+			continue
+		}
+
+		additionalRoutes = append(additionalRoutes, additionalRoute)
+	}
+
+	return additionalRoutes, nil
+}
+
+// SetNetworkAdditionalRoutesCommand builds the networksetup command that replaces
+// the network's additional routes with the given setup list.
+func (e *Executor) SetNetworkAdditionalRoutesCommand(
+	network *entity.Network,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) *entity.Command {
+	return e.SetServiceAdditionalRoutesCommand(entity.NetworkService(network.Name), networkHostSetupList)
+}
+
+// SetServiceAdditionalRoutes replaces the additional routes of any network service,
+// e.g. the physical one inverse mode routes listed hosts through.
+func (e *Executor) SetServiceAdditionalRoutes(
+	ctx context.Context,
+	networkService entity.NetworkService,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) error {
+	command := e.SetServiceAdditionalRoutesCommand(networkService, networkHostSetupList)
+
+	_, err := e.run(ctx, command.Executable, command.Args...)
+	if err != nil {
+		return fmt.Errorf("failed to sync execute command: %w", err)
+	}
+
+	return nil
+}
+
+// SetServiceAdditionalRoutesCommand builds the networksetup command that replaces
+// the network service's additional routes with the given setup list.
+func (e *Executor) SetServiceAdditionalRoutesCommand(
+	networkService entity.NetworkService,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) *entity.Command {
 	args := append([]string{}, e.cmdSetNetworkAdditionalRoutesArgs...)
-	args = append(args, network.Name)
+	args = append(args, string(networkService))
 
 	for _, networkHostSetup := range networkHostSetupList {
 		args = append(args, []string{
@@ -168,16 +266,38 @@ func (e *Executor) SetNetworkAdditionalRoutes(
 		}...)
 	}
 
-	_, err := e.cmdRunner.Run(ctx, cmdNetworkSetup, args...)
-	if err != nil {
-		return fmt.Errorf("failed to sync execute command: %w", err)
+	return &entity.Command{
+		Executable: cmdNetworkSetup,
+		Args:       args,
 	}
+}
 
-	return nil
+// RouteCommands builds route commands that add or delete each setup directly in the routing table.
+func (e *Executor) RouteCommands(
+	action entity.RouteAction,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) []*entity.Command {
+	commands := make([]*entity.Command, 0, len(networkHostSetupList))
+	for _, networkHostSetup := range networkHostSetupList {
+		args := append([]string{}, e.cmdRouteArgs...)
+		args = append(args,
+			string(action),
+			"-net", networkHostSetup.NetworkHostIP,
+			"-netmask", networkHostSetup.SubnetMask,
+			networkHostSetup.Router,
+		)
+
+		commands = append(commands, &entity.Command{
+			Executable: cmdRoute,
+			Args:       args,
+		})
+	}
+
+	return commands
 }
 
 func (e *Executor) ListVPN(ctx context.Context) ([]entity.VPNService, error) {
-	output, err := e.cmdRunner.Run(ctx, cmdSCUtil, e.cmdListVPNArgs...)
+	output, err := e.run(ctx, cmdSCUtil, e.cmdListVPNArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sync execute command: %w", err)
 	}
@@ -200,7 +320,7 @@ func (e *Executor) ListVPN(ctx context.Context) ([]entity.VPNService, error) {
 }
 
 func (e *Executor) GetCurrentVPN(ctx context.Context) (entity.VPNService, error) {
-	output, err := e.cmdRunner.Run(ctx, cmdSCUtil, e.cmdListVPNArgs...)
+	output, err := e.run(ctx, cmdSCUtil, e.cmdListVPNArgs...)
 	if err != nil {
 		return "", fmt.Errorf("failed to sync execute command: %w", err)
 	}
@@ -221,11 +341,32 @@ func (e *Executor) GetCurrentVPN(ctx context.Context) (entity.VPNService, error)
 	return "", errs.ErrVPNServiceNotFound
 }
 
+// GetVPNServerAddress returns the server address, an IP or a hostname, configured for a VPN service.
+func (e *Executor) GetVPNServerAddress(ctx context.Context, vpnService entity.VPNService) (string, error) {
+	args := make([]string, 0, len(e.cmdShowVPNArgs)+1)
+	args = append(args, e.cmdShowVPNArgs...)
+	args = append(args, string(vpnService))
+
+	output, err := e.run(ctx, cmdSCUtil, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to sync execute command: %w", err)
+	}
+
+	for _, line := range output {
+		remoteAddress := e.outputParser.parseRemoteAddress(line)
+		if remoteAddress != "" {
+			return remoteAddress, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to find server address of VPN service %s", vpnService)
+}
+
 func (e *Executor) OpenInFinder(ctx context.Context, path string) error {
 	args := make([]string, 0, len(e.cmdOpenInFinderArgs)+1)
 	args = append(args, e.cmdOpenInFinderArgs...)
 	args = append(args, path)
-	_, err := e.cmdRunner.Run(ctx, cmdOpen, args...)
+	_, err := e.run(ctx, cmdOpen, args...)
 	if err != nil {
 		return fmt.Errorf("failed to sync execute command: %w", err)
 	}
@@ -3,7 +3,9 @@ package command
 import (
 	"context"
 	"errors"
+	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,6 +16,11 @@ import (
 	"github.com/dmitrorlov/splitr/backend/pkg/errs"
 )
 
+// commandResult returns the result of a command that exited cleanly after printing the stdout lines.
+func commandResult(stdout []string) *entity.CommandResult {
+	return &entity.CommandResult{Stdout: stdout}
+}
+
 func TestNewExecutor(t *testing.T) {
 	executor := NewExecutor()
 
@@ -45,10 +52,69 @@ func TestDefaultCommandRunner_Run(t *testing.T) {
 	runner := &defaultCommandRunner{}
 	ctx := context.Background()
 
-	output, err := runner.Run(ctx, "echo", "test")
+	result, err := runner.Run(ctx, "echo", "test")
 
 	require.NoError(t, err)
-	assert.Contains(t, output, "test")
+	assert.Contains(t, result.Stdout, "test")
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Empty(t, result.Stderr)
+	assert.Positive(t, result.Duration)
+}
+
+func TestDefaultCommandRunner_Run_Failure(t *testing.T) {
+	runner := &defaultCommandRunner{}
+
+	t.Run("keeps stderr and the exit code", func(t *testing.T) {
+		result, err := runner.Run(context.Background(), "sh", "-c", "echo partial; echo 'went wrong' >&2; exit 3")
+
+		require.Error(t, err)
+		require.NotNil(t, result)
+		assert.Contains(t, result.Stdout, "partial")
+		assert.Equal(t, "went wrong", result.Stderr)
+		assert.Equal(t, 3, result.ExitCode)
+	})
+
+	t.Run("command not found", func(t *testing.T) {
+		result, err := runner.Run(context.Background(), "splitr-command-that-does-not-exist")
+
+		require.ErrorIs(t, err, exec.ErrNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestDefaultCommandRunner_Run_Cancel(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  string
+		args []string
+	}{
+		{
+			name: "kills the command",
+			cmd:  "sleep",
+			args: []string{"10"},
+		},
+		{
+			name: "kills the processes the command started",
+			cmd:  "sh",
+			args: []string{"-c", "sleep 10 & sleep 10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &defaultCommandRunner{}
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			started := time.Now()
+			result, err := runner.Run(ctx, tt.cmd, tt.args...)
+
+			require.ErrorIs(t, err, context.Canceled)
+			require.NotNil(t, result)
+			assert.Equal(t, -1, result.ExitCode)
+			assert.Less(t, time.Since(started), 5*time.Second)
+		})
+	}
 }
 
 func TestExecutor_GetDefaultNetworkInterface(t *testing.T) {
@@ -126,7 +192,7 @@ func TestExecutor_GetDefaultNetworkInterface(t *testing.T) {
 
 			mockRunner.EXPECT().
 				Run(ctx, cmdRoute, "get", "default").
-				Return(tt.commandOutput, tt.commandError).
+				Return(commandResult(tt.commandOutput), tt.commandError).
 				Times(1)
 
 			result, err := executor.GetDefaultNetworkInterface(ctx)
@@ -253,7 +319,7 @@ func TestExecutor_GetNetworkServiceByNetworkInterface(t *testing.T) {
 
 			mockRunner.EXPECT().
 				Run(ctx, cmdNetworkSetup, "-listnetworkserviceorder").
-				Return(tt.commandOutput, tt.commandError).
+				Return(commandResult(tt.commandOutput), tt.commandError).
 				Times(1)
 
 			result, err := executor.GetNetworkServiceByNetworkInterface(ctx, tt.networkInterface)
@@ -291,8 +357,9 @@ func TestExecutor_GetNetworkInfoByNetworkService(t *testing.T) {
 				"IPv6: Automatic",
 			},
 			expectedResult: &entity.NetworkInfo{
-				SubnetMask: "255.255.255.0",
-				Router:     "192.168.1.1",
+				NetworkService: "Wi-Fi",
+				SubnetMask:     "255.255.255.0",
+				Router:         "192.168.1.1",
 			},
 		},
 		{
@@ -305,8 +372,9 @@ func TestExecutor_GetNetworkInfoByNetworkService(t *testing.T) {
 				"Router: 10.0.0.1",
 			},
 			expectedResult: &entity.NetworkInfo{
-				SubnetMask: "255.255.0.0",
-				Router:     "10.0.0.1",
+				NetworkService: "Ethernet",
+				SubnetMask:     "255.255.0.0",
+				Router:         "10.0.0.1",
 			},
 		},
 		{
@@ -364,7 +432,7 @@ func TestExecutor_GetNetworkInfoByNetworkService(t *testing.T) {
 
 			mockRunner.EXPECT().
 				Run(ctx, cmdNetworkSetup, "-getinfo", string(tt.networkService)).
-				Return(tt.commandOutput, tt.commandError).
+				Return(commandResult(tt.commandOutput), tt.commandError).
 				Times(1)
 
 			result, err := executor.GetNetworkInfoByNetworkService(ctx, tt.networkService)
@@ -475,12 +543,12 @@ func TestExecutor_SetNetworkAdditionalRoutes(t *testing.T) {
 
 			mockRunner.EXPECT().
 				Run(gomock.Any(), gomock.Eq(cmdNetworkSetup), gomock.Any()).
-				DoAndReturn(func(_ context.Context, _ string, args ...string) ([]string, error) {
+				DoAndReturn(func(_ context.Context, _ string, args ...string) (*entity.CommandResult, error) {
 					// Verify all expected args are present
 					for _, expectedArg := range tt.expectedCommandArgs {
 						assert.Contains(t, args, expectedArg)
 					}
-					return []string{}, tt.commandError
+					return commandResult(nil), tt.commandError
 				}).
 				Times(1)
 
@@ -496,6 +564,171 @@ func TestExecutor_SetNetworkAdditionalRoutes(t *testing.T) {
 	}
 }
 
+func TestExecutor_GetNetworkAdditionalRoutes(t *testing.T) {
+	tests := []struct {
+		name          string
+		network       *entity.Network
+		commandOutput []string
+		commandError  error
+		expected      []*entity.AdditionalRoute
+		expectedError string
+	}{
+		{
+			name:    "routes configured",
+			network: &entity.Network{Name: "Corp VPN"},
+			commandOutput: []string{
+				"10.0.0.100 255.255.255.255 10.0.0.1",
+				"10.20.0.0 255.255.0.0 10.0.0.1",
+			},
+			expected: []*entity.AdditionalRoute{
+				{Destination: "10.0.0.100", SubnetMask: "255.255.255.255", Router: "10.0.0.1"},
+				{Destination: "10.20.0.0", SubnetMask: "255.255.0.0", Router: "10.0.0.1"},
+			},
+		},
+		{
+			name:          "no routes configured",
+			network:       &entity.Network{Name: "Corp VPN"},
+			commandOutput: []string{"There are no additional IPv4 routes on Corp VPN."},
+			expected:      []*entity.AdditionalRoute{},
+		},
+		{
+			name:          "command execution error",
+			network:       &entity.Network{Name: "Corp VPN"},
+			commandError:  errors.New("networksetup getadditionalroutes failed"),
+			expectedError: "failed to sync execute command: networksetup getadditionalroutes failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+			executor := NewExecutorWithRunner(mockRunner)
+
+			mockRunner.EXPECT().
+				Run(gomock.Any(), cmdNetworkSetup, "-getadditionalroutes", tt.network.Name).
+				Return(commandResult(tt.commandOutput), tt.commandError).
+				Times(1)
+
+			result, err := executor.GetNetworkAdditionalRoutes(context.Background(), tt.network)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExecutor_GetServiceAdditionalRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+	executor := NewExecutorWithRunner(mockRunner)
+
+	mockRunner.EXPECT().
+		Run(gomock.Any(), cmdNetworkSetup, "-getadditionalroutes", "Wi-Fi").
+		Return(commandResult([]string{"192.168.50.0 255.255.255.0 192.168.1.254"}), nil)
+
+	result, err := executor.GetServiceAdditionalRoutes(context.Background(), "Wi-Fi")
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.AdditionalRoute{
+		{Destination: "192.168.50.0", SubnetMask: "255.255.255.0", Router: "192.168.1.254"},
+	}, result)
+}
+
+func TestExecutor_SetNetworkAdditionalRoutesCommand(t *testing.T) {
+	executor := NewExecutorWithRunner(nil)
+
+	command := executor.SetNetworkAdditionalRoutesCommand(
+		&entity.Network{Name: "Corp VPN"},
+		[]*entity.NetworkHostSetup{
+			{NetworkHostIP: "10.0.0.100", SubnetMask: "255.255.255.0", Router: "10.0.0.1"},
+			{NetworkHostIP: "10.0.0.101", SubnetMask: "255.255.255.0", Router: "10.0.0.1"},
+		},
+	)
+
+	assert.Equal(t, &entity.Command{
+		Executable: cmdNetworkSetup,
+		Args: []string{
+			"-setadditionalroutes", "Corp VPN",
+			"10.0.0.100", "255.255.255.0", "10.0.0.1",
+			"10.0.0.101", "255.255.255.0", "10.0.0.1",
+		},
+	}, command)
+
+	emptyCommand := executor.SetNetworkAdditionalRoutesCommand(&entity.Network{Name: "Corp VPN"}, nil)
+	assert.Equal(t, []string{"-setadditionalroutes", "Corp VPN"}, emptyCommand.Args)
+}
+
+func TestExecutor_SetServiceAdditionalRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+	executor := NewExecutorWithRunner(mockRunner)
+	ctx := context.Background()
+	networkHostSetupList := []*entity.NetworkHostSetup{
+		{NetworkHostIP: "203.0.113.10", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+	}
+
+	mockRunner.EXPECT().
+		Run(ctx, cmdNetworkSetup, "-setadditionalroutes", "Wi-Fi", "203.0.113.10", "255.255.255.255", "192.168.1.1").
+		Return(commandResult(nil), nil)
+	require.NoError(t, executor.SetServiceAdditionalRoutes(ctx, "Wi-Fi", networkHostSetupList))
+
+	mockRunner.EXPECT().
+		Run(ctx, cmdNetworkSetup, "-setadditionalroutes", "Wi-Fi").
+		Return(nil, errors.New("networksetup setadditionalroutes failed"))
+	err := executor.SetServiceAdditionalRoutes(ctx, "Wi-Fi", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to sync execute command: networksetup setadditionalroutes failed")
+}
+
+func TestExecutor_RouteCommands(t *testing.T) {
+	executor := NewExecutorWithRunner(nil)
+	networkHostSetupList := []*entity.NetworkHostSetup{
+		{NetworkHostIP: "10.0.0.100", SubnetMask: "255.255.255.0", Router: "10.0.0.1"},
+		{NetworkHostIP: "10.0.0.101", SubnetMask: "255.255.255.0", Router: "10.0.0.1"},
+	}
+
+	tests := []struct {
+		name   string
+		action entity.RouteAction
+	}{
+		{name: "add routes", action: entity.RouteActionAdd},
+		{name: "delete routes", action: entity.RouteActionDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := executor.RouteCommands(tt.action, networkHostSetupList)
+
+			require.Len(t, commands, 2)
+			for i, command := range commands {
+				assert.Equal(t, cmdRoute, command.Executable)
+				assert.Equal(t, []string{
+					"-n", string(tt.action),
+					"-net", networkHostSetupList[i].NetworkHostIP,
+					"-netmask", networkHostSetupList[i].SubnetMask,
+					networkHostSetupList[i].Router,
+				}, command.Args)
+			}
+		})
+	}
+
+	assert.Empty(t, executor.RouteCommands(entity.RouteActionAdd, nil))
+}
+
 func TestExecutor_ListVPN(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -569,7 +802,7 @@ func TestExecutor_ListVPN(t *testing.T) {
 
 			mockRunner.EXPECT().
 				Run(ctx, cmdSCUtil, "--nc", "list").
-				Return(tt.commandOutput, tt.commandError).
+				Return(commandResult(tt.commandOutput), tt.commandError).
 				Times(1)
 
 			result, err := executor.ListVPN(ctx)
@@ -656,7 +889,7 @@ func TestExecutor_GetCurrentVPN(t *testing.T) {
 
 			mockRunner.EXPECT().
 				Run(ctx, cmdSCUtil, "--nc", "list").
-				Return(tt.commandOutput, tt.commandError).
+				Return(commandResult(tt.commandOutput), tt.commandError).
 				Times(1)
 
 			result, err := executor.GetCurrentVPN(ctx)
@@ -720,7 +953,7 @@ func TestExecutor_OpenInFinder(t *testing.T) {
 
 			mockRunner.EXPECT().
 				Run(ctx, cmdOpen, tt.expectedCommandArgs[0], tt.expectedCommandArgs[1]).
-				Return([]string{}, tt.commandError).
+				Return(commandResult(nil), tt.commandError).
 				Times(1)
 
 			err := executor.OpenInFinder(ctx, tt.path)
@@ -749,17 +982,17 @@ func TestExecutor_ContextPropagation(t *testing.T) {
 
 	mockRunner.EXPECT().
 		Run(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(receivedCtx context.Context, _ string, _ ...string) ([]string, error) {
+		DoAndReturn(func(receivedCtx context.Context, _ string, _ ...string) (*entity.CommandResult, error) {
 			assert.Equal(t, "test-value", receivedCtx.Value(testKey))
-			return []string{"interface wlan0"}, nil
+			return commandResult([]string{"interface wlan0"}), nil
 		}).
 		AnyTimes()
 
 	mockRunner.EXPECT().
 		Run(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(receivedCtx context.Context, _ string, _ ...string) ([]string, error) {
+		DoAndReturn(func(receivedCtx context.Context, _ string, _ ...string) (*entity.CommandResult, error) {
 			assert.Equal(t, "test-value", receivedCtx.Value(testKey))
-			return []string{}, nil
+			return commandResult(nil), nil
 		}).
 		AnyTimes()
 
@@ -768,3 +1001,64 @@ func TestExecutor_ContextPropagation(t *testing.T) {
 	_, _ = executor.GetCurrentVPN(ctx)
 	_ = executor.OpenInFinder(ctx, "/test/path")
 }
+
+func TestExecutor_GetVPNServerAddress(t *testing.T) {
+	tests := []struct {
+		name           string
+		commandOutput  []string
+		commandError   error
+		expectedResult string
+		expectedError  string
+	}{
+		{
+			name: "server address found",
+			commandOutput: []string{
+				`* (Connected)      ABC PPP --> L2TP       "Corporate-VPN"          [PPP:L2TP]`,
+				"<dictionary> {",
+				"  PPP : <dictionary> {",
+				"    AuthName : user",
+				"    CommRemoteAddress : vpn.example.com",
+				"  }",
+				"}",
+			},
+			expectedResult: "vpn.example.com",
+		},
+		{
+			name:          "server address missing",
+			commandOutput: []string{"<dictionary> {", "}"},
+			expectedError: "failed to find server address of VPN service Corporate-VPN",
+		},
+		{
+			name:          "command execution error",
+			commandError:  errors.New("scutil failed"),
+			expectedError: "failed to sync execute command: scutil failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+			executor := NewExecutorWithRunner(mockRunner)
+			ctx := context.Background()
+
+			mockRunner.EXPECT().
+				Run(ctx, cmdSCUtil, "--nc", "show", "Corporate-VPN").
+				Return(commandResult(tt.commandOutput), tt.commandError)
+
+			result, err := executor.GetVPNServerAddress(ctx, "Corporate-VPN")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Empty(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package command
 
 import (
 	"regexp"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
 )
 
 const (
@@ -12,12 +14,16 @@ const (
 	regexpNetworkServiceName = `\(\d+\) (.+)`
 	regexpSubnetMask         = `Subnet mask: ` + regexpPartIP
 	regexpRouter             = `Router: ` + regexpPartIP
+	regexpRemoteAddress      = `CommRemoteAddress : (\S+)`
+	regexpAdditionalRoute    = `^\s*` + regexpPartIP + `\s+` + regexpPartIP + `(?:\s+` + regexpPartIP + `)?\s*$`
 
 	minVPNNameParseLength            = 2
 	minInterfaceNameParseLength      = 2
 	minNetworkServiceNameParseLength = 2
 	minSubnetMaskParseLength         = 2
 	minRouterParseLength             = 2
+	minRemoteAddressParseLength      = 2
+	minAdditionalRouteParseLength    = 4
 )
 
 type outputParser struct{}
@@ -80,3 +86,29 @@ func (p *outputParser) parseRouter(line string) string {
 
 	return m[1]
 }
+
+func (p *outputParser) parseRemoteAddress(line string) string {
+	r := regexp.MustCompile(regexpRemoteAddress)
+	m := r.FindStringSubmatch(line)
+
+	if len(m) < minRemoteAddressParseLength {
+		return ""
+	}
+
+	return m[1]
+}
+
+func (p *outputParser) parseAdditionalRoute(line string) *entity.AdditionalRoute {
+	r := regexp.MustCompile(regexpAdditionalRoute)
+	m := r.FindStringSubmatch(line)
+
+	if len(m) < minAdditionalRouteParseLength {
+		return nil
+	}
+
+	return &entity.AdditionalRoute{
+		Destination: m[1],
+		SubnetMask:  m[2],
+		Router:      m[3],
+	}
+}
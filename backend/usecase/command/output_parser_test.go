@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
 )
 
 func TestNewOutputParser(t *testing.T) {
@@ -395,6 +397,86 @@ func TestOutputParser_ParseRouter(t *testing.T) {
 	}
 }
 
+func TestOutputParser_ParseAdditionalRoute(t *testing.T) {
+	parser := newOutputParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected *entity.AdditionalRoute
+	}{
+		{
+			name:  "route with router",
+			input: "10.0.0.100 255.255.255.255 10.0.0.1",
+			expected: &entity.AdditionalRoute{
+				Destination: "10.0.0.100",
+				SubnetMask:  "255.255.255.255",
+				Router:      "10.0.0.1",
+			},
+		},
+		{
+			name:  "route without router",
+			input: "10.20.0.0\t255.255.0.0",
+			expected: &entity.AdditionalRoute{
+				Destination: "10.20.0.0",
+				SubnetMask:  "255.255.0.0",
+			},
+		},
+		{
+			name:  "surrounding whitespace",
+			input: "  192.168.1.0 255.255.255.0 192.168.1.1  ",
+			expected: &entity.AdditionalRoute{
+				Destination: "192.168.1.0",
+				SubnetMask:  "255.255.255.0",
+				Router:      "192.168.1.1",
+			},
+		},
+		{
+			name:     "no routes message",
+			input:    "There are no additional IPv4 routes on Wi-Fi.",
+			expected: nil,
+		},
+		{
+			name:     "single IP",
+			input:    "10.0.0.100",
+			expected: nil,
+		},
+		{
+			name:     "empty string",
+			input:    "",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.parseAdditionalRoute(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestOutputParser_ParseRemoteAddress(t *testing.T) {
+	parser := newOutputParser()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "hostname", input: "    CommRemoteAddress : vpn.example.com", expected: "vpn.example.com"},
+		{name: "IP", input: "CommRemoteAddress : 203.0.113.7", expected: "203.0.113.7"},
+		{name: "other key", input: "    AuthName : user", expected: ""},
+		{name: "empty string", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.parseRemoteAddress(tt.input))
+		})
+	}
+}
+
 func TestOutputParser_EdgeCases(t *testing.T) {
 	parser := newOutputParser()
 
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+const transcriptFileMode = 0o600
+
+// RecordingRunner runs commands with another runner and appends each run to a transcript file.
+// Transcripts hold network service names, addresses and VPN servers, so users share them on purpose.
+type RecordingRunner struct {
+	runner usecase.CommandRunner
+	path   string
+
+	mu sync.Mutex
+}
+
+func NewRecordingRunner(runner usecase.CommandRunner, path string) *RecordingRunner {
+	return &RecordingRunner{
+		runner: runner,
+		path:   path,
+	}
+}
+
+// Run runs the command and records it. A failure to record is logged and doesn't fail the command.
+func (r *RecordingRunner) Run(ctx context.Context, name string, args ...string) (*entity.CommandResult, error) {
+	result, err := r.runner.Run(ctx, name, args...)
+
+	record := entity.NewCommandRecord(&entity.Command{Executable: name, Args: args}, result, err)
+	if recordErr := r.record(record); recordErr != nil {
+		slog.ErrorContext(ctx, "failed to record command", "path", r.path, "error", recordErr)
+	}
+
+	return result, err
+}
+
+func (r *RecordingRunner) record(record *entity.CommandRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal command record: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, transcriptFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+
+	return nil
+}
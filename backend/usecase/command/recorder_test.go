@@ -0,0 +1,103 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+)
+
+func TestRecordingRunner_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+	recorder := NewRecordingRunner(mockRunner, path)
+
+	listResult := &entity.CommandResult{Stdout: []string{"  \"Office VPN\" [PPP:L2TP] (Connected)", ""}}
+	mockRunner.EXPECT().Run(ctx, cmdSCUtil, "--nc", "list").Return(listResult, nil)
+	infoResult := &entity.CommandResult{Stderr: "** Error: The parameters were not valid.", ExitCode: 4}
+	infoErr := errors.New("failed to execute command: exit status 4")
+	mockRunner.EXPECT().Run(ctx, cmdNetworkSetup, "-getinfo", "Wi-Fi").Return(infoResult, infoErr)
+
+	result, err := recorder.Run(ctx, cmdSCUtil, "--nc", "list")
+	require.NoError(t, err)
+	assert.Equal(t, listResult, result)
+
+	result, err = recorder.Run(ctx, cmdNetworkSetup, "-getinfo", "Wi-Fi")
+	require.Equal(t, infoErr, err)
+	assert.Equal(t, infoResult, result)
+
+	replay, err := NewReplayRunner(path)
+	require.NoError(t, err)
+
+	replayed, err := replay.Run(ctx, cmdSCUtil, "--nc", "list")
+	require.NoError(t, err)
+	assert.Equal(t, listResult, replayed)
+
+	replayed, err = replay.Run(ctx, cmdNetworkSetup, "-getinfo", "Wi-Fi")
+	require.EqualError(t, err, infoErr.Error())
+	assert.Equal(t, infoResult, replayed)
+}
+
+func TestRecordingRunner_Run_RecordFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "missing", "transcript.jsonl")
+	mockRunner := mock_usecase.NewMockCommandRunner(ctrl)
+	recorder := NewRecordingRunner(mockRunner, path)
+
+	expected := &entity.CommandResult{Stdout: []string{"interface: en0"}}
+	mockRunner.EXPECT().Run(ctx, cmdRoute, "get", "default").Return(expected, nil)
+
+	result, err := recorder.Run(ctx, cmdRoute, "get", "default")
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+	assert.NoFileExists(t, path)
+}
+
+func TestNewRunner(t *testing.T) {
+	transcript := filepath.Join(t.TempDir(), "transcript.jsonl")
+	require.NoError(t, os.WriteFile(transcript, nil, 0o600))
+
+	t.Run("system commands by default", func(t *testing.T) {
+		runner, err := NewRunner(&config.Command{})
+
+		require.NoError(t, err)
+		assert.IsType(t, &defaultCommandRunner{}, runner)
+	})
+
+	t.Run("recording", func(t *testing.T) {
+		runner, err := NewRunner(&config.Command{RecordPath: transcript})
+
+		require.NoError(t, err)
+		assert.IsType(t, &RecordingRunner{}, runner)
+	})
+
+	t.Run("replay takes precedence over recording", func(t *testing.T) {
+		runner, err := NewRunner(&config.Command{RecordPath: transcript, ReplayPath: transcript})
+
+		require.NoError(t, err)
+		assert.IsType(t, &ReplayRunner{}, runner)
+	})
+
+	t.Run("replay of a missing transcript", func(t *testing.T) {
+		_, err := NewRunner(&config.Command{ReplayPath: filepath.Join(t.TempDir(), "missing.jsonl")})
+
+		require.Error(t, err)
+	})
+}
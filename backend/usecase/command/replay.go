@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+// ReplayRunner serves the runs of a recorded transcript instead of running commands, so a user's session
+// can be reproduced on any platform. Runs of the same command are served in the order they were recorded,
+// the last one again once they are used up.
+type ReplayRunner struct {
+	mu      sync.Mutex
+	records map[string][]*entity.CommandRecord
+}
+
+// NewReplayRunner loads the transcript at path.
+func NewReplayRunner(path string) (*ReplayRunner, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer file.Close()
+
+	return newReplayRunner(file)
+}
+
+func newReplayRunner(transcript io.Reader) (*ReplayRunner, error) {
+	runner := &ReplayRunner{
+		records: make(map[string][]*entity.CommandRecord),
+	}
+
+	decoder := json.NewDecoder(transcript)
+	for {
+		record := new(entity.CommandRecord)
+		err := decoder.Decode(record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transcript: %w", err)
+		}
+
+		key := replayKey(record.Executable, record.Args)
+		runner.records[key] = append(runner.records[key], record)
+	}
+
+	return runner, nil
+}
+
+// Run returns the next recorded run of the command.
+func (r *ReplayRunner) Run(ctx context.Context, name string, args ...string) (*entity.CommandResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to execute command: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := replayKey(name, args)
+	records := r.records[key]
+	if len(records) == 0 {
+		command := &entity.Command{Executable: name, Args: args}
+		return nil, fmt.Errorf("%w: %s", errs.ErrCommandNotRecorded, command.ShellString())
+	}
+
+	record := records[0]
+	if len(records) > 1 {
+		r.records[key] = records[1:]
+	}
+
+	return record.Result()
+}
+
+func replayKey(name string, args []string) string {
+	return strings.Join(append([]string{name}, args...), "\x00")
+}
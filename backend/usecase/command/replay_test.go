@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+// TestReplayRunner_Session reproduces a recorded macOS session through the executor.
+func TestReplayRunner_Session(t *testing.T) {
+	runner, err := NewReplayRunner(filepath.Join("testdata", "session.jsonl"))
+	require.NoError(t, err)
+	executor := NewExecutorWithRunner(runner)
+	ctx := context.Background()
+
+	_, err = executor.GetCurrentVPN(ctx)
+	require.ErrorIs(t, err, errs.ErrVPNServiceNotFound)
+
+	vpnService, err := executor.GetCurrentVPN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entity.VPNService("Office VPN"), vpnService)
+
+	networkInterface, err := executor.GetDefaultNetworkInterface(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entity.NetworkInterface("en0"), networkInterface)
+
+	networkService, err := executor.GetNetworkServiceByNetworkInterface(ctx, networkInterface)
+	require.NoError(t, err)
+	assert.Equal(t, entity.NetworkService("Wi-Fi"), networkService)
+
+	networkInfo, err := executor.GetNetworkInfoByNetworkService(ctx, networkService)
+	require.NoError(t, err)
+	assert.Equal(t, "255.255.255.0", networkInfo.SubnetMask)
+	assert.Equal(t, "192.168.1.1", networkInfo.Router)
+
+	err = executor.SetNetworkAdditionalRoutes(ctx, &entity.Network{Name: "Office VPN"}, []*entity.NetworkHostSetup{
+		{NetworkHostIP: "10.0.0.0", SubnetMask: "255.0.0.0", Router: "10.8.0.1"},
+	})
+	require.ErrorIs(t, err, errs.ErrCommandRequiresAdmin)
+}
+
+func TestReplayRunner_Run(t *testing.T) {
+	transcript := strings.Join([]string{
+		`{"executable":"scutil","args":["--nc","list"],"stdout":["first"],"exit_code":0,"duration_ms":5}`,
+		`{"executable":"scutil","args":["--nc","list"],"stdout":["second"],"exit_code":0,"duration_ms":5}`,
+		`{"executable":"open","args":["-R","/missing"],"stderr":"does not exist","exit_code":1,` +
+			`"error":"failed to execute command: exit status 1"}`,
+		`{"executable":"missing","args":[],"not_started":true,"exit_code":0,` +
+			`"error":"failed to execute command: executable file not found"}`,
+	}, "\n")
+
+	t.Run("runs of the same command are served in order and the last one repeats", func(t *testing.T) {
+		runner, err := newReplayRunner(strings.NewReader(transcript))
+		require.NoError(t, err)
+
+		for _, expected := range []string{"first", "second", "second"} {
+			result, runErr := runner.Run(context.Background(), "scutil", "--nc", "list")
+			require.NoError(t, runErr)
+			assert.Equal(t, []string{expected}, result.Stdout)
+			assert.Equal(t, 5*time.Millisecond, result.Duration)
+		}
+	})
+
+	t.Run("failed run is replayed with its error", func(t *testing.T) {
+		runner, err := newReplayRunner(strings.NewReader(transcript))
+		require.NoError(t, err)
+
+		result, err := runner.Run(context.Background(), "open", "-R", "/missing")
+
+		require.EqualError(t, err, "failed to execute command: exit status 1")
+		require.NotNil(t, result)
+		assert.Equal(t, 1, result.ExitCode)
+		assert.Equal(t, "does not exist", result.Stderr)
+	})
+
+	t.Run("command that did not start has no result", func(t *testing.T) {
+		runner, err := newReplayRunner(strings.NewReader(transcript))
+		require.NoError(t, err)
+
+		result, err := runner.Run(context.Background(), "missing")
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("command not in the transcript", func(t *testing.T) {
+		runner, err := newReplayRunner(strings.NewReader(transcript))
+		require.NoError(t, err)
+
+		result, err := runner.Run(context.Background(), "networksetup", "-getinfo", "Wi-Fi")
+
+		require.ErrorIs(t, err, errs.ErrCommandNotRecorded)
+		assert.Contains(t, err.Error(), "networksetup -getinfo Wi-Fi")
+		assert.Nil(t, result)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		runner, err := newReplayRunner(strings.NewReader(transcript))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = runner.Run(ctx, "scutil", "--nc", "list")
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestNewReplayRunner_Errors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewReplayRunner(filepath.Join(t.TempDir(), "missing.jsonl"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open transcript")
+	})
+
+	t.Run("malformed transcript", func(t *testing.T) {
+		_, err := newReplayRunner(strings.NewReader(`{"executable":`))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decode transcript")
+	})
+}
@@ -1,23 +1,86 @@
 package command
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
 	"os/exec"
 	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/usecase"
 )
 
+// commandWaitDelay is how long a killed command may keep its output pipes open before they are closed on it.
+const commandWaitDelay = time.Second
+
 type defaultCommandRunner struct{}
 
-func (r *defaultCommandRunner) Run(ctx context.Context, name string, args ...string) ([]string, error) {
+// NewRunner returns the runner the config asks for: a replay of a transcript, the system commands
+// recorded to a transcript, or just the system commands.
+func NewRunner(commandCfg *config.Command) (usecase.CommandRunner, error) {
+	switch {
+	case commandCfg.ReplayPath != "":
+		runner, err := NewReplayRunner(commandCfg.ReplayPath)
+		if err != nil {
+			return nil, err
+		}
+		slog.Warn("replaying recorded commands instead of running them", "path", commandCfg.ReplayPath)
+		return runner, nil
+	case commandCfg.RecordPath != "":
+		slog.Info("recording commands", "path", commandCfg.RecordPath)
+		return NewRecordingRunner(&defaultCommandRunner{}, commandCfg.RecordPath), nil
+	default:
+		return &defaultCommandRunner{}, nil
+	}
+}
+
+// Run executes the command and returns what it printed and how it exited. The result is nil only when
+// the command could not be started. When ctx is done the command is killed along with the processes
+// it started, and the returned error wraps ctx.Err().
+func (r *defaultCommandRunner) Run(ctx context.Context, name string, args ...string) (*entity.CommandResult, error) {
 	slog.InfoContext(ctx, fmt.Sprintf("executing command: %s %s", name, strings.Join(args, " ")))
 
 	cmd := exec.CommandContext(ctx, name, args...)
-	output, err := cmd.Output()
-	if err != nil {
+	// The command gets a process group of its own so cancelling kills the children it started too.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = commandWaitDelay
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	startedAt := time.Now()
+	err := cmd.Run()
+	if cmd.ProcessState == nil {
 		return nil, fmt.Errorf("failed to execute command: %w", err)
 	}
 
-	return strings.Split(string(output), "\n"), nil
+	result := &entity.CommandResult{
+		Stdout:   strings.Split(stdout.String(), "\n"),
+		Stderr:   strings.TrimSpace(stderr.String()),
+		ExitCode: cmd.ProcessState.ExitCode(),
+		Duration: time.Since(startedAt),
+	}
+	slog.DebugContext(ctx, "command finished",
+		"command", name,
+		"exit_code", result.ExitCode,
+		"duration", result.Duration,
+	)
+
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, fmt.Errorf("failed to execute command: %w", ctxErr)
+		}
+		return result, fmt.Errorf("failed to execute command: %w", err)
+	}
+
+	return result, nil
 }
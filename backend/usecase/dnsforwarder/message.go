@@ -0,0 +1,135 @@
+package dnsforwarder
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// answer is what the forwarder needs from a DNS response: the names asked and the IPv4 addresses returned.
+type answer struct {
+	names   []string
+	records []answerRecord
+}
+
+type answerRecord struct {
+	name string
+	ip   string
+	ttl  time.Duration
+}
+
+// matchingName returns the queried name covered by a wildcard address. Every IPv4 address in the answer
+// belongs to it, CNAME targets included, since the client connects to them to reach the queried name.
+func (a *answer) matchingName(address string) (string, bool) {
+	for _, name := range a.names {
+		if entity.MatchesWildcard(address, name) {
+			return name, true
+		}
+	}
+
+	for _, record := range a.records {
+		if entity.MatchesWildcard(address, record.name) {
+			return record.name, true
+		}
+	}
+
+	return "", false
+}
+
+// parseAnswer reads the questions and A records of a successful DNS response.
+// Failed responses and anything that isn't a response have no records.
+func parseAnswer(response []byte) (*answer, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DNS response header: %w", err)
+	}
+
+	res := &answer{}
+	if !header.Response || header.RCode != dnsmessage.RCodeSuccess {
+		return res, nil
+	}
+
+	questions, err := parser.AllQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DNS questions: %w", err)
+	}
+	for _, question := range questions {
+		res.names = append(res.names, dnsName(question.Name))
+	}
+
+	for {
+		resourceHeader, headerErr := parser.AnswerHeader()
+		if errors.Is(headerErr, dnsmessage.ErrSectionDone) {
+			break
+		}
+		if headerErr != nil {
+			return nil, fmt.Errorf("failed to parse DNS answer: %w", headerErr)
+		}
+
+		if resourceHeader.Type != dnsmessage.TypeA || resourceHeader.Class != dnsmessage.ClassINET {
+			err = parser.SkipAnswer()
+			if err != nil {
+				return nil, fmt.Errorf("failed to skip DNS answer: %w", err)
+			}
+			continue
+		}
+
+		resource, resourceErr := parser.AResource()
+		if resourceErr != nil {
+			return nil, fmt.Errorf("failed to parse A record: %w", resourceErr)
+		}
+
+		res.records = append(res.records, answerRecord{
+			name: dnsName(resourceHeader.Name),
+			ip:   netip.AddrFrom4(resource.A).String(),
+			ttl:  time.Duration(resourceHeader.TTL) * time.Second,
+		})
+	}
+
+	return res, nil
+}
+
+// serverFailure builds a SERVFAIL response to a query.
+func serverFailure(query []byte) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DNS query header: %w", err)
+	}
+
+	questions, err := parser.AllQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DNS questions: %w", err)
+	}
+
+	message := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 header.ID,
+			Response:           true,
+			OpCode:             header.OpCode,
+			RecursionDesired:   header.RecursionDesired,
+			RecursionAvailable: true,
+			RCode:              dnsmessage.RCodeServerFailure,
+		},
+		Questions: questions,
+	}
+
+	response, err := message.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack DNS response: %w", err)
+	}
+
+	return response, nil
+}
+
+// dnsName returns a name without its trailing root dot.
+func dnsName(name dnsmessage.Name) string {
+	return strings.TrimSuffix(name.String(), ".")
+}
@@ -0,0 +1,127 @@
+package dnsforwarder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testResponse packs a response to an A query for name with the given answers.
+func testResponse(t *testing.T, name string, rcode dnsmessage.RCode, answers ...dnsmessage.Resource) []byte {
+	t.Helper()
+
+	message := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: 42, Response: true, RCode: rcode},
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(name + "."),
+			Type:  dnsmessage.TypeA,
+			Class: dnsmessage.ClassINET,
+		}},
+		Answers: answers,
+	}
+
+	response, err := message.Pack()
+	require.NoError(t, err)
+
+	return response
+}
+
+func aRecord(name string, ip [4]byte, ttl uint32) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName(name + "."),
+			Type:  dnsmessage.TypeA,
+			Class: dnsmessage.ClassINET,
+			TTL:   ttl,
+		},
+		Body: &dnsmessage.AResource{A: ip},
+	}
+}
+
+func cnameRecord(name, target string) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{
+			Name:  dnsmessage.MustNewName(name + "."),
+			Type:  dnsmessage.TypeCNAME,
+			Class: dnsmessage.ClassINET,
+			TTL:   300,
+		},
+		Body: &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(target + ".")},
+	}
+}
+
+func TestParseAnswer(t *testing.T) {
+	t.Run("A records behind a CNAME", func(t *testing.T) {
+		response := testResponse(t, "git.corp.example.com", dnsmessage.RCodeSuccess,
+			cnameRecord("git.corp.example.com", "edge.cdn.example.net"),
+			aRecord("edge.cdn.example.net", [4]byte{10, 0, 0, 5}, 60),
+			aRecord("edge.cdn.example.net", [4]byte{10, 0, 0, 6}, 30),
+		)
+
+		res, err := parseAnswer(response)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"git.corp.example.com"}, res.names)
+		assert.Equal(t, []answerRecord{
+			{name: "edge.cdn.example.net", ip: "10.0.0.5", ttl: time.Minute},
+			{name: "edge.cdn.example.net", ip: "10.0.0.6", ttl: 30 * time.Second},
+		}, res.records)
+
+		name, ok := res.matchingName("*.corp.example.com")
+		assert.True(t, ok)
+		assert.Equal(t, "git.corp.example.com", name)
+
+		name, ok = res.matchingName(".cdn.example.net")
+		assert.True(t, ok)
+		assert.Equal(t, "edge.cdn.example.net", name)
+
+		_, ok = res.matchingName("*.other.example.com")
+		assert.False(t, ok)
+	})
+
+	t.Run("failed response has no records", func(t *testing.T) {
+		response := testResponse(t, "missing.corp.example.com", dnsmessage.RCodeNameError)
+
+		res, err := parseAnswer(response)
+
+		require.NoError(t, err)
+		assert.Empty(t, res.records)
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		_, err := parseAnswer([]byte{0x01})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse DNS response header")
+	})
+}
+
+func TestServerFailure(t *testing.T) {
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: 7, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName("git.corp.example.com."),
+			Type:  dnsmessage.TypeA,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	packed, err := query.Pack()
+	require.NoError(t, err)
+
+	response, err := serverFailure(packed)
+	require.NoError(t, err)
+
+	var message dnsmessage.Message
+	require.NoError(t, message.Unpack(response))
+	assert.Equal(t, uint16(7), message.Header.ID)
+	assert.True(t, message.Header.Response)
+	assert.True(t, message.Header.RecursionDesired)
+	assert.Equal(t, dnsmessage.RCodeServerFailure, message.Header.RCode)
+	assert.Equal(t, query.Questions, message.Questions)
+
+	_, err = serverFailure(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,333 @@
+package dnsforwarder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+const (
+	// maxMessageSize is the largest DNS message that fits a UDP datagram.
+	maxMessageSize  = 65535
+	upstreamTimeout = 5 * time.Second
+	// syncDelay is how long a network waits to sync after an answer taught it a new IP,
+	// so a burst of answers for the same network ends in a single sync.
+	syncDelay = 500 * time.Millisecond
+)
+
+type (
+	nowFunc      func() time.Time
+	exchangeFunc func(ctx context.Context, query []byte) ([]byte, error)
+)
+
+// UseCase is a local DNS forwarder. It answers queries from an upstream server and routes the IPs
+// that subdomains of wildcard network hosts resolve to through their network, until the answer's TTL runs out.
+type UseCase struct {
+	networkHostSetupUC usecase.NetworkHostSetup
+	networkHostStorage storage.NetworkHost
+	learnedIPStorage   storage.LearnedIP
+
+	enabled       bool
+	listenAddress string
+	upstream      string
+	minTTL        time.Duration
+	checkInterval time.Duration
+
+	exchange  exchangeFunc
+	now       nowFunc
+	syncDelay time.Duration
+
+	pendingSyncsMu sync.Mutex
+	pendingSyncs   map[uint64]struct{}
+}
+
+func New(
+	networkHostSetupUC usecase.NetworkHostSetup,
+	networkHostStorage storage.NetworkHost,
+	learnedIPStorage storage.LearnedIP,
+	forwarderCfg *config.DNSForwarder,
+) *UseCase {
+	u := &UseCase{
+		networkHostSetupUC: networkHostSetupUC,
+		networkHostStorage: networkHostStorage,
+		learnedIPStorage:   learnedIPStorage,
+		enabled:            forwarderCfg.Enabled,
+		listenAddress:      forwarderCfg.ListenAddress,
+		upstream:           forwarderCfg.Upstream,
+		minTTL:             forwarderCfg.MinTTL,
+		checkInterval:      forwarderCfg.CheckInterval,
+		now:                time.Now,
+		syncDelay:          syncDelay,
+		pendingSyncs:       make(map[uint64]struct{}),
+	}
+	u.exchange = u.exchangeUpstream
+
+	return u
+}
+
+// Run expires learned IPs on every check interval until ctx is done and, when the forwarder is enabled,
+// serves DNS queries on the listen address. Learned IPs expire even with the forwarder disabled,
+// so the routes it added before don't outlive their TTL.
+func (u *UseCase) Run(ctx context.Context) {
+	if u.enabled {
+		go u.serve(ctx)
+	}
+
+	ticker := time.NewTicker(u.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		err := u.ExpireAll(ctx)
+		if err != nil {
+			slog.Warn("failed to expire learned IPs", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Observe learns the IPs in a DNS response whose question matches a wildcard network host
+// and schedules a sync of the networks that got new ones. IPs learned again only have their expiry extended.
+func (u *UseCase) Observe(ctx context.Context, response []byte) error {
+	answer, err := parseAnswer(response)
+	if err != nil {
+		return err
+	}
+	if len(answer.records) == 0 {
+		return nil
+	}
+
+	wildcardHosts, err := u.listWildcardHosts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, wildcardHost := range wildcardHosts {
+		name, ok := answer.matchingName(wildcardHost.Address)
+		if !ok {
+			continue
+		}
+
+		learned, learnErr := u.learn(ctx, wildcardHost, name, answer.records)
+		if learnErr != nil {
+			return learnErr
+		}
+
+		if learned {
+			u.scheduleSync(ctx, wildcardHost.NetworkID)
+		}
+	}
+
+	return nil
+}
+
+// ExpireAll removes the learned IPs past their TTL and re-syncs the networks that routed them.
+func (u *UseCase) ExpireAll(ctx context.Context) error {
+	networkIDs, err := u.learnedIPStorage.DeleteExpired(ctx, u.now())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired learned IPs: %w", err)
+	}
+
+	return u.syncNetworks(ctx, networkIDs)
+}
+
+// serve answers queries on the listen address until ctx is done.
+func (u *UseCase) serve(ctx context.Context) {
+	if u.upstream == "" {
+		slog.Error("DNS forwarder is enabled without an upstream server, set SPLITR_DNS_FORWARDER_UPSTREAM")
+		return
+	}
+
+	conn, err := new(net.ListenConfig).ListenPacket(ctx, "udp", u.listenAddress)
+	if err != nil {
+		slog.Error("failed to start DNS forwarder", "address", u.listenAddress, "error", err)
+		return
+	}
+	slog.Info("DNS forwarder started", "address", conn.LocalAddr().String(), "upstream", u.upstream)
+
+	go func() {
+		<-ctx.Done()
+		if closeErr := conn.Close(); closeErr != nil {
+			slog.Warn("failed to stop DNS forwarder", "error", closeErr)
+		}
+	}()
+
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, clientAddr, readErr := conn.ReadFrom(buf)
+		if readErr != nil {
+			if ctx.Err() == nil {
+				slog.Error("DNS forwarder stopped", "error", readErr)
+			}
+			return
+		}
+
+		go u.handle(ctx, conn, clientAddr, slices.Clone(buf[:n]))
+	}
+}
+
+// handle forwards a query, replies to the client and then observes the answer. Replying never waits
+// for a sync, which can take seconds and may itself resolve hosts through this forwarder.
+func (u *UseCase) handle(ctx context.Context, conn net.PacketConn, clientAddr net.Addr, query []byte) {
+	response, err := u.resolve(ctx, query)
+	if err != nil {
+		slog.Debug("failed to answer DNS query", "client", clientAddr.String(), "error", err)
+		return
+	}
+
+	_, err = conn.WriteTo(response, clientAddr)
+	if err != nil {
+		slog.Debug("failed to reply to DNS query", "client", clientAddr.String(), "error", err)
+	}
+
+	err = u.Observe(ctx, response)
+	if err != nil {
+		slog.Warn("failed to route DNS answer", "error", err)
+	}
+}
+
+// resolve forwards a query upstream. An unreachable upstream is answered with a server failure
+// so the client doesn't wait for its own timeout.
+func (u *UseCase) resolve(ctx context.Context, query []byte) ([]byte, error) {
+	response, err := u.exchange(ctx, query)
+	if err != nil {
+		slog.Warn("failed to forward DNS query", "upstream", u.upstream, "error", err)
+		return serverFailure(query)
+	}
+
+	return response, nil
+}
+
+// exchangeUpstream sends a query to the upstream server and waits for its response.
+func (u *UseCase) exchangeUpstream(ctx context.Context, query []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, upstreamTimeout)
+	defer cancel()
+
+	conn, err := new(net.Dialer).DialContext(ctx, "udp", u.upstream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial upstream %s: %w", u.upstream, err)
+	}
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			slog.Debug("failed to close upstream connection", "error", closeErr)
+		}
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set upstream deadline: %w", err)
+		}
+	}
+
+	_, err = conn.Write(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send query upstream: %w", err)
+	}
+
+	buf := make([]byte, maxMessageSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upstream response: %w", err)
+	}
+
+	return buf[:n], nil
+}
+
+// listWildcardHosts returns the enabled wildcard hosts of every network.
+func (u *UseCase) listWildcardHosts(ctx context.Context) ([]*entity.NetworkHost, error) {
+	enabled := true
+	networkHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{Enabled: &enabled})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network hosts: %w", err)
+	}
+
+	return slices.DeleteFunc(networkHosts, func(networkHost *entity.NetworkHost) bool {
+		return !networkHost.IsWildcard()
+	}), nil
+}
+
+// learn stores the answered IPs for a wildcard host and reports whether any of them wasn't routed yet.
+func (u *UseCase) learn(
+	ctx context.Context,
+	wildcardHost *entity.NetworkHost,
+	name string,
+	records []answerRecord,
+) (bool, error) {
+	activeIPs, err := u.learnedIPStorage.ListActive(ctx, []uint64{wildcardHost.ID}, u.now())
+	if err != nil {
+		return false, fmt.Errorf("failed to list learned IPs of %s: %w", wildcardHost.Address, err)
+	}
+
+	learned := false
+	for _, record := range records {
+		learnedIP := entity.NewLearnedIP(wildcardHost, name, record.ip, max(record.ttl, u.minTTL))
+		err = u.learnedIPStorage.Upsert(ctx, learnedIP)
+		if err != nil {
+			return false, fmt.Errorf("failed to store learned IP %s of %s: %w", record.ip, wildcardHost.Address, err)
+		}
+
+		if !slices.ContainsFunc(activeIPs, func(activeIP *entity.LearnedIP) bool {
+			return activeIP.IP == record.ip
+		}) {
+			learned = true
+			slog.Info("learned IP for wildcard host", "address", wildcardHost.Address, "name", name, "ip", record.ip)
+		}
+	}
+
+	return learned, nil
+}
+
+// scheduleSync syncs a network after the sync delay, unless a sync of it is already scheduled.
+// The pending mark is cleared before the sync starts, so IPs learned while it runs schedule another one.
+func (u *UseCase) scheduleSync(ctx context.Context, networkID uint64) {
+	u.pendingSyncsMu.Lock()
+	defer u.pendingSyncsMu.Unlock()
+
+	if _, ok := u.pendingSyncs[networkID]; ok {
+		return
+	}
+	u.pendingSyncs[networkID] = struct{}{}
+
+	time.AfterFunc(u.syncDelay, func() {
+		u.pendingSyncsMu.Lock()
+		delete(u.pendingSyncs, networkID)
+		u.pendingSyncsMu.Unlock()
+
+		err := u.syncNetworks(ctx, []uint64{networkID})
+		if err != nil {
+			slog.Warn("failed to route learned IPs", "network_id", networkID, "error", err)
+		}
+	})
+}
+
+// syncNetworks re-syncs each network, one that fails to sync doesn't stop the others.
+func (u *UseCase) syncNetworks(ctx context.Context, networkIDs []uint64) error {
+	var syncErrs []error
+	for _, networkID := range networkIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := u.networkHostSetupUC.SyncByNetworkID(ctx, networkID)
+		if err != nil {
+			syncErrs = append(syncErrs, fmt.Errorf("failed to sync network %d: %w", networkID, err))
+		}
+	}
+
+	return errors.Join(syncErrs...)
+}
@@ -0,0 +1,315 @@
+package dnsforwarder
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+)
+
+func testNow() time.Time {
+	return time.Date(2025, 10, 27, 12, 0, 0, 0, time.UTC)
+}
+
+type testMocks struct {
+	networkHostSetupUC *mock_usecase.MockNetworkHostSetup
+	networkHostStorage *mock_storage.MockNetworkHost
+	learnedIPStorage   *mock_storage.MockLearnedIP
+}
+
+func newTestUseCase(t *testing.T) (*UseCase, *testMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := &testMocks{
+		networkHostSetupUC: mock_usecase.NewMockNetworkHostSetup(ctrl),
+		networkHostStorage: mock_storage.NewMockNetworkHost(ctrl),
+		learnedIPStorage:   mock_storage.NewMockLearnedIP(ctrl),
+	}
+
+	useCase := New(
+		mocks.networkHostSetupUC,
+		mocks.networkHostStorage,
+		mocks.learnedIPStorage,
+		&config.DNSForwarder{
+			ListenAddress: "127.0.0.1:0",
+			Upstream:      "127.0.0.1:53",
+			MinTTL:        time.Minute,
+			CheckInterval: time.Minute,
+		},
+	)
+	useCase.now = testNow
+	useCase.syncDelay = 0
+
+	return useCase, mocks
+}
+
+func (m *testMocks) expectNetworkHosts(networkHosts ...*entity.NetworkHost) {
+	enabled := true
+	m.networkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{Enabled: &enabled}).
+		Return(networkHosts, nil)
+}
+
+func testNetworkHosts() []*entity.NetworkHost {
+	return []*entity.NetworkHost{
+		{ID: 1, NetworkID: 10, Address: "*.corp.example.com"},
+		{ID: 2, NetworkID: 10, Address: "git.corp.example.com"},
+		{ID: 3, NetworkID: 20, Address: ".example.com"},
+		{ID: 4, NetworkID: 30, Address: "*.lab.example.org"},
+	}
+}
+
+func TestNew(t *testing.T) {
+	useCase, mocks := newTestUseCase(t)
+
+	assert.Equal(t, mocks.networkHostSetupUC, useCase.networkHostSetupUC)
+	assert.Equal(t, mocks.networkHostStorage, useCase.networkHostStorage)
+	assert.Equal(t, mocks.learnedIPStorage, useCase.learnedIPStorage)
+	assert.False(t, useCase.enabled)
+	assert.Equal(t, "127.0.0.1:53", useCase.upstream)
+	assert.Equal(t, time.Minute, useCase.minTTL)
+	assert.NotNil(t, useCase.exchange)
+}
+
+func TestUseCase_Observe(t *testing.T) {
+	response := func(t *testing.T) []byte {
+		t.Helper()
+
+		return testResponse(t, "git.corp.example.com", dnsmessage.RCodeSuccess,
+			aRecord("git.corp.example.com", [4]byte{10, 0, 0, 5}, 30),
+			aRecord("git.corp.example.com", [4]byte{10, 0, 0, 6}, 600),
+		)
+	}
+
+	t.Run("learns IPs of matching wildcards and schedules syncs of networks with new ones", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.expectNetworkHosts(testNetworkHosts()...)
+
+		// The corp wildcard already routes 10.0.0.5, 10.0.0.6 is new.
+		mocks.learnedIPStorage.EXPECT().
+			ListActive(gomock.Any(), []uint64{1}, testNow()).
+			Return([]*entity.LearnedIP{{NetworkHostID: 1, NetworkID: 10, IP: "10.0.0.5"}}, nil)
+		// The example.com wildcard of another network routes both already.
+		mocks.learnedIPStorage.EXPECT().
+			ListActive(gomock.Any(), []uint64{3}, testNow()).
+			Return([]*entity.LearnedIP{
+				{NetworkHostID: 3, NetworkID: 20, IP: "10.0.0.5"},
+				{NetworkHostID: 3, NetworkID: 20, IP: "10.0.0.6"},
+			}, nil)
+
+		var upserted []*entity.LearnedIP
+		mocks.learnedIPStorage.EXPECT().
+			Upsert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, learnedIP *entity.LearnedIP) error {
+				upserted = append(upserted, learnedIP)
+				return nil
+			}).
+			Times(4)
+		synced := make(chan struct{})
+		mocks.networkHostSetupUC.EXPECT().
+			SyncByNetworkID(gomock.Any(), uint64(10)).
+			DoAndReturn(func(context.Context, uint64) error {
+				close(synced)
+				return nil
+			})
+
+		err := useCase.Observe(context.Background(), response(t))
+
+		require.NoError(t, err)
+		waitFor(t, synced)
+		require.Len(t, upserted, 4)
+		assert.Equal(t, uint64(1), upserted[0].NetworkHostID)
+		assert.Equal(t, uint64(10), upserted[0].NetworkID)
+		assert.Equal(t, "git.corp.example.com", upserted[0].Name)
+		assert.Equal(t, "10.0.0.5", upserted[0].IP)
+		// Short TTLs are raised to the minimum, longer ones are kept.
+		assert.WithinDuration(t, time.Now().Add(time.Minute), upserted[0].ExpiresAt.Time, time.Second)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), upserted[1].ExpiresAt.Time, time.Second)
+		assert.Equal(t, uint64(3), upserted[2].NetworkHostID)
+	})
+
+	t.Run("answers without matching wildcards are ignored", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.expectNetworkHosts(testNetworkHosts()[3])
+
+		err := useCase.Observe(context.Background(), response(t))
+
+		require.NoError(t, err)
+	})
+
+	t.Run("answers without addresses don't list hosts", func(t *testing.T) {
+		useCase, _ := newTestUseCase(t)
+
+		err := useCase.Observe(
+			context.Background(),
+			testResponse(t, "missing.corp.example.com", dnsmessage.RCodeNameError),
+		)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("storage errors", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.expectNetworkHosts(testNetworkHosts()[0])
+		mocks.learnedIPStorage.EXPECT().ListActive(gomock.Any(), []uint64{1}, testNow()).Return(nil, nil)
+		mocks.learnedIPStorage.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(errors.New("disk full"))
+
+		err := useCase.Observe(context.Background(), response(t))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to store learned IP 10.0.0.5 of *.corp.example.com: disk full")
+	})
+
+	t.Run("answers in a burst sync their network once and don't wait for it", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		useCase.syncDelay = 50 * time.Millisecond
+		mocks.networkHostStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(testNetworkHosts()[:1], nil).Times(2)
+		mocks.learnedIPStorage.EXPECT().ListActive(gomock.Any(), []uint64{1}, testNow()).Return(nil, nil).Times(2)
+		mocks.learnedIPStorage.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+		synced := make(chan struct{})
+		mocks.networkHostSetupUC.EXPECT().
+			SyncByNetworkID(gomock.Any(), uint64(10)).
+			DoAndReturn(func(context.Context, uint64) error {
+				close(synced)
+				return errors.New("no VPN")
+			})
+
+		require.NoError(t, useCase.Observe(context.Background(), response(t)))
+		require.NoError(t, useCase.Observe(context.Background(), response(t)))
+
+		waitFor(t, synced)
+	})
+}
+
+func TestUseCase_ExpireAll(t *testing.T) {
+	t.Run("syncs networks that lost learned IPs", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.learnedIPStorage.EXPECT().DeleteExpired(gomock.Any(), testNow()).Return([]uint64{10, 20}, nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(10)).Return(errors.New("no VPN"))
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(20)).Return(nil)
+
+		err := useCase.ExpireAll(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to sync network 10: no VPN")
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.learnedIPStorage.EXPECT().DeleteExpired(gomock.Any(), testNow()).Return(nil, errors.New("locked"))
+
+		err := useCase.ExpireAll(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete expired learned IPs: locked")
+	})
+}
+
+func TestUseCase_resolve(t *testing.T) {
+	query := testResponse(t, "git.corp.example.com", dnsmessage.RCodeSuccess)
+
+	t.Run("unreachable upstream answers with a server failure", func(t *testing.T) {
+		useCase, _ := newTestUseCase(t)
+		useCase.exchange = func(context.Context, []byte) ([]byte, error) {
+			return nil, errors.New("timeout")
+		}
+
+		response, err := useCase.resolve(context.Background(), query)
+
+		require.NoError(t, err)
+		var message dnsmessage.Message
+		require.NoError(t, message.Unpack(response))
+		assert.Equal(t, dnsmessage.RCodeServerFailure, message.Header.RCode)
+	})
+}
+
+func TestUseCase_handle(t *testing.T) {
+	query := testResponse(t, "git.corp.example.com", dnsmessage.RCodeSuccess)
+	answer := testResponse(t, "git.corp.example.com", dnsmessage.RCodeSuccess,
+		aRecord("git.corp.example.com", [4]byte{10, 0, 0, 5}, 30),
+	)
+
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+	client, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer client.Close()
+
+	useCase, mocks := newTestUseCase(t)
+	useCase.exchange = func(context.Context, []byte) ([]byte, error) {
+		return answer, nil
+	}
+	// The answer is observed only once the client has it, a slow or failing observation doesn't hold it back.
+	replied := make(chan struct{})
+	mocks.networkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *entity.ListNetworkHostFilter) ([]*entity.NetworkHost, error) {
+			<-replied
+			return nil, errors.New("locked")
+		})
+
+	handled := make(chan struct{})
+	go func() {
+		useCase.handle(context.Background(), server, client.LocalAddr(), query)
+		close(handled)
+	}()
+
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, maxMessageSize)
+	n, _, err := client.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, answer, buf[:n])
+	close(replied)
+
+	waitFor(t, handled)
+}
+
+// waitFor fails the test unless done is closed within a second.
+func waitFor(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+	}
+}
+
+func TestUseCase_exchangeUpstream(t *testing.T) {
+	upstream, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer upstream.Close()
+
+	answer := testResponse(t, "git.corp.example.com", dnsmessage.RCodeSuccess,
+		aRecord("git.corp.example.com", [4]byte{10, 0, 0, 5}, 30),
+	)
+	go func() {
+		buf := make([]byte, maxMessageSize)
+		_, addr, readErr := upstream.ReadFrom(buf)
+		if readErr != nil {
+			return
+		}
+		_, _ = upstream.WriteTo(answer, addr)
+	}()
+
+	useCase, _ := newTestUseCase(t)
+	useCase.upstream = upstream.LocalAddr().String()
+
+	response, err := useCase.exchangeUpstream(context.Background(), []byte("query"))
+
+	require.NoError(t, err)
+	assert.Equal(t, answer, response)
+}
@@ -2,18 +2,38 @@ package host
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 
 	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
 	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
 )
 
 type UseCase struct {
-	hostStorage storage.Host
+	trm                trm.Manager
+	networkHostSetupUC usecase.NetworkHostSetup
+	hostStorage        storage.Host
+	networkStorage     storage.Network
+	networkHostStorage storage.NetworkHost
 }
 
-func New(hostStorage storage.Host) *UseCase {
+func New(
+	trm trm.Manager,
+	networkHostSetupUC usecase.NetworkHostSetup,
+	hostStorage storage.Host,
+	networkStorage storage.Network,
+	networkHostStorage storage.NetworkHost,
+) *UseCase {
 	return &UseCase{
-		hostStorage: hostStorage,
+		trm:                trm,
+		networkHostSetupUC: networkHostSetupUC,
+		hostStorage:        hostStorage,
+		networkStorage:     networkStorage,
+		networkHostStorage: networkHostStorage,
 	}
 }
 
@@ -25,6 +45,265 @@ func (u *UseCase) List(ctx context.Context, filter *entity.ListHostFilter) ([]*e
 	return u.hostStorage.List(ctx, filter)
 }
 
+// ListWithUsage lists library hosts together with the networks each of them is attached to.
+func (u *UseCase) ListWithUsage(
+	ctx context.Context,
+	filter *entity.ListHostFilter,
+) ([]*entity.HostWithUsage, error) {
+	hosts, err := u.hostStorage.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %w", err)
+	}
+
+	res := make([]*entity.HostWithUsage, 0, len(hosts))
+	if len(hosts) == 0 {
+		return res, nil
+	}
+
+	hostIDs := make([]uint64, 0, len(hosts))
+	for _, host := range hosts {
+		hostIDs = append(hostIDs, host.ID)
+	}
+
+	linkedHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{HostID: hostIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked network hosts: %w", err)
+	}
+
+	networkIDsByHostID := make(map[uint64]map[uint64]struct{}, len(hosts))
+	for _, linkedHost := range linkedHosts {
+		if networkIDsByHostID[*linkedHost.HostID] == nil {
+			networkIDsByHostID[*linkedHost.HostID] = make(map[uint64]struct{})
+		}
+		networkIDsByHostID[*linkedHost.HostID][linkedHost.NetworkID] = struct{}{}
+	}
+
+	var networks []*entity.Network
+	if len(linkedHosts) > 0 {
+		networks, err = u.networkStorage.List(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list networks: %w", err)
+		}
+	}
+
+	for _, host := range hosts {
+		hostWithUsage := &entity.HostWithUsage{
+			Host:   *host,
+			UsedIn: make([]*entity.Network, 0),
+		}
+		for _, network := range networks {
+			if _, ok := networkIDsByHostID[host.ID][network.ID]; ok {
+				hostWithUsage.UsedIn = append(hostWithUsage.UsedIn, network)
+			}
+		}
+
+		res = append(res, hostWithUsage)
+	}
+
+	return res, nil
+}
+
+// Update changes a library host and propagates the change to every network it is attached to.
+func (u *UseCase) Update(ctx context.Context, host *entity.Host) (*entity.Host, error) {
+	var (
+		res         *entity.Host
+		linkedHosts []*entity.NetworkHost
+	)
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		updatedHost, trErr := u.hostStorage.Update(ctx, host)
+		if trErr != nil {
+			return fmt.Errorf("failed to update host: %w", trErr)
+		}
+
+		res = updatedHost
+		linkedHosts, trErr = u.listLinkedHosts(ctx, updatedHost.ID)
+		if trErr != nil {
+			return trErr
+		}
+
+		if len(linkedHosts) == 0 {
+			return nil
+		}
+
+		trErr = u.networkHostStorage.UpdateByHostID(ctx, updatedHost)
+		if trErr != nil {
+			return fmt.Errorf("failed to update linked network hosts: %w", trErr)
+		}
+
+		return u.validateNetworks(ctx, linkedHosts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	err = u.syncNetworks(ctx, linkedHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Delete removes a library host and detaches it from every network it is attached to.
 func (u *UseCase) Delete(ctx context.Context, id uint64) error {
-	return u.hostStorage.Delete(ctx, id)
+	linkedHosts, err := u.listLinkedHosts(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(linkedHosts) == 0 {
+		return u.hostStorage.Delete(ctx, id)
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.deleteNetworkHosts(ctx, linkedHosts)
+		if trErr != nil {
+			return trErr
+		}
+
+		trErr = u.hostStorage.Delete(ctx, id)
+		if trErr != nil {
+			return fmt.Errorf("failed to delete host: %w", trErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	return u.syncNetworks(ctx, linkedHosts)
+}
+
+// AttachToNetwork routes a library host through a network. The network gets its own copy of
+// the host, linked to the library entry so later changes follow it.
+func (u *UseCase) AttachToNetwork(ctx context.Context, hostID, networkID uint64) (*entity.NetworkHost, error) {
+	host, err := u.hostStorage.Get(ctx, hostID)
+	if err != nil {
+		if errors.Is(err, errs.ErrHostNotFound) {
+			return nil, fmt.Errorf("host with ID %d not found: %w", hostID, err)
+		}
+		return nil, fmt.Errorf("failed to get host: %w", err)
+	}
+
+	_, err = u.networkStorage.Get(ctx, networkID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkNotFound) {
+			return nil, fmt.Errorf("network with ID %d not found: %w", networkID, err)
+		}
+		return nil, fmt.Errorf("failed to validate network: %w", err)
+	}
+
+	var res *entity.NetworkHost
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		addedHost, trErr := u.networkHostStorage.Add(ctx, &entity.NetworkHost{
+			NetworkID:   networkID,
+			Address:     host.Address,
+			Description: host.Description,
+			HostID:      &host.ID,
+			Enabled:     true,
+		})
+		if trErr != nil {
+			return fmt.Errorf("failed to add network host: %w", trErr)
+		}
+
+		res = addedHost
+		return u.validateNetworks(ctx, []*entity.NetworkHost{addedHost})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	err = u.networkHostSetupUC.SyncByNetworkID(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	return res, nil
+}
+
+// DetachFromNetwork stops routing a library host through a network.
+func (u *UseCase) DetachFromNetwork(ctx context.Context, hostID, networkID uint64) error {
+	linkedHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{
+		NetworkID: []uint64{networkID},
+		HostID:    []uint64{hostID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list linked network hosts: %w", err)
+	}
+
+	if len(linkedHosts) == 0 {
+		return nil
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		return u.deleteNetworkHosts(ctx, linkedHosts)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	return u.syncNetworks(ctx, linkedHosts)
+}
+
+func (u *UseCase) listLinkedHosts(ctx context.Context, hostID uint64) ([]*entity.NetworkHost, error) {
+	linkedHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{
+		HostID: []uint64{hostID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked network hosts: %w", err)
+	}
+
+	return linkedHosts, nil
+}
+
+func (u *UseCase) deleteNetworkHosts(ctx context.Context, networkHosts []*entity.NetworkHost) error {
+	for _, networkHost := range networkHosts {
+		err := u.networkHostStorage.Delete(ctx, networkHost.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete network host %s: %w", networkHost.Address, err)
+		}
+	}
+
+	return nil
+}
+
+// validateNetworks checks, inside the caller's transaction, that each network the given hosts belong to
+// still resolves its routes. The sync runs after the commit, so a change it couldn't route is rolled back here.
+func (u *UseCase) validateNetworks(ctx context.Context, networkHosts []*entity.NetworkHost) error {
+	for _, networkID := range networkIDs(networkHosts) {
+		err := u.networkHostSetupUC.ValidateByNetworkID(ctx, networkID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve network routes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// syncNetworks syncs each network the given hosts belong to, once.
+func (u *UseCase) syncNetworks(ctx context.Context, networkHosts []*entity.NetworkHost) error {
+	for _, networkID := range networkIDs(networkHosts) {
+		err := u.networkHostSetupUC.SyncByNetworkID(ctx, networkID)
+		if err != nil {
+			return fmt.Errorf("failed to sync network host setup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// networkIDs lists the networks the given hosts belong to, each once.
+func networkIDs(networkHosts []*entity.NetworkHost) []uint64 {
+	seen := make(map[uint64]struct{}, len(networkHosts))
+	ids := make([]uint64, 0, len(networkHosts))
+	for _, networkHost := range networkHosts {
+		if _, ok := seen[networkHost.NetworkID]; ok {
+			continue
+		}
+		seen[networkHost.NetworkID] = struct{}{}
+		ids = append(ids, networkHost.NetworkID)
+	}
+
+	return ids
 }
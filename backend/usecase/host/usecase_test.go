@@ -11,20 +11,67 @@ import (
 
 	"github.com/dmitrorlov/splitr/backend/entity"
 	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_trm "github.com/dmitrorlov/splitr/backend/mocks/trm"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
 )
 
 type contextKey string
 
+type testMocks struct {
+	trm                *mock_trm.MockManager
+	networkHostSetupUC *mock_usecase.MockNetworkHostSetup
+	hostStorage        *mock_storage.MockHost
+	networkStorage     *mock_storage.MockNetwork
+	networkHostStorage *mock_storage.MockNetworkHost
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testMocks) {
+	mocks := &testMocks{
+		trm:                mock_trm.NewMockManager(ctrl),
+		networkHostSetupUC: mock_usecase.NewMockNetworkHostSetup(ctrl),
+		hostStorage:        mock_storage.NewMockHost(ctrl),
+		networkStorage:     mock_storage.NewMockNetwork(ctrl),
+		networkHostStorage: mock_storage.NewMockNetworkHost(ctrl),
+	}
+
+	useCase := New(
+		mocks.trm,
+		mocks.networkHostSetupUC,
+		mocks.hostStorage,
+		mocks.networkStorage,
+		mocks.networkHostStorage,
+	)
+
+	return useCase, mocks
+}
+
+func (m *testMocks) expectTransaction() {
+	m.trm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func (m *testMocks) expectNoLinkedHosts(hostID uint64) {
+	m.networkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{HostID: []uint64{hostID}}).
+		Return([]*entity.NetworkHost{}, nil)
+}
+
 func TestNew(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockHostStorage := mock_storage.NewMockHost(ctrl)
-
-	useCase := New(mockHostStorage)
+	useCase, _ := newTestUseCase(ctrl)
 
 	require.NotNil(t, useCase)
+	assert.NotNil(t, useCase.trm)
+	assert.NotNil(t, useCase.networkHostSetupUC)
 	assert.NotNil(t, useCase.hostStorage)
+	assert.NotNil(t, useCase.networkStorage)
+	assert.NotNil(t, useCase.networkHostStorage)
 }
 
 func TestUseCase_Add(t *testing.T) {
@@ -107,10 +154,8 @@ func TestUseCase_Add(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockHostStorage := mock_storage.NewMockHost(ctrl)
-			tt.setupMocks(mockHostStorage)
-
-			useCase := New(mockHostStorage)
+			useCase, mocks := newTestUseCase(ctrl)
+			tt.setupMocks(mocks.hostStorage)
 
 			result, err := useCase.Add(context.Background(), tt.host)
 
@@ -326,10 +371,8 @@ func TestUseCase_List(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockHostStorage := mock_storage.NewMockHost(ctrl)
-			tt.setupMocks(mockHostStorage)
-
-			useCase := New(mockHostStorage)
+			useCase, mocks := newTestUseCase(ctrl)
+			tt.setupMocks(mocks.hostStorage)
 
 			result, err := useCase.List(context.Background(), tt.filter)
 
@@ -419,10 +462,9 @@ func TestUseCase_Delete(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			mockHostStorage := mock_storage.NewMockHost(ctrl)
-			tt.setupMocks(mockHostStorage)
-
-			useCase := New(mockHostStorage)
+			useCase, mocks := newTestUseCase(ctrl)
+			mocks.expectNoLinkedHosts(tt.hostID)
+			tt.setupMocks(mocks.hostStorage)
 
 			err := useCase.Delete(context.Background(), tt.hostID)
 
@@ -440,8 +482,8 @@ func TestUseCase_Methods_Context_Propagation(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockHostStorage := mock_storage.NewMockHost(ctrl)
-	useCase := New(mockHostStorage)
+	useCase, mocks := newTestUseCase(ctrl)
+	mockHostStorage := mocks.hostStorage
 
 	testCtx := context.WithValue(context.Background(), contextKey("test"), "value")
 
@@ -466,6 +508,7 @@ func TestUseCase_Methods_Context_Propagation(t *testing.T) {
 	})
 
 	t.Run("Delete propagates context", func(t *testing.T) {
+		mocks.expectNoLinkedHosts(1)
 		mockHostStorage.EXPECT().
 			Delete(gomock.Eq(testCtx), uint64(1)).
 			Return(nil)
@@ -479,8 +522,8 @@ func TestUseCase_Argument_Passing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockHostStorage := mock_storage.NewMockHost(ctrl)
-	useCase := New(mockHostStorage)
+	useCase, mocks := newTestUseCase(ctrl)
+	mockHostStorage := mocks.hostStorage
 
 	t.Run("Add passes correct host", func(t *testing.T) {
 		inputHost := &entity.Host{
@@ -512,6 +555,7 @@ func TestUseCase_Argument_Passing(t *testing.T) {
 
 	t.Run("Delete passes correct ID", func(t *testing.T) {
 		targetID := uint64(42)
+		mocks.expectNoLinkedHosts(targetID)
 
 		mockHostStorage.EXPECT().
 			Delete(gomock.Any(), gomock.Eq(targetID)).
@@ -526,8 +570,8 @@ func TestUseCase_Edge_Cases(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockHostStorage := mock_storage.NewMockHost(ctrl)
-	useCase := New(mockHostStorage)
+	useCase, mocks := newTestUseCase(ctrl)
+	mockHostStorage := mocks.hostStorage
 
 	t.Run("Add with nil host - storage handles it", func(t *testing.T) {
 		mockHostStorage.EXPECT().
@@ -551,6 +595,7 @@ func TestUseCase_Edge_Cases(t *testing.T) {
 	})
 
 	t.Run("Delete with zero ID", func(t *testing.T) {
+		mocks.expectNoLinkedHosts(0)
 		mockHostStorage.EXPECT().
 			Delete(gomock.Any(), uint64(0)).
 			Return(nil)
@@ -560,6 +605,326 @@ func TestUseCase_Edge_Cases(t *testing.T) {
 	})
 }
 
+func TestUseCase_ListWithUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+
+	hostID := uint64(1)
+	mocks.hostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListHostFilter{Search: "example"}).
+		Return([]*entity.Host{
+			{ID: 1, Address: "git.example.com"},
+			{ID: 2, Address: "wiki.example.com"},
+		}, nil)
+	mocks.networkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{HostID: []uint64{1, 2}}).
+		Return([]*entity.NetworkHost{
+			{ID: 10, NetworkID: 100, Address: "git.example.com", HostID: &hostID},
+			{ID: 11, NetworkID: 200, Address: "git.example.com", HostID: &hostID},
+		}, nil)
+	mocks.networkStorage.EXPECT().
+		List(gomock.Any(), nil).
+		Return([]*entity.Network{
+			{ID: 100, Name: "Corp VPN"},
+			{ID: 200, Name: "Lab VPN"},
+			{ID: 300, Name: "Home VPN"},
+		}, nil)
+
+	result, err := useCase.ListWithUsage(context.Background(), &entity.ListHostFilter{Search: "example"})
+
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "git.example.com", result[0].Address)
+	require.Len(t, result[0].UsedIn, 2)
+	assert.Equal(t, "Corp VPN", result[0].UsedIn[0].Name)
+	assert.Equal(t, "Lab VPN", result[0].UsedIn[1].Name)
+	assert.Equal(t, "wiki.example.com", result[1].Address)
+	assert.Empty(t, result[1].UsedIn)
+}
+
+func TestUseCase_ListWithUsage_NoLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+
+	mocks.hostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.Host{{ID: 1, Address: "git.example.com"}}, nil)
+	mocks.networkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{}, nil)
+
+	result, err := useCase.ListWithUsage(context.Background(), nil)
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Empty(t, result[0].UsedIn)
+}
+
+func TestUseCase_Update(t *testing.T) {
+	hostID := uint64(1)
+	host := &entity.Host{ID: hostID, Address: "git2.example.com", Description: stringPtr("Git")}
+
+	t.Run("propagates to linked network hosts and syncs each network once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostStorage.EXPECT().Update(gomock.Any(), host).Return(host, nil)
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), &entity.ListNetworkHostFilter{HostID: []uint64{hostID}}).
+			Return([]*entity.NetworkHost{
+				{ID: 10, NetworkID: 100, HostID: &hostID},
+				{ID: 11, NetworkID: 200, HostID: &hostID},
+			}, nil)
+		mocks.networkHostStorage.EXPECT().UpdateByHostID(gomock.Any(), host).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), uint64(100)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), uint64(200)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(100)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(200)).Return(nil)
+
+		result, err := useCase.Update(context.Background(), host)
+
+		require.NoError(t, err)
+		assert.Equal(t, host, result)
+	})
+
+	t.Run("unlinked host doesn't sync", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostStorage.EXPECT().Update(gomock.Any(), host).Return(host, nil)
+		mocks.expectNoLinkedHosts(hostID)
+
+		result, err := useCase.Update(context.Background(), host)
+
+		require.NoError(t, err)
+		assert.Equal(t, host, result)
+	})
+
+	t.Run("address taken in a linked network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostStorage.EXPECT().Update(gomock.Any(), host).Return(host, nil)
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			Return([]*entity.NetworkHost{{ID: 10, NetworkID: 100, HostID: &hostID}}, nil)
+		mocks.networkHostStorage.EXPECT().
+			UpdateByHostID(gomock.Any(), host).
+			Return(errs.ErrNetworkHostAlreadyExists)
+
+		result, err := useCase.Update(context.Background(), host)
+
+		require.ErrorIs(t, err, errs.ErrNetworkHostAlreadyExists)
+		assert.Nil(t, result)
+	})
+
+	t.Run("unresolvable address is rolled back and not synced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostStorage.EXPECT().Update(gomock.Any(), host).Return(host, nil)
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			Return([]*entity.NetworkHost{{ID: 10, NetworkID: 100, HostID: &hostID}}, nil)
+		mocks.networkHostStorage.EXPECT().UpdateByHostID(gomock.Any(), host).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().
+			ValidateByNetworkID(gomock.Any(), uint64(100)).
+			Return(errors.New("no such host"))
+
+		result, err := useCase.Update(context.Background(), host)
+
+		require.EqualError(t, err, "failed to apply transaction: failed to resolve network routes: no such host")
+		assert.Nil(t, result)
+	})
+
+	t.Run("host not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostStorage.EXPECT().Update(gomock.Any(), host).Return(nil, errs.ErrHostNotFound)
+
+		result, err := useCase.Update(context.Background(), host)
+
+		require.ErrorIs(t, err, errs.ErrHostNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestUseCase_Delete_WithLinkedHosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hostID := uint64(1)
+	useCase, mocks := newTestUseCase(ctrl)
+	mocks.networkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{HostID: []uint64{hostID}}).
+		Return([]*entity.NetworkHost{
+			{ID: 10, NetworkID: 100, Address: "git.example.com", HostID: &hostID},
+		}, nil)
+	mocks.expectTransaction()
+	mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(10)).Return(nil)
+	mocks.hostStorage.EXPECT().Delete(gomock.Any(), hostID).Return(nil)
+	mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(100)).Return(nil)
+
+	err := useCase.Delete(context.Background(), hostID)
+
+	require.NoError(t, err)
+}
+
+func TestUseCase_AttachToNetwork(t *testing.T) {
+	hostID := uint64(1)
+	networkID := uint64(100)
+	host := &entity.Host{ID: hostID, Address: "git.example.com", Description: stringPtr("Git")}
+
+	t.Run("adds a linked network host and syncs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostStorage.EXPECT().Get(gomock.Any(), hostID).Return(host, nil)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), networkID).Return(&entity.Network{ID: networkID}, nil)
+		mocks.expectTransaction()
+		expectedHost := &entity.NetworkHost{
+			NetworkID:   networkID,
+			Address:     "git.example.com",
+			Description: stringPtr("Git"),
+			HostID:      &hostID,
+			Enabled:     true,
+		}
+		mocks.networkHostStorage.EXPECT().
+			Add(gomock.Any(), expectedHost).
+			DoAndReturn(func(_ context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error) {
+				networkHost.ID = 10
+				return networkHost, nil
+			})
+		mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), networkID).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), networkID).Return(nil)
+
+		result, err := useCase.AttachToNetwork(context.Background(), hostID, networkID)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), result.ID)
+		require.NotNil(t, result.HostID)
+		assert.Equal(t, hostID, *result.HostID)
+	})
+
+	t.Run("host not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostStorage.EXPECT().Get(gomock.Any(), hostID).Return(nil, errs.ErrHostNotFound)
+
+		result, err := useCase.AttachToNetwork(context.Background(), hostID, networkID)
+
+		require.ErrorIs(t, err, errs.ErrHostNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("network not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostStorage.EXPECT().Get(gomock.Any(), hostID).Return(host, nil)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), networkID).Return(nil, errs.ErrNetworkNotFound)
+
+		result, err := useCase.AttachToNetwork(context.Background(), hostID, networkID)
+
+		require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("address already in the network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostStorage.EXPECT().Get(gomock.Any(), hostID).Return(host, nil)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), networkID).Return(&entity.Network{ID: networkID}, nil)
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().
+			Add(gomock.Any(), gomock.Any()).
+			Return(nil, errs.ErrNetworkHostAlreadyExists)
+
+		result, err := useCase.AttachToNetwork(context.Background(), hostID, networkID)
+
+		require.ErrorIs(t, err, errs.ErrNetworkHostAlreadyExists)
+		assert.Nil(t, result)
+	})
+}
+
+func TestUseCase_DetachFromNetwork(t *testing.T) {
+	hostID := uint64(1)
+	networkID := uint64(100)
+	filter := &entity.ListNetworkHostFilter{NetworkID: []uint64{networkID}, HostID: []uint64{hostID}}
+
+	t.Run("removes the linked network host and syncs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), filter).
+			Return([]*entity.NetworkHost{{ID: 10, NetworkID: networkID, HostID: &hostID}}, nil)
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(10)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), networkID).Return(nil)
+
+		err := useCase.DetachFromNetwork(context.Background(), hostID, networkID)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("not attached is a no-op", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), filter).
+			Return([]*entity.NetworkHost{}, nil)
+
+		err := useCase.DetachFromNetwork(context.Background(), hostID, networkID)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("sync failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), filter).
+			Return([]*entity.NetworkHost{{ID: 10, NetworkID: networkID, HostID: &hostID}}, nil)
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(10)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().
+			SyncByNetworkID(gomock.Any(), networkID).
+			Return(errors.New("networksetup failed"))
+
+		err := useCase.DetachFromNetwork(context.Background(), hostID, networkID)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "networksetup failed")
+	})
+}
+
 // stringPtr is a helper function to create string pointers for tests.
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,223 @@
+package hostgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+type UseCase struct {
+	trm                trm.Manager
+	networkHostSetupUC usecase.NetworkHostSetup
+	networkStorage     storage.Network
+	hostGroupStorage   storage.HostGroup
+}
+
+func New(
+	trm trm.Manager,
+	networkHostSetupUC usecase.NetworkHostSetup,
+	networkStorage storage.Network,
+	hostGroupStorage storage.HostGroup,
+) *UseCase {
+	return &UseCase{
+		trm:                trm,
+		networkHostSetupUC: networkHostSetupUC,
+		networkStorage:     networkStorage,
+		hostGroupStorage:   hostGroupStorage,
+	}
+}
+
+func (u *UseCase) Add(ctx context.Context, hostGroup *entity.HostGroup) (*entity.HostGroup, error) {
+	return u.hostGroupStorage.Add(ctx, hostGroup)
+}
+
+func (u *UseCase) List(ctx context.Context, filter *entity.ListHostGroupFilter) ([]*entity.HostGroup, error) {
+	return u.hostGroupStorage.List(ctx, filter)
+}
+
+// Delete removes a host group and re-syncs every network it was attached to.
+func (u *UseCase) Delete(ctx context.Context, id uint64) error {
+	var networkIDs []uint64
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		var trErr error
+		networkIDs, trErr = u.hostGroupStorage.ListNetworkIDs(ctx, id)
+		if trErr != nil {
+			return fmt.Errorf("failed to list attached networks: %w", trErr)
+		}
+
+		trErr = u.hostGroupStorage.Delete(ctx, id)
+		if trErr != nil {
+			return fmt.Errorf("failed to delete host group: %w", trErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	return u.syncNetworks(ctx, networkIDs)
+}
+
+func (u *UseCase) AddHost(
+	ctx context.Context,
+	hostGroupHost *entity.HostGroupHost,
+) (*entity.HostGroupHost, error) {
+	var (
+		res        *entity.HostGroupHost
+		networkIDs []uint64
+	)
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		addedHost, trErr := u.hostGroupStorage.AddHost(ctx, hostGroupHost)
+		if trErr != nil {
+			return fmt.Errorf("failed to add host group host: %w", trErr)
+		}
+
+		res = addedHost
+		networkIDs, trErr = u.hostGroupStorage.ListNetworkIDs(ctx, hostGroupHost.HostGroupID)
+		if trErr != nil {
+			return fmt.Errorf("failed to list attached networks: %w", trErr)
+		}
+
+		return u.validateNetworks(ctx, networkIDs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	err = u.syncNetworks(ctx, networkIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (u *UseCase) ListHosts(ctx context.Context, hostGroupID uint64) ([]*entity.HostGroupHost, error) {
+	return u.hostGroupStorage.ListHosts(ctx, &entity.ListHostGroupHostFilter{
+		HostGroupID: []uint64{hostGroupID},
+	})
+}
+
+func (u *UseCase) DeleteHost(ctx context.Context, id uint64) error {
+	hostGroupHost, err := u.hostGroupStorage.GetHost(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrHostGroupHostNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get host group host: %w", err)
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.hostGroupStorage.DeleteHost(ctx, hostGroupHost.ID)
+		if trErr != nil {
+			return fmt.Errorf("failed to delete host group host: %w", trErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	return u.syncAttachedNetworks(ctx, hostGroupHost.HostGroupID)
+}
+
+// AttachToNetwork routes every address of a host group through a network.
+func (u *UseCase) AttachToNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	err := u.validate(ctx, hostGroupID, networkID)
+	if err != nil {
+		return err
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.hostGroupStorage.AttachToNetwork(ctx, hostGroupID, networkID)
+		if trErr != nil {
+			return fmt.Errorf("failed to attach host group: %w", trErr)
+		}
+
+		return u.validateNetworks(ctx, []uint64{networkID})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	return u.syncNetworks(ctx, []uint64{networkID})
+}
+
+func (u *UseCase) DetachFromNetwork(ctx context.Context, hostGroupID, networkID uint64) error {
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.hostGroupStorage.DetachFromNetwork(ctx, hostGroupID, networkID)
+		if trErr != nil {
+			return fmt.Errorf("failed to detach host group: %w", trErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	return u.syncNetworks(ctx, []uint64{networkID})
+}
+
+func (u *UseCase) validate(ctx context.Context, hostGroupID, networkID uint64) error {
+	_, err := u.hostGroupStorage.Get(ctx, hostGroupID)
+	if err != nil {
+		if errors.Is(err, errs.ErrHostGroupNotFound) {
+			return fmt.Errorf("host group with ID %d not found: %w", hostGroupID, err)
+		}
+		return fmt.Errorf("failed to validate host group: %w", err)
+	}
+
+	_, err = u.networkStorage.Get(ctx, networkID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkNotFound) {
+			return fmt.Errorf("network with ID %d not found: %w", networkID, err)
+		}
+		return fmt.Errorf("failed to validate network: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UseCase) syncAttachedNetworks(ctx context.Context, hostGroupID uint64) error {
+	networkIDs, err := u.hostGroupStorage.ListNetworkIDs(ctx, hostGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to list attached networks: %w", err)
+	}
+
+	return u.syncNetworks(ctx, networkIDs)
+}
+
+// validateNetworks checks, inside the caller's transaction, that the given networks still resolve their routes.
+// The sync runs after the commit, so a change it couldn't route is rolled back here.
+func (u *UseCase) validateNetworks(ctx context.Context, networkIDs []uint64) error {
+	for _, networkID := range networkIDs {
+		err := u.networkHostSetupUC.ValidateByNetworkID(ctx, networkID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve network routes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *UseCase) syncNetworks(ctx context.Context, networkIDs []uint64) error {
+	for _, networkID := range networkIDs {
+		err := u.networkHostSetupUC.SyncByNetworkID(ctx, networkID)
+		if err != nil {
+			return fmt.Errorf("failed to sync network host setup: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package hostgroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_trm "github.com/dmitrorlov/splitr/backend/mocks/trm"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type testMocks struct {
+	trm                *mock_trm.MockManager
+	networkHostSetupUC *mock_usecase.MockNetworkHostSetup
+	networkStorage     *mock_storage.MockNetwork
+	hostGroupStorage   *mock_storage.MockHostGroup
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testMocks) {
+	mocks := &testMocks{
+		trm:                mock_trm.NewMockManager(ctrl),
+		networkHostSetupUC: mock_usecase.NewMockNetworkHostSetup(ctrl),
+		networkStorage:     mock_storage.NewMockNetwork(ctrl),
+		hostGroupStorage:   mock_storage.NewMockHostGroup(ctrl),
+	}
+
+	useCase := New(mocks.trm, mocks.networkHostSetupUC, mocks.networkStorage, mocks.hostGroupStorage)
+
+	return useCase, mocks
+}
+
+func (m *testMocks) expectTransaction() {
+	m.trm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+
+	require.NotNil(t, useCase)
+	assert.Equal(t, mocks.trm, useCase.trm)
+	assert.Equal(t, mocks.networkHostSetupUC, useCase.networkHostSetupUC)
+	assert.Equal(t, mocks.networkStorage, useCase.networkStorage)
+	assert.Equal(t, mocks.hostGroupStorage, useCase.hostGroupStorage)
+}
+
+func TestUseCase_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+
+	hostGroup := &entity.HostGroup{Name: "Build farm"}
+	mocks.hostGroupStorage.EXPECT().
+		Add(gomock.Any(), hostGroup).
+		Return(&entity.HostGroup{ID: 1, Name: "Build farm"}, nil)
+
+	result, err := useCase.Add(context.Background(), hostGroup)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), result.ID)
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	t.Run("syncs every attached network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().ListNetworkIDs(gomock.Any(), uint64(1)).Return([]uint64{10, 20}, nil)
+		mocks.hostGroupStorage.EXPECT().Delete(gomock.Any(), uint64(1)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(10)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(20)).Return(nil)
+
+		err := useCase.Delete(context.Background(), 1)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().ListNetworkIDs(gomock.Any(), uint64(1)).Return(nil, nil)
+		mocks.hostGroupStorage.EXPECT().Delete(gomock.Any(), uint64(1)).Return(errors.New("database locked"))
+
+		err := useCase.Delete(context.Background(), 1)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete host group: database locked")
+	})
+}
+
+func TestUseCase_AddHost(t *testing.T) {
+	hostGroupHost := &entity.HostGroupHost{HostGroupID: 1, Address: "ci.example.com"}
+
+	t.Run("syncs attached networks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().
+			AddHost(gomock.Any(), hostGroupHost).
+			Return(&entity.HostGroupHost{ID: 10, HostGroupID: 1, Address: "ci.example.com"}, nil)
+		mocks.hostGroupStorage.EXPECT().ListNetworkIDs(gomock.Any(), uint64(1)).Return([]uint64{10}, nil)
+		mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), uint64(10)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(10)).Return(nil)
+
+		result, err := useCase.AddHost(context.Background(), hostGroupHost)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), result.ID)
+	})
+
+	t.Run("unresolvable address is rolled back and not synced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().
+			AddHost(gomock.Any(), hostGroupHost).
+			Return(&entity.HostGroupHost{ID: 10, HostGroupID: 1, Address: "ci.example.com"}, nil)
+		mocks.hostGroupStorage.EXPECT().ListNetworkIDs(gomock.Any(), uint64(1)).Return([]uint64{10}, nil)
+		mocks.networkHostSetupUC.EXPECT().
+			ValidateByNetworkID(gomock.Any(), uint64(10)).
+			Return(errors.New("no such host"))
+
+		result, err := useCase.AddHost(context.Background(), hostGroupHost)
+
+		require.EqualError(t, err, "failed to apply transaction: failed to resolve network routes: no such host")
+		assert.Nil(t, result)
+	})
+
+	t.Run("duplicate address", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().
+			AddHost(gomock.Any(), hostGroupHost).
+			Return(nil, errs.ErrHostGroupHostAlreadyExists)
+
+		result, err := useCase.AddHost(context.Background(), hostGroupHost)
+
+		require.ErrorIs(t, err, errs.ErrHostGroupHostAlreadyExists)
+		assert.Nil(t, result)
+	})
+}
+
+func TestUseCase_ListHosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+
+	hosts := []*entity.HostGroupHost{{ID: 10, HostGroupID: 1, Address: "ci.example.com"}}
+	mocks.hostGroupStorage.EXPECT().
+		ListHosts(gomock.Any(), &entity.ListHostGroupHostFilter{HostGroupID: []uint64{1}}).
+		Return(hosts, nil)
+
+	result, err := useCase.ListHosts(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, hosts, result)
+}
+
+func TestUseCase_DeleteHost(t *testing.T) {
+	t.Run("syncs attached networks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostGroupStorage.EXPECT().
+			GetHost(gomock.Any(), uint64(10)).
+			Return(&entity.HostGroupHost{ID: 10, HostGroupID: 1}, nil)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().DeleteHost(gomock.Any(), uint64(10)).Return(nil)
+		mocks.hostGroupStorage.EXPECT().ListNetworkIDs(gomock.Any(), uint64(1)).Return([]uint64{20}, nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(20)).Return(nil)
+
+		err := useCase.DeleteHost(context.Background(), 10)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing host is a no-op", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostGroupStorage.EXPECT().
+			GetHost(gomock.Any(), uint64(10)).
+			Return(nil, errs.ErrHostGroupHostNotFound)
+
+		err := useCase.DeleteHost(context.Background(), 10)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestUseCase_AttachToNetwork(t *testing.T) {
+	t.Run("attaches and syncs the network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostGroupStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(&entity.HostGroup{ID: 1}, nil)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(2)).Return(&entity.Network{ID: 2}, nil)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().AttachToNetwork(gomock.Any(), uint64(1), uint64(2)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), uint64(2)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(2)).Return(nil)
+
+		err := useCase.AttachToNetwork(context.Background(), 1, 2)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("host group not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostGroupStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(nil, errs.ErrHostGroupNotFound)
+
+		err := useCase.AttachToNetwork(context.Background(), 1, 2)
+
+		require.ErrorIs(t, err, errs.ErrHostGroupNotFound)
+	})
+
+	t.Run("network not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostGroupStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(&entity.HostGroup{ID: 1}, nil)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(2)).Return(nil, errs.ErrNetworkNotFound)
+
+		err := useCase.AttachToNetwork(context.Background(), 1, 2)
+
+		require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+	})
+
+	t.Run("sync failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.hostGroupStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(&entity.HostGroup{ID: 1}, nil)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(2)).Return(&entity.Network{ID: 2}, nil)
+		mocks.expectTransaction()
+		mocks.hostGroupStorage.EXPECT().AttachToNetwork(gomock.Any(), uint64(1), uint64(2)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), uint64(2)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().
+			SyncByNetworkID(gomock.Any(), uint64(2)).
+			Return(errors.New("networksetup failed"))
+
+		err := useCase.AttachToNetwork(context.Background(), 1, 2)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "networksetup failed")
+	})
+}
+
+func TestUseCase_DetachFromNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+	mocks.expectTransaction()
+	mocks.hostGroupStorage.EXPECT().DetachFromNetwork(gomock.Any(), uint64(1), uint64(2)).Return(nil)
+	mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(2)).Return(nil)
+
+	err := useCase.DetachFromNetwork(context.Background(), 1, 2)
+
+	require.NoError(t, err)
+}
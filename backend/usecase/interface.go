@@ -21,25 +21,69 @@ type CommandExecutor interface {
 		network *entity.Network,
 		networkHostSetupList []*entity.NetworkHostSetup,
 	) error
+	SetServiceAdditionalRoutes(
+		ctx context.Context,
+		networkService entity.NetworkService,
+		networkHostSetupList []*entity.NetworkHostSetup,
+	) error
+	GetNetworkAdditionalRoutes(ctx context.Context, network *entity.Network) ([]*entity.AdditionalRoute, error)
+	GetServiceAdditionalRoutes(
+		ctx context.Context,
+		networkService entity.NetworkService,
+	) ([]*entity.AdditionalRoute, error)
+	SetNetworkAdditionalRoutesCommand(
+		network *entity.Network,
+		networkHostSetupList []*entity.NetworkHostSetup,
+	) *entity.Command
+	SetServiceAdditionalRoutesCommand(
+		networkService entity.NetworkService,
+		networkHostSetupList []*entity.NetworkHostSetup,
+	) *entity.Command
+	RouteCommands(action entity.RouteAction, networkHostSetupList []*entity.NetworkHostSetup) []*entity.Command
 	ListVPN(ctx context.Context) ([]entity.VPNService, error)
 	GetCurrentVPN(ctx context.Context) (entity.VPNService, error)
+	GetVPNServerAddress(ctx context.Context, vpnService entity.VPNService) (string, error)
 	OpenInFinder(ctx context.Context, path string) error
 }
 
+type Simulation interface {
+	ListSimulatedVPN(ctx context.Context) ([]*entity.SimulatedVPN, error)
+	ConnectVPN(ctx context.Context, vpnService entity.VPNService) error
+	DisconnectVPN(ctx context.Context, vpnService entity.VPNService) error
+}
+
 type CommandRunner interface {
-	Run(ctx context.Context, name string, args ...string) ([]string, error)
+	Run(ctx context.Context, name string, args ...string) (*entity.CommandResult, error)
 }
 
 type Host interface {
 	Add(ctx context.Context, host *entity.Host) (*entity.Host, error)
 	List(ctx context.Context, filter *entity.ListHostFilter) ([]*entity.Host, error)
+	ListWithUsage(ctx context.Context, filter *entity.ListHostFilter) ([]*entity.HostWithUsage, error)
+	Update(ctx context.Context, host *entity.Host) (*entity.Host, error)
 	Delete(ctx context.Context, id uint64) error
+	AttachToNetwork(ctx context.Context, hostID, networkID uint64) (*entity.NetworkHost, error)
+	DetachFromNetwork(ctx context.Context, hostID, networkID uint64) error
+}
+
+type HostGroup interface {
+	Add(ctx context.Context, hostGroup *entity.HostGroup) (*entity.HostGroup, error)
+	List(ctx context.Context, filter *entity.ListHostGroupFilter) ([]*entity.HostGroup, error)
+	Delete(ctx context.Context, id uint64) error
+	AddHost(ctx context.Context, hostGroupHost *entity.HostGroupHost) (*entity.HostGroupHost, error)
+	ListHosts(ctx context.Context, hostGroupID uint64) ([]*entity.HostGroupHost, error)
+	DeleteHost(ctx context.Context, id uint64) error
+	AttachToNetwork(ctx context.Context, hostGroupID, networkID uint64) error
+	DetachFromNetwork(ctx context.Context, hostGroupID, networkID uint64) error
 }
 
 type Network interface {
 	Add(ctx context.Context, network *entity.Network) (*entity.Network, error)
 	List(ctx context.Context, filter *entity.ListNetworkFilter) ([]*entity.NetworkWithStatus, error)
 	Delete(ctx context.Context, id uint64) error
+	SetRoutingMode(ctx context.Context, id uint64, mode entity.RoutingMode) (*entity.Network, error)
+	SetDNS(ctx context.Context, id uint64, domains, nameservers []string) (*entity.Network, error)
+	SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) (*entity.Network, error)
 
 	ListVPNServices(ctx context.Context) ([]entity.VPNService, error)
 }
@@ -48,18 +92,69 @@ type NetworkHost interface {
 	Add(ctx context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error)
 	List(ctx context.Context, filter *entity.ListNetworkHostFilter) ([]*entity.NetworkHost, error)
 	Delete(ctx context.Context, id uint64) error
+	SetEnabled(ctx context.Context, id uint64, enabled bool) (*entity.NetworkHost, error)
+	SetPinnedIPs(
+		ctx context.Context,
+		id uint64,
+		pinnedIPs []string,
+		mode entity.NetworkHostPinMode,
+	) (*entity.NetworkHost, error)
+	SetRouteOverride(ctx context.Context, id uint64, override entity.RouteOverride) (*entity.NetworkHost, error)
 	ExportByNetworkIDForContext(
 		ctx context.Context,
 		networkID uint64,
 	) (*entity.NetworkHostContextExportPayload, error)
 	ImportByNetworkIDFromJSON(ctx context.Context, networkID uint64, jsonData string) error
+	ImportByNetworkID(ctx context.Context, networkID uint64, hostDTOs []entity.NetworkHostDTO) error
+	ExportPACByNetworkID(
+		ctx context.Context,
+		networkID uint64,
+		proxy entity.PACProxy,
+	) (*entity.PACFile, error)
+}
+
+type NetworkHostImport interface {
+	PreviewFromSystemRoutes(
+		ctx context.Context,
+		networkID uint64,
+		reverseResolve bool,
+	) (*entity.NetworkHostImportPreview, error)
+	PreviewFromSSHConfig(
+		ctx context.Context,
+		networkID uint64,
+		includeKnownHosts bool,
+	) (*entity.NetworkHostImportPreview, error)
+}
+
+type NetworkSubscription interface {
+	Add(ctx context.Context, networkID uint64, url string) (*entity.NetworkSubscription, error)
+	List(ctx context.Context, networkID uint64) ([]*entity.NetworkSubscription, error)
+	Delete(ctx context.Context, id uint64) error
+	Refresh(ctx context.Context, id uint64) error
+	RefreshAll(ctx context.Context) error
 }
 
 type NetworkHostSetup interface {
 	SyncByNetworkID(ctx context.Context, network uint64) error
+	SyncByNetworkIDWithResult(ctx context.Context, networkID uint64) (*entity.SyncResult, error)
 	ResetByNetworkID(ctx context.Context, networkID uint64) error
+	ExportScriptsByNetworkID(ctx context.Context, networkID uint64) (*entity.RouteScripts, error)
+	PreviewByNetworkID(ctx context.Context, networkID uint64) ([]*entity.RoutePreview, error)
+	PreviewByAddress(ctx context.Context, networkID uint64, address string) ([]*entity.NetworkHostSetup, error)
+	ValidateByNetworkID(ctx context.Context, networkID uint64) error
+}
+
+type ScopedDNS interface {
+	ApplyByNetwork(ctx context.Context, network *entity.Network) ([]string, error)
+	RemoveByNetworkID(ctx context.Context, networkID uint64) error
+}
+
+type RouteConflict interface {
+	Analyze(ctx context.Context) ([]*entity.RouteConflict, error)
+	AnalyzeByNetworkID(ctx context.Context, networkID uint64) ([]*entity.RouteConflict, error)
+	CheckAddress(ctx context.Context, networkID uint64, address string) ([]*entity.RouteConflict, error)
 }
 
 type Update interface {
-	CheckForUpdates() (*entity.UpdateInfo, error)
+	CheckForUpdates(ctx context.Context) (*entity.UpdateInfo, error)
 }
@@ -65,6 +65,94 @@ func (u *UseCase) Delete(ctx context.Context, id uint64) error {
 	return u.networkStorage.Delete(ctx, id)
 }
 
+// SetRoutingMode switches which traffic of a network goes through the VPN. The routes of the previous mode
+// are reset before the change and the network is synced in the new mode afterwards.
+func (u *UseCase) SetRoutingMode(
+	ctx context.Context,
+	id uint64,
+	mode entity.RoutingMode,
+) (*entity.Network, error) {
+	network, err := u.networkStorage.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network by id %d: %w", id, err)
+	}
+
+	if network.RoutingMode == mode {
+		return network, nil
+	}
+
+	if err = u.networkHostSetupUC.ResetByNetworkID(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to reset network host setup: %w", err)
+	}
+
+	if err = u.networkStorage.SetRoutingMode(ctx, id, mode); err != nil {
+		return nil, fmt.Errorf("failed to set routing mode: %w", err)
+	}
+	network.RoutingMode = mode
+
+	if err = u.networkHostSetupUC.SyncByNetworkID(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	return network, nil
+}
+
+// SetDNS sets the domains resolved through the network's nameservers and syncs the network,
+// which writes their resolver files while it is the active VPN.
+func (u *UseCase) SetDNS(ctx context.Context, id uint64, domains, nameservers []string) (*entity.Network, error) {
+	network, err := u.networkStorage.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network by id %d: %w", id, err)
+	}
+
+	if err = network.SetDNS(domains, nameservers); err != nil {
+		return nil, err
+	}
+
+	if err = u.networkStorage.SetDNS(ctx, id, network.DNSDomains, network.DNSNameservers); err != nil {
+		return nil, fmt.Errorf("failed to set DNS: %w", err)
+	}
+
+	if err = u.networkHostSetupUC.SyncByNetworkID(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	return network, nil
+}
+
+// SetRouteOverride sets the router, subnet mask or interface sync uses for the network instead of detecting them.
+// Like a routing mode switch, the routes set with the previous values are reset before the change
+// and the network is synced afterwards.
+func (u *UseCase) SetRouteOverride(
+	ctx context.Context,
+	id uint64,
+	override entity.RouteOverride,
+) (*entity.Network, error) {
+	network, err := u.networkStorage.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network by id %d: %w", id, err)
+	}
+
+	if network.RouteOverride() == override {
+		return network, nil
+	}
+
+	if err = u.networkHostSetupUC.ResetByNetworkID(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to reset network host setup: %w", err)
+	}
+
+	if err = u.networkStorage.SetRouteOverride(ctx, id, override); err != nil {
+		return nil, fmt.Errorf("failed to set route override: %w", err)
+	}
+	network.SetRouteOverride(override)
+
+	if err = u.networkHostSetupUC.SyncByNetworkID(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	return network, nil
+}
+
 func (u *UseCase) ListVPNServices(ctx context.Context) ([]entity.VPNService, error) {
 	vpnServices, err := u.commandExecutorUC.ListVPN(ctx)
 	if err != nil {
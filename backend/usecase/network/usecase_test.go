@@ -331,6 +331,328 @@ func TestUseCase_Delete(t *testing.T) {
 	}
 }
 
+func TestUseCase_SetRoutingMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(*mock_usecase.MockNetworkHostSetup, *mock_storage.MockNetwork)
+		expectedMode  entity.RoutingMode
+		expectedError string
+	}{
+		{
+			name: "switches mode and resyncs",
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				gomock.InOrder(
+					mockStorage.EXPECT().
+						Get(gomock.Any(), uint64(1)).
+						Return(&entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeSplit}, nil),
+					mockHostSetup.EXPECT().
+						ResetByNetworkID(gomock.Any(), uint64(1)).
+						Return(nil),
+					mockStorage.EXPECT().
+						SetRoutingMode(gomock.Any(), uint64(1), entity.RoutingModeInverse).
+						Return(nil),
+					mockHostSetup.EXPECT().
+						SyncByNetworkID(gomock.Any(), uint64(1)).
+						Return(nil),
+				)
+			},
+			expectedMode: entity.RoutingModeInverse,
+		},
+		{
+			name: "unchanged mode is a no-op",
+			setupMocks: func(_ *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeInverse}, nil)
+			},
+			expectedMode: entity.RoutingModeInverse,
+		},
+		{
+			name: "network not found",
+			setupMocks: func(_ *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(nil, errs.ErrNetworkNotFound)
+			},
+			expectedError: "failed to get network by id 1",
+		},
+		{
+			name: "reset error",
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeSplit}, nil)
+				mockHostSetup.EXPECT().
+					ResetByNetworkID(gomock.Any(), uint64(1)).
+					Return(errors.New("reset error"))
+			},
+			expectedError: "failed to reset network host setup: reset error",
+		},
+		{
+			name: "sync error",
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeSplit}, nil)
+				mockHostSetup.EXPECT().
+					ResetByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+				mockStorage.EXPECT().
+					SetRoutingMode(gomock.Any(), uint64(1), entity.RoutingModeInverse).
+					Return(nil)
+				mockHostSetup.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(1)).
+					Return(errors.New("sync error"))
+			},
+			expectedError: "failed to sync network host setup: sync error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostSetup := mock_usecase.NewMockNetworkHostSetup(ctrl)
+
+			tt.setupMocks(mockNetworkHostSetup, mockNetworkStorage)
+
+			useCase := New(mock_usecase.NewMockCommandExecutor(ctrl), mockNetworkStorage, mockNetworkHostSetup)
+
+			network, err := useCase.SetRoutingMode(context.Background(), 1, entity.RoutingModeInverse)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, network)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMode, network.RoutingMode)
+		})
+	}
+}
+
+func TestUseCase_SetDNS(t *testing.T) {
+	tests := []struct {
+		name          string
+		domains       []string
+		nameservers   []string
+		setupMocks    func(*mock_usecase.MockNetworkHostSetup, *mock_storage.MockNetwork)
+		expectedError string
+	}{
+		{
+			name:        "stores normalized settings and resyncs",
+			domains:     []string{"Corp.Example.com."},
+			nameservers: []string{"10.8.0.1"},
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				gomock.InOrder(
+					mockStorage.EXPECT().
+						Get(gomock.Any(), uint64(1)).
+						Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil),
+					mockStorage.EXPECT().
+						SetDNS(gomock.Any(), uint64(1), entity.DomainList{"corp.example.com"}, entity.IPList{"10.8.0.1"}).
+						Return(nil),
+					mockHostSetup.EXPECT().
+						SyncByNetworkID(gomock.Any(), uint64(1)).
+						Return(nil),
+				)
+			},
+		},
+		{
+			name: "network not found",
+			setupMocks: func(_ *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(nil, errs.ErrNetworkNotFound)
+			},
+			expectedError: "failed to get network by id 1",
+		},
+		{
+			name:    "domains without nameservers",
+			domains: []string{"corp.example.com"},
+			setupMocks: func(_ *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+			},
+			expectedError: "DNS domains and nameservers must be set together",
+		},
+		{
+			name:        "storage error",
+			domains:     []string{"corp.example.com"},
+			nameservers: []string{"10.8.0.1"},
+			setupMocks: func(_ *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+				mockStorage.EXPECT().
+					SetDNS(gomock.Any(), uint64(1), gomock.Any(), gomock.Any()).
+					Return(errors.New("db error"))
+			},
+			expectedError: "failed to set DNS: db error",
+		},
+		{
+			name:        "sync error",
+			domains:     []string{"corp.example.com"},
+			nameservers: []string{"10.8.0.1"},
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+				mockStorage.EXPECT().
+					SetDNS(gomock.Any(), uint64(1), gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockHostSetup.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(1)).
+					Return(errors.New("sync error"))
+			},
+			expectedError: "failed to sync network host setup: sync error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostSetup := mock_usecase.NewMockNetworkHostSetup(ctrl)
+
+			tt.setupMocks(mockNetworkHostSetup, mockNetworkStorage)
+
+			useCase := New(mock_usecase.NewMockCommandExecutor(ctrl), mockNetworkStorage, mockNetworkHostSetup)
+
+			network, err := useCase.SetDNS(context.Background(), 1, tt.domains, tt.nameservers)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, network)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.DomainList{"corp.example.com"}, network.DNSDomains)
+			assert.Equal(t, entity.IPList{"10.8.0.1"}, network.DNSNameservers)
+		})
+	}
+}
+
+func TestUseCase_SetRouteOverride(t *testing.T) {
+	override := entity.RouteOverride{Router: "192.168.50.1", SubnetMask: "255.255.255.0", NetworkInterface: "en7"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mock_usecase.MockNetworkHostSetup, *mock_storage.MockNetwork)
+		expectedError string
+	}{
+		{
+			name: "resets, stores the override and resyncs",
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				gomock.InOrder(
+					mockStorage.EXPECT().
+						Get(gomock.Any(), uint64(1)).
+						Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil),
+					mockHostSetup.EXPECT().
+						ResetByNetworkID(gomock.Any(), uint64(1)).
+						Return(nil),
+					mockStorage.EXPECT().
+						SetRouteOverride(gomock.Any(), uint64(1), override).
+						Return(nil),
+					mockHostSetup.EXPECT().
+						SyncByNetworkID(gomock.Any(), uint64(1)).
+						Return(nil),
+				)
+			},
+		},
+		{
+			name: "unchanged override is a no-op",
+			setupMocks: func(_ *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{
+						ID:               1,
+						Name:             "TestNetwork",
+						Router:           "192.168.50.1",
+						SubnetMask:       "255.255.255.0",
+						NetworkInterface: "en7",
+					}, nil)
+			},
+		},
+		{
+			name: "network not found",
+			setupMocks: func(_ *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(nil, errs.ErrNetworkNotFound)
+			},
+			expectedError: "failed to get network by id 1",
+		},
+		{
+			name: "storage error",
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+				mockHostSetup.EXPECT().
+					ResetByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+				mockStorage.EXPECT().
+					SetRouteOverride(gomock.Any(), uint64(1), override).
+					Return(errors.New("db error"))
+			},
+			expectedError: "failed to set route override: db error",
+		},
+		{
+			name: "sync error",
+			setupMocks: func(mockHostSetup *mock_usecase.MockNetworkHostSetup, mockStorage *mock_storage.MockNetwork) {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+				mockHostSetup.EXPECT().
+					ResetByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+				mockStorage.EXPECT().
+					SetRouteOverride(gomock.Any(), uint64(1), override).
+					Return(nil)
+				mockHostSetup.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(1)).
+					Return(errors.New("sync error"))
+			},
+			expectedError: "failed to sync network host setup: sync error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostSetup := mock_usecase.NewMockNetworkHostSetup(ctrl)
+
+			tt.setupMocks(mockNetworkHostSetup, mockNetworkStorage)
+
+			useCase := New(mock_usecase.NewMockCommandExecutor(ctrl), mockNetworkStorage, mockNetworkHostSetup)
+
+			network, err := useCase.SetRouteOverride(context.Background(), 1, override)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, network)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, override, network.RouteOverride())
+		})
+	}
+}
+
 func TestUseCase_ListVPNServices(t *testing.T) {
 	tests := []struct {
 		name           string
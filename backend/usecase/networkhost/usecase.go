@@ -48,17 +48,17 @@ func (u *UseCase) Add(
 		}
 
 		res = addedHost
-		trErr = u.networkHostSetupUC.SyncByNetworkID(ctx, networkHost.NetworkID)
-		if trErr != nil {
-			return fmt.Errorf("failed to sync network host setup: %w", trErr)
-		}
-
-		return nil
+		return u.validateRoutes(ctx, networkHost.NetworkID)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply transaction: %w", err)
 	}
 
+	err = u.networkHostSetupUC.SyncByNetworkID(ctx, networkHost.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
 	return res, nil
 }
 
@@ -79,26 +79,152 @@ func (u *UseCase) Delete(ctx context.Context, id uint64) error {
 		return fmt.Errorf("failed to get network host: %w", err)
 	}
 
+	// Managed hosts would come back on the next subscription refresh.
+	if networkHost.IsManaged() {
+		return errs.ErrNetworkHostManaged
+	}
+
 	err = u.trm.Do(ctx, func(ctx context.Context) error {
 		trErr := u.networkHostStorage.Delete(ctx, id)
 		if trErr != nil {
 			return fmt.Errorf("failed to delete network host: %w", trErr)
 		}
 
-		trErr = u.networkHostSetupUC.SyncByNetworkID(ctx, networkHost.NetworkID)
-		if trErr != nil {
-			return fmt.Errorf("failed to sync network host setup: %w", trErr)
-		}
-
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to apply transaction: %w", err)
 	}
 
+	err = u.networkHostSetupUC.SyncByNetworkID(ctx, networkHost.NetworkID)
+	if err != nil {
+		return fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
 	return nil
 }
 
+// SetEnabled turns routing of a network host on or off without removing it from the network.
+func (u *UseCase) SetEnabled(ctx context.Context, id uint64, enabled bool) (*entity.NetworkHost, error) {
+	networkHost, err := u.networkHostStorage.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkHostNotFound) {
+			return nil, fmt.Errorf("network host with ID %d not found: %w", id, err)
+		}
+		return nil, fmt.Errorf("failed to get network host: %w", err)
+	}
+
+	if networkHost.Enabled == enabled {
+		return networkHost, nil
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.networkHostStorage.SetEnabled(ctx, id, enabled)
+		if trErr != nil {
+			return fmt.Errorf("failed to set network host enabled: %w", trErr)
+		}
+
+		// Disabling only removes routes, it's allowed even while another host doesn't resolve.
+		if !enabled {
+			return nil
+		}
+
+		return u.validateRoutes(ctx, networkHost.NetworkID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	err = u.networkHostSetupUC.SyncByNetworkID(ctx, networkHost.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	networkHost.Enabled = enabled
+	return networkHost, nil
+}
+
+// SetPinnedIPs pins IPs to a hostname network host, routed instead of or along with its DNS results
+// depending on mode. No IPs unpins the host.
+func (u *UseCase) SetPinnedIPs(
+	ctx context.Context,
+	id uint64,
+	pinnedIPs []string,
+	mode entity.NetworkHostPinMode,
+) (*entity.NetworkHost, error) {
+	networkHost, err := u.networkHostStorage.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkHostNotFound) {
+			return nil, fmt.Errorf("network host with ID %d not found: %w", id, err)
+		}
+		return nil, fmt.Errorf("failed to get network host: %w", err)
+	}
+
+	err = networkHost.PinIPs(pinnedIPs, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.networkHostStorage.SetPinnedIPs(ctx, id, networkHost.PinnedIPs, networkHost.PinMode)
+		if trErr != nil {
+			return fmt.Errorf("failed to set network host pinned IPs: %w", trErr)
+		}
+
+		return u.validateRoutes(ctx, networkHost.NetworkID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	err = u.networkHostSetupUC.SyncByNetworkID(ctx, networkHost.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	return networkHost, nil
+}
+
+// SetRouteOverride sets the router, subnet mask or interface the routes of a network host are built with
+// instead of the network's. An empty override clears it.
+func (u *UseCase) SetRouteOverride(
+	ctx context.Context,
+	id uint64,
+	override entity.RouteOverride,
+) (*entity.NetworkHost, error) {
+	networkHost, err := u.networkHostStorage.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkHostNotFound) {
+			return nil, fmt.Errorf("network host with ID %d not found: %w", id, err)
+		}
+		return nil, fmt.Errorf("failed to get network host: %w", err)
+	}
+
+	err = networkHost.SetRouteOverride(override)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.networkHostStorage.SetRouteOverride(ctx, id, override)
+		if trErr != nil {
+			return fmt.Errorf("failed to set network host route override: %w", trErr)
+		}
+
+		return u.validateRoutes(ctx, networkHost.NetworkID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	err = u.networkHostSetupUC.SyncByNetworkID(ctx, networkHost.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	return networkHost, nil
+}
+
 // ExportByNetworkIDForContext exports network hosts without including the network ID in the payload
 // This is suitable for exports from a specific network context where the network is already known.
 func (u *UseCase) ExportByNetworkIDForContext(
@@ -127,10 +253,16 @@ func (u *UseCase) ExportByNetworkIDForContext(
 	for _, host := range networkHosts {
 		dto := entity.NetworkHostDTO{
 			Address: host.Address,
+			Enabled: &host.Enabled,
+			Kind:    host.Kind,
 		}
 		if host.Description != nil {
 			dto.Description = *host.Description
 		}
+		if host.HasPinnedIPs() {
+			dto.PinnedIPs = host.PinnedIPs
+			dto.PinMode = host.PinMode
+		}
 		hostDTOs = append(hostDTOs, dto)
 	}
 
@@ -143,6 +275,37 @@ func (u *UseCase) ExportByNetworkIDForContext(
 	return payload, nil
 }
 
+// ExportPACByNetworkID builds a proxy auto-config file sending the network's hosts through proxy.
+func (u *UseCase) ExportPACByNetworkID(
+	ctx context.Context,
+	networkID uint64,
+	proxy entity.PACProxy,
+) (*entity.PACFile, error) {
+	network, err := u.networkStorage.Get(ctx, networkID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkNotFound) {
+			return nil, fmt.Errorf("network with ID %d not found: %w", networkID, err)
+		}
+		return nil, fmt.Errorf("failed to validate network: %w", err)
+	}
+
+	enabled := true
+	networkHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{
+		NetworkID: []uint64{networkID},
+		Enabled:   &enabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network hosts: %w", err)
+	}
+
+	return &entity.PACFile{
+		NetworkName: network.Name,
+		Proxy:       proxy,
+		Hosts:       networkHosts,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
 func (u *UseCase) ImportByNetworkIDFromJSON(
 	ctx context.Context,
 	networkID uint64,
@@ -160,6 +323,19 @@ func (u *UseCase) ImportByNetworkIDFromJSON(
 	return u.importHostsInTransaction(ctx, networkID, contextPayload.Hosts)
 }
 
+// ImportByNetworkID adds the given hosts to a network, skipping ones it already has.
+func (u *UseCase) ImportByNetworkID(
+	ctx context.Context,
+	networkID uint64,
+	hostDTOs []entity.NetworkHostDTO,
+) error {
+	if validateErr := u.validateNetworkExists(ctx, networkID); validateErr != nil {
+		return validateErr
+	}
+
+	return u.importHostsInTransaction(ctx, networkID, hostDTOs)
+}
+
 func (u *UseCase) unmarshalImportData(jsonData string) (*entity.NetworkHostContextExportPayload, error) {
 	var contextPayload entity.NetworkHostContextExportPayload
 	err := json.Unmarshal([]byte(jsonData), &contextPayload)
@@ -185,20 +361,21 @@ func (u *UseCase) importHostsInTransaction(
 	networkID uint64,
 	hostDTOs []entity.NetworkHostDTO,
 ) error {
+	var importedCount int
 	err := u.trm.Do(ctx, func(ctx context.Context) error {
-		importedCount, err := u.processHostImports(ctx, networkID, hostDTOs)
-		if err != nil {
-			return err
+		var trErr error
+		importedCount, trErr = u.processHostImports(ctx, networkID, hostDTOs)
+		if trErr != nil || importedCount == 0 {
+			return trErr
 		}
 
-		return u.syncIfNeeded(ctx, networkID, importedCount)
+		return u.validateRoutes(ctx, networkID)
 	})
-
 	if err != nil {
 		return fmt.Errorf("failed to import network hosts: %w", err)
 	}
 
-	return nil
+	return u.syncIfNeeded(ctx, networkID, importedCount)
 }
 
 func (u *UseCase) processHostImports(
@@ -256,6 +433,22 @@ func (u *UseCase) createAndAddHost(ctx context.Context, networkID uint64, hostDT
 			err,
 		)
 	}
+	networkHost.Enabled = hostDTO.IsEnabled()
+
+	networkHost.Kind, err = entity.ParseNetworkHostKind(string(hostDTO.Kind))
+	if err != nil {
+		return false, fmt.Errorf("failed to create network host for %s: %w", hostDTO.Address, err)
+	}
+
+	pinMode, err := entity.ParseNetworkHostPinMode(string(hostDTO.PinMode))
+	if err != nil {
+		return false, fmt.Errorf("failed to create network host for %s: %w", hostDTO.Address, err)
+	}
+
+	err = networkHost.PinIPs(hostDTO.PinnedIPs, pinMode)
+	if err != nil {
+		return false, fmt.Errorf("failed to create network host for %s: %w", hostDTO.Address, err)
+	}
 
 	_, err = u.networkHostStorage.Add(ctx, networkHost)
 	if err != nil {
@@ -268,6 +461,17 @@ func (u *UseCase) createAndAddHost(ctx context.Context, networkID uint64, hostDT
 	return true, nil
 }
 
+// validateRoutes checks, inside the caller's transaction, that the network's routes still resolve with
+// the uncommitted change. The sync runs after the commit, so a change it couldn't route is rolled back here.
+func (u *UseCase) validateRoutes(ctx context.Context, networkID uint64) error {
+	err := u.networkHostSetupUC.ValidateByNetworkID(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve network routes: %w", err)
+	}
+
+	return nil
+}
+
 func (u *UseCase) syncIfNeeded(ctx context.Context, networkID uint64, importedCount int) error {
 	if importedCount > 0 {
 		err := u.networkHostSetupUC.SyncByNetworkID(ctx, networkID)
@@ -52,6 +52,13 @@ func TestUseCase_ExportByNetworkIDForContext(t *testing.T) {
 						NetworkID: 1,
 						Address:   "192.168.1.101",
 					},
+					{
+						ID:        3,
+						NetworkID: 1,
+						Address:   "git.corp.example",
+						PinnedIPs: entity.IPList{"10.0.0.5"},
+						PinMode:   entity.NetworkHostPinModeAppend,
+					},
 				}
 				mockNetworkHostStorage.EXPECT().
 					List(gomock.Any(), &entity.ListNetworkHostFilter{
@@ -68,6 +75,11 @@ func TestUseCase_ExportByNetworkIDForContext(t *testing.T) {
 					{
 						Address: "192.168.1.101",
 					},
+					{
+						Address:   "git.corp.example",
+						PinnedIPs: []string{"10.0.0.5"},
+						PinMode:   entity.NetworkHostPinModeAppend,
+					},
 				},
 			},
 		},
@@ -282,6 +294,8 @@ func TestUseCase_ExportByNetworkIDForContext(t *testing.T) {
 					for i, expectedHost := range tt.expectedResult.Hosts {
 						assert.Equal(t, expectedHost.Address, result.Hosts[i].Address)
 						assert.Equal(t, expectedHost.Description, result.Hosts[i].Description)
+						assert.Equal(t, expectedHost.PinnedIPs, result.Hosts[i].PinnedIPs)
+						assert.Equal(t, expectedHost.PinMode, result.Hosts[i].PinMode)
 					}
 				}
 			}
@@ -356,6 +370,113 @@ func BenchmarkUseCase_ExportByNetworkIDForContext(b *testing.B) {
 	}
 }
 
+func TestUseCase_ExportPACByNetworkID(t *testing.T) {
+	proxy := entity.PACProxy("PROXY proxy.example.com:3128")
+
+	tests := []struct {
+		name          string
+		networkID     uint64
+		setupMocks    func(*mock_storage.MockNetwork, *mock_storage.MockNetworkHost)
+		expectedHosts []string
+		expectedError string
+	}{
+		{
+			name:      "successfully export PAC for existing network",
+			networkID: 1,
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{1},
+						Enabled:   boolPtr(true),
+					}).
+					Return([]*entity.NetworkHost{
+						{ID: 1, NetworkID: 1, Address: "example.com"},
+						{ID: 2, NetworkID: 1, Address: "192.168.1.100"},
+					}, nil)
+			},
+			expectedHosts: []string{"example.com", "192.168.1.100"},
+		},
+		{
+			name:      "error when network not found",
+			networkID: 999,
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, _ *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(999)).
+					Return(nil, errs.ErrNetworkNotFound)
+			},
+			expectedError: "network with ID 999 not found",
+		},
+		{
+			name:      "error when network storage fails",
+			networkID: 2,
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, _ *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(2)).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedError: "failed to validate network: database connection failed",
+		},
+		{
+			name:      "error when network host list fails",
+			networkID: 3,
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(3)).
+					Return(&entity.Network{ID: 3, Name: "TestNetwork"}, nil)
+
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{3},
+						Enabled:   boolPtr(true),
+					}).
+					Return(nil, errors.New("database query failed"))
+			},
+			expectedError: "failed to list network hosts: database query failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTrm := mock_trm.NewMockManager(ctrl)
+			mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			tt.setupMocks(mockNetworkStorage, mockNetworkHostStorage)
+
+			useCase := New(mockTrm, mockNetworkHostSetupUC, mockNetworkStorage, mockNetworkHostStorage)
+
+			result, err := useCase.ExportPACByNetworkID(context.Background(), tt.networkID, proxy)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, "TestNetwork", result.NetworkName)
+			assert.Equal(t, proxy, result.Proxy)
+			assert.False(t, result.GeneratedAt.IsZero())
+
+			addresses := make([]string, 0, len(result.Hosts))
+			for _, host := range result.Hosts {
+				addresses = append(addresses, host.Address)
+			}
+			assert.Equal(t, tt.expectedHosts, addresses)
+		})
+	}
+}
+
 // TestExportPayloadStructure verifies the exact structure of the export payload.
 func TestExportPayloadStructure(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -382,6 +503,7 @@ func TestExportPayloadStructure(t *testing.T) {
 			NetworkID:   100,
 			Address:     "mail.example.com",
 			Description: &desc1,
+			Enabled:     false,
 		},
 	}
 	mockNetworkHostStorage.EXPECT().
@@ -406,6 +528,8 @@ func TestExportPayloadStructure(t *testing.T) {
 	assert.Len(t, result.Hosts, 1)
 	assert.Equal(t, "mail.example.com", result.Hosts[0].Address)
 	assert.Equal(t, "Primary server", result.Hosts[0].Description)
+	require.NotNil(t, result.Hosts[0].Enabled)
+	assert.False(t, *result.Hosts[0].Enabled)
 
 	// Verify export date is set and recent
 	assert.False(t, result.ExportDate.IsZero())
@@ -486,6 +610,9 @@ func TestUseCase_ImportByNetworkIDFromJSON(t *testing.T) {
 					})
 
 				// Sync network host setup
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
 				mockNetworkHostSetupUC.EXPECT().
 					SyncByNetworkID(gomock.Any(), uint64(1)).
 					Return(nil)
@@ -548,6 +675,9 @@ func TestUseCase_ImportByNetworkIDFromJSON(t *testing.T) {
 					})
 
 				// Sync network host setup
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(2)).
+					Return(nil)
 				mockNetworkHostSetupUC.EXPECT().
 					SyncByNetworkID(gomock.Any(), uint64(2)).
 					Return(nil)
@@ -875,6 +1005,9 @@ func TestUseCase_ImportByNetworkIDFromJSON(t *testing.T) {
 					})
 
 				// Sync fails
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(11)).
+					Return(nil)
 				mockNetworkHostSetupUC.EXPECT().
 					SyncByNetworkID(gomock.Any(), uint64(11)).
 					Return(errors.New("sync failed"))
@@ -919,150 +1052,423 @@ func TestUseCase_ImportByNetworkIDFromJSON(t *testing.T) {
 	}
 }
 
-func TestUseCase_Add(t *testing.T) {
+func TestUseCase_ImportByNetworkID(t *testing.T) {
 	tests := []struct {
 		name          string
-		networkHost   *entity.NetworkHost
-		setupMocks    func(*mock_storage.MockNetworkHost, *mock_usecase.MockNetworkHostSetup, *mock_trm.MockManager)
+		networkID     uint64
+		hostDTOs      []entity.NetworkHostDTO
+		setupMocks    func(*mock_storage.MockNetwork, *mock_storage.MockNetworkHost, *mock_usecase.MockNetworkHostSetup, *mock_trm.MockManager)
 		expectedError string
-		expectedHost  *entity.NetworkHost
 	}{
 		{
-			name: "successfully add network host",
-			networkHost: &entity.NetworkHost{
-				NetworkID:   1,
-				Address:     "192.168.1.100",
-				Description: stringPtr("Web server"),
+			name:      "import new CIDR host and skip existing",
+			networkID: 1,
+			hostDTOs: []entity.NetworkHostDTO{
+				{Address: "10.20.0.0/16"},
+				{Address: "10.1.2.3"},
 			},
-			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				// Setup transaction mock
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
 				mockTrm.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 
-				// Mock successful storage add
-				addedHost := &entity.NetworkHost{
-					ID:          1,
-					NetworkID:   1,
-					Address:     "192.168.1.100",
-					Description: stringPtr("Web server"),
-				}
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{1},
+						Address:   []string{"10.20.0.0/16"},
+					}).
+					Return([]*entity.NetworkHost{}, nil)
 				mockNetworkHostStorage.EXPECT().
 					Add(gomock.Any(), gomock.Any()).
-					Return(addedHost, nil)
+					DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+						assert.Equal(t, "10.20.0.0/16", host.Address)
+						host.ID = 1
+						return host, nil
+					})
 
-				// Mock successful sync
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{1},
+						Address:   []string{"10.1.2.3"},
+					}).
+					Return([]*entity.NetworkHost{{ID: 2, NetworkID: 1, Address: "10.1.2.3"}}, nil)
+
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
 				mockNetworkHostSetupUC.EXPECT().
 					SyncByNetworkID(gomock.Any(), uint64(1)).
 					Return(nil)
 			},
-			expectedHost: &entity.NetworkHost{
-				ID:          1,
-				NetworkID:   1,
-				Address:     "192.168.1.100",
-				Description: stringPtr("Web server"),
-			},
 		},
 		{
-			name: "successfully add network host without description",
-			networkHost: &entity.NetworkHost{
-				NetworkID: 2,
-				Address:   "example.com",
+			name:      "import keeps disabled flag",
+			networkID: 1,
+			hostDTOs: []entity.NetworkHostDTO{
+				{Address: "10.1.2.3", Enabled: boolPtr(false)},
 			},
-			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				// Setup transaction mock
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
 				mockTrm.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 
-				// Mock successful storage add
-				addedHost := &entity.NetworkHost{
-					ID:        10,
-					NetworkID: 2,
-					Address:   "example.com",
-				}
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{1},
+						Address:   []string{"10.1.2.3"},
+					}).
+					Return([]*entity.NetworkHost{}, nil)
 				mockNetworkHostStorage.EXPECT().
 					Add(gomock.Any(), gomock.Any()).
-					Return(addedHost, nil)
+					DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+						assert.False(t, host.Enabled)
+						host.ID = 1
+						return host, nil
+					})
 
-				// Mock successful sync
 				mockNetworkHostSetupUC.EXPECT().
-					SyncByNetworkID(gomock.Any(), uint64(2)).
+					ValidateByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(1)).
 					Return(nil)
-			},
-			expectedHost: &entity.NetworkHost{
-				ID:        10,
-				NetworkID: 2,
-				Address:   "example.com",
 			},
 		},
 		{
-			name: "error - storage add fails",
-			networkHost: &entity.NetworkHost{
-				NetworkID: 3,
-				Address:   "test.com",
+			name:      "import keeps exclusion kind",
+			networkID: 1,
+			hostDTOs: []entity.NetworkHostDTO{
+				{Address: "10.99.0.0/16", Kind: entity.NetworkHostKindExclude},
 			},
-			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				// Setup transaction mock
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
 				mockTrm.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 
-				// Mock storage add failure
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{1},
+						Address:   []string{"10.99.0.0/16"},
+					}).
+					Return([]*entity.NetworkHost{}, nil)
 				mockNetworkHostStorage.EXPECT().
 					Add(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("database insert failed"))
+					DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+						assert.Equal(t, entity.NetworkHostKindExclude, host.Kind)
+						host.ID = 1
+						return host, nil
+					})
+
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
 			},
-			expectedError: "failed to add network host: database insert failed",
 		},
 		{
-			name: "error - sync fails after successful add",
-			networkHost: &entity.NetworkHost{
-				NetworkID: 4,
-				Address:   "sync.fail.com",
+			name:      "import keeps pinned IPs",
+			networkID: 1,
+			hostDTOs: []entity.NetworkHostDTO{
+				{
+					Address:   "git.corp.example",
+					PinnedIPs: []string{"10.0.0.5"},
+					PinMode:   entity.NetworkHostPinModeAppend,
+				},
 			},
-			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				// Setup transaction mock
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
 				mockTrm.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 
-				// Mock successful storage add
-				addedHost := &entity.NetworkHost{
-					ID:        20,
-					NetworkID: 4,
-					Address:   "sync.fail.com",
-				}
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]*entity.NetworkHost{}, nil)
 				mockNetworkHostStorage.EXPECT().
 					Add(gomock.Any(), gomock.Any()).
-					Return(addedHost, nil)
+					DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+						assert.Equal(t, entity.IPList{"10.0.0.5"}, host.PinnedIPs)
+						assert.Equal(t, entity.NetworkHostPinModeAppend, host.PinMode)
+						host.ID = 1
+						return host, nil
+					})
 
-				// Mock sync failure
 				mockNetworkHostSetupUC.EXPECT().
-					SyncByNetworkID(gomock.Any(), uint64(4)).
-					Return(errors.New("sync service unavailable"))
+					ValidateByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
 			},
-			expectedError: "failed to sync network host setup: sync service unavailable",
 		},
 		{
-			name: "error - transaction fails",
-			networkHost: &entity.NetworkHost{
-				NetworkID: 5,
-				Address:   "tx.fail.com",
+			name:      "error - pinned IPs on an IP address",
+			networkID: 1,
+			hostDTOs: []entity.NetworkHostDTO{
+				{Address: "10.1.2.3", PinnedIPs: []string{"10.0.0.5"}},
 			},
-			setupMocks: func(_ *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				// Setup transaction failure
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
 				mockTrm.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					Return(errors.New("transaction failed"))
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]*entity.NetworkHost{}, nil)
+			},
+			expectedError: "only hostnames can have pinned IPs",
+		},
+		{
+			name:      "error - unknown kind",
+			networkID: 1,
+			hostDTOs: []entity.NetworkHostDTO{
+				{Address: "10.99.0.0/16", Kind: "block"},
+			},
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]*entity.NetworkHost{}, nil)
+			},
+			expectedError: "invalid network host kind",
+		},
+		{
+			name:      "error - network not found",
+			networkID: 999,
+			hostDTOs:  []entity.NetworkHostDTO{{Address: "10.1.2.3"}},
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, _ *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				mockNetworkStorage.EXPECT().
+					Get(gomock.Any(), uint64(999)).
+					Return(nil, errs.ErrNetworkNotFound)
+			},
+			expectedError: "network with ID 999 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTrm := mock_trm.NewMockManager(ctrl)
+			mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			tt.setupMocks(mockNetworkStorage, mockNetworkHostStorage, mockNetworkHostSetupUC, mockTrm)
+
+			useCase := New(
+				mockTrm,
+				mockNetworkHostSetupUC,
+				mockNetworkStorage,
+				mockNetworkHostStorage,
+			)
+
+			err := useCase.ImportByNetworkID(context.Background(), tt.networkID, tt.hostDTOs)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUseCase_Add(t *testing.T) {
+	tests := []struct {
+		name          string
+		networkHost   *entity.NetworkHost
+		setupMocks    func(*mock_storage.MockNetworkHost, *mock_usecase.MockNetworkHostSetup, *mock_trm.MockManager)
+		expectedError string
+		expectedHost  *entity.NetworkHost
+	}{
+		{
+			name: "successfully add network host",
+			networkHost: &entity.NetworkHost{
+				NetworkID:   1,
+				Address:     "192.168.1.100",
+				Description: stringPtr("Web server"),
+			},
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				// Setup transaction mock
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				// Mock successful storage add
+				addedHost := &entity.NetworkHost{
+					ID:          1,
+					NetworkID:   1,
+					Address:     "192.168.1.100",
+					Description: stringPtr("Web server"),
+				}
+				mockNetworkHostStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(addedHost, nil)
+
+				// Mock successful sync
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(1)).
+					Return(nil)
+			},
+			expectedHost: &entity.NetworkHost{
+				ID:          1,
+				NetworkID:   1,
+				Address:     "192.168.1.100",
+				Description: stringPtr("Web server"),
+			},
+		},
+		{
+			name: "successfully add network host without description",
+			networkHost: &entity.NetworkHost{
+				NetworkID: 2,
+				Address:   "example.com",
+			},
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				// Setup transaction mock
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				// Mock successful storage add
+				addedHost := &entity.NetworkHost{
+					ID:        10,
+					NetworkID: 2,
+					Address:   "example.com",
+				}
+				mockNetworkHostStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(addedHost, nil)
+
+				// Mock successful sync
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(2)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(2)).
+					Return(nil)
+			},
+			expectedHost: &entity.NetworkHost{
+				ID:        10,
+				NetworkID: 2,
+				Address:   "example.com",
+			},
+		},
+		{
+			name: "error - storage add fails",
+			networkHost: &entity.NetworkHost{
+				NetworkID: 3,
+				Address:   "test.com",
+			},
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				// Setup transaction mock
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				// Mock storage add failure
+				mockNetworkHostStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database insert failed"))
+			},
+			expectedError: "failed to add network host: database insert failed",
+		},
+		{
+			name: "error - sync fails after successful add",
+			networkHost: &entity.NetworkHost{
+				NetworkID: 4,
+				Address:   "sync.fail.com",
+			},
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				// Setup transaction mock
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				// Mock successful storage add
+				addedHost := &entity.NetworkHost{
+					ID:        20,
+					NetworkID: 4,
+					Address:   "sync.fail.com",
+				}
+				mockNetworkHostStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(addedHost, nil)
+
+				// Mock sync failure
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(4)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(4)).
+					Return(errors.New("sync service unavailable"))
+			},
+			expectedError: "failed to sync network host setup: sync service unavailable",
+		},
+		{
+			name: "error - transaction fails",
+			networkHost: &entity.NetworkHost{
+				NetworkID: 5,
+				Address:   "tx.fail.com",
+			},
+			setupMocks: func(_ *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				// Setup transaction failure
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					Return(errors.New("transaction failed"))
 			},
 			expectedError: "failed to apply transaction: transaction failed",
 		},
@@ -1115,7 +1521,73 @@ func TestUseCase_Add(t *testing.T) {
 	}
 }
 
+type inTransactionKey struct{}
+
+func TestUseCase_Add_ValidatesBeforeCommit(t *testing.T) {
+	setup := func(t *testing.T, validateErr error) (*UseCase, *mock_usecase.MockNetworkHostSetup, *bool) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		mockTrm := mock_trm.NewMockManager(ctrl)
+		mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+		mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+		committed := false
+		mockTrm.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				err := fn(context.WithValue(ctx, inTransactionKey{}, true))
+				committed = err == nil
+				return err
+			})
+		mockNetworkHostStorage.EXPECT().
+			Add(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, networkHost *entity.NetworkHost) (*entity.NetworkHost, error) {
+				assert.Equal(t, true, ctx.Value(inTransactionKey{}))
+				return networkHost, nil
+			})
+		mockNetworkHostSetupUC.EXPECT().
+			ValidateByNetworkID(gomock.Any(), uint64(1)).
+			DoAndReturn(func(ctx context.Context, _ uint64) error {
+				assert.Equal(t, true, ctx.Value(inTransactionKey{}), "validation must run inside the transaction")
+				return validateErr
+			})
+
+		useCase := New(mockTrm, mockNetworkHostSetupUC, mock_storage.NewMockNetwork(ctrl), mockNetworkHostStorage)
+
+		return useCase, mockNetworkHostSetupUC, &committed
+	}
+
+	t.Run("unresolvable host is rolled back and not synced", func(t *testing.T) {
+		useCase, _, committed := setup(t, errors.New("no such host"))
+
+		_, err := useCase.Add(context.Background(), &entity.NetworkHost{NetworkID: 1, Address: "missing.invalid"})
+
+		require.EqualError(t, err, "failed to apply transaction: failed to resolve network routes: no such host")
+		assert.False(t, *committed, "the added host must not be committed")
+	})
+
+	t.Run("valid host is synced after the commit", func(t *testing.T) {
+		useCase, mockNetworkHostSetupUC, committed := setup(t, nil)
+		mockNetworkHostSetupUC.EXPECT().
+			SyncByNetworkID(gomock.Any(), uint64(1)).
+			DoAndReturn(func(ctx context.Context, _ uint64) error {
+				assert.Nil(t, ctx.Value(inTransactionKey{}), "sync must not run inside the transaction")
+				assert.True(t, *committed, "sync must run after the commit")
+				return nil
+			})
+
+		_, err := useCase.Add(context.Background(), &entity.NetworkHost{NetworkID: 1, Address: "10.0.0.1"})
+
+		require.NoError(t, err)
+	})
+}
+
 // stringPtr is a helper function to create string pointers for tests.
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -1412,55 +1884,466 @@ func TestUseCase_List(t *testing.T) {
 	}
 }
 
-func TestUseCase_Delete(t *testing.T) {
+func TestUseCase_SetEnabled(t *testing.T) {
 	tests := []struct {
 		name          string
 		id            uint64
+		enabled       bool
 		setupMocks    func(*mock_storage.MockNetworkHost, *mock_usecase.MockNetworkHostSetup, *mock_trm.MockManager)
 		expectedError string
 	}{
 		{
-			name: "successfully delete existing network host",
-			id:   1,
+			name:    "disable host and sync network",
+			id:      1,
+			enabled: false,
 			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				// Mock existing host
-				existingHost := &entity.NetworkHost{
-					ID:        1,
-					NetworkID: 5,
-					Address:   "to.delete.com",
-				}
 				mockNetworkHostStorage.EXPECT().
 					Get(gomock.Any(), uint64(1)).
-					Return(existingHost, nil)
+					Return(&entity.NetworkHost{ID: 1, NetworkID: 5, Address: "example.com", Enabled: true}, nil)
 
-				// Setup transaction mock
 				mockTrm.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					})
 
-				// Mock successful delete
 				mockNetworkHostStorage.EXPECT().
-					Delete(gomock.Any(), uint64(1)).
+					SetEnabled(gomock.Any(), uint64(1), false).
 					Return(nil)
-
-				// Mock successful sync
 				mockNetworkHostSetupUC.EXPECT().
 					SyncByNetworkID(gomock.Any(), uint64(5)).
 					Return(nil)
 			},
 		},
 		{
-			name: "silently handle host not found",
-			id:   999,
+			name:    "no-op when flag is unchanged",
+			id:      2,
+			enabled: true,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(2)).
+					Return(&entity.NetworkHost{ID: 2, NetworkID: 5, Address: "example.com", Enabled: true}, nil)
+			},
+		},
+		{
+			name:    "error - host not found",
+			id:      999,
+			enabled: true,
 			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
-				// Mock host not found
 				mockNetworkHostStorage.EXPECT().
 					Get(gomock.Any(), uint64(999)).
 					Return(nil, errs.ErrNetworkHostNotFound)
-				// No other mocks should be called when host not found
 			},
+			expectedError: "network host with ID 999 not found",
+		},
+		{
+			name:    "error - sync fails",
+			id:      3,
+			enabled: true,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(3)).
+					Return(&entity.NetworkHost{ID: 3, NetworkID: 5, Address: "example.com"}, nil)
+
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					SetEnabled(gomock.Any(), uint64(3), true).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(5)).
+					Return(errors.New("sync failed"))
+			},
+			expectedError: "failed to sync network host setup: sync failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTrm := mock_trm.NewMockManager(ctrl)
+			mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			tt.setupMocks(mockNetworkHostStorage, mockNetworkHostSetupUC, mockTrm)
+
+			useCase := New(
+				mockTrm,
+				mockNetworkHostSetupUC,
+				mockNetworkStorage,
+				mockNetworkHostStorage,
+			)
+
+			result, err := useCase.SetEnabled(context.Background(), tt.id, tt.enabled)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.enabled, result.Enabled)
+		})
+	}
+}
+
+func TestUseCase_SetPinnedIPs(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            uint64
+		pinnedIPs     []string
+		mode          entity.NetworkHostPinMode
+		setupMocks    func(*mock_storage.MockNetworkHost, *mock_usecase.MockNetworkHostSetup, *mock_trm.MockManager)
+		expected      entity.IPList
+		expectedError string
+	}{
+		{
+			name:      "pin IPs and sync network",
+			id:        1,
+			pinnedIPs: []string{" 10.0.0.5 ", "10.0.0.6", "10.0.0.5", ""},
+			mode:      entity.NetworkHostPinModeAppend,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.NetworkHost{ID: 1, NetworkID: 5, Address: "git.corp.example"}, nil)
+
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					SetPinnedIPs(
+						gomock.Any(),
+						uint64(1),
+						entity.IPList{"10.0.0.5", "10.0.0.6"},
+						entity.NetworkHostPinModeAppend,
+					).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+			},
+			expected: entity.IPList{"10.0.0.5", "10.0.0.6"},
+		},
+		{
+			name: "unpin IPs",
+			id:   2,
+			mode: entity.NetworkHostPinModeReplace,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(2)).
+					Return(&entity.NetworkHost{
+						ID:        2,
+						NetworkID: 5,
+						Address:   "git.corp.example",
+						PinnedIPs: entity.IPList{"10.0.0.5"},
+					}, nil)
+
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					SetPinnedIPs(gomock.Any(), uint64(2), entity.IPList{}, entity.NetworkHostPinModeReplace).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+			},
+			expected: entity.IPList{},
+		},
+		{
+			name:      "error - invalid IP",
+			id:        3,
+			pinnedIPs: []string{"git.corp.example"},
+			mode:      entity.NetworkHostPinModeReplace,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(3)).
+					Return(&entity.NetworkHost{ID: 3, NetworkID: 5, Address: "git.corp.example"}, nil)
+			},
+			expectedError: "must be an IPv4 address",
+		},
+		{
+			name:      "error - host not found",
+			id:        999,
+			pinnedIPs: []string{"10.0.0.5"},
+			mode:      entity.NetworkHostPinModeReplace,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(999)).
+					Return(nil, errs.ErrNetworkHostNotFound)
+			},
+			expectedError: "network host with ID 999 not found",
+		},
+		{
+			name:      "error - sync fails",
+			id:        4,
+			pinnedIPs: []string{"10.0.0.5"},
+			mode:      entity.NetworkHostPinModeReplace,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(4)).
+					Return(&entity.NetworkHost{ID: 4, NetworkID: 5, Address: "git.corp.example"}, nil)
+
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					SetPinnedIPs(gomock.Any(), uint64(4), gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(5)).
+					Return(errors.New("sync failed"))
+			},
+			expectedError: "failed to sync network host setup: sync failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTrm := mock_trm.NewMockManager(ctrl)
+			mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			tt.setupMocks(mockNetworkHostStorage, mockNetworkHostSetupUC, mockTrm)
+
+			useCase := New(
+				mockTrm,
+				mockNetworkHostSetupUC,
+				mock_storage.NewMockNetwork(ctrl),
+				mockNetworkHostStorage,
+			)
+
+			result, err := useCase.SetPinnedIPs(context.Background(), tt.id, tt.pinnedIPs, tt.mode)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.PinnedIPs)
+			assert.Equal(t, tt.mode, result.PinMode)
+		})
+	}
+}
+
+func TestUseCase_SetRouteOverride(t *testing.T) {
+	override := entity.RouteOverride{Router: "192.168.50.1", NetworkInterface: "en7"}
+
+	tests := []struct {
+		name          string
+		id            uint64
+		override      entity.RouteOverride
+		setupMocks    func(*mock_storage.MockNetworkHost, *mock_usecase.MockNetworkHostSetup, *mock_trm.MockManager)
+		expectedError string
+	}{
+		{
+			name:     "override route and sync network",
+			id:       1,
+			override: override,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(&entity.NetworkHost{ID: 1, NetworkID: 5, Address: "git.corp.example"}, nil)
+
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					SetRouteOverride(gomock.Any(), uint64(1), override).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					ValidateByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+			},
+		},
+		{
+			name:     "error - exclusion",
+			id:       2,
+			override: override,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(2)).
+					Return(&entity.NetworkHost{
+						ID:        2,
+						NetworkID: 5,
+						Address:   "10.0.0.5",
+						Kind:      entity.NetworkHostKindExclude,
+					}, nil)
+			},
+			expectedError: "exclusions aren't routed",
+		},
+		{
+			name:     "error - host not found",
+			id:       999,
+			override: override,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(999)).
+					Return(nil, errs.ErrNetworkHostNotFound)
+			},
+			expectedError: "network host with ID 999 not found",
+		},
+		{
+			name:     "error - storage fails",
+			id:       3,
+			override: override,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(3)).
+					Return(&entity.NetworkHost{ID: 3, NetworkID: 5, Address: "git.corp.example"}, nil)
+
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mockNetworkHostStorage.EXPECT().
+					SetRouteOverride(gomock.Any(), uint64(3), override).
+					Return(errors.New("db error"))
+			},
+			expectedError: "failed to set network host route override: db error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTrm := mock_trm.NewMockManager(ctrl)
+			mockNetworkHostSetupUC := mock_usecase.NewMockNetworkHostSetup(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			tt.setupMocks(mockNetworkHostStorage, mockNetworkHostSetupUC, mockTrm)
+
+			useCase := New(
+				mockTrm,
+				mockNetworkHostSetupUC,
+				mock_storage.NewMockNetwork(ctrl),
+				mockNetworkHostStorage,
+			)
+
+			result, err := useCase.SetRouteOverride(context.Background(), tt.id, tt.override)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.override, result.RouteOverride())
+		})
+	}
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            uint64
+		setupMocks    func(*mock_storage.MockNetworkHost, *mock_usecase.MockNetworkHostSetup, *mock_trm.MockManager)
+		expectedError string
+	}{
+		{
+			name: "successfully delete existing network host",
+			id:   1,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupUC *mock_usecase.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
+				// Mock existing host
+				existingHost := &entity.NetworkHost{
+					ID:        1,
+					NetworkID: 5,
+					Address:   "to.delete.com",
+				}
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(1)).
+					Return(existingHost, nil)
+
+				// Setup transaction mock
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				// Mock successful delete
+				mockNetworkHostStorage.EXPECT().
+					Delete(gomock.Any(), uint64(1)).
+					Return(nil)
+
+				// Mock successful sync
+				mockNetworkHostSetupUC.EXPECT().
+					SyncByNetworkID(gomock.Any(), uint64(5)).
+					Return(nil)
+			},
+		},
+		{
+			name: "silently handle host not found",
+			id:   999,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				// Mock host not found
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(999)).
+					Return(nil, errs.ErrNetworkHostNotFound)
+				// No other mocks should be called when host not found
+			},
+		},
+		{
+			name: "error - host is managed by a subscription",
+			id:   3,
+			setupMocks: func(mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_usecase.MockNetworkHostSetup, _ *mock_trm.MockManager) {
+				subscriptionID := uint64(4)
+				mockNetworkHostStorage.EXPECT().
+					Get(gomock.Any(), uint64(3)).
+					Return(&entity.NetworkHost{
+						ID:             3,
+						NetworkID:      5,
+						Address:        "managed.com",
+						SubscriptionID: &subscriptionID,
+					}, nil)
+				// Managed hosts are not deleted
+			},
+			expectedError: "network host is managed by a subscription",
 		},
 		{
 			name: "error - storage get fails with non-not-found error",
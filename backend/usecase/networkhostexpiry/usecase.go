@@ -0,0 +1,154 @@
+package networkhostexpiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+type nowFunc func() time.Time
+
+type UseCase struct {
+	trm                trm.Manager
+	networkHostSetupUC usecase.NetworkHostSetup
+	networkHostStorage storage.NetworkHost
+
+	checkInterval time.Duration
+	action        entity.NetworkHostExpiryAction
+
+	now nowFunc
+}
+
+// New creates the janitor for temporary network hosts. The configured action is validated when the config is loaded.
+func New(
+	trm trm.Manager,
+	networkHostSetupUC usecase.NetworkHostSetup,
+	networkHostStorage storage.NetworkHost,
+	expiryCfg *config.Expiry,
+) *UseCase {
+	return &UseCase{
+		trm:                trm,
+		networkHostSetupUC: networkHostSetupUC,
+		networkHostStorage: networkHostStorage,
+		checkInterval:      expiryCfg.CheckInterval,
+		action:             entity.NetworkHostExpiryAction(expiryCfg.Action),
+		now:                time.Now,
+	}
+}
+
+// ExpireAll disables or deletes every network host that has expired and re-syncs the affected networks.
+// A network that fails to sync doesn't stop the others.
+func (u *UseCase) ExpireAll(ctx context.Context) error {
+	now := u.now()
+	expiredHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{ExpiredAt: &now})
+	if err != nil {
+		return fmt.Errorf("failed to list expired network hosts: %w", err)
+	}
+
+	var networkIDs []uint64
+	hostsByNetworkID := make(map[uint64][]*entity.NetworkHost)
+	for _, expiredHost := range expiredHosts {
+		if _, ok := hostsByNetworkID[expiredHost.NetworkID]; !ok {
+			networkIDs = append(networkIDs, expiredHost.NetworkID)
+		}
+		hostsByNetworkID[expiredHost.NetworkID] = append(hostsByNetworkID[expiredHost.NetworkID], expiredHost)
+	}
+
+	var expireErrs []error
+	for _, networkID := range networkIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		expireErr := u.expireNetworkHosts(ctx, networkID, hostsByNetworkID[networkID])
+		if expireErr != nil {
+			expireErrs = append(expireErrs, expireErr)
+		}
+	}
+
+	return errors.Join(expireErrs...)
+}
+
+// Run expires hosts right away and then on every check interval until ctx is done.
+func (u *UseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		err := u.ExpireAll(ctx)
+		if err != nil {
+			slog.Warn("failed to expire network hosts", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *UseCase) expireNetworkHosts(ctx context.Context, networkID uint64, networkHosts []*entity.NetworkHost) error {
+	err := u.trm.Do(ctx, func(ctx context.Context) error {
+		for _, networkHost := range networkHosts {
+			trErr := u.expire(ctx, networkHost)
+			if trErr != nil {
+				return trErr
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to expire hosts of network %d: %w", networkID, err)
+	}
+
+	for _, networkHost := range networkHosts {
+		slog.Info(
+			"network host expired",
+			"network_id", networkHost.NetworkID,
+			"address", networkHost.Address,
+			"action", u.action,
+		)
+	}
+
+	err = u.networkHostSetupUC.SyncByNetworkID(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("failed to sync network %d after expiring its hosts: %w", networkID, err)
+	}
+
+	return nil
+}
+
+// expire deletes the host, or disables it and clears its expiry so re-enabling it makes it permanent.
+func (u *UseCase) expire(ctx context.Context, networkHost *entity.NetworkHost) error {
+	if u.action == entity.NetworkHostExpiryActionDelete {
+		err := u.networkHostStorage.Delete(ctx, networkHost.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete network host %s: %w", networkHost.Address, err)
+		}
+
+		return nil
+	}
+
+	err := u.networkHostStorage.SetEnabled(ctx, networkHost.ID, false)
+	if err != nil {
+		return fmt.Errorf("failed to disable network host %s: %w", networkHost.Address, err)
+	}
+
+	err = u.networkHostStorage.SetExpiresAt(ctx, networkHost.ID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to clear expiry of network host %s: %w", networkHost.Address, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package networkhostexpiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_trm "github.com/dmitrorlov/splitr/backend/mocks/trm"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+)
+
+func testNow() time.Time {
+	return time.Date(2025, 10, 22, 12, 0, 0, 0, time.UTC)
+}
+
+type testMocks struct {
+	trm                *mock_trm.MockManager
+	networkHostSetupUC *mock_usecase.MockNetworkHostSetup
+	networkHostStorage *mock_storage.MockNetworkHost
+}
+
+func newTestUseCase(ctrl *gomock.Controller, action string) (*UseCase, *testMocks) {
+	mocks := &testMocks{
+		trm:                mock_trm.NewMockManager(ctrl),
+		networkHostSetupUC: mock_usecase.NewMockNetworkHostSetup(ctrl),
+		networkHostStorage: mock_storage.NewMockNetworkHost(ctrl),
+	}
+
+	useCase := New(
+		mocks.trm,
+		mocks.networkHostSetupUC,
+		mocks.networkHostStorage,
+		&config.Expiry{CheckInterval: time.Minute, Action: action},
+	)
+	useCase.now = testNow
+
+	return useCase, mocks
+}
+
+func (m *testMocks) expectTransaction() {
+	m.trm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func (m *testMocks) expectExpiredHosts(hosts ...*entity.NetworkHost) {
+	now := testNow()
+	m.networkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{ExpiredAt: &now}).
+		Return(hosts, nil)
+}
+
+func TestNew_Action(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, _ := newTestUseCase(ctrl, "delete")
+	assert.Equal(t, entity.NetworkHostExpiryActionDelete, useCase.action)
+
+	useCase, _ = newTestUseCase(ctrl, "disable")
+	assert.Equal(t, entity.NetworkHostExpiryActionDisable, useCase.action)
+}
+
+func TestUseCase_ExpireAll(t *testing.T) {
+	t.Run("disables expired hosts, clears their expiry and syncs each network once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, "disable")
+		mocks.expectExpiredHosts(
+			&entity.NetworkHost{ID: 1, NetworkID: 10, Address: "vendor.example.com"},
+			&entity.NetworkHost{ID: 2, NetworkID: 10, Address: "demo.example.com"},
+			&entity.NetworkHost{ID: 3, NetworkID: 20, Address: "10.1.2.3"},
+		)
+
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().SetEnabled(gomock.Any(), uint64(1), false).Return(nil)
+		mocks.networkHostStorage.EXPECT().SetExpiresAt(gomock.Any(), uint64(1), nil).Return(nil)
+		mocks.networkHostStorage.EXPECT().SetEnabled(gomock.Any(), uint64(2), false).Return(nil)
+		mocks.networkHostStorage.EXPECT().SetExpiresAt(gomock.Any(), uint64(2), nil).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(10)).Return(nil)
+
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().SetEnabled(gomock.Any(), uint64(3), false).Return(nil)
+		mocks.networkHostStorage.EXPECT().SetExpiresAt(gomock.Any(), uint64(3), nil).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(20)).Return(nil)
+
+		err := useCase.ExpireAll(context.Background())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("deletes expired hosts when configured to", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, "delete")
+		mocks.expectExpiredHosts(&entity.NetworkHost{ID: 1, NetworkID: 10, Address: "vendor.example.com"})
+
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(1)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(10)).Return(nil)
+
+		err := useCase.ExpireAll(context.Background())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("does nothing without expired hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, "disable")
+		mocks.expectExpiredHosts()
+
+		err := useCase.ExpireAll(context.Background())
+
+		require.NoError(t, err)
+	})
+
+	t.Run("continues with other networks when one fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, "delete")
+		mocks.expectExpiredHosts(
+			&entity.NetworkHost{ID: 1, NetworkID: 10, Address: "vendor.example.com"},
+			&entity.NetworkHost{ID: 2, NetworkID: 20, Address: "demo.example.com"},
+		)
+
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(1)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().
+			SyncByNetworkID(gomock.Any(), uint64(10)).
+			Return(errors.New("sync failed"))
+
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(2)).Return(nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(20)).Return(nil)
+
+		err := useCase.ExpireAll(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to sync network 10 after expiring its hosts")
+		assert.Contains(t, err.Error(), "sync failed")
+	})
+
+	t.Run("error when listing expired hosts fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, "disable")
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		err := useCase.ExpireAll(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list expired network hosts")
+	})
+}
+
+func TestUseCase_Run_StopsOnContextCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl, "disable")
+	mocks.expectExpiredHosts()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		useCase.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after context cancel")
+	}
+}
@@ -0,0 +1,43 @@
+package networkhostimport
+
+import (
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// previewBuilder collects import candidates, keeping only the first occurrence of each address.
+type previewBuilder struct {
+	preview *entity.NetworkHostImportPreview
+	seen    map[string]struct{}
+}
+
+func newPreviewBuilder(networkID uint64, sizeHint int) *previewBuilder {
+	return &previewBuilder{
+		preview: &entity.NetworkHostImportPreview{
+			NetworkID:  networkID,
+			Candidates: make([]entity.NetworkHostImportCandidate, 0, sizeHint),
+		},
+		seen: make(map[string]struct{}, sizeHint),
+	}
+}
+
+// addCandidate reports whether the candidate was added, false means the address was already offered.
+func (b *previewBuilder) addCandidate(candidate entity.NetworkHostImportCandidate) bool {
+	if _, exists := b.seen[candidate.Address]; exists {
+		return false
+	}
+	b.seen[candidate.Address] = struct{}{}
+
+	b.preview.Candidates = append(b.preview.Candidates, candidate)
+	return true
+}
+
+func (b *previewBuilder) lastCandidate() *entity.NetworkHostImportCandidate {
+	return &b.preview.Candidates[len(b.preview.Candidates)-1]
+}
+
+func (b *previewBuilder) skip(value, reason string) {
+	b.preview.Skipped = append(b.preview.Skipped, entity.NetworkHostImportSkipped{
+		Value:  value,
+		Reason: reason,
+	})
+}
@@ -0,0 +1,79 @@
+package networkhostimport
+
+import (
+	"strings"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/sshconfig"
+)
+
+const (
+	skipReasonWildcard      = "wildcard pattern"
+	skipReasonInvalid       = "invalid address"
+	skipReasonHashed        = "hashed hostname"
+	skipReasonHostNameToken = "unsupported HostName token"
+
+	hashedKnownHostPrefix = "|"
+)
+
+// addSSHConfigHost offers each alias of a Host block. When the block sets HostName, the real
+// hostname becomes the address and the alias is kept as the description.
+func addSSHConfigHost(builder *previewBuilder, sshHost *sshconfig.Host) {
+	for _, pattern := range sshHost.Patterns {
+		if isSSHPattern(pattern) {
+			builder.skip(pattern, skipReasonWildcard)
+			continue
+		}
+
+		if sshHost.HostName == "" {
+			addSSHAddress(builder, pattern, "")
+			continue
+		}
+
+		hostName := expandHostNameTokens(sshHost.HostName, pattern)
+		if strings.Contains(hostName, "%") {
+			builder.skip(sshHost.HostName, skipReasonHostNameToken)
+			continue
+		}
+
+		description := ""
+		if !strings.EqualFold(hostName, pattern) {
+			description = pattern
+		}
+		addSSHAddress(builder, hostName, description)
+	}
+}
+
+func addKnownHost(builder *previewBuilder, knownHost string) {
+	switch {
+	case strings.HasPrefix(knownHost, hashedKnownHostPrefix):
+		builder.skip(knownHost, skipReasonHashed)
+	case isSSHPattern(knownHost):
+		builder.skip(knownHost, skipReasonWildcard)
+	default:
+		addSSHAddress(builder, knownHost, "")
+	}
+}
+
+func addSSHAddress(builder *previewBuilder, address, description string) {
+	if !entity.IsValidAddress(address) {
+		builder.skip(address, skipReasonInvalid)
+		return
+	}
+
+	builder.addCandidate(entity.NetworkHostImportCandidate{
+		Address:     address,
+		Description: description,
+	})
+}
+
+// isSSHPattern reports whether value matches more than one host: it has wildcards or is negated.
+func isSSHPattern(value string) bool {
+	return strings.ContainsAny(value, "*?") || strings.HasPrefix(value, "!")
+}
+
+// expandHostNameTokens replaces the tokens ssh accepts in HostName that only depend on the alias.
+func expandHostNameTokens(hostName, alias string) string {
+	hostName = strings.ReplaceAll(hostName, "%h", alias)
+	return strings.ReplaceAll(hostName, "%%", "%")
+}
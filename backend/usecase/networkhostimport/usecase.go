@@ -0,0 +1,208 @@
+package networkhostimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/pkg/sshconfig"
+	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+type (
+	lookupAddrFunc func(ctx context.Context, addr string) ([]string, error)
+	homeDirFunc    func() (string, error)
+)
+
+type UseCase struct {
+	commandExecutorUC  usecase.CommandExecutor
+	networkStorage     storage.Network
+	networkHostStorage storage.NetworkHost
+
+	lookupAddr lookupAddrFunc
+	homeDir    homeDirFunc
+}
+
+func New(
+	commandExecutorUC usecase.CommandExecutor,
+	networkStorage storage.Network,
+	networkHostStorage storage.NetworkHost,
+) *UseCase {
+	return &UseCase{
+		commandExecutorUC:  commandExecutorUC,
+		networkStorage:     networkStorage,
+		networkHostStorage: networkHostStorage,
+		lookupAddr:         net.DefaultResolver.LookupAddr,
+		homeDir:            os.UserHomeDir,
+	}
+}
+
+// PreviewFromSystemRoutes reads the additional routes already configured on the network's
+// service and offers each destination as a host, optionally suggesting hostnames for IPs.
+func (u *UseCase) PreviewFromSystemRoutes(
+	ctx context.Context,
+	networkID uint64,
+	reverseResolve bool,
+) (*entity.NetworkHostImportPreview, error) {
+	network, err := u.getNetwork(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	additionalRoutes, err := u.commandExecutorUC.GetNetworkAdditionalRoutes(ctx, network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network additional routes: %w", err)
+	}
+
+	builder := newPreviewBuilder(networkID, len(additionalRoutes))
+	for _, additionalRoute := range additionalRoutes {
+		address, ok := entity.AddressFromRoute(additionalRoute.Destination, additionalRoute.SubnetMask)
+		if !ok {
+			builder.skip(
+				additionalRoute.Destination+" "+additionalRoute.SubnetMask,
+				"invalid destination or subnet mask",
+			)
+			continue
+		}
+
+		if !builder.addCandidate(entity.NetworkHostImportCandidate{Address: address}) {
+			continue
+		}
+		if reverseResolve && !entity.IsCIDR(address) {
+			builder.lastCandidate().SuggestedHostnames = u.listHostnamesByIP(ctx, address)
+		}
+	}
+
+	return u.buildPreview(ctx, builder)
+}
+
+// PreviewFromSSHConfig offers the hosts of ~/.ssh/config, and optionally ~/.ssh/known_hosts, as network hosts.
+// Wildcard patterns and hashed known_hosts entries can't be turned into hosts and are reported as skipped.
+func (u *UseCase) PreviewFromSSHConfig(
+	ctx context.Context,
+	networkID uint64,
+	includeKnownHosts bool,
+) (*entity.NetworkHostImportPreview, error) {
+	if _, err := u.getNetwork(ctx, networkID); err != nil {
+		return nil, err
+	}
+
+	homeDir, err := u.homeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	sshHosts, err := sshconfig.ParseConfigFile(filepath.Join(homeDir, ".ssh", "config"), homeDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to parse ssh config: %w", err)
+	}
+
+	builder := newPreviewBuilder(networkID, len(sshHosts))
+	for _, sshHost := range sshHosts {
+		addSSHConfigHost(builder, sshHost)
+	}
+
+	if includeKnownHosts {
+		knownHosts, parseErr := sshconfig.ParseKnownHostsFile(filepath.Join(homeDir, ".ssh", "known_hosts"))
+		if parseErr != nil && !errors.Is(parseErr, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to parse known_hosts: %w", parseErr)
+		}
+
+		for _, knownHost := range knownHosts {
+			addKnownHost(builder, knownHost)
+		}
+	}
+
+	return u.buildPreview(ctx, builder)
+}
+
+func (u *UseCase) buildPreview(
+	ctx context.Context,
+	builder *previewBuilder,
+) (*entity.NetworkHostImportPreview, error) {
+	err := u.markExistingCandidates(ctx, builder.preview.NetworkID, builder.preview.Candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.preview, nil
+}
+
+func (u *UseCase) getNetwork(ctx context.Context, networkID uint64) (*entity.Network, error) {
+	network, err := u.networkStorage.Get(ctx, networkID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkNotFound) {
+			return nil, fmt.Errorf("network with ID %d not found: %w", networkID, err)
+		}
+		return nil, fmt.Errorf("failed to validate network: %w", err)
+	}
+
+	return network, nil
+}
+
+func (u *UseCase) markExistingCandidates(
+	ctx context.Context,
+	networkID uint64,
+	candidates []entity.NetworkHostImportCandidate,
+) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		addresses = append(addresses, candidate.Address)
+	}
+
+	existingHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{
+		NetworkID: []uint64{networkID},
+		Address:   addresses,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list existing network hosts: %w", err)
+	}
+
+	existingAddresses := make(map[string]struct{}, len(existingHosts))
+	for _, existingHost := range existingHosts {
+		existingAddresses[existingHost.Address] = struct{}{}
+	}
+
+	for i := range candidates {
+		_, candidates[i].AlreadyExists = existingAddresses[candidates[i].Address]
+	}
+
+	return nil
+}
+
+// listHostnamesByIP returns valid hostnames pointing at ip. Lookup failures are not fatal,
+// the suggestion is simply left empty.
+func (u *UseCase) listHostnamesByIP(ctx context.Context, ip string) []string {
+	names, err := u.lookupAddr(ctx, ip)
+	if err != nil {
+		return nil
+	}
+
+	hostnames := make([]string, 0, len(names))
+	for _, name := range names {
+		hostname := strings.TrimSuffix(name, ".")
+		if !entity.IsValidAddress(hostname) {
+			continue
+		}
+
+		hostnames = append(hostnames, hostname)
+	}
+
+	if len(hostnames) == 0 {
+		return nil
+	}
+
+	return hostnames
+}
@@ -0,0 +1,342 @@
+package networkhostimport
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+	useCase := New(mockCommandExecutor, mockNetworkStorage, mockNetworkHostStorage)
+
+	require.NotNil(t, useCase)
+	assert.Equal(t, mockCommandExecutor, useCase.commandExecutorUC)
+	assert.Equal(t, mockNetworkStorage, useCase.networkStorage)
+	assert.Equal(t, mockNetworkHostStorage, useCase.networkHostStorage)
+	assert.NotNil(t, useCase.lookupAddr)
+	assert.NotNil(t, useCase.homeDir)
+}
+
+func TestUseCase_PreviewFromSystemRoutes(t *testing.T) {
+	network := &entity.Network{ID: 1, Name: "Corp VPN"}
+
+	tests := []struct {
+		name           string
+		reverseResolve bool
+		setupMocks     func(*mock_usecase.MockCommandExecutor, *mock_storage.MockNetwork, *mock_storage.MockNetworkHost)
+		lookupAddr     lookupAddrFunc
+		expected       *entity.NetworkHostImportPreview
+		expectedError  string
+	}{
+		{
+			name:           "routes become candidates",
+			reverseResolve: true,
+			setupMocks: func(
+				mockCommandExecutor *mock_usecase.MockCommandExecutor,
+				mockNetworkStorage *mock_storage.MockNetwork,
+				mockNetworkHostStorage *mock_storage.MockNetworkHost,
+			) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkAdditionalRoutes(gomock.Any(), network).
+					Return([]*entity.AdditionalRoute{
+						{Destination: "10.1.2.3", SubnetMask: "255.255.255.255", Router: "10.0.0.1"},
+						{Destination: "10.20.0.0", SubnetMask: "255.255.0.0", Router: "10.0.0.1"},
+						{Destination: "10.1.2.3", SubnetMask: "255.255.255.255", Router: "10.0.0.1"},
+						{Destination: "10.30.0.0", SubnetMask: "255.0.255.0", Router: "10.0.0.1"},
+					}, nil)
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{1},
+						Address:   []string{"10.1.2.3", "10.20.0.0/16"},
+					}).
+					Return([]*entity.NetworkHost{{ID: 5, NetworkID: 1, Address: "10.20.0.0/16"}}, nil)
+			},
+			lookupAddr: func(_ context.Context, addr string) ([]string, error) {
+				assert.Equal(t, "10.1.2.3", addr)
+				return []string{"git.corp.example.", "-invalid."}, nil
+			},
+			expected: &entity.NetworkHostImportPreview{
+				NetworkID: 1,
+				Candidates: []entity.NetworkHostImportCandidate{
+					{Address: "10.1.2.3", SuggestedHostnames: []string{"git.corp.example"}},
+					{Address: "10.20.0.0/16", AlreadyExists: true},
+				},
+				Skipped: []entity.NetworkHostImportSkipped{
+					{Value: "10.30.0.0 255.0.255.0", Reason: "invalid destination or subnet mask"},
+				},
+			},
+		},
+		{
+			name: "lookup failure leaves suggestions empty",
+			setupMocks: func(
+				mockCommandExecutor *mock_usecase.MockCommandExecutor,
+				mockNetworkStorage *mock_storage.MockNetwork,
+				mockNetworkHostStorage *mock_storage.MockNetworkHost,
+			) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkAdditionalRoutes(gomock.Any(), network).
+					Return([]*entity.AdditionalRoute{
+						{Destination: "10.1.2.3", SubnetMask: "255.255.255.255", Router: "10.0.0.1"},
+					}, nil)
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]*entity.NetworkHost{}, nil)
+			},
+			reverseResolve: true,
+			lookupAddr: func(_ context.Context, _ string) ([]string, error) {
+				return nil, errors.New("no such host")
+			},
+			expected: &entity.NetworkHostImportPreview{
+				NetworkID:  1,
+				Candidates: []entity.NetworkHostImportCandidate{{Address: "10.1.2.3"}},
+			},
+		},
+		{
+			name: "no routes configured",
+			setupMocks: func(
+				mockCommandExecutor *mock_usecase.MockCommandExecutor,
+				mockNetworkStorage *mock_storage.MockNetwork,
+				_ *mock_storage.MockNetworkHost,
+			) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkAdditionalRoutes(gomock.Any(), network).
+					Return([]*entity.AdditionalRoute{}, nil)
+			},
+			expected: &entity.NetworkHostImportPreview{
+				NetworkID:  1,
+				Candidates: []entity.NetworkHostImportCandidate{},
+			},
+		},
+		{
+			name: "network not found",
+			setupMocks: func(
+				_ *mock_usecase.MockCommandExecutor,
+				mockNetworkStorage *mock_storage.MockNetwork,
+				_ *mock_storage.MockNetworkHost,
+			) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(nil, errs.ErrNetworkNotFound)
+			},
+			expectedError: "network with ID 1 not found",
+		},
+		{
+			name: "command error",
+			setupMocks: func(
+				mockCommandExecutor *mock_usecase.MockCommandExecutor,
+				mockNetworkStorage *mock_storage.MockNetwork,
+				_ *mock_storage.MockNetworkHost,
+			) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkAdditionalRoutes(gomock.Any(), network).
+					Return(nil, errors.New("networksetup failed"))
+			},
+			expectedError: "failed to get network additional routes: networksetup failed",
+		},
+		{
+			name: "storage error",
+			setupMocks: func(
+				mockCommandExecutor *mock_usecase.MockCommandExecutor,
+				mockNetworkStorage *mock_storage.MockNetwork,
+				mockNetworkHostStorage *mock_storage.MockNetworkHost,
+			) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkAdditionalRoutes(gomock.Any(), network).
+					Return([]*entity.AdditionalRoute{
+						{Destination: "10.1.2.3", SubnetMask: "255.255.255.255", Router: "10.0.0.1"},
+					}, nil)
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: "failed to list existing network hosts: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			tt.setupMocks(mockCommandExecutor, mockNetworkStorage, mockNetworkHostStorage)
+
+			useCase := New(mockCommandExecutor, mockNetworkStorage, mockNetworkHostStorage)
+			useCase.lookupAddr = func(_ context.Context, _ string) ([]string, error) {
+				t.Fatal("unexpected reverse lookup")
+				return nil, nil
+			}
+			if tt.lookupAddr != nil {
+				useCase.lookupAddr = tt.lookupAddr
+			}
+
+			result, err := useCase.PreviewFromSystemRoutes(context.Background(), 1, tt.reverseResolve)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestUseCase_PreviewFromSSHConfig(t *testing.T) {
+	network := &entity.Network{ID: 1, Name: "Corp VPN"}
+
+	tests := []struct {
+		name              string
+		files             map[string]string
+		includeKnownHosts bool
+		setupMocks        func(*mock_storage.MockNetwork, *mock_storage.MockNetworkHost)
+		expected          *entity.NetworkHostImportPreview
+		expectedError     string
+	}{
+		{
+			name: "config and known_hosts",
+			files: map[string]string{
+				"config": "Include config.d/*\n" +
+					"Host gitlab\n  HostName gitlab.corp.example\n" +
+					"Host *.corp.example !bastion\n  User admin\n" +
+					"Host bad..host\n" +
+					"Host jira\n  HostName %h.%d.example\n",
+				"config.d/db":  "Host db.corp.example\n",
+				"known_hosts":  "gitlab.corp.example,10.1.2.3 ssh-ed25519 AAAA\n|1|c2FsdA==|aGFzaA== ssh-rsa AAAA\n",
+				"unrelated.db": "Host ignored\n",
+			},
+			includeKnownHosts: true,
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), &entity.ListNetworkHostFilter{
+						NetworkID: []uint64{1},
+						Address:   []string{"db.corp.example", "gitlab.corp.example", "10.1.2.3"},
+					}).
+					Return([]*entity.NetworkHost{{ID: 3, NetworkID: 1, Address: "10.1.2.3"}}, nil)
+			},
+			expected: &entity.NetworkHostImportPreview{
+				NetworkID: 1,
+				Candidates: []entity.NetworkHostImportCandidate{
+					{Address: "db.corp.example"},
+					{Address: "gitlab.corp.example", Description: "gitlab"},
+					{Address: "10.1.2.3", AlreadyExists: true},
+				},
+				Skipped: []entity.NetworkHostImportSkipped{
+					{Value: "*.corp.example", Reason: "wildcard pattern"},
+					{Value: "!bastion", Reason: "wildcard pattern"},
+					{Value: "bad..host", Reason: "invalid address"},
+					{Value: "%h.%d.example", Reason: "unsupported HostName token"},
+					{Value: "|1|c2FsdA==|aGFzaA==", Reason: "hashed hostname"},
+				},
+			},
+		},
+		{
+			name: "known_hosts excluded",
+			files: map[string]string{
+				"config":      "Host git.corp.example\n",
+				"known_hosts": "10.1.2.3 ssh-ed25519 AAAA\n",
+			},
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return([]*entity.NetworkHost{}, nil)
+			},
+			expected: &entity.NetworkHostImportPreview{
+				NetworkID:  1,
+				Candidates: []entity.NetworkHostImportCandidate{{Address: "git.corp.example"}},
+			},
+		},
+		{
+			name:              "no ssh files",
+			includeKnownHosts: true,
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, _ *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+			},
+			expected: &entity.NetworkHostImportPreview{
+				NetworkID:  1,
+				Candidates: []entity.NetworkHostImportCandidate{},
+			},
+		},
+		{
+			name:  "include loop",
+			files: map[string]string{"config": "Include config\n"},
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, _ *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+			},
+			expectedError: "failed to parse ssh config",
+		},
+		{
+			name: "network not found",
+			setupMocks: func(mockNetworkStorage *mock_storage.MockNetwork, _ *mock_storage.MockNetworkHost) {
+				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(nil, errs.ErrNetworkNotFound)
+			},
+			expectedError: "network with ID 1 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			homeDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(homeDir, ".ssh", name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			}
+
+			mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			tt.setupMocks(mockNetworkStorage, mockNetworkHostStorage)
+
+			useCase := New(mockCommandExecutor, mockNetworkStorage, mockNetworkHostStorage)
+			useCase.homeDir = func() (string, error) {
+				return homeDir, nil
+			}
+
+			result, err := useCase.PreviewFromSSHConfig(context.Background(), 1, tt.includeKnownHosts)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package networkhostsetup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+// compensationTimeout bounds restoring routes after a failed generation. Compensation runs even when the sync
+// was cancelled, since the cancellation may have cut networksetup off halfway.
+const compensationTimeout = 10 * time.Second
+
+// applyPlan moves a network's routes to the plan in recorded steps. The plan's routes are added as a pending
+// setup generation, handed to the system, and committed by storing the network host setups and marking
+// the generation applied in one transaction. If the routes or the commit fail, the routes of the last
+// applied generation are restored and the pending generation is marked failed, so the system routes
+// and the stored setups don't drift apart.
+func (u *UseCase) applyPlan(ctx context.Context, plan *routePlan) error {
+	previous, err := u.lastApplied(ctx, plan.network.ID)
+	if err != nil {
+		return err
+	}
+
+	generation := planGeneration(plan)
+
+	pending, err := u.setupGenerationStorage.Add(ctx, generation)
+	if err != nil {
+		return fmt.Errorf("failed to add pending setup generation: %w", err)
+	}
+	generation.ID = pending.ID
+
+	err = u.applyGeneration(ctx, plan.network, generation, previous)
+	if err != nil {
+		return u.compensate(ctx, plan.network, generation, previous, err)
+	}
+
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		trErr := u.storeHostSetups(ctx, plan.hostSetups)
+		if trErr != nil {
+			return trErr
+		}
+
+		trErr = u.setupGenerationStorage.MarkApplied(ctx, generation.ID)
+		if trErr != nil {
+			return fmt.Errorf("failed to mark setup generation applied: %w", trErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return u.compensate(ctx, plan.network, generation, previous, fmt.Errorf("failed to apply transaction: %w", err))
+	}
+
+	return nil
+}
+
+// planGeneration returns the pending generation of the routes the plan applies.
+func planGeneration(plan *routePlan) *entity.NetworkSetupGeneration {
+	var networkService entity.NetworkService
+	if plan.network.IsInverse() {
+		networkService = plan.networkInfo.NetworkService
+	}
+
+	return entity.NewNetworkSetupGeneration(plan.network.ID, networkService, plan.tunnelSetups, plan.directSetups)
+}
+
+// applyGeneration hands the tunnel routes to the VPN service and, in inverse mode, the direct routes
+// to the physical service. replaced is the generation whose routes the system may still have, nil without one.
+// Its direct routes are taken off the service they were set on, even when the network has moved
+// to another service or out of inverse mode since.
+func (u *UseCase) applyGeneration(
+	ctx context.Context,
+	network *entity.Network,
+	generation *entity.NetworkSetupGeneration,
+	replaced *entity.NetworkSetupGeneration,
+) error {
+	err := u.commandExecutorUC.SetNetworkAdditionalRoutes(ctx, network, generation.TunnelRoutes)
+	if err != nil {
+		return fmt.Errorf("failed to set network additional routes: %w", err)
+	}
+
+	var replacedService entity.NetworkService
+	var replacedRoutes []*entity.NetworkHostSetup
+	if replaced != nil {
+		replacedService = replaced.NetworkService
+		replacedRoutes = replaced.DirectRoutes
+	}
+
+	if generation.NetworkService != "" {
+		var owned []*entity.NetworkHostSetup
+		if replacedService == generation.NetworkService {
+			owned = replacedRoutes
+		}
+
+		err = u.setDirectRoutes(ctx, generation.NetworkService, owned, generation.DirectRoutes)
+		if err != nil {
+			return fmt.Errorf("failed to set direct routes: %w", err)
+		}
+	}
+
+	if replacedService != "" && replacedService != generation.NetworkService {
+		err = u.setDirectRoutes(ctx, replacedService, replacedRoutes, nil)
+		if err != nil {
+			return fmt.Errorf("failed to remove direct routes from %s: %w", replacedService, err)
+		}
+	}
+
+	return nil
+}
+
+// setDirectRoutes sets routes on a physical service in place of owned, the routes Splitr set there before.
+// Routes the service has from anyone else are kept, networksetup would otherwise replace them all.
+func (u *UseCase) setDirectRoutes(
+	ctx context.Context,
+	networkService entity.NetworkService,
+	owned []*entity.NetworkHostSetup,
+	routes []*entity.NetworkHostSetup,
+) error {
+	currentRoutes, err := u.commandExecutorUC.GetServiceAdditionalRoutes(ctx, networkService)
+	if err != nil {
+		return fmt.Errorf("failed to get additional routes of %s: %w", networkService, err)
+	}
+
+	foreign := foreignRoutes(currentRoutes, owned, routes)
+	if len(foreign) > 0 {
+		slog.Info("keeping additional routes Splitr didn't set",
+			"network_service", networkService, "routes", len(foreign))
+	}
+
+	err = u.commandExecutorUC.SetServiceAdditionalRoutes(ctx, networkService, append(foreign, routes...))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// foreignRoutes returns the current routes of a service that none of the given route lists has,
+// matched by destination and subnet mask. The result is never nil, so it replaces a service's routes as is.
+func foreignRoutes(
+	currentRoutes []*entity.AdditionalRoute,
+	splitrRoutes ...[]*entity.NetworkHostSetup,
+) []*entity.NetworkHostSetup {
+	foreign := make([]*entity.NetworkHostSetup, 0, len(currentRoutes))
+	for _, route := range currentRoutes {
+		isSplitrRoute := slices.ContainsFunc(splitrRoutes, func(setups []*entity.NetworkHostSetup) bool {
+			return slices.ContainsFunc(setups, func(setup *entity.NetworkHostSetup) bool {
+				return setup.NetworkHostIP == route.Destination && setup.SubnetMask == route.SubnetMask
+			})
+		})
+		if isSplitrRoute {
+			continue
+		}
+
+		foreign = append(foreign, &entity.NetworkHostSetup{
+			NetworkHostIP: route.Destination,
+			SubnetMask:    route.SubnetMask,
+			Router:        route.Router,
+		})
+	}
+
+	return foreign
+}
+
+// lastApplied returns the network's last applied generation, nil when none was recorded.
+func (u *UseCase) lastApplied(ctx context.Context, networkID uint64) (*entity.NetworkSetupGeneration, error) {
+	generation, err := u.setupGenerationStorage.GetLastApplied(ctx, networkID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkSetupGenerationNotFound) {
+			return nil, nil //nolint:nilnil // no generation was recorded
+		}
+		return nil, fmt.Errorf("failed to get last applied setup generation: %w", err)
+	}
+
+	return generation, nil
+}
+
+// storeHostSetups replaces the stored setups of the network hosts the routes were resolved from.
+// Routes coming from host groups have no network host to be stored against, they are only applied.
+func (u *UseCase) storeHostSetups(ctx context.Context, hostSetups []*entity.NetworkHostSetup) error {
+	storedSetupList := slices.DeleteFunc(slices.Clone(hostSetups), func(setup *entity.NetworkHostSetup) bool {
+		return setup.NetworkHostID == 0
+	})
+	if len(storedSetupList) == 0 {
+		return nil
+	}
+
+	networkHostIDsMap := make(map[uint64]struct{}, len(storedSetupList))
+	for _, networkHostSetup := range storedSetupList {
+		networkHostIDsMap[networkHostSetup.NetworkHostID] = struct{}{}
+	}
+
+	err := u.networkHostSetupStorage.DeleteBatchByNetworkHostIDs(ctx, slices.Collect(maps.Keys(networkHostIDsMap)))
+	if err != nil {
+		return fmt.Errorf("failed to delete network host setup list by network host ids: %w", err)
+	}
+
+	err = u.networkHostSetupStorage.AddBatch(ctx, storedSetupList)
+	if err != nil {
+		return fmt.Errorf("failed to add network host setup list: %w", err)
+	}
+
+	return nil
+}
+
+// compensate restores the routes of the network's last applied generation, previous, after the pending one failed
+// and marks the pending generation failed. It outlives the cancellation of ctx, bounded by compensationTimeout.
+// The returned error carries the failure and anything compensation couldn't undo.
+func (u *UseCase) compensate(
+	ctx context.Context,
+	network *entity.Network,
+	pending *entity.NetworkSetupGeneration,
+	previous *entity.NetworkSetupGeneration,
+	cause error,
+) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), compensationTimeout)
+	defer cancel()
+
+	restoreErr := u.restoreLastApplied(ctx, network, pending, previous)
+	if restoreErr != nil {
+		restoreErr = fmt.Errorf("failed to restore routes of the last applied setup generation: %w", restoreErr)
+	}
+
+	markErr := u.setupGenerationStorage.MarkFailed(ctx, pending.ID)
+	if markErr != nil {
+		markErr = fmt.Errorf("failed to mark setup generation failed: %w", markErr)
+	}
+
+	return errors.Join(cause, restoreErr, markErr)
+}
+
+// restoreLastApplied re-applies the network's last applied generation in place of the pending one,
+// which also removes direct routes the pending generation set on a service the previous one didn't use.
+// Without a previous generation, the routes Splitr set before are unknown and are left as the pending one got them.
+func (u *UseCase) restoreLastApplied(
+	ctx context.Context,
+	network *entity.Network,
+	pending *entity.NetworkSetupGeneration,
+	previous *entity.NetworkSetupGeneration,
+) error {
+	if previous == nil {
+		slog.Warn("no applied setup generation to restore", "network", network.Name)
+		return nil
+	}
+
+	err := u.applyGeneration(ctx, network, previous, pending)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("restored routes of the last applied setup generation",
+		"network", network.Name, "generation_id", previous.ID)
+
+	return nil
+}
+
+// recordReset records a reset network as an applied generation without routes,
+// which a later failed sync restores.
+func (u *UseCase) recordReset(ctx context.Context, networkID uint64) error {
+	generation, err := u.setupGenerationStorage.Add(ctx, entity.NewNetworkSetupGeneration(networkID, "", nil, nil))
+	if err != nil {
+		return fmt.Errorf("failed to add reset setup generation: %w", err)
+	}
+
+	err = u.setupGenerationStorage.MarkApplied(ctx, generation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark setup generation applied: %w", err)
+	}
+
+	return nil
+}
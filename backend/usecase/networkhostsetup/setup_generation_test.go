@@ -0,0 +1,543 @@
+package networkhostsetup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_trm "github.com/dmitrorlov/splitr/backend/mocks/trm"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type setupGenerationMocks struct {
+	trm                     *mock_trm.MockManager
+	commandExecutor         *mock_usecase.MockCommandExecutor
+	networkHostSetupStorage *mock_storage.MockNetworkHostSetup
+	setupGenerationStorage  *mock_storage.MockNetworkSetupGeneration
+}
+
+// expectCommit runs the commit transaction with the host setups stored and the generation marked applied,
+// and returns commitErr as the transaction result.
+func (m *setupGenerationMocks) expectCommit(markAppliedErr, commitErr error) {
+	m.trm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return commitErr
+		})
+	m.networkHostSetupStorage.EXPECT().DeleteBatchByNetworkHostIDs(gomock.Any(), []uint64{1}).Return(nil)
+	m.networkHostSetupStorage.EXPECT().AddBatch(gomock.Any(), gomock.Len(1)).Return(nil)
+	m.setupGenerationStorage.EXPECT().MarkApplied(gomock.Any(), uint64(8)).Return(markAppliedErr)
+}
+
+func TestUseCase_SyncByNetworkID_SetupGenerations(t *testing.T) {
+	splitNetwork := &entity.Network{ID: 1, Name: "TestNetwork"}
+	inverseNetwork := &entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeInverse}
+	tunnelRoutes := []*entity.NetworkHostSetup{{
+		NetworkHostID:  1,
+		NetworkHostIP:  "10.20.0.0",
+		SubnetMask:     "255.255.0.0",
+		Router:         "192.168.1.1",
+		NetworkHostIDs: []uint64{1},
+	}}
+	previousRoutes := []*entity.NetworkHostSetup{{
+		NetworkHostIP: "10.30.0.0",
+		SubnetMask:    "255.255.0.0",
+		Router:        "192.168.1.1",
+	}}
+	previous := &entity.NetworkSetupGeneration{
+		ID:           7,
+		NetworkID:    1,
+		Status:       entity.NetworkSetupGenerationStatusApplied,
+		TunnelRoutes: previousRoutes,
+	}
+
+	tests := []struct {
+		name          string
+		network       *entity.Network
+		setupMocks    func(m *setupGenerationMocks, network *entity.Network)
+		expectedError []string
+	}{
+		{
+			name:    "routes and setups are committed with the generation",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					GetLastApplied(gomock.Any(), uint64(1)).
+					Return(nil, errs.ErrNetworkSetupGenerationNotFound)
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						_ context.Context,
+						generation *entity.NetworkSetupGeneration,
+					) (*entity.NetworkSetupGeneration, error) {
+						assert.Equal(t, entity.NetworkSetupGenerationStatusPending, generation.Status)
+						assert.Equal(t, entity.RouteList(tunnelRoutes), generation.TunnelRoutes)
+						assert.Empty(t, generation.NetworkService)
+						return &entity.NetworkSetupGeneration{ID: 8}, nil
+					})
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).Return(nil)
+				m.expectCommit(nil, nil)
+			},
+		},
+		{
+			name:    "nothing changes when the VPN can't be checked",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, _ *entity.Network) {
+				m.commandExecutor.EXPECT().
+					GetCurrentVPN(gomock.Any()).
+					Return(entity.VPNService(""), errors.New("scutil failed"))
+			},
+			expectedError: []string{"failed to get current VPN: scutil failed"},
+		},
+		{
+			name:    "routes aren't touched when the pending generation can't be recorded",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, _ *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					GetLastApplied(gomock.Any(), uint64(1)).
+					Return(nil, errs.ErrNetworkSetupGenerationNotFound)
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("disk full"))
+			},
+			expectedError: []string{"failed to add pending setup generation: disk full"},
+		},
+		{
+			name:    "failed routes are restored to the last applied generation",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				gomock.InOrder(
+					m.commandExecutor.EXPECT().
+						SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).
+						Return(errors.New("networksetup failed")),
+					m.commandExecutor.EXPECT().
+						SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).
+						Return(nil),
+				)
+				m.setupGenerationStorage.EXPECT().GetLastApplied(gomock.Any(), uint64(1)).Return(previous, nil)
+				m.setupGenerationStorage.EXPECT().MarkFailed(gomock.Any(), uint64(8)).Return(nil)
+			},
+			expectedError: []string{"failed to set network additional routes: networksetup failed"},
+		},
+		{
+			name:    "partly applied inverse routes are undone",
+			network: inverseNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						_ context.Context,
+						generation *entity.NetworkSetupGeneration,
+					) (*entity.NetworkSetupGeneration, error) {
+						assert.Equal(t, entity.NetworkService("Wi-Fi"), generation.NetworkService)
+						return &entity.NetworkSetupGeneration{ID: 8}, nil
+					})
+				gomock.InOrder(
+					m.commandExecutor.EXPECT().
+						SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Not(previousRoutes)).
+						Return(nil),
+					m.commandExecutor.EXPECT().
+						GetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Wi-Fi")).
+						Return(nil, nil),
+					m.commandExecutor.EXPECT().
+						SetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Wi-Fi"), gomock.Len(1)).
+						Return(errors.New("networksetup failed")),
+					m.commandExecutor.EXPECT().
+						SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).
+						Return(nil),
+					m.commandExecutor.EXPECT().
+						GetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Wi-Fi")).
+						Return(nil, nil),
+					// The previous generation had no direct routes, the ones just set are removed.
+					m.commandExecutor.EXPECT().
+						SetServiceAdditionalRoutes(
+							gomock.Any(),
+							entity.NetworkService("Wi-Fi"),
+							[]*entity.NetworkHostSetup{},
+						).
+						Return(nil),
+				)
+				m.setupGenerationStorage.EXPECT().GetLastApplied(gomock.Any(), uint64(1)).Return(previous, nil)
+				m.setupGenerationStorage.EXPECT().MarkFailed(gomock.Any(), uint64(8)).Return(nil)
+			},
+			expectedError: []string{"failed to set direct routes: networksetup failed"},
+		},
+		{
+			name:    "direct routes move off the service the last generation set them on",
+			network: inverseNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				movedRoute := &entity.NetworkHostSetup{
+					NetworkHostIP: "10.20.0.0",
+					SubnetMask:    "255.255.0.0",
+					Router:        "192.168.5.1",
+				}
+				foreignRoute := &entity.NetworkHostSetup{
+					NetworkHostIP: "172.16.0.0",
+					SubnetMask:    "255.240.0.0",
+					Router:        "192.168.5.254",
+				}
+				m.setupGenerationStorage.EXPECT().
+					GetLastApplied(gomock.Any(), uint64(1)).
+					Return(&entity.NetworkSetupGeneration{
+						ID:             7,
+						NetworkID:      1,
+						Status:         entity.NetworkSetupGenerationStatusApplied,
+						NetworkService: "Ethernet",
+						DirectRoutes:   []*entity.NetworkHostSetup{movedRoute},
+					}, nil)
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				m.commandExecutor.EXPECT().
+					SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Any()).
+					Return(nil)
+				m.commandExecutor.EXPECT().
+					GetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Wi-Fi")).
+					Return(nil, nil)
+				m.commandExecutor.EXPECT().
+					SetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Wi-Fi"), gomock.Len(1)).
+					Return(nil)
+				// Only the routes Splitr set on Ethernet go, the user's own route stays.
+				m.commandExecutor.EXPECT().
+					GetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Ethernet")).
+					Return([]*entity.AdditionalRoute{
+						{Destination: "10.20.0.0", SubnetMask: "255.255.0.0", Router: "192.168.5.1"},
+						{Destination: "172.16.0.0", SubnetMask: "255.240.0.0", Router: "192.168.5.254"},
+					}, nil)
+				m.commandExecutor.EXPECT().
+					SetServiceAdditionalRoutes(
+						gomock.Any(),
+						entity.NetworkService("Ethernet"),
+						[]*entity.NetworkHostSetup{foreignRoute},
+					).
+					Return(nil)
+				m.expectCommit(nil, nil)
+			},
+		},
+		{
+			name:    "routes are restored when the host setups can't be deleted",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).Return(nil)
+				m.trm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				m.networkHostSetupStorage.EXPECT().
+					DeleteBatchByNetworkHostIDs(gomock.Any(), []uint64{1}).
+					Return(errors.New("delete failed"))
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).Return(nil)
+				m.setupGenerationStorage.EXPECT().GetLastApplied(gomock.Any(), uint64(1)).Return(previous, nil)
+				m.setupGenerationStorage.EXPECT().MarkFailed(gomock.Any(), uint64(8)).Return(nil)
+			},
+			expectedError: []string{
+				"failed to apply transaction: failed to delete network host setup list by network host ids: " +
+					"delete failed",
+			},
+		},
+		{
+			name:    "routes are restored when the host setups can't be added",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).Return(nil)
+				m.trm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				m.networkHostSetupStorage.EXPECT().DeleteBatchByNetworkHostIDs(gomock.Any(), []uint64{1}).Return(nil)
+				m.networkHostSetupStorage.EXPECT().AddBatch(gomock.Any(), gomock.Any()).Return(errors.New("add failed"))
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).Return(nil)
+				m.setupGenerationStorage.EXPECT().GetLastApplied(gomock.Any(), uint64(1)).Return(previous, nil)
+				m.setupGenerationStorage.EXPECT().MarkFailed(gomock.Any(), uint64(8)).Return(nil)
+			},
+			expectedError: []string{"failed to apply transaction: failed to add network host setup list: add failed"},
+		},
+		{
+			name:    "routes are restored when the generation can't be marked applied",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).Return(nil)
+				m.expectCommit(errors.New("update failed"), nil)
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).Return(nil)
+				m.setupGenerationStorage.EXPECT().GetLastApplied(gomock.Any(), uint64(1)).Return(previous, nil)
+				m.setupGenerationStorage.EXPECT().MarkFailed(gomock.Any(), uint64(8)).Return(nil)
+			},
+			expectedError: []string{
+				"failed to apply transaction: failed to mark setup generation applied: update failed",
+			},
+		},
+		{
+			name:    "routes are restored when the commit fails",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).Return(nil)
+				m.expectCommit(nil, errors.New("database is locked"))
+				m.commandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).Return(nil)
+				m.setupGenerationStorage.EXPECT().GetLastApplied(gomock.Any(), uint64(1)).Return(previous, nil)
+				m.setupGenerationStorage.EXPECT().MarkFailed(gomock.Any(), uint64(8)).Return(nil)
+			},
+			expectedError: []string{"failed to apply transaction: database is locked"},
+		},
+		{
+			name:    "routes are left alone without an applied generation to restore",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				m.commandExecutor.EXPECT().
+					SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).
+					Return(errors.New("networksetup failed"))
+				m.setupGenerationStorage.EXPECT().
+					GetLastApplied(gomock.Any(), uint64(1)).
+					Return(nil, errs.ErrNetworkSetupGenerationNotFound)
+				m.setupGenerationStorage.EXPECT().MarkFailed(gomock.Any(), uint64(8)).Return(nil)
+			},
+			expectedError: []string{"failed to set network additional routes: networksetup failed"},
+		},
+		{
+			name:    "failed compensation is reported with the failure",
+			network: splitNetwork,
+			setupMocks: func(m *setupGenerationMocks, network *entity.Network) {
+				m.setupGenerationStorage.EXPECT().
+					Add(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+				gomock.InOrder(
+					m.commandExecutor.EXPECT().
+						SetNetworkAdditionalRoutes(gomock.Any(), network, tunnelRoutes).
+						Return(errors.New("networksetup failed")),
+					m.commandExecutor.EXPECT().
+						SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).
+						Return(errors.New("networksetup still failing")),
+				)
+				m.setupGenerationStorage.EXPECT().GetLastApplied(gomock.Any(), uint64(1)).Return(previous, nil)
+				m.setupGenerationStorage.EXPECT().
+					MarkFailed(gomock.Any(), uint64(8)).
+					Return(errors.New("database is locked"))
+			},
+			expectedError: []string{
+				"failed to set network additional routes: networksetup failed",
+				"failed to restore routes of the last applied setup generation: " +
+					"failed to set network additional routes: networksetup still failing",
+				"failed to mark setup generation failed: database is locked",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mocks := &setupGenerationMocks{
+				trm:                     mock_trm.NewMockManager(ctrl),
+				commandExecutor:         mock_usecase.NewMockCommandExecutor(ctrl),
+				networkHostSetupStorage: mock_storage.NewMockNetworkHostSetup(ctrl),
+				setupGenerationStorage:  mock_storage.NewMockNetworkSetupGeneration(ctrl),
+			}
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+			mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(tt.network, nil)
+			mockNetworkHostStorage.EXPECT().
+				List(gomock.Any(), gomock.Any()).
+				Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "10.20.0.0/16"}}, nil)
+			expectWiFiNetworkInfo(mocks.commandExecutor)
+			tt.setupMocks(mocks, tt.network)
+			mocks.commandExecutor.EXPECT().
+				GetCurrentVPN(gomock.Any()).
+				Return(entity.VPNService("TestNetwork"), nil).
+				MaxTimes(1)
+
+			useCase := New(
+				mocks.trm,
+				mocks.commandExecutor,
+				newMockScopedDNS(ctrl),
+				mockNetworkStorage,
+				mockNetworkHostStorage,
+				mocks.networkHostSetupStorage,
+				newMockHostGroupStorage(ctrl),
+				mocks.setupGenerationStorage,
+				newMockDNSCacheStorage(ctrl),
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
+			)
+
+			err := useCase.SyncByNetworkID(context.Background(), 1)
+
+			if len(tt.expectedError) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expectedError := range tt.expectedError {
+				assert.Contains(t, err.Error(), expectedError)
+			}
+		})
+	}
+}
+
+func TestUseCase_SyncByNetworkID_CancelledWhileApplying(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	network := &entity.Network{ID: 1, Name: "TestNetwork"}
+	previousRoutes := []*entity.NetworkHostSetup{{
+		NetworkHostIP: "10.30.0.0",
+		SubnetMask:    "255.255.0.0",
+		Router:        "192.168.1.1",
+	}}
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockSetupGenerationStorage := mock_storage.NewMockNetworkSetupGeneration(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	compensated := make(chan struct{})
+	requireLive := func(ctx context.Context) {
+		if ctx.Err() != nil {
+			t.Errorf("compensation must outlive the cancelled sync: %v", ctx.Err())
+		}
+		_, ok := ctx.Deadline()
+		assert.True(t, ok, "compensation must be bounded")
+	}
+
+	mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "10.20.0.0/16"}}, nil)
+	expectWiFiNetworkInfo(mockCommandExecutor)
+	mockCommandExecutor.EXPECT().GetCurrentVPN(gomock.Any()).Return(entity.VPNService("TestNetwork"), nil)
+	mockSetupGenerationStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		Return(&entity.NetworkSetupGeneration{ID: 8}, nil)
+	gomock.InOrder(
+		// networksetup is killed halfway when the sync is cancelled.
+		mockCommandExecutor.EXPECT().
+			SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Not(previousRoutes)).
+			DoAndReturn(func(ctx context.Context, _ *entity.Network, _ []*entity.NetworkHostSetup) error {
+				cancel()
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+		mockCommandExecutor.EXPECT().
+			SetNetworkAdditionalRoutes(gomock.Any(), network, previousRoutes).
+			DoAndReturn(func(ctx context.Context, _ *entity.Network, _ []*entity.NetworkHostSetup) error {
+				requireLive(ctx)
+				return nil
+			}),
+	)
+	mockSetupGenerationStorage.EXPECT().
+		GetLastApplied(gomock.Any(), uint64(1)).
+		Return(&entity.NetworkSetupGeneration{ID: 7, NetworkID: 1, TunnelRoutes: previousRoutes}, nil)
+	mockSetupGenerationStorage.EXPECT().
+		MarkFailed(gomock.Any(), uint64(8)).
+		DoAndReturn(func(ctx context.Context, _ uint64) error {
+			requireLive(ctx)
+			close(compensated)
+			return nil
+		})
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		mockSetupGenerationStorage,
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	err := useCase.SyncByNetworkID(ctx, 1)
+
+	require.ErrorIs(t, err, context.Canceled)
+	select {
+	case <-compensated:
+	case <-time.After(time.Second):
+		t.Fatal("cancelled sync wasn't compensated")
+	}
+}
+
+func TestUseCase_ResetByNetworkID_RecordsEmptyGeneration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	network := &entity.Network{ID: 1, Name: "TestNetwork"}
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockSetupGenerationStorage := mock_storage.NewMockNetworkSetupGeneration(ctrl)
+
+	mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+	mockCommandExecutor.EXPECT().GetCurrentVPN(gomock.Any()).Return(entity.VPNService("TestNetwork"), nil)
+	mockCommandExecutor.EXPECT().
+		SetNetworkAdditionalRoutes(gomock.Any(), network, []*entity.NetworkHostSetup{}).
+		Return(nil)
+	mockSetupGenerationStorage.EXPECT().
+		GetLastApplied(gomock.Any(), uint64(1)).
+		Return(nil, errs.ErrNetworkSetupGenerationNotFound)
+	mockSetupGenerationStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			generation *entity.NetworkSetupGeneration,
+		) (*entity.NetworkSetupGeneration, error) {
+			assert.Equal(t, uint64(1), generation.NetworkID)
+			assert.Empty(t, generation.TunnelRoutes)
+			assert.Empty(t, generation.DirectRoutes)
+			return &entity.NetworkSetupGeneration{ID: 9}, nil
+		})
+	mockSetupGenerationStorage.EXPECT().MarkApplied(gomock.Any(), uint64(9)).Return(nil)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mock_storage.NewMockNetworkHost(ctrl),
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		mockSetupGenerationStorage,
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	require.NoError(t, useCase.ResetByNetworkID(context.Background(), 1))
+}
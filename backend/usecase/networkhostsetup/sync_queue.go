@@ -0,0 +1,162 @@
+package networkhostsetup
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/v2/context"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type syncFunc func(ctx context.Context) (*entity.SyncResult, error)
+
+// syncQueue runs the syncs and resets of a network one at a time. Sync requests that queue up behind a running one
+// are coalesced into a single run, and every caller it covers gets that run's result.
+type syncQueue struct {
+	mu       sync.Mutex
+	networks map[uint64]*networkSyncQueue
+}
+
+type networkSyncQueue struct {
+	running bool
+	// pending are the runs waiting for their turn, in order.
+	pending []*syncCall
+}
+
+// syncCall is a single run. It keeps the values of the context of the caller that queued it, which never carries
+// a transaction, and is cancelled once every caller it covers stopped waiting for it.
+type syncCall struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	fn     syncFunc
+	// coalesced calls take the requests that arrive while they wait.
+	coalesced bool
+	// requests counts the callers the run covers, waiting the ones still waiting for its result.
+	requests int
+	waiting  int
+
+	done   chan struct{}
+	result *entity.SyncResult
+	err    error
+}
+
+func newSyncQueue() *syncQueue {
+	return &syncQueue{
+		networks: make(map[uint64]*networkSyncQueue),
+	}
+}
+
+// Do runs fn once it is the network's turn. With coalesce set, the request joins the run that is already waiting,
+// if that one takes other requests too, instead of queueing a run of its own. Do returns when ctx is done
+// without waiting for the run. Runs outlive their callers on goroutines of their own, so a ctx carrying
+// a transaction is refused with errs.ErrSyncInTransaction: the run would keep using it after the caller
+// rolled it back.
+func (q *syncQueue) Do(
+	ctx context.Context,
+	networkID uint64,
+	coalesce bool,
+	fn syncFunc,
+) (*entity.SyncResult, error) {
+	if trmcontext.DefaultManager.Default(ctx) != nil {
+		return nil, errs.ErrSyncInTransaction
+	}
+
+	call := q.enqueue(ctx, networkID, coalesce, fn)
+
+	select {
+	case <-call.done:
+		return call.result, call.err
+	case <-ctx.Done():
+		q.leave(call)
+		return nil, ctx.Err()
+	}
+}
+
+// enqueue returns the run covering the request, starting it if the network has nothing running.
+func (q *syncQueue) enqueue(ctx context.Context, networkID uint64, coalesce bool, fn syncFunc) *syncCall {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	networkQueue, ok := q.networks[networkID]
+	if !ok {
+		networkQueue = &networkSyncQueue{}
+		q.networks[networkID] = networkQueue
+	}
+
+	if coalesce && len(networkQueue.pending) > 0 {
+		last := networkQueue.pending[len(networkQueue.pending)-1]
+		if last.coalesced {
+			last.requests++
+			last.waiting++
+			return last
+		}
+	}
+
+	callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	call := &syncCall{
+		ctx:       callCtx,
+		cancel:    cancel,
+		fn:        fn,
+		coalesced: coalesce,
+		requests:  1,
+		waiting:   1,
+		done:      make(chan struct{}),
+	}
+
+	if networkQueue.running {
+		networkQueue.pending = append(networkQueue.pending, call)
+	} else {
+		networkQueue.running = true
+		go q.run(networkID, call)
+	}
+
+	return call
+}
+
+// run executes the call and starts the network's next one. A call nobody waits for anymore is skipped.
+func (q *syncQueue) run(networkID uint64, call *syncCall) {
+	defer close(call.done)
+	defer q.finish(networkID)
+	defer call.cancel()
+
+	if err := call.ctx.Err(); err != nil {
+		call.err = err
+		return
+	}
+
+	if call.requests > 1 {
+		slog.Debug("coalesced network sync requests", "network_id", networkID, "requests", call.requests)
+	}
+
+	call.result, call.err = call.fn(call.ctx)
+}
+
+// leave stops waiting for the call and cancels it when it was the last caller waiting.
+func (q *syncQueue) leave(call *syncCall) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	call.waiting--
+	if call.waiting == 0 {
+		call.cancel()
+	}
+}
+
+// finish starts the network's next run, if any.
+func (q *syncQueue) finish(networkID uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	networkQueue := q.networks[networkID]
+	if len(networkQueue.pending) == 0 {
+		delete(q.networks, networkID)
+		return
+	}
+
+	next := networkQueue.pending[0]
+	networkQueue.pending = networkQueue.pending[1:]
+	go q.run(networkID, next)
+}
@@ -0,0 +1,293 @@
+package networkhostsetup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// pendingRequests returns the number of requests covered by each run waiting for the network's turn.
+func (q *syncQueue) pendingRequests(networkID uint64) []int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	networkQueue, ok := q.networks[networkID]
+	if !ok {
+		return nil
+	}
+
+	requests := make([]int, len(networkQueue.pending))
+	for i, call := range networkQueue.pending {
+		requests[i] = call.requests
+	}
+
+	return requests
+}
+
+// blockingSync returns a sync that blocks until release is closed and then reports its run number.
+func blockingSync(runs *atomic.Int32, release <-chan struct{}) syncFunc {
+	return func(context.Context) (*entity.SyncResult, error) {
+		run := runs.Add(1)
+		<-release
+		return &entity.SyncResult{RouteCount: int(run)}, nil
+	}
+}
+
+func countingSync(runs *atomic.Int32) syncFunc {
+	return func(context.Context) (*entity.SyncResult, error) {
+		return &entity.SyncResult{RouteCount: int(runs.Add(1))}, nil
+	}
+}
+
+func TestSyncQueue_Do(t *testing.T) {
+	t.Run("single request runs right away", func(t *testing.T) {
+		queue := newSyncQueue()
+		var runs atomic.Int32
+
+		result, err := queue.Do(context.Background(), 1, true, countingSync(&runs))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.RouteCount)
+		assert.Empty(t, queue.networks)
+	})
+
+	t.Run("requests behind a running sync are coalesced into one run", func(t *testing.T) {
+		queue := newSyncQueue()
+		var runs atomic.Int32
+		release := make(chan struct{})
+
+		firstDone := make(chan *entity.SyncResult)
+		go func() {
+			result, _ := queue.Do(context.Background(), 1, true, blockingSync(&runs, release))
+			firstDone <- result
+		}()
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+		const waiting = 5
+		results := make([]*entity.SyncResult, waiting)
+		var wg sync.WaitGroup
+		for i := range waiting {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = queue.Do(context.Background(), 1, true, countingSync(&runs))
+			}()
+		}
+		require.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]int{waiting}, queue.pendingRequests(1))
+		}, time.Second, time.Millisecond)
+
+		close(release)
+		assert.Equal(t, 1, (<-firstDone).RouteCount)
+		wg.Wait()
+
+		assert.Equal(t, int32(2), runs.Load())
+		for _, result := range results {
+			assert.Same(t, results[0], result)
+			assert.Equal(t, 2, result.RouteCount)
+		}
+		assert.Empty(t, queue.networks)
+	})
+
+	t.Run("uncoalesced requests get runs of their own in order", func(t *testing.T) {
+		queue := newSyncQueue()
+		var runs atomic.Int32
+		release := make(chan struct{})
+
+		go func() {
+			_, _ = queue.Do(context.Background(), 1, true, blockingSync(&runs, release))
+		}()
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+		var order []string
+		var orderMu sync.Mutex
+		record := func(name string) syncFunc {
+			return func(context.Context) (*entity.SyncResult, error) {
+				orderMu.Lock()
+				defer orderMu.Unlock()
+				order = append(order, name)
+				return nil, nil
+			}
+		}
+
+		var wg sync.WaitGroup
+		queueRequest := func(name string, coalesce bool, pending []int) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = queue.Do(context.Background(), 1, coalesce, record(name))
+			}()
+			require.Eventually(t, func() bool {
+				return assert.ObjectsAreEqual(pending, queue.pendingRequests(1))
+			}, time.Second, time.Millisecond)
+		}
+
+		queueRequest("sync", true, []int{1})
+		queueRequest("reset", false, []int{1, 1})
+		queueRequest("transaction", false, []int{1, 1, 1})
+		queueRequest("after", true, []int{1, 1, 1, 1})
+		queueRequest("after", true, []int{1, 1, 1, 2})
+
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, []string{"sync", "reset", "transaction", "after"}, order)
+	})
+
+	t.Run("coalesced callers share the error", func(t *testing.T) {
+		queue := newSyncQueue()
+		var runs atomic.Int32
+		release := make(chan struct{})
+		syncErr := errors.New("networksetup failed")
+
+		go func() {
+			_, _ = queue.Do(context.Background(), 1, true, blockingSync(&runs, release))
+		}()
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = queue.Do(context.Background(), 1, true, func(context.Context) (*entity.SyncResult, error) {
+					return nil, syncErr
+				})
+			}()
+		}
+		require.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]int{2}, queue.pendingRequests(1))
+		}, time.Second, time.Millisecond)
+
+		close(release)
+		wg.Wait()
+
+		for _, err := range errs {
+			require.ErrorIs(t, err, syncErr)
+		}
+	})
+
+	t.Run("networks don't wait for each other", func(t *testing.T) {
+		queue := newSyncQueue()
+		var runs atomic.Int32
+		release := make(chan struct{})
+		defer close(release)
+
+		go func() {
+			_, _ = queue.Do(context.Background(), 1, true, blockingSync(&runs, release))
+		}()
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+		result, err := queue.Do(context.Background(), 2, true, countingSync(&runs))
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.RouteCount)
+	})
+	t.Run("cancelled caller returns while the others keep waiting", func(t *testing.T) {
+		queue := newSyncQueue()
+		var runs atomic.Int32
+		release := make(chan struct{})
+
+		go func() {
+			_, _ = queue.Do(context.Background(), 1, true, blockingSync(&runs, release))
+		}()
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelledErr := make(chan error)
+		go func() {
+			_, err := queue.Do(ctx, 1, true, countingSync(&runs))
+			cancelledErr <- err
+		}()
+
+		waitingResult := make(chan *entity.SyncResult)
+		go func() {
+			result, _ := queue.Do(context.Background(), 1, true, countingSync(&runs))
+			waitingResult <- result
+		}()
+		require.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]int{2}, queue.pendingRequests(1))
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		require.ErrorIs(t, <-cancelledErr, context.Canceled)
+
+		close(release)
+		result := <-waitingResult
+		require.NotNil(t, result)
+		assert.Equal(t, 2, result.RouteCount)
+	})
+
+	t.Run("run is cancelled once every caller left", func(t *testing.T) {
+		queue := newSyncQueue()
+		started := make(chan struct{})
+		runErr := make(chan error, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		doErr := make(chan error)
+		go func() {
+			_, err := queue.Do(ctx, 1, true, func(runCtx context.Context) (*entity.SyncResult, error) {
+				close(started)
+				<-runCtx.Done()
+				runErr <- runCtx.Err()
+				return nil, runCtx.Err()
+			})
+			doErr <- err
+		}()
+		<-started
+
+		cancel()
+
+		require.ErrorIs(t, <-doErr, context.Canceled)
+		require.ErrorIs(t, <-runErr, context.Canceled)
+		require.Eventually(t, func() bool {
+			queue.mu.Lock()
+			defer queue.mu.Unlock()
+			return len(queue.networks) == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("queued run nobody waits for is skipped", func(t *testing.T) {
+		queue := newSyncQueue()
+		var runs atomic.Int32
+		release := make(chan struct{})
+
+		firstDone := make(chan struct{})
+		go func() {
+			_, _ = queue.Do(context.Background(), 2, false, blockingSync(&runs, release))
+			close(firstDone)
+		}()
+		require.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		doErr := make(chan error)
+		go func() {
+			_, err := queue.Do(ctx, 2, false, countingSync(&runs))
+			doErr <- err
+		}()
+		require.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual([]int{1}, queue.pendingRequests(2))
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		require.ErrorIs(t, <-doErr, context.Canceled)
+
+		close(release)
+		<-firstDone
+		require.Eventually(t, func() bool {
+			queue.mu.Lock()
+			defer queue.mu.Unlock()
+			return len(queue.networks) == 0
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), runs.Load())
+	})
+}
@@ -0,0 +1,227 @@
+package networkhostsetup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/database"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/storage/networksetupgeneration"
+)
+
+// transactionTest is a use case recording its setup generations in a real database, for the tests
+// of syncs racing or nested in callers' transactions.
+type transactionTest struct {
+	db              *database.Database
+	trm             *manager.Manager
+	generations     *networksetupgeneration.Storage
+	commandExecutor *mock_usecase.MockCommandExecutor
+	useCase         *UseCase
+}
+
+func newTransactionTest(t *testing.T, ctrl *gomock.Controller) *transactionTest {
+	t.Helper()
+
+	db, err := database.NewForTesting("networkhostsetup_test")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	_, err = db.GetDB(ctx).ExecContext(ctx, `
+		DROP TABLE IF EXISTS network_setup_generations;
+
+		CREATE TABLE network_setup_generations (
+			id INTEGER PRIMARY KEY,
+			network_id INTEGER NOT NULL,
+			status VARCHAR(16) NOT NULL,
+			network_service VARCHAR(255) DEFAULT '' NOT NULL,
+			tunnel_routes TEXT DEFAULT '[]' NOT NULL,
+			direct_routes TEXT DEFAULT '[]' NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+		);
+	`)
+	require.NoError(t, err)
+
+	trManager, err := database.NewTxManager(db)
+	require.NoError(t, err)
+
+	network := &entity.Network{ID: 1, Name: "TestNetwork"}
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil).AnyTimes()
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{{NetworkID: 1, Address: "10.20.0.0/16"}}, nil).
+		AnyTimes()
+
+	tt := &transactionTest{
+		db:              db,
+		trm:             trManager,
+		generations:     networksetupgeneration.New(db),
+		commandExecutor: mock_usecase.NewMockCommandExecutor(ctrl),
+	}
+	tt.useCase = New(
+		trManager,
+		tt.commandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		tt.generations,
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	return tt
+}
+
+// expectSync expects a sync of the active network, applying its routes with apply.
+func (tt *transactionTest) expectSync(apply func(ctx context.Context) error) {
+	expectWiFiNetworkInfo(tt.commandExecutor)
+	tt.commandExecutor.EXPECT().GetCurrentVPN(gomock.Any()).Return(entity.VPNService("TestNetwork"), nil)
+	tt.commandExecutor.EXPECT().
+		SetNetworkAdditionalRoutes(gomock.Any(), gomock.Any(), gomock.Len(1)).
+		DoAndReturn(func(ctx context.Context, _ *entity.Network, _ []*entity.NetworkHostSetup) error {
+			return apply(ctx)
+		})
+}
+
+func TestUseCase_SyncByNetworkID_CallerTransaction(t *testing.T) {
+	t.Run("sync inside a transaction is refused and leaves nothing for a rollback to undo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tt := newTransactionTest(t, ctrl)
+		ctx := context.Background()
+		callerErr := errors.New("scoped DNS failed")
+
+		err := tt.trm.Do(ctx, func(ctx context.Context) error {
+			require.ErrorIs(t, tt.useCase.SyncByNetworkID(ctx, 1), errs.ErrSyncInTransaction)
+			require.ErrorIs(t, tt.useCase.ResetByNetworkID(ctx, 1), errs.ErrSyncInTransaction)
+			return callerErr
+		})
+
+		require.ErrorIs(t, err, callerErr)
+		_, err = tt.generations.GetLastApplied(ctx, 1)
+		require.ErrorIs(t, err, errs.ErrNetworkSetupGenerationNotFound)
+	})
+
+	t.Run("sync queue never takes a transaction to a run of its own", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tt := newTransactionTest(t, ctrl)
+		ctx := context.Background()
+
+		err := tt.trm.Do(ctx, func(ctx context.Context) error {
+			_, doErr := newSyncQueue().Do(ctx, 1, true, func(context.Context) (*entity.SyncResult, error) {
+				t.Error("run started with the caller's transaction")
+				return &entity.SyncResult{}, nil
+			})
+			return doErr
+		})
+
+		require.ErrorIs(t, err, errs.ErrSyncInTransaction)
+	})
+
+	t.Run("generation applied after the caller's commit survives the caller's later rollback", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tt := newTransactionTest(t, ctrl)
+		ctx := context.Background()
+		tt.expectSync(func(context.Context) error { return nil })
+
+		require.NoError(t, tt.trm.Do(ctx, func(context.Context) error { return nil }))
+		require.NoError(t, tt.useCase.SyncByNetworkID(ctx, 1))
+		err := tt.trm.Do(ctx, func(ctx context.Context) error {
+			_, trErr := tt.generations.Add(ctx, entity.NewNetworkSetupGeneration(1, "", nil, nil))
+			require.NoError(t, trErr)
+			return errors.New("commit failed")
+		})
+		require.Error(t, err)
+
+		applied, err := tt.generations.GetLastApplied(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, applied.TunnelRoutes, 1)
+		assert.Equal(t, "10.20.0.0", applied.TunnelRoutes[0].NetworkHostIP)
+	})
+
+	t.Run("failed generation stays recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tt := newTransactionTest(t, ctrl)
+		ctx := context.Background()
+		tt.expectSync(func(context.Context) error { return errors.New("networksetup failed") })
+
+		err := tt.useCase.SyncByNetworkID(ctx, 1)
+		require.Error(t, err)
+
+		var status entity.NetworkSetupGenerationStatus
+		require.NoError(t, tt.db.GetDB(ctx).GetContext(ctx, &status,
+			"SELECT status FROM network_setup_generations WHERE network_id = 1"))
+		assert.Equal(t, entity.NetworkSetupGenerationStatusFailed, status)
+	})
+}
+
+func TestUseCase_SyncByNetworkID_CallerTransactionRacingSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tt := newTransactionTest(t, ctrl)
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	tt.expectSync(func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	tt.expectSync(func(context.Context) error { return nil })
+
+	running := make(chan error)
+	go func() {
+		running <- tt.useCase.SyncByNetworkID(ctx, 1)
+	}()
+	<-started
+
+	// The caller holds the write lock while the running sync still has its generation to commit.
+	// Its sync is refused right away instead of queueing behind that one and waiting for the lock.
+	err := tt.trm.Do(ctx, func(ctx context.Context) error {
+		_, trErr := tt.generations.Add(ctx, entity.NewNetworkSetupGeneration(2, "", nil, nil))
+		if trErr != nil {
+			return trErr
+		}
+
+		return tt.useCase.SyncByNetworkID(ctx, 1)
+	})
+	require.ErrorIs(t, err, errs.ErrSyncInTransaction)
+
+	// Synced after the commit, the caller queues behind the running sync and both go through.
+	queued := make(chan error)
+	go func() {
+		queued <- tt.useCase.SyncByNetworkID(ctx, 1)
+	}()
+	require.Eventually(t, func() bool {
+		return len(tt.useCase.syncQueue.pendingRequests(1)) == 1
+	}, time.Second, time.Millisecond)
+	close(release)
+
+	require.NoError(t, <-running)
+	require.NoError(t, <-queued)
+}
@@ -4,107 +4,214 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"maps"
+	"log/slog"
 	"net"
+	"net/netip"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
 
+	"github.com/dmitrorlov/splitr/backend/config"
 	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/cidr"
 	"github.com/dmitrorlov/splitr/backend/pkg/errs"
 	"github.com/dmitrorlov/splitr/backend/storage"
 	"github.com/dmitrorlov/splitr/backend/usecase"
 )
 
+const ipv4Bits = 32
+
+// routePlan holds the routes sync applies for a network. In split mode the network's hosts go through
+// the tunnel. In inverse mode the tunnel takes everything else and the hosts go through the physical gateway.
+type routePlan struct {
+	network     *entity.Network
+	networkInfo *entity.NetworkInfo
+	// hostSetups are the routes resolved from the network's hosts, one per host and address.
+	hostSetups []*entity.NetworkHostSetup
+	// tunnelSetups and directSetups are the optimised routes that are applied.
+	tunnelSetups []*entity.NetworkHostSetup
+	directSetups []*entity.NetworkHostSetup
+	// resolutions are the lookups made while resolving the plan.
+	resolutions *resolutions
+}
+
+// resolutions collects the lookups made while resolving a plan, nil discards them.
+// Planning only reads the DNS cache, a sync stores the fresh lookups in it afterwards,
+// so previews and analyses don't change what later syncs fall back to.
+type resolutions struct {
+	// stale are the hostnames routed to cached IPs because their lookup failed.
+	stale []*entity.StaleResolution
+	// fresh are the successful lookups of hostnames.
+	fresh []*entity.DNSCacheEntry
+}
+
+func (r *resolutions) addStale(staleResolution *entity.StaleResolution) {
+	if r == nil {
+		return
+	}
+
+	r.stale = append(r.stale, staleResolution)
+}
+
+func (r *resolutions) addFresh(entry *entity.DNSCacheEntry) {
+	if r == nil {
+		return
+	}
+
+	r.fresh = append(r.fresh, entry)
+}
+
 type UseCase struct {
 	trm trm.Manager
 
 	commandExecutorUC       usecase.CommandExecutor
+	scopedDNSUC             usecase.ScopedDNS
 	networkStorage          storage.Network
 	networkHostStorage      storage.NetworkHost
 	networkHostSetupStorage storage.NetworkHostSetup
+	hostGroupStorage        storage.HostGroup
+	setupGenerationStorage  storage.NetworkSetupGeneration
+	dnsCacheStorage         storage.DNSCache
+	learnedIPStorage        storage.LearnedIP
+
+	aggregatePrefixLength int
+	dnsCacheTTL           time.Duration
+
+	lookupIP func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+	syncQueue *syncQueue
 }
 
 func New(
 	trm trm.Manager,
 	commandExecutorUC usecase.CommandExecutor,
+	scopedDNSUC usecase.ScopedDNS,
 	networkStorage storage.Network,
 	networkHostStorage storage.NetworkHost,
 	networkHostSetupStorage storage.NetworkHostSetup,
+	hostGroupStorage storage.HostGroup,
+	setupGenerationStorage storage.NetworkSetupGeneration,
+	dnsCacheStorage storage.DNSCache,
+	learnedIPStorage storage.LearnedIP,
+	routeCfg *config.Route,
+	dnsCfg *config.DNS,
 ) *UseCase {
 	return &UseCase{
 		trm:                     trm,
 		commandExecutorUC:       commandExecutorUC,
+		scopedDNSUC:             scopedDNSUC,
 		networkStorage:          networkStorage,
 		networkHostStorage:      networkHostStorage,
 		networkHostSetupStorage: networkHostSetupStorage,
+		hostGroupStorage:        hostGroupStorage,
+		setupGenerationStorage:  setupGenerationStorage,
+		dnsCacheStorage:         dnsCacheStorage,
+		learnedIPStorage:        learnedIPStorage,
+		aggregatePrefixLength:   routeCfg.AggregatePrefixLength,
+		dnsCacheTTL:             dnsCfg.CacheTTL,
+		lookupIP:                net.DefaultResolver.LookupIPAddr,
+		syncQueue:               newSyncQueue(),
 	}
 }
 
 func (u *UseCase) SyncByNetworkID(ctx context.Context, networkID uint64) error {
+	_, err := u.SyncByNetworkIDWithResult(ctx, networkID)
+	return err
+}
+
+// SyncByNetworkIDWithResult syncs a network's routes and reports how they were applied,
+// including the hostnames that had to be routed to cached IPs. The resolver files of the network's DNS domains
+// are written along with the routes, and removed while the network isn't the active VPN.
+//
+// Syncs of the same network run one at a time, and the requests made while a sync runs are covered by
+// a single sync after it. Callers sync after committing their changes: the routes a sync applies are recorded
+// in transactions of its own, which a caller's rollback mustn't undo, so a sync inside a transaction fails
+// with errs.ErrSyncInTransaction.
+func (u *UseCase) SyncByNetworkIDWithResult(ctx context.Context, networkID uint64) (*entity.SyncResult, error) {
+	return u.syncQueue.Do(ctx, networkID, true, func(ctx context.Context) (*entity.SyncResult, error) {
+		return u.syncByNetworkID(ctx, networkID)
+	})
+}
+
+func (u *UseCase) syncByNetworkID(ctx context.Context, networkID uint64) (*entity.SyncResult, error) {
 	// Retrieve network and setups
-	network, networkHostSetupList, err := u.listNetworkAndSetupsByNetworkID(ctx, networkID)
+	plan, err := u.planByNetworkID(ctx, networkID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	result := &entity.SyncResult{
+		NetworkID:        networkID,
+		StaleResolutions: plan.resolutions.stale,
+	}
+
+	u.cacheResolutions(ctx, plan.resolutions.fresh)
+
 	// Check if this network is currently active
 	currentVPN, err := u.commandExecutorUC.GetCurrentVPN(ctx)
 	if err != nil {
 		if errors.Is(err, errs.ErrVPNServiceNotFound) {
-			return nil
+			return u.removeScopedDNS(ctx, result)
 		}
-		return fmt.Errorf("failed to get current VPN: %w", err)
+		return nil, fmt.Errorf("failed to get current VPN: %w", err)
 	}
 
-	// If the network is not the currently active VPN, return successful no-op
-	if entity.VPNService(network.Name) != currentVPN {
-		return nil
+	// If the network is not the currently active VPN, only its resolver files are removed
+	if entity.VPNService(plan.network.Name) != currentVPN {
+		return u.removeScopedDNS(ctx, result)
 	}
 
-	err = u.trm.Do(ctx, func(ctx context.Context) error {
-		if len(networkHostSetupList) > 0 {
-			networkHostIDsMap := make(map[uint64]struct{}, len(networkHostSetupList))
-			for _, networkHostSetup := range networkHostSetupList {
-				networkHostIDsMap[networkHostSetup.NetworkHostID] = struct{}{}
-			}
+	err = u.applyPlan(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
 
-			trErr := u.networkHostSetupStorage.DeleteBatchByNetworkHostIDs(ctx,
-				slices.Collect(maps.Keys(networkHostIDsMap)))
-			if trErr != nil {
-				return fmt.Errorf(
-					"failed to delete network host setup list by network host ids: %w",
-					trErr,
-				)
-			}
+	result.Applied = true
+	result.RouteCount = len(plan.tunnelSetups) + len(plan.directSetups)
 
-			trErr = u.networkHostSetupStorage.AddBatch(ctx, networkHostSetupList)
-			if trErr != nil {
-				return fmt.Errorf("failed to add network host setup list: %w", trErr)
-			}
-		}
+	// The routes are already applied, a resolver file failure is reported instead of failing the sync.
+	result.SkippedDNSDomains, err = u.scopedDNSUC.ApplyByNetwork(ctx, plan.network)
+	if err != nil {
+		slog.Warn("failed to apply scoped DNS", "network", plan.network.Name, "error", err)
+		result.ScopedDNSError = err.Error()
+	}
 
-		trErr := u.commandExecutorUC.SetNetworkAdditionalRoutes(ctx, network, networkHostSetupList)
-		if trErr != nil {
-			return fmt.Errorf("failed to set network additional routes: %w", trErr)
-		}
+	return result, nil
+}
 
-		return nil
-	})
+// removeScopedDNS removes the resolver files of a network that isn't the active VPN.
+func (u *UseCase) removeScopedDNS(ctx context.Context, result *entity.SyncResult) (*entity.SyncResult, error) {
+	err := u.scopedDNSUC.RemoveByNetworkID(ctx, result.NetworkID)
 	if err != nil {
-		return fmt.Errorf("failed to apply transaction: %w", err)
+		return nil, fmt.Errorf("failed to remove scoped DNS: %w", err)
 	}
 
-	return nil
+	return result, nil
 }
 
-// ResetByNetworkID resets additional routes for a network by setting them to empty.
+// ResetByNetworkID resets additional routes for a network by setting them to empty
+// and removes the resolver files of its DNS domains. It waits for the network's queued syncs like a sync does,
+// and like a sync it can't run inside a transaction.
 func (u *UseCase) ResetByNetworkID(ctx context.Context, networkID uint64) error {
+	_, err := u.syncQueue.Do(ctx, networkID, false, func(ctx context.Context) (*entity.SyncResult, error) {
+		return nil, u.resetByNetworkID(ctx, networkID)
+	})
+	return err
+}
+
+func (u *UseCase) resetByNetworkID(ctx context.Context, networkID uint64) error {
 	network, err := u.networkStorage.Get(ctx, networkID)
 	if err != nil {
 		return fmt.Errorf("failed to get network by id %d: %w", networkID, err)
 	}
 
+	err = u.scopedDNSUC.RemoveByNetworkID(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("failed to remove scoped DNS: %w", err)
+	}
+
 	// Add active-network check and early return
 	currentVPN, err := u.commandExecutorUC.GetCurrentVPN(ctx)
 	if err != nil {
@@ -125,84 +232,636 @@ func (u *UseCase) ResetByNetworkID(ctx context.Context, networkID uint64) error
 		return fmt.Errorf("failed to reset network additional routes: %w", err)
 	}
 
-	return nil
+	// Inverse mode also routed the network's hosts through a physical service. The one the last applied
+	// generation recorded is reset, the network may be on another one by now.
+	previous, err := u.lastApplied(ctx, networkID)
+	if err != nil {
+		return err
+	}
+
+	if previous != nil && previous.NetworkService != "" {
+		err = u.setDirectRoutes(ctx, previous.NetworkService, previous.DirectRoutes, nil)
+		if err != nil {
+			return fmt.Errorf("failed to reset direct routes: %w", err)
+		}
+	}
+
+	return u.recordReset(ctx, networkID)
+}
+
+// ExportScriptsByNetworkID resolves the network's routes the same way sync does
+// and renders them as standalone apply and teardown shell scripts.
+func (u *UseCase) ExportScriptsByNetworkID(ctx context.Context, networkID uint64) (*entity.RouteScripts, error) {
+	plan, err := u.planByNetworkID(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedAt := time.Now()
+	routedSetups := slices.Concat(plan.tunnelSetups, plan.directSetups)
+
+	apply := &entity.RouteScript{
+		NetworkName: plan.network.Name,
+		Description: "Apply Splitr routes",
+		GeneratedAt: generatedAt,
+		NetworkInfo: plan.networkInfo,
+		NetworkSetupCommand: u.commandExecutorUC.SetNetworkAdditionalRoutesCommand(
+			plan.network,
+			plan.tunnelSetups,
+		),
+		RouteCommands: u.commandExecutorUC.RouteCommands(entity.RouteActionAdd, routedSetups),
+	}
+	teardown := &entity.RouteScript{
+		NetworkName: plan.network.Name,
+		Description: "Remove Splitr routes",
+		GeneratedAt: generatedAt,
+		NetworkInfo: plan.networkInfo,
+		NetworkSetupCommand: u.commandExecutorUC.SetNetworkAdditionalRoutesCommand(
+			plan.network,
+			[]*entity.NetworkHostSetup{},
+		),
+		RouteCommands: u.commandExecutorUC.RouteCommands(entity.RouteActionDelete, routedSetups),
+	}
+
+	if plan.network.IsInverse() {
+		// The scripts keep the routes the physical service has from anyone else, like a sync does.
+		foreign, foreignErr := u.foreignDirectRoutes(ctx, plan)
+		if foreignErr != nil {
+			return nil, foreignErr
+		}
+
+		apply.DirectNetworkSetupCommand = u.commandExecutorUC.SetServiceAdditionalRoutesCommand(
+			plan.networkInfo.NetworkService,
+			slices.Concat(foreign, plan.directSetups),
+		)
+		teardown.DirectNetworkSetupCommand = u.commandExecutorUC.SetServiceAdditionalRoutesCommand(
+			plan.networkInfo.NetworkService,
+			foreign,
+		)
+	}
+
+	return &entity.RouteScripts{
+		Apply:    apply,
+		Teardown: teardown,
+	}, nil
+}
+
+// foreignDirectRoutes returns the routes of the plan's physical service that neither the plan
+// nor the last applied generation set there.
+func (u *UseCase) foreignDirectRoutes(ctx context.Context, plan *routePlan) ([]*entity.NetworkHostSetup, error) {
+	currentRoutes, err := u.commandExecutorUC.GetServiceAdditionalRoutes(ctx, plan.networkInfo.NetworkService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get additional routes of %s: %w", plan.networkInfo.NetworkService, err)
+	}
+
+	previous, err := u.lastApplied(ctx, plan.network.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []*entity.NetworkHostSetup
+	if previous != nil && previous.NetworkService == plan.networkInfo.NetworkService {
+		owned = previous.DirectRoutes
+	}
+
+	return foreignRoutes(currentRoutes, owned, plan.directSetups), nil
+}
+
+// PreviewByNetworkID resolves the routes sync would apply for a network, exclusions already subtracted,
+// without touching the system. In inverse mode it lists the direct routes after the tunnel ones.
+func (u *UseCase) PreviewByNetworkID(ctx context.Context, networkID uint64) ([]*entity.RoutePreview, error) {
+	plan, err := u.planByNetworkID(ctx, networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewRoutePreviews(plan.tunnelSetups, plan.directSetups), nil
 }
 
-func (u *UseCase) listNetworkAndSetupsByNetworkID(
+// PreviewByAddress resolves the routes sync would apply for an address once it's added to a network,
+// built with the network's route override like the routes of its hosts. A wildcard has none until its IPs
+// are learned.
+func (u *UseCase) PreviewByAddress(
 	ctx context.Context,
 	networkID uint64,
-) (*entity.Network, []*entity.NetworkHostSetup, error) {
+	address string,
+) ([]*entity.NetworkHostSetup, error) {
 	network, err := u.networkStorage.Get(ctx, networkID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get network by id %d: %w", networkID, err)
+		return nil, fmt.Errorf("failed to get network by id %d: %w", networkID, err)
+	}
+
+	if entity.IsWildcard(address) {
+		return nil, nil
 	}
 
-	networkHostSetupList, err := u.listSetupsByNetwork(ctx, network)
+	networkInfo, err := u.getNetworkInfo(ctx, network.RouteOverride())
 	if err != nil {
-		return nil, nil, err
+		return nil, fmt.Errorf("failed to get current network info: %w", err)
+	}
+
+	return u.listSetupsByAddress(ctx, 0, address, networkInfo, nil)
+}
+
+// ValidateByNetworkID resolves the routes a sync of the network would apply, without applying or recording them.
+// Callers that add routes to a network run it inside their transaction before committing, so a change sync
+// couldn't route, such as a hostname that doesn't resolve, is rolled back instead of saved.
+func (u *UseCase) ValidateByNetworkID(ctx context.Context, networkID uint64) error {
+	_, err := u.planByNetworkID(ctx, networkID)
+	return err
+}
+
+// planByNetworkID resolves the network's routes and splits them between the tunnel and the physical gateway
+// according to the network's routing mode.
+func (u *UseCase) planByNetworkID(ctx context.Context, networkID uint64) (*routePlan, error) {
+	network, err := u.networkStorage.Get(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network by id %d: %w", networkID, err)
+	}
+
+	resolved := new(resolutions)
+	networkHostSetupList, currentNetworkInfo, err := u.listSetupsByNetwork(ctx, network, resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &routePlan{
+		network:      network,
+		networkInfo:  currentNetworkInfo,
+		hostSetups:   networkHostSetupList,
+		tunnelSetups: u.optimizeSetups(networkHostSetupList),
+		resolutions:  resolved,
+	}
+	if network.IsInverse() {
+		plan.tunnelSetups = u.optimizeSetups(inverseSetups(networkHostSetupList, currentNetworkInfo))
+		plan.directSetups = u.optimizeSetups(networkHostSetupList)
+	}
+
+	return plan, nil
+}
+
+// optimizeSetups de-duplicates routes shared by several hosts and aggregates adjacent and contained ones
+// up to the configured prefix length. A route that survives unchanged keeps its address and mask as resolved,
+// every route lists the network hosts it serves.
+func (u *UseCase) optimizeSetups(networkHostSetupList []*entity.NetworkHostSetup) []*entity.NetworkHostSetup {
+	res := make([]*entity.NetworkHostSetup, 0, len(networkHostSetupList))
+
+	routers := make([]string, 0, 1)
+	setupsByRouter := make(map[string][]*entity.NetworkHostSetup)
+	for _, setup := range networkHostSetupList {
+		if _, ok := setup.Prefix(); !ok {
+			res = append(res, setup)
+			continue
+		}
+
+		if _, ok := setupsByRouter[setup.Router]; !ok {
+			routers = append(routers, setup.Router)
+		}
+		setupsByRouter[setup.Router] = append(setupsByRouter[setup.Router], setup)
 	}
 
-	return network, networkHostSetupList, nil
+	for _, router := range routers {
+		setups := setupsByRouter[router]
+
+		prefixes := make([]netip.Prefix, 0, len(setups))
+		for _, setup := range setups {
+			prefix, _ := setup.Prefix()
+			prefixes = append(prefixes, prefix)
+		}
+
+		for _, aggregated := range cidr.Aggregate(prefixes, u.aggregatePrefixLength) {
+			res = append(res, aggregatedSetup(aggregated, router, setups, prefixes))
+		}
+	}
+
+	return res
+}
+
+// aggregatedSetup builds the route for an aggregated prefix from the setups it covers.
+func aggregatedSetup(
+	aggregated netip.Prefix,
+	router string,
+	setups []*entity.NetworkHostSetup,
+	prefixes []netip.Prefix,
+) *entity.NetworkHostSetup {
+	var (
+		original       *entity.NetworkHostSetup
+		networkHostIDs []uint64
+	)
+	for i, setup := range setups {
+		if !aggregated.Overlaps(prefixes[i]) {
+			continue
+		}
+
+		if original == nil && prefixes[i] == aggregated {
+			original = setup
+		}
+		if setup.NetworkHostID != 0 && !slices.Contains(networkHostIDs, setup.NetworkHostID) {
+			networkHostIDs = append(networkHostIDs, setup.NetworkHostID)
+		}
+	}
+
+	var res *entity.NetworkHostSetup
+	if original != nil {
+		setupCopy := *original
+		res = &setupCopy
+	} else {
+		res = prefixSetup(aggregated, 0, router)
+	}
+
+	slices.Sort(networkHostIDs)
+	res.NetworkHostIDs = networkHostIDs
+	if len(networkHostIDs) == 1 {
+		res.NetworkHostID = networkHostIDs[0]
+	} else {
+		res.NetworkHostID = 0
+	}
+
+	return res
+}
+
+// inverseSetups covers the IPv4 space that isn't local, reserved or listed, which is what inverse mode
+// sends through the tunnel.
+func inverseSetups(
+	networkHostSetupList []*entity.NetworkHostSetup,
+	networkInfo *entity.NetworkInfo,
+) []*entity.NetworkHostSetup {
+	excluded := nonRoutablePrefixes()
+	if localPrefix, ok := networkInfo.LocalPrefix(); ok {
+		excluded = append(excluded, localPrefix)
+	}
+
+	for _, setup := range networkHostSetupList {
+		if prefix, ok := setup.Prefix(); ok {
+			excluded = append(excluded, prefix)
+		}
+	}
+
+	remaining := cidr.Subtract(netip.PrefixFrom(netip.IPv4Unspecified(), 0), excluded)
+
+	res := make([]*entity.NetworkHostSetup, 0, len(remaining))
+	for _, prefix := range remaining {
+		res = append(res, prefixSetup(prefix, 0, networkInfo.Router))
+	}
+
+	return res
+}
+
+// nonRoutablePrefixes are the IPv4 ranges that never leave the machine or its link.
+func nonRoutablePrefixes() []netip.Prefix {
+	return []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("127.0.0.0/8"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+	}
+}
+
+// prefixSetup turns a prefix into a route through router.
+func prefixSetup(prefix netip.Prefix, networkHostID uint64, router string) *entity.NetworkHostSetup {
+	return &entity.NetworkHostSetup{
+		NetworkHostID: networkHostID,
+		NetworkHostIP: prefix.Addr().String(),
+		SubnetMask:    net.IP(net.CIDRMask(prefix.Bits(), ipv4Bits)).String(),
+		Router:        router,
+	}
 }
 
+// listSetupsByNetwork resolves the routes of a network's own hosts and of the host groups attached to it.
+// An address listed more than once is routed once, the network's own host taking precedence.
+// The network's exclusions are subtracted from the result. The network info the routes were resolved with,
+// the network's route override applied, is returned along with them. Lookups are recorded in resolved.
 func (u *UseCase) listSetupsByNetwork(
 	ctx context.Context,
 	network *entity.Network,
-) ([]*entity.NetworkHostSetup, error) {
+	resolved *resolutions,
+) ([]*entity.NetworkHostSetup, *entity.NetworkInfo, error) {
+	enabled := true
 	networkHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{
 		NetworkID: []uint64{network.ID},
+		Enabled:   &enabled,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list network hosts: %w", err)
+		return nil, nil, fmt.Errorf("failed to list network hosts: %w", err)
 	}
 
-	currentNetworkInfo, err := u.getCurrentNetworkInfo(ctx)
+	hostGroupHosts, err := u.hostGroupStorage.ListHosts(ctx, &entity.ListHostGroupHostFilter{
+		NetworkID: []uint64{network.ID},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current network info: %w", err)
+		return nil, nil, fmt.Errorf("failed to list host group hosts: %w", err)
+	}
+
+	currentNetworkInfo, err := u.getNetworkInfo(ctx, network.RouteOverride())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get current network info: %w", err)
 	}
 
+	var exclusionHosts []*entity.NetworkHost
+	seenAddresses := make(map[string]struct{}, len(networkHosts)+len(hostGroupHosts))
 	networkHostSetupList := make([]*entity.NetworkHostSetup, 0, len(networkHosts))
 	for _, networkHost := range networkHosts {
-		hostIPList, lookupErr := u.listIPByAddress(ctx, networkHost.Address)
-		if lookupErr != nil {
-			return nil, fmt.Errorf(
-				"failed to list IP by address %s: %w",
-				networkHost.Address,
-				lookupErr,
-			)
+		if networkHost.IsExclusion() {
+			exclusionHosts = append(exclusionHosts, networkHost)
+			continue
 		}
+		seenAddresses[strings.ToLower(networkHost.Address)] = struct{}{}
 
-		for _, hostIP := range hostIPList {
-			networkHostSetupList = append(networkHostSetupList, &entity.NetworkHostSetup{
-				NetworkHostID: networkHost.ID,
-				NetworkHostIP: hostIP,
-				SubnetMask:    currentNetworkInfo.SubnetMask,
-				Router:        currentNetworkInfo.Router,
-			})
+		hostNetworkInfo, infoErr := u.hostNetworkInfo(ctx, networkHost, currentNetworkInfo)
+		if infoErr != nil {
+			return nil, nil, infoErr
+		}
+
+		setups, setupErr := u.listSetupsByNetworkHost(ctx, networkHost, hostNetworkInfo, resolved)
+		if setupErr != nil {
+			return nil, nil, setupErr
+		}
+
+		networkHostSetupList = append(networkHostSetupList, setups...)
+	}
+
+	networkHostSetupList, err = u.appendHostGroupSetups(
+		ctx,
+		networkHostSetupList,
+		hostGroupHosts,
+		seenAddresses,
+		currentNetworkInfo,
+		resolved,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	networkHostSetupList, err = u.subtractExclusions(ctx, networkHostSetupList, exclusionHosts, resolved)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return networkHostSetupList, currentNetworkInfo, nil
+}
+
+// appendHostGroupSetups adds the routes of host group addresses that aren't already routed.
+func (u *UseCase) appendHostGroupSetups(
+	ctx context.Context,
+	networkHostSetupList []*entity.NetworkHostSetup,
+	hostGroupHosts []*entity.HostGroupHost,
+	seenAddresses map[string]struct{},
+	networkInfo *entity.NetworkInfo,
+	resolved *resolutions,
+) ([]*entity.NetworkHostSetup, error) {
+	if len(hostGroupHosts) == 0 {
+		return networkHostSetupList, nil
+	}
+
+	seenRoutes := make(map[string]struct{}, len(networkHostSetupList))
+	for _, setup := range networkHostSetupList {
+		seenRoutes[setup.NetworkHostIP+"/"+setup.SubnetMask] = struct{}{}
+	}
+
+	for _, hostGroupHost := range hostGroupHosts {
+		address := strings.ToLower(hostGroupHost.Address)
+		if _, ok := seenAddresses[address]; ok {
+			continue
+		}
+		seenAddresses[address] = struct{}{}
+
+		setups, err := u.listSetupsByAddress(ctx, 0, hostGroupHost.Address, networkInfo, resolved)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, setup := range setups {
+			route := setup.NetworkHostIP + "/" + setup.SubnetMask
+			if _, ok := seenRoutes[route]; ok {
+				continue
+			}
+			seenRoutes[route] = struct{}{}
+
+			networkHostSetupList = append(networkHostSetupList, setup)
 		}
 	}
 
 	return networkHostSetupList, nil
 }
 
-func (u *UseCase) getCurrentNetworkInfo(ctx context.Context) (*entity.NetworkInfo, error) {
-	defaultNetworkInterface, err := u.commandExecutorUC.GetDefaultNetworkInterface(ctx)
+// subtractExclusions removes excluded addresses from the routes. A route that only partly overlaps
+// an exclusion is split into the prefixes around it, routes clear of every exclusion are kept as they are.
+func (u *UseCase) subtractExclusions(
+	ctx context.Context,
+	networkHostSetupList []*entity.NetworkHostSetup,
+	exclusionHosts []*entity.NetworkHost,
+	resolved *resolutions,
+) ([]*entity.NetworkHostSetup, error) {
+	if len(exclusionHosts) == 0 {
+		return networkHostSetupList, nil
+	}
+
+	exclusions, err := u.listExclusionPrefixes(ctx, exclusionHosts, resolved)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default network interface: %w", err)
+		return nil, err
 	}
 
-	networkService, err := u.commandExecutorUC.GetNetworkServiceByNetworkInterface(
-		ctx,
-		defaultNetworkInterface,
-	)
+	res := make([]*entity.NetworkHostSetup, 0, len(networkHostSetupList))
+	for _, setup := range networkHostSetupList {
+		prefix, ok := setup.Prefix()
+		if !ok || !cidr.Overlaps(prefix, exclusions) {
+			res = append(res, setup)
+			continue
+		}
+
+		for _, remaining := range cidr.Subtract(prefix, exclusions) {
+			res = append(res, prefixSetup(remaining, setup.NetworkHostID, setup.Router))
+		}
+	}
+
+	return res, nil
+}
+
+// listExclusionPrefixes turns exclusion hosts into prefixes. Hostnames exclude each address they resolve to.
+func (u *UseCase) listExclusionPrefixes(
+	ctx context.Context,
+	exclusionHosts []*entity.NetworkHost,
+	resolved *resolutions,
+) ([]netip.Prefix, error) {
+	exclusions := make([]netip.Prefix, 0, len(exclusionHosts))
+	for _, exclusionHost := range exclusionHosts {
+		if prefix, err := netip.ParsePrefix(exclusionHost.Address); err == nil {
+			exclusions = append(exclusions, prefix.Masked())
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(exclusionHost.Address); err == nil {
+			exclusions = append(exclusions, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		hostIPList, err := u.listIPByNetworkHost(ctx, exclusionHost, resolved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list IP by exclusion address %s: %w", exclusionHost.Address, err)
+		}
+
+		for _, hostIP := range hostIPList {
+			addr, parseErr := netip.ParseAddr(hostIP)
+			if parseErr != nil {
+				continue
+			}
+
+			exclusions = append(exclusions, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+
+	return exclusions, nil
+}
+
+func (u *UseCase) listSetupsByAddress(
+	ctx context.Context,
+	networkHostID uint64,
+	address string,
+	networkInfo *entity.NetworkInfo,
+	resolved *resolutions,
+) ([]*entity.NetworkHostSetup, error) {
+	if networkIP, subnetMask, ok := entity.ParseCIDR(address); ok {
+		return []*entity.NetworkHostSetup{{
+			NetworkHostID: networkHostID,
+			NetworkHostIP: networkIP,
+			SubnetMask:    subnetMask,
+			Router:        networkInfo.Router,
+		}}, nil
+	}
+
+	hostIPList, err := u.listIPByAddress(ctx, address, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP by address %s: %w", address, err)
+	}
+
+	return hostIPSetups(networkHostID, hostIPList, networkInfo), nil
+}
+
+// listSetupsByNetworkHost resolves the routes of a network host, taking the IPs pinned to it
+// and the IPs learned for a wildcard into account.
+func (u *UseCase) listSetupsByNetworkHost(
+	ctx context.Context,
+	networkHost *entity.NetworkHost,
+	networkInfo *entity.NetworkInfo,
+	resolved *resolutions,
+) ([]*entity.NetworkHostSetup, error) {
+	if !networkHost.HasPinnedIPs() && !networkHost.IsWildcard() {
+		return u.listSetupsByAddress(ctx, networkHost.ID, networkHost.Address, networkInfo, resolved)
+	}
+
+	hostIPList, err := u.listIPByNetworkHost(ctx, networkHost, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP by address %s: %w", networkHost.Address, err)
+	}
+
+	return hostIPSetups(networkHost.ID, hostIPList, networkInfo), nil
+}
+
+// listIPByNetworkHost returns the IPs a hostname host is routed to. Pinned IPs replace the DNS results or
+// are added to them depending on the pin mode. In append mode a failed lookup leaves just the pinned IPs,
+// since those are usually pinned because DNS can't be relied on for the name.
+// Wildcards aren't resolved, they are routed to the IPs the DNS forwarder has learned for them.
+func (u *UseCase) listIPByNetworkHost(
+	ctx context.Context,
+	networkHost *entity.NetworkHost,
+	resolved *resolutions,
+) ([]string, error) {
+	if networkHost.IsWildcard() {
+		return u.listLearnedIPs(ctx, networkHost)
+	}
+
+	if !networkHost.HasPinnedIPs() {
+		return u.listIPByAddress(ctx, networkHost.Address, resolved)
+	}
+
+	if networkHost.PinMode != entity.NetworkHostPinModeAppend {
+		return networkHost.PinnedIPs, nil
+	}
+
+	hostIPList, err := u.listIPByAddress(ctx, networkHost.Address, resolved)
+	if err != nil {
+		slog.Warn(
+			"failed to resolve network host, routing its pinned IPs only",
+			"address", networkHost.Address,
+			"error", err,
+		)
+	}
+
+	for _, pinnedIP := range networkHost.PinnedIPs {
+		if !slices.Contains(hostIPList, pinnedIP) {
+			hostIPList = append(hostIPList, pinnedIP)
+		}
+	}
+
+	return hostIPList, nil
+}
+
+// listLearnedIPs returns the unexpired IPs learned for a wildcard network host, none until it's seen in DNS.
+func (u *UseCase) listLearnedIPs(ctx context.Context, networkHost *entity.NetworkHost) ([]string, error) {
+	learnedIPs, err := u.learnedIPStorage.ListActive(ctx, []uint64{networkHost.ID}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list learned IPs: %w", err)
+	}
+
+	hostIPList := make([]string, 0, len(learnedIPs))
+	for _, learnedIP := range learnedIPs {
+		hostIPList = append(hostIPList, learnedIP.IP)
+	}
+
+	return hostIPList, nil
+}
+
+// hostIPSetups builds the host routes of resolved IPs.
+func hostIPSetups(
+	networkHostID uint64,
+	hostIPList []string,
+	networkInfo *entity.NetworkInfo,
+) []*entity.NetworkHostSetup {
+	setups := make([]*entity.NetworkHostSetup, 0, len(hostIPList))
+	for _, hostIP := range hostIPList {
+		setups = append(setups, &entity.NetworkHostSetup{
+			NetworkHostID: networkHostID,
+			NetworkHostIP: hostIP,
+			SubnetMask:    networkInfo.SubnetMask,
+			Router:        networkInfo.Router,
+		})
+	}
+
+	return setups
+}
+
+// getNetworkInfo detects the network service routes go through and its router and subnet mask, with the override
+// applied. The service is the one of the default interface unless the override names another interface.
+// Router and mask aren't read from the service when the override sets both.
+func (u *UseCase) getNetworkInfo(ctx context.Context, override entity.RouteOverride) (*entity.NetworkInfo, error) {
+	networkInterface := override.NetworkInterface
+	if networkInterface == "" {
+		defaultNetworkInterface, err := u.commandExecutorUC.GetDefaultNetworkInterface(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default network interface: %w", err)
+		}
+		networkInterface = defaultNetworkInterface
+	}
+
+	networkService, err := u.commandExecutorUC.GetNetworkServiceByNetworkInterface(ctx, networkInterface)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to get network service by network interface %s: %w",
-			defaultNetworkInterface,
+			networkInterface,
 			err,
 		)
 	}
 
+	if override.HasGateway() {
+		return &entity.NetworkInfo{
+			NetworkInterface: networkInterface,
+			NetworkService:   networkService,
+			SubnetMask:       override.SubnetMask,
+			Router:           override.Router,
+		}, nil
+	}
+
 	networkInfo, err := u.commandExecutorUC.GetNetworkInfoByNetworkService(ctx, networkService)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -211,13 +870,81 @@ func (u *UseCase) getCurrentNetworkInfo(ctx context.Context) (*entity.NetworkInf
 			err,
 		)
 	}
+	networkInfo.NetworkInterface = networkInterface
+
+	return override.Apply(networkInfo), nil
+}
+
+// hostNetworkInfo returns the network info a host's routes are built with, the network's with the host's
+// route override applied. A host naming another interface takes the router and mask of that interface instead.
+// In inverse mode its direct routes are still set on the network's physical service.
+func (u *UseCase) hostNetworkInfo(
+	ctx context.Context,
+	networkHost *entity.NetworkHost,
+	networkInfo *entity.NetworkInfo,
+) (*entity.NetworkInfo, error) {
+	override := networkHost.RouteOverride()
+	if override.IsEmpty() {
+		return networkInfo, nil
+	}
+
+	if override.NetworkInterface == "" || override.NetworkInterface == networkInfo.NetworkInterface {
+		return override.Apply(networkInfo), nil
+	}
+
+	hostNetworkInfo, err := u.getNetworkInfo(ctx, override)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network info of %s: %w", networkHost.Address, err)
+	}
+
+	return hostNetworkInfo, nil
+}
+
+// listIPByAddress resolves a hostname to its IPv4 addresses and records the lookup in resolved.
+// When the lookup fails the cached IPs are returned instead and recorded as stale,
+// the lookup error is only returned when nothing is cached for the hostname.
+// IP literals resolve to themselves and aren't recorded.
+func (u *UseCase) listIPByAddress(ctx context.Context, address string, resolved *resolutions) ([]string, error) {
+	hostIPs, err := u.lookupIPv4(ctx, address)
+	if err == nil {
+		if net.ParseIP(address) == nil {
+			resolved.addFresh(entity.NewDNSCacheEntry(strings.ToLower(address), hostIPs, u.dnsCacheTTL))
+		}
+		return hostIPs, nil
+	}
 
-	return networkInfo, nil
+	entry, cacheErr := u.dnsCacheStorage.Get(ctx, strings.ToLower(address))
+	if cacheErr != nil {
+		if !errors.Is(cacheErr, errs.ErrDNSCacheEntryNotFound) {
+			slog.Warn("failed to get cached IPs", "address", address, "error", cacheErr)
+		}
+		return nil, err
+	}
+
+	slog.Warn(
+		"failed to resolve address, routing its cached IPs",
+		"address", address,
+		"resolved_at", entry.ResolvedAt.String(),
+		"error", err,
+	)
+	resolved.addStale(entity.NewStaleResolution(entry, err, time.Now()))
+
+	return entry.IPs, nil
+}
+
+// cacheResolutions stores a sync's successful lookups for later fallback.
+// A failed write only costs the fallback and doesn't fail the sync.
+func (u *UseCase) cacheResolutions(ctx context.Context, entries []*entity.DNSCacheEntry) {
+	for _, entry := range entries {
+		err := u.dnsCacheStorage.Upsert(ctx, entry)
+		if err != nil {
+			slog.Warn("failed to cache resolved IPs", "address", entry.Address, "error", err)
+		}
+	}
 }
 
-func (u *UseCase) listIPByAddress(ctx context.Context, address string) ([]string, error) {
-	resolver := net.DefaultResolver
-	ips, err := resolver.LookupIPAddr(ctx, address)
+func (u *UseCase) lookupIPv4(ctx context.Context, address string) ([]string, error) {
+	ips, err := u.lookupIP(ctx, address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to lookup IP for address %s: %w", address, err)
 	}
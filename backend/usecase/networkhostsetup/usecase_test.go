@@ -3,13 +3,18 @@ package networkhostsetup
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
+	"net/netip"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/dmitrorlov/splitr/backend/config"
 	"github.com/dmitrorlov/splitr/backend/entity"
 	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
 	mock_trm "github.com/dmitrorlov/splitr/backend/mocks/trm"
@@ -26,13 +31,21 @@ func TestNew(t *testing.T) {
 	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
 	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
 	mockNetworkHostSetupStorage := mock_storage.NewMockNetworkHostSetup(ctrl)
+	mockHostGroupStorage := mock_storage.NewMockHostGroup(ctrl)
 
 	useCase := New(
 		mockTrm,
 		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
 		mockNetworkStorage,
 		mockNetworkHostStorage,
 		mockNetworkHostSetupStorage,
+		mockHostGroupStorage,
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
 	)
 
 	assert.NotNil(t, useCase)
@@ -41,6 +54,90 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, mockNetworkStorage, useCase.networkStorage)
 	assert.Equal(t, mockNetworkHostStorage, useCase.networkHostStorage)
 	assert.Equal(t, mockNetworkHostSetupStorage, useCase.networkHostSetupStorage)
+	assert.Equal(t, mockHostGroupStorage, useCase.hostGroupStorage)
+	assert.NotNil(t, useCase.setupGenerationStorage)
+	assert.NotNil(t, useCase.dnsCacheStorage)
+	assert.Equal(t, 32, useCase.aggregatePrefixLength)
+	assert.Equal(t, time.Hour, useCase.dnsCacheTTL)
+	assert.NotNil(t, useCase.lookupIP)
+	assert.NotNil(t, useCase.syncQueue)
+}
+
+func testRouteConfig() *config.Route {
+	return &config.Route{AggregatePrefixLength: 32}
+}
+
+func testDNSConfig() *config.DNS {
+	return &config.DNS{CacheTTL: time.Hour}
+}
+
+// newMockDNSCacheStorage returns a DNS cache that stores resolutions and has nothing to fall back to.
+func newMockDNSCacheStorage(ctrl *gomock.Controller) *mock_storage.MockDNSCache {
+	mockDNSCacheStorage := mock_storage.NewMockDNSCache(ctrl)
+	mockDNSCacheStorage.EXPECT().
+		Upsert(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+	mockDNSCacheStorage.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(nil, errs.ErrDNSCacheEntryNotFound).
+		AnyTimes()
+
+	return mockDNSCacheStorage
+}
+
+// newMockScopedDNS returns a scoped DNS use case for networks without DNS domains.
+func newMockScopedDNS(ctrl *gomock.Controller) *mock_usecase.MockScopedDNS {
+	mockScopedDNS := mock_usecase.NewMockScopedDNS(ctrl)
+	mockScopedDNS.EXPECT().
+		ApplyByNetwork(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	mockScopedDNS.EXPECT().
+		RemoveByNetworkID(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	return mockScopedDNS
+}
+
+// newMockSetupGenerationStorage returns a setup generation storage that records generations
+// and has none to restore.
+func newMockSetupGenerationStorage(ctrl *gomock.Controller) *mock_storage.MockNetworkSetupGeneration {
+	mockSetupGenerationStorage := mock_storage.NewMockNetworkSetupGeneration(ctrl)
+	mockSetupGenerationStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, generation *entity.NetworkSetupGeneration) (*entity.NetworkSetupGeneration, error) {
+			added := *generation
+			added.ID = 1
+			return &added, nil
+		}).
+		AnyTimes()
+	mockSetupGenerationStorage.EXPECT().
+		MarkApplied(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+	mockSetupGenerationStorage.EXPECT().
+		MarkFailed(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+	mockSetupGenerationStorage.EXPECT().
+		GetLastApplied(gomock.Any(), gomock.Any()).
+		Return(nil, errs.ErrNetworkSetupGenerationNotFound).
+		AnyTimes()
+
+	return mockSetupGenerationStorage
+}
+
+// newMockHostGroupStorage returns a host group storage for networks without attached groups.
+func newMockHostGroupStorage(ctrl *gomock.Controller) *mock_storage.MockHostGroup {
+	mockHostGroupStorage := mock_storage.NewMockHostGroup(ctrl)
+	mockHostGroupStorage.EXPECT().
+		ListHosts(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	return mockHostGroupStorage
 }
 
 func TestUseCase_SyncByNetworkID(t *testing.T) {
@@ -67,10 +164,11 @@ func TestUseCase_SyncByNetworkID(t *testing.T) {
 				mockNetworkHostStorage.EXPECT().
 					List(gomock.Any(), &entity.ListNetworkHostFilter{
 						NetworkID: []uint64{1},
+						Enabled:   boolPtr(true),
 					}).
 					Return([]*entity.NetworkHost{}, nil)
 
-				// Mock getCurrentNetworkInfo chain - needed by listSetupsByNetwork
+				// Mock getNetworkInfo chain - needed by listSetupsByNetwork
 				mockCommandExecutor.EXPECT().
 					GetDefaultNetworkInterface(gomock.Any()).
 					Return(entity.NetworkInterface("eth0"), nil)
@@ -117,10 +215,11 @@ func TestUseCase_SyncByNetworkID(t *testing.T) {
 				mockNetworkHostStorage.EXPECT().
 					List(gomock.Any(), &entity.ListNetworkHostFilter{
 						NetworkID: []uint64{1},
+						Enabled:   boolPtr(true),
 					}).
 					Return([]*entity.NetworkHost{}, nil)
 
-				// Mock getCurrentNetworkInfo chain - needed by listSetupsByNetwork
+				// Mock getNetworkInfo chain - needed by listSetupsByNetwork
 				// This executes before the VPN check, so must be mocked
 				mockCommandExecutor.EXPECT().
 					GetDefaultNetworkInterface(gomock.Any()).
@@ -178,6 +277,11 @@ func TestUseCase_SyncByNetworkID(t *testing.T) {
 					GetCurrentVPN(gomock.Any()).
 					Return(entity.VPNService("TestNetwork"), nil)
 
+				// Mock successful command execution
+				mockCommandExecutor.EXPECT().
+					SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Any()).
+					Return(nil)
+
 				mockTrm.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -189,11 +293,6 @@ func TestUseCase_SyncByNetworkID(t *testing.T) {
 							AddBatch(gomock.Any(), gomock.Any()).
 							Return(nil)
 
-						// Mock successful command execution
-						mockCommandExecutor.EXPECT().
-							SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Any()).
-							Return(nil)
-
 						return fn(ctx)
 					})
 			},
@@ -216,10 +315,11 @@ func TestUseCase_SyncByNetworkID(t *testing.T) {
 				mockNetworkHostStorage.EXPECT().
 					List(gomock.Any(), &entity.ListNetworkHostFilter{
 						NetworkID: []uint64{1},
+						Enabled:   boolPtr(true),
 					}).
 					Return([]*entity.NetworkHost{}, nil)
 
-				// Mock getCurrentNetworkInfo chain - needed by listSetupsByNetwork
+				// Mock getNetworkInfo chain - needed by listSetupsByNetwork
 				// This executes before the VPN check, so must be mocked
 				mockCommandExecutor.EXPECT().
 					GetDefaultNetworkInterface(gomock.Any()).
@@ -267,9 +367,16 @@ func TestUseCase_SyncByNetworkID(t *testing.T) {
 			useCase := New(
 				mockTrm,
 				mockCommandExecutor,
+				newMockScopedDNS(ctrl),
 				mockNetworkStorage,
 				mockNetworkHostStorage,
 				mockNetworkHostSetupStorage,
+				newMockHostGroupStorage(ctrl),
+				newMockSetupGenerationStorage(ctrl),
+				newMockDNSCacheStorage(ctrl),
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
 			)
 
 			// Execute the method
@@ -470,9 +577,16 @@ func TestUseCase_ResetByNetworkID(t *testing.T) {
 			useCase := New(
 				mockTrm,
 				mockCommandExecutor,
+				newMockScopedDNS(ctrl),
 				mockNetworkStorage,
 				mockNetworkHostStorage,
 				mockNetworkHostSetupStorage,
+				newMockHostGroupStorage(ctrl),
+				newMockSetupGenerationStorage(ctrl),
+				newMockDNSCacheStorage(ctrl),
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
 			)
 
 			// Execute the method
@@ -489,237 +603,126 @@ func TestUseCase_ResetByNetworkID(t *testing.T) {
 	}
 }
 
-// Additional tests for uncovered code paths
-
-func TestUseCase_SyncByNetworkID_TransactionErrors(t *testing.T) {
-	tests := []struct {
-		name          string
-		networkID     uint64
-		setupMocks    func(*mock_usecase.MockCommandExecutor, *mock_storage.MockNetwork, *mock_storage.MockNetworkHost, *mock_storage.MockNetworkHostSetup, *mock_trm.MockManager)
-		expectedError string
-	}{
-		{
-			name:      "transaction error during DeleteBatchByNetworkHostIDs",
-			networkID: 1,
-			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupStorage *mock_storage.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				network := &entity.Network{ID: 1, Name: "TestNetwork"}
-				networkHost := &entity.NetworkHost{ID: 1, NetworkID: 1, Address: "example.com"}
-
-				// Mock the complete chain to get to transaction
-				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
-				mockNetworkHostStorage.EXPECT().
-					List(gomock.Any(), gomock.Any()).
-					Return([]*entity.NetworkHost{networkHost}, nil)
-
-				// Mock network info chain
-				mockCommandExecutor.EXPECT().
-					GetDefaultNetworkInterface(gomock.Any()).
-					Return(entity.NetworkInterface("eth0"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
-					Return(entity.NetworkService("service"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
-					Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
-
-				// Mock VPN check
-				mockCommandExecutor.EXPECT().
-					GetCurrentVPN(gomock.Any()).
-					Return(entity.VPNService("TestNetwork"), nil)
-
-				// Mock transaction with DeleteBatch error
-				mockTrm.EXPECT().
-					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						// Inside transaction: DeleteBatch fails
-						mockNetworkHostSetupStorage.EXPECT().
-							DeleteBatchByNetworkHostIDs(gomock.Any(), gomock.Any()).
-							Return(errors.New("delete batch failed"))
-						return fn(ctx)
-					})
-			},
-			expectedError: "failed to delete network host setup list by network host ids: delete batch failed",
-		},
-		{
-			name:      "transaction error during AddBatch",
-			networkID: 1,
-			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupStorage *mock_storage.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				network := &entity.Network{ID: 1, Name: "TestNetwork"}
-				networkHost := &entity.NetworkHost{ID: 1, NetworkID: 1, Address: "example.com"}
-
-				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
-				mockNetworkHostStorage.EXPECT().
-					List(gomock.Any(), gomock.Any()).
-					Return([]*entity.NetworkHost{networkHost}, nil)
-
-				mockCommandExecutor.EXPECT().
-					GetDefaultNetworkInterface(gomock.Any()).
-					Return(entity.NetworkInterface("eth0"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
-					Return(entity.NetworkService("service"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
-					Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
-				mockCommandExecutor.EXPECT().
-					GetCurrentVPN(gomock.Any()).
-					Return(entity.VPNService("TestNetwork"), nil)
-
-				mockTrm.EXPECT().
-					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						// DeleteBatch succeeds, AddBatch fails
-						mockNetworkHostSetupStorage.EXPECT().
-							DeleteBatchByNetworkHostIDs(gomock.Any(), gomock.Any()).
-							Return(nil)
-						mockNetworkHostSetupStorage.EXPECT().
-							AddBatch(gomock.Any(), gomock.Any()).
-							Return(errors.New("add batch failed"))
-						return fn(ctx)
-					})
-			},
-			expectedError: "failed to add network host setup list: add batch failed",
-		},
-		{
-			name:      "transaction error during SetNetworkAdditionalRoutes",
-			networkID: 1,
-			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, mockNetworkHostSetupStorage *mock_storage.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				network := &entity.Network{ID: 1, Name: "TestNetwork"}
-				networkHost := &entity.NetworkHost{ID: 1, NetworkID: 1, Address: "example.com"}
-
-				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
-				mockNetworkHostStorage.EXPECT().
-					List(gomock.Any(), gomock.Any()).
-					Return([]*entity.NetworkHost{networkHost}, nil)
-
-				mockCommandExecutor.EXPECT().
-					GetDefaultNetworkInterface(gomock.Any()).
-					Return(entity.NetworkInterface("eth0"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
-					Return(entity.NetworkService("service"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
-					Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
-				mockCommandExecutor.EXPECT().
-					GetCurrentVPN(gomock.Any()).
-					Return(entity.VPNService("TestNetwork"), nil)
-
-				mockTrm.EXPECT().
-					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						// Storage operations succeed, command executor fails
-						mockNetworkHostSetupStorage.EXPECT().
-							DeleteBatchByNetworkHostIDs(gomock.Any(), gomock.Any()).
-							Return(nil)
-						mockNetworkHostSetupStorage.EXPECT().
-							AddBatch(gomock.Any(), gomock.Any()).
-							Return(nil)
-						mockCommandExecutor.EXPECT().
-							SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Any()).
-							Return(errors.New("command failed"))
-						return fn(ctx)
-					})
-			},
-			expectedError: "failed to set network additional routes: command failed",
-		},
-		{
-			name:      "transaction Do method itself fails",
-			networkID: 1,
-			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_storage.MockNetworkHostSetup, mockTrm *mock_trm.MockManager) {
-				network := &entity.Network{ID: 1, Name: "TestNetwork"}
-
-				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
-				mockNetworkHostStorage.EXPECT().
-					List(gomock.Any(), gomock.Any()).
-					Return([]*entity.NetworkHost{}, nil)
-
-				mockCommandExecutor.EXPECT().
-					GetDefaultNetworkInterface(gomock.Any()).
-					Return(entity.NetworkInterface("eth0"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
-					Return(entity.NetworkService("service"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
-					Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
-				mockCommandExecutor.EXPECT().
-					GetCurrentVPN(gomock.Any()).
-					Return(entity.VPNService("TestNetwork"), nil)
-
-				// Transaction manager itself fails
-				mockTrm.EXPECT().
-					Do(gomock.Any(), gomock.Any()).
-					Return(errors.New("transaction failed"))
-			},
-			expectedError: "failed to apply transaction: transaction failed",
-		},
-		{
-			name:      "error from GetCurrentVPN that is not ErrVPNServiceNotFound",
-			networkID: 1,
-			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost, _ *mock_storage.MockNetworkHostSetup, _ *mock_trm.MockManager) {
-				network := &entity.Network{ID: 1, Name: "TestNetwork"}
-
-				mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
-				mockNetworkHostStorage.EXPECT().
-					List(gomock.Any(), gomock.Any()).
-					Return([]*entity.NetworkHost{}, nil)
-
-				mockCommandExecutor.EXPECT().
-					GetDefaultNetworkInterface(gomock.Any()).
-					Return(entity.NetworkInterface("eth0"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
-					Return(entity.NetworkService("service"), nil)
-				mockCommandExecutor.EXPECT().
-					GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
-					Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
-
-				// Return a different error (not ErrVPNServiceNotFound)
-				mockCommandExecutor.EXPECT().
-					GetCurrentVPN(gomock.Any()).
-					Return(entity.VPNService(""), errors.New("some other VPN error"))
-			},
-			expectedError: "failed to get current VPN: some other VPN error",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
+func TestUseCase_ExportScriptsByNetworkID(t *testing.T) {
+	t.Run("successfully export apply and teardown scripts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+		mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+		mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+		network := &entity.Network{ID: 1, Name: "TestNetwork"}
+		mockNetworkStorage.EXPECT().
+			Get(gomock.Any(), uint64(1)).
+			Return(network, nil)
+		mockNetworkHostStorage.EXPECT().
+			List(gomock.Any(), &entity.ListNetworkHostFilter{NetworkID: []uint64{1}, Enabled: boolPtr(true)}).
+			Return([]*entity.NetworkHost{{ID: 10, NetworkID: 1, Address: "10.0.0.5"}}, nil)
+		mockCommandExecutor.EXPECT().
+			GetDefaultNetworkInterface(gomock.Any()).
+			Return(entity.NetworkInterface("en0"), nil)
+		mockCommandExecutor.EXPECT().
+			GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en0")).
+			Return(entity.NetworkService("Wi-Fi"), nil)
+		mockCommandExecutor.EXPECT().
+			GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("Wi-Fi")).
+			Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+
+		expectedSetups := []*entity.NetworkHostSetup{{
+			NetworkHostID:  10,
+			NetworkHostIP:  "10.0.0.5",
+			SubnetMask:     "255.255.255.0",
+			Router:         "192.168.1.1",
+			NetworkHostIDs: []uint64{10},
+		}}
+		applyCommand := &entity.Command{Executable: "networksetup", Args: []string{"apply"}}
+		teardownCommand := &entity.Command{Executable: "networksetup", Args: []string{"teardown"}}
+		addCommands := []*entity.Command{{Executable: "route", Args: []string{"add"}}}
+		deleteCommands := []*entity.Command{{Executable: "route", Args: []string{"delete"}}}
+
+		mockCommandExecutor.EXPECT().
+			SetNetworkAdditionalRoutesCommand(network, expectedSetups).
+			Return(applyCommand)
+		mockCommandExecutor.EXPECT().
+			SetNetworkAdditionalRoutesCommand(network, []*entity.NetworkHostSetup{}).
+			Return(teardownCommand)
+		mockCommandExecutor.EXPECT().
+			RouteCommands(entity.RouteActionAdd, expectedSetups).
+			Return(addCommands)
+		mockCommandExecutor.EXPECT().
+			RouteCommands(entity.RouteActionDelete, expectedSetups).
+			Return(deleteCommands)
+
+		useCase := New(
+			mock_trm.NewMockManager(ctrl),
+			mockCommandExecutor,
+			newMockScopedDNS(ctrl),
+			mockNetworkStorage,
+			mockNetworkHostStorage,
+			mock_storage.NewMockNetworkHostSetup(ctrl),
+			newMockHostGroupStorage(ctrl),
+			newMockSetupGenerationStorage(ctrl),
+			newMockDNSCacheStorage(ctrl),
+			mock_storage.NewMockLearnedIP(ctrl),
+			testRouteConfig(),
+			testDNSConfig(),
+		)
 
-			mockTrm := mock_trm.NewMockManager(ctrl)
-			mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
-			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
-			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
-			mockNetworkHostSetupStorage := mock_storage.NewMockNetworkHostSetup(ctrl)
+		scripts, err := useCase.ExportScriptsByNetworkID(context.Background(), 1)
 
-			tt.setupMocks(
-				mockCommandExecutor,
-				mockNetworkStorage,
-				mockNetworkHostStorage,
-				mockNetworkHostSetupStorage,
-				mockTrm,
-			)
+		require.NoError(t, err)
+		require.NotNil(t, scripts)
+		assert.Equal(t, "TestNetwork", scripts.Apply.NetworkName)
+		assert.Equal(t, applyCommand, scripts.Apply.NetworkSetupCommand)
+		assert.Equal(t, addCommands, scripts.Apply.RouteCommands)
+		assert.Equal(t, "TestNetwork", scripts.Teardown.NetworkName)
+		assert.Equal(t, teardownCommand, scripts.Teardown.NetworkSetupCommand)
+		assert.Equal(t, deleteCommands, scripts.Teardown.RouteCommands)
+		assert.Equal(t, scripts.Apply.GeneratedAt, scripts.Teardown.GeneratedAt)
+		assert.Equal(t, &entity.NetworkInfo{
+			NetworkInterface: "en0",
+			SubnetMask:       "255.255.255.0",
+			Router:           "192.168.1.1",
+		}, scripts.Apply.NetworkInfo)
+		assert.Equal(t, scripts.Apply.NetworkInfo, scripts.Teardown.NetworkInfo)
+	})
 
-			useCase := New(
-				mockTrm,
-				mockCommandExecutor,
-				mockNetworkStorage,
-				mockNetworkHostStorage,
-				mockNetworkHostSetupStorage,
-			)
+	t.Run("error when network not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+		mockNetworkStorage.EXPECT().
+			Get(gomock.Any(), uint64(1)).
+			Return(nil, errs.ErrNetworkNotFound)
+
+		useCase := New(
+			mock_trm.NewMockManager(ctrl),
+			mock_usecase.NewMockCommandExecutor(ctrl),
+			newMockScopedDNS(ctrl),
+			mockNetworkStorage,
+			mock_storage.NewMockNetworkHost(ctrl),
+			mock_storage.NewMockNetworkHostSetup(ctrl),
+			newMockHostGroupStorage(ctrl),
+			newMockSetupGenerationStorage(ctrl),
+			newMockDNSCacheStorage(ctrl),
+			mock_storage.NewMockLearnedIP(ctrl),
+			testRouteConfig(),
+			testDNSConfig(),
+		)
 
-			err := useCase.SyncByNetworkID(context.Background(), tt.networkID)
+		scripts, err := useCase.ExportScriptsByNetworkID(context.Background(), 1)
 
-			require.Error(t, err)
-			assert.Contains(t, err.Error(), tt.expectedError)
-		})
-	}
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get network by id 1")
+		assert.Nil(t, scripts)
+	})
 }
 
-func TestUseCase_listNetworkAndSetupsByNetworkID(t *testing.T) {
+// Additional tests for uncovered code paths
+
+func TestUseCase_planByNetworkID(t *testing.T) {
 	tests := []struct {
 		name           string
 		networkID      uint64
@@ -741,7 +744,7 @@ func TestUseCase_listNetworkAndSetupsByNetworkID(t *testing.T) {
 			expectedResult: false,
 		},
 		{
-			name:      "error from getCurrentNetworkInfo",
+			name:      "error from getNetworkInfo",
 			networkID: 1,
 			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkStorage *mock_storage.MockNetwork, mockNetworkHostStorage *mock_storage.MockNetworkHost) {
 				network := &entity.Network{ID: 1, Name: "TestNetwork"}
@@ -798,33 +801,40 @@ func TestUseCase_listNetworkAndSetupsByNetworkID(t *testing.T) {
 			useCase := New(
 				mockTrm,
 				mockCommandExecutor,
+				newMockScopedDNS(ctrl),
 				mockNetworkStorage,
 				mockNetworkHostStorage,
 				mockNetworkHostSetupStorage,
+				newMockHostGroupStorage(ctrl),
+				newMockSetupGenerationStorage(ctrl),
+				newMockDNSCacheStorage(ctrl),
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
 			)
 
-			network, setups, err := useCase.listNetworkAndSetupsByNetworkID(
-				context.Background(),
-				tt.networkID,
-			)
+			plan, err := useCase.planByNetworkID(context.Background(), tt.networkID)
 
 			if tt.expectedError != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
-				assert.Nil(t, network)
-				assert.Nil(t, setups)
+				assert.Nil(t, plan)
 			} else if tt.expectedResult {
 				require.NoError(t, err)
-				assert.NotNil(t, network)
-				assert.NotNil(t, setups)
+				assert.NotNil(t, plan.network)
+				assert.NotNil(t, plan.networkInfo)
+				assert.NotNil(t, plan.hostSetups)
+				assert.Equal(t, plan.hostSetups, plan.tunnelSetups)
+				assert.Empty(t, plan.directSetups)
 			}
 		})
 	}
 }
 
-func TestUseCase_getCurrentNetworkInfo(t *testing.T) {
+func TestUseCase_getNetworkInfo(t *testing.T) {
 	tests := []struct {
 		name          string
+		override      entity.RouteOverride
 		setupMocks    func(*mock_usecase.MockCommandExecutor)
 		expectedError string
 		expectedInfo  *entity.NetworkInfo
@@ -878,7 +888,52 @@ func TestUseCase_getCurrentNetworkInfo(t *testing.T) {
 					GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("test-service")).
 					Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
 			},
-			expectedInfo: &entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"},
+			expectedInfo: &entity.NetworkInfo{
+				NetworkInterface: "eth0",
+				NetworkService:   "",
+				SubnetMask:       "255.255.255.0",
+				Router:           "192.168.1.1",
+			},
+		},
+		{
+			name:     "interface and router overridden",
+			override: entity.RouteOverride{Router: "10.10.0.1", NetworkInterface: "en7"},
+			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor) {
+				mockCommandExecutor.EXPECT().
+					GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en7")).
+					Return(entity.NetworkService("USB LAN"), nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("USB LAN")).
+					Return(&entity.NetworkInfo{
+						NetworkService: "USB LAN",
+						SubnetMask:     "255.255.0.0",
+						Router:         "10.10.0.254",
+					}, nil)
+			},
+			expectedInfo: &entity.NetworkInfo{
+				NetworkInterface: "en7",
+				NetworkService:   "USB LAN",
+				SubnetMask:       "255.255.0.0",
+				Router:           "10.10.0.1",
+			},
+		},
+		{
+			name:     "router and mask overridden aren't read from the service",
+			override: entity.RouteOverride{Router: "192.168.50.1", SubnetMask: "255.255.255.0"},
+			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor) {
+				mockCommandExecutor.EXPECT().
+					GetDefaultNetworkInterface(gomock.Any()).
+					Return(entity.NetworkInterface("en0"), nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en0")).
+					Return(entity.NetworkService("Wi-Fi"), nil)
+			},
+			expectedInfo: &entity.NetworkInfo{
+				NetworkInterface: "en0",
+				NetworkService:   "Wi-Fi",
+				SubnetMask:       "255.255.255.0",
+				Router:           "192.168.50.1",
+			},
 		},
 	}
 
@@ -898,12 +953,19 @@ func TestUseCase_getCurrentNetworkInfo(t *testing.T) {
 			useCase := New(
 				mockTrm,
 				mockCommandExecutor,
+				newMockScopedDNS(ctrl),
 				mockNetworkStorage,
 				mockNetworkHostStorage,
 				mockNetworkHostSetupStorage,
+				newMockHostGroupStorage(ctrl),
+				newMockSetupGenerationStorage(ctrl),
+				newMockDNSCacheStorage(ctrl),
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
 			)
 
-			result, err := useCase.getCurrentNetworkInfo(context.Background())
+			result, err := useCase.getNetworkInfo(context.Background(), tt.override)
 
 			if tt.expectedError != "" {
 				require.Error(t, err)
@@ -927,18 +989,26 @@ func TestUseCase_listIPByAddress(t *testing.T) {
 	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
 	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
 	mockNetworkHostSetupStorage := mock_storage.NewMockNetworkHostSetup(ctrl)
+	mockHostGroupStorage := mock_storage.NewMockHostGroup(ctrl)
 
 	useCase := New(
 		mockTrm,
 		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
 		mockNetworkStorage,
 		mockNetworkHostStorage,
 		mockNetworkHostSetupStorage,
+		mockHostGroupStorage,
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
 	)
 
 	t.Run("successful IPv4 filtering", func(t *testing.T) {
 		// Test with a real domain that should resolve to IPv4
-		ips, err := useCase.listIPByAddress(context.Background(), "google.com")
+		ips, err := useCase.listIPByAddress(context.Background(), "google.com", nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, ips)
 
@@ -955,6 +1025,7 @@ func TestUseCase_listIPByAddress(t *testing.T) {
 		ips, err := useCase.listIPByAddress(
 			context.Background(),
 			"this-domain-should-not-exist-12345.invalid",
+			nil,
 		)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to lookup IP for address")
@@ -964,7 +1035,7 @@ func TestUseCase_listIPByAddress(t *testing.T) {
 	t.Run("no IPv4 addresses found", func(t *testing.T) {
 		// This is harder to test reliably since most domains have IPv4.
 		// We'll test with localhost which should always resolve
-		ips, err := useCase.listIPByAddress(context.Background(), "localhost")
+		ips, err := useCase.listIPByAddress(context.Background(), "localhost", nil)
 
 		// localhost should resolve to at least IPv4 loopback
 		if err != nil {
@@ -1024,6 +1095,35 @@ func TestUseCase_listSetupsByNetwork_WithHosts(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "CIDR hosts keep their own subnet mask",
+			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkHostStorage *mock_storage.MockNetworkHost) {
+				networkHosts := []*entity.NetworkHost{
+					{ID: 1, NetworkID: 1, Address: "10.20.0.0/16"},
+					{ID: 2, NetworkID: 1, Address: "10.1.2.3"},
+				}
+				mockNetworkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(networkHosts, nil)
+
+				mockCommandExecutor.EXPECT().
+					GetDefaultNetworkInterface(gomock.Any()).
+					Return(entity.NetworkInterface("eth0"), nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
+					Return(entity.NetworkService("service"), nil)
+				mockCommandExecutor.EXPECT().
+					GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
+					Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+			},
+			network: &entity.Network{ID: 1, Name: "TestNetwork"},
+			verifyResult: func(setups []*entity.NetworkHostSetup) {
+				assert.Equal(t, []*entity.NetworkHostSetup{
+					{NetworkHostID: 1, NetworkHostIP: "10.20.0.0", SubnetMask: "255.255.0.0", Router: "192.168.1.1"},
+					{NetworkHostID: 2, NetworkHostIP: "10.1.2.3", SubnetMask: "255.255.255.0", Router: "192.168.1.1"},
+				}, setups)
+			},
+		},
 		{
 			name: "error from listIPByAddress",
 			setupMocks: func(mockCommandExecutor *mock_usecase.MockCommandExecutor, mockNetworkHostStorage *mock_storage.MockNetworkHost) {
@@ -1065,12 +1165,19 @@ func TestUseCase_listSetupsByNetwork_WithHosts(t *testing.T) {
 			useCase := New(
 				mockTrm,
 				mockCommandExecutor,
+				newMockScopedDNS(ctrl),
 				mockNetworkStorage,
 				mockNetworkHostStorage,
 				mockNetworkHostSetupStorage,
+				newMockHostGroupStorage(ctrl),
+				newMockSetupGenerationStorage(ctrl),
+				newMockDNSCacheStorage(ctrl),
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
 			)
 
-			setups, err := useCase.listSetupsByNetwork(context.Background(), tt.network)
+			setups, _, err := useCase.listSetupsByNetwork(context.Background(), tt.network, nil)
 
 			if tt.expectedError != "" {
 				require.Error(t, err)
@@ -1084,3 +1191,1498 @@ func TestUseCase_listSetupsByNetwork_WithHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_listSetupsByNetwork_WithHostGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockHostGroupStorage := mock_storage.NewMockHostGroup(ctrl)
+
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{NetworkID: []uint64{1}, Enabled: boolPtr(true)}).
+		Return([]*entity.NetworkHost{
+			{ID: 1, NetworkID: 1, Address: "10.1.2.3"},
+		}, nil)
+	mockHostGroupStorage.EXPECT().
+		ListHosts(gomock.Any(), &entity.ListHostGroupHostFilter{NetworkID: []uint64{1}}).
+		Return([]*entity.HostGroupHost{
+			// Already routed by the network's own host
+			{ID: 10, HostGroupID: 1, Address: "10.1.2.3"},
+			{ID: 11, HostGroupID: 1, Address: "10.20.0.0/16"},
+			// Same address in a second group
+			{ID: 12, HostGroupID: 2, Address: "10.20.0.0/16"},
+			{ID: 14, HostGroupID: 2, Address: "10.30.0.1"},
+		}, nil)
+	mockCommandExecutor.EXPECT().
+		GetDefaultNetworkInterface(gomock.Any()).
+		Return(entity.NetworkInterface("eth0"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(entity.NetworkService("service"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
+		Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mock_storage.NewMockNetwork(ctrl),
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		mockHostGroupStorage,
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	setups, _, err := useCase.listSetupsByNetwork(
+		context.Background(),
+		&entity.Network{ID: 1, Name: "TestNetwork"},
+		nil,
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.NetworkHostSetup{
+		{NetworkHostID: 1, NetworkHostIP: "10.1.2.3", SubnetMask: "255.255.255.0", Router: "192.168.1.1"},
+		{NetworkHostID: 0, NetworkHostIP: "10.20.0.0", SubnetMask: "255.255.0.0", Router: "192.168.1.1"},
+		{NetworkHostID: 0, NetworkHostIP: "10.30.0.1", SubnetMask: "255.255.255.0", Router: "192.168.1.1"},
+	}, setups)
+}
+
+func expectCurrentNetworkInfo(mockCommandExecutor *mock_usecase.MockCommandExecutor) {
+	mockCommandExecutor.EXPECT().
+		GetDefaultNetworkInterface(gomock.Any()).
+		Return(entity.NetworkInterface("eth0"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(entity.NetworkService("service"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
+		Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+}
+
+func TestUseCase_listSetupsByNetwork_Exclusions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockHostGroupStorage := mock_storage.NewMockHostGroup(ctrl)
+
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{NetworkID: []uint64{1}, Enabled: boolPtr(true)}).
+		Return([]*entity.NetworkHost{
+			{ID: 1, NetworkID: 1, Address: "10.0.0.0/8", Kind: entity.NetworkHostKindInclude},
+			{ID: 2, NetworkID: 1, Address: "10.99.0.0/16", Kind: entity.NetworkHostKindExclude},
+			{ID: 3, NetworkID: 1, Address: "172.16.5.10", Kind: entity.NetworkHostKindInclude},
+			{ID: 4, NetworkID: 1, Address: "172.16.5.10", Kind: entity.NetworkHostKindExclude},
+		}, nil)
+	mockHostGroupStorage.EXPECT().
+		ListHosts(gomock.Any(), &entity.ListHostGroupHostFilter{NetworkID: []uint64{1}}).
+		Return([]*entity.HostGroupHost{
+			{ID: 10, HostGroupID: 1, Address: "192.168.50.0/30"},
+			{ID: 11, HostGroupID: 1, Address: "192.168.60.0/24"},
+		}, nil)
+	expectCurrentNetworkInfo(mockCommandExecutor)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mock_storage.NewMockNetwork(ctrl),
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		mockHostGroupStorage,
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	setups, _, err := useCase.listSetupsByNetwork(
+		context.Background(),
+		&entity.Network{ID: 1, Name: "TestNetwork"},
+		nil,
+	)
+
+	require.NoError(t, err)
+
+	routes := make([]string, 0, len(setups))
+	for _, setup := range setups {
+		assert.Equal(t, "192.168.1.1", setup.Router)
+		routes = append(routes, fmt.Sprintf("%d %s/%s", setup.NetworkHostID, setup.NetworkHostIP, setup.SubnetMask))
+	}
+
+	// 10.0.0.0/8 is split around 10.99.0.0/16, 172.16.5.10 is excluded together with its /24 route
+	// and the host group routes are left alone.
+	assert.Equal(t, []string{
+		"1 10.0.0.0/255.192.0.0",
+		"1 10.64.0.0/255.224.0.0",
+		"1 10.96.0.0/255.254.0.0",
+		"1 10.98.0.0/255.255.0.0",
+		"1 10.100.0.0/255.252.0.0",
+		"1 10.104.0.0/255.248.0.0",
+		"1 10.112.0.0/255.240.0.0",
+		"1 10.128.0.0/255.128.0.0",
+		"3 172.16.5.0/255.255.255.248",
+		"3 172.16.5.8/255.255.255.254",
+		"3 172.16.5.11/255.255.255.255",
+		"3 172.16.5.12/255.255.255.252",
+		"3 172.16.5.16/255.255.255.240",
+		"3 172.16.5.32/255.255.255.224",
+		"3 172.16.5.64/255.255.255.192",
+		"3 172.16.5.128/255.255.255.128",
+		"0 192.168.50.0/255.255.255.252",
+		"0 192.168.60.0/255.255.255.0",
+	}, routes)
+}
+
+func TestUseCase_listSetupsByNetwork_PinnedIPs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{NetworkID: []uint64{1}, Enabled: boolPtr(true)}).
+		Return([]*entity.NetworkHost{
+			{
+				ID:        1,
+				NetworkID: 1,
+				Address:   "git.corp.invalid",
+				Kind:      entity.NetworkHostKindInclude,
+				PinnedIPs: entity.IPList{"10.0.0.5", "10.0.0.6"},
+				PinMode:   entity.NetworkHostPinModeReplace,
+			},
+			{
+				ID:        2,
+				NetworkID: 1,
+				Address:   "wiki.corp.invalid",
+				Kind:      entity.NetworkHostKindInclude,
+				PinnedIPs: entity.IPList{"10.0.0.7"},
+				PinMode:   entity.NetworkHostPinModeAppend,
+			},
+			{
+				ID:        3,
+				NetworkID: 1,
+				Address:   "printer.corp.invalid",
+				Kind:      entity.NetworkHostKindExclude,
+				PinnedIPs: entity.IPList{"10.0.1.2"},
+				PinMode:   entity.NetworkHostPinModeReplace,
+			},
+			{ID: 4, NetworkID: 1, Address: "10.0.1.0/30", Kind: entity.NetworkHostKindInclude},
+		}, nil)
+	expectCurrentNetworkInfo(mockCommandExecutor)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mock_storage.NewMockNetwork(ctrl),
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	setups, _, err := useCase.listSetupsByNetwork(
+		context.Background(),
+		&entity.Network{ID: 1, Name: "TestNetwork"},
+		nil,
+	)
+
+	require.NoError(t, err)
+
+	routes := make([]string, 0, len(setups))
+	for _, setup := range setups {
+		routes = append(routes, fmt.Sprintf("%d %s/%s", setup.NetworkHostID, setup.NetworkHostIP, setup.SubnetMask))
+	}
+
+	// Replace mode never looks the name up, append mode falls back to the pinned IPs when the lookup fails
+	// and a pinned exclusion is subtracted like an IP exclusion.
+	assert.Equal(t, []string{
+		"1 10.0.0.5/255.255.255.0",
+		"1 10.0.0.6/255.255.255.0",
+		"2 10.0.0.7/255.255.255.0",
+		"4 10.0.1.0/255.255.255.254",
+		"4 10.0.1.3/255.255.255.255",
+	}, routes)
+}
+
+func TestUseCase_listSetupsByNetwork_Wildcards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockLearnedIPStorage := mock_storage.NewMockLearnedIP(ctrl)
+
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{NetworkID: []uint64{1}, Enabled: boolPtr(true)}).
+		Return([]*entity.NetworkHost{
+			{ID: 1, NetworkID: 1, Address: "*.corp.invalid", Kind: entity.NetworkHostKindInclude},
+			{ID: 2, NetworkID: 1, Address: ".printers.corp.invalid", Kind: entity.NetworkHostKindExclude},
+			{ID: 3, NetworkID: 1, Address: "*.unseen.invalid", Kind: entity.NetworkHostKindInclude},
+			{ID: 4, NetworkID: 1, Address: "10.0.1.0/30", Kind: entity.NetworkHostKindInclude},
+		}, nil)
+	mockLearnedIPStorage.EXPECT().
+		ListActive(gomock.Any(), []uint64{1}, gomock.Any()).
+		Return([]*entity.LearnedIP{
+			{NetworkHostID: 1, NetworkID: 1, IP: "10.0.0.5", Name: "git.corp.invalid"},
+		}, nil)
+	mockLearnedIPStorage.EXPECT().
+		ListActive(gomock.Any(), []uint64{3}, gomock.Any()).
+		Return(nil, nil)
+	mockLearnedIPStorage.EXPECT().
+		ListActive(gomock.Any(), []uint64{2}, gomock.Any()).
+		Return([]*entity.LearnedIP{
+			{NetworkHostID: 2, NetworkID: 1, IP: "10.0.1.2", Name: "hp.printers.corp.invalid"},
+		}, nil)
+	expectCurrentNetworkInfo(mockCommandExecutor)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mock_storage.NewMockNetwork(ctrl),
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mockLearnedIPStorage,
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+	useCase.lookupIP = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		t.Errorf("wildcard %s must not be looked up", host)
+		return nil, errors.New("unexpected lookup")
+	}
+
+	setups, _, err := useCase.listSetupsByNetwork(
+		context.Background(),
+		&entity.Network{ID: 1, Name: "TestNetwork"},
+		nil,
+	)
+
+	require.NoError(t, err)
+
+	routes := make([]string, 0, len(setups))
+	for _, setup := range setups {
+		routes = append(routes, fmt.Sprintf("%d %s/%s", setup.NetworkHostID, setup.NetworkHostIP, setup.SubnetMask))
+	}
+
+	// Wildcards are routed to their learned IPs only, one that hasn't been seen in DNS has no routes yet.
+	assert.Equal(t, []string{
+		"1 10.0.0.5/255.255.255.0",
+		"4 10.0.1.0/255.255.255.254",
+		"4 10.0.1.3/255.255.255.255",
+	}, routes)
+}
+
+func TestUseCase_PreviewByNetworkID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+	mockNetworkStorage.EXPECT().
+		Get(gomock.Any(), uint64(1)).
+		Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{NetworkID: []uint64{1}, Enabled: boolPtr(true)}).
+		Return([]*entity.NetworkHost{
+			{ID: 1, NetworkID: 1, Address: "10.20.0.0/16", Kind: entity.NetworkHostKindInclude},
+			{ID: 2, NetworkID: 1, Address: "10.20.128.0/17", Kind: entity.NetworkHostKindExclude},
+		}, nil)
+	expectCurrentNetworkInfo(mockCommandExecutor)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	previews, err := useCase.PreviewByNetworkID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.NewRoutePreviews([]*entity.NetworkHostSetup{{
+		NetworkHostID:  1,
+		NetworkHostIP:  "10.20.0.0",
+		SubnetMask:     "255.255.128.0",
+		Router:         "192.168.1.1",
+		NetworkHostIDs: []uint64{1},
+	}}, nil), previews)
+}
+
+func TestUseCase_PreviewByNetworkID_InverseMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+	mockNetworkStorage.EXPECT().
+		Get(gomock.Any(), uint64(1)).
+		Return(&entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeInverse}, nil)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "203.0.113.10/32"}}, nil)
+	expectWiFiNetworkInfo(mockCommandExecutor)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	previews, err := useCase.PreviewByNetworkID(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Greater(t, len(previews), 1)
+	for _, preview := range previews[:len(previews)-1] {
+		assert.Equal(t, entity.RoutePathTunnel, preview.Path)
+	}
+	assert.Equal(t, &entity.RoutePreview{
+		Path: entity.RoutePathDirect,
+		Setup: &entity.NetworkHostSetup{
+			NetworkHostID:  1,
+			NetworkHostIP:  "203.0.113.10",
+			SubnetMask:     "255.255.255.255",
+			Router:         "192.168.1.1",
+			NetworkHostIDs: []uint64{1},
+		},
+	}, previews[len(previews)-1])
+}
+
+func TestUseCase_PreviewByNetworkID_RouteOverrides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+	mockNetworkStorage.EXPECT().
+		Get(gomock.Any(), uint64(1)).
+		Return(&entity.Network{ID: 1, Name: "TestNetwork", Router: "192.168.1.254"}, nil)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{NetworkID: []uint64{1}, Enabled: boolPtr(true)}).
+		Return([]*entity.NetworkHost{
+			{ID: 1, NetworkID: 1, Address: "10.20.0.0/16", Kind: entity.NetworkHostKindInclude},
+			{ID: 2, NetworkID: 1, Address: "10.30.0.0/16", Kind: entity.NetworkHostKindInclude, Router: "10.0.0.1"},
+			{
+				ID:               3,
+				NetworkID:        1,
+				Address:          "10.40.0.0/16",
+				Kind:             entity.NetworkHostKindInclude,
+				NetworkInterface: "en7",
+			},
+		}, nil)
+	gomock.InOrder(
+		mockCommandExecutor.EXPECT().
+			GetDefaultNetworkInterface(gomock.Any()).
+			Return(entity.NetworkInterface("en0"), nil),
+		mockCommandExecutor.EXPECT().
+			GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en0")).
+			Return(entity.NetworkService("Wi-Fi"), nil),
+		mockCommandExecutor.EXPECT().
+			GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("Wi-Fi")).
+			Return(&entity.NetworkInfo{NetworkService: "Wi-Fi", SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil),
+		mockCommandExecutor.EXPECT().
+			GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en7")).
+			Return(entity.NetworkService("USB LAN"), nil),
+		mockCommandExecutor.EXPECT().
+			GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("USB LAN")).
+			Return(&entity.NetworkInfo{NetworkService: "USB LAN", SubnetMask: "255.255.0.0", Router: "172.16.0.1"}, nil),
+	)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	previews, err := useCase.PreviewByNetworkID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.NewRoutePreviews([]*entity.NetworkHostSetup{
+		{
+			NetworkHostID:  1,
+			NetworkHostIP:  "10.20.0.0",
+			SubnetMask:     "255.255.0.0",
+			Router:         "192.168.1.254",
+			NetworkHostIDs: []uint64{1},
+		},
+		{
+			NetworkHostID:  2,
+			NetworkHostIP:  "10.30.0.0",
+			SubnetMask:     "255.255.0.0",
+			Router:         "10.0.0.1",
+			NetworkHostIDs: []uint64{2},
+		},
+		{
+			NetworkHostID:  3,
+			NetworkHostIP:  "10.40.0.0",
+			SubnetMask:     "255.255.0.0",
+			Router:         "172.16.0.1",
+			NetworkHostIDs: []uint64{3},
+		},
+	}, nil), previews)
+}
+
+func TestUseCase_PreviewByAddress(t *testing.T) {
+	newUseCase := func(
+		ctrl *gomock.Controller,
+		mockCommandExecutor *mock_usecase.MockCommandExecutor,
+		mockNetworkStorage *mock_storage.MockNetwork,
+	) *UseCase {
+		return New(
+			mock_trm.NewMockManager(ctrl),
+			mockCommandExecutor,
+			newMockScopedDNS(ctrl),
+			mockNetworkStorage,
+			mock_storage.NewMockNetworkHost(ctrl),
+			mock_storage.NewMockNetworkHostSetup(ctrl),
+			newMockHostGroupStorage(ctrl),
+			newMockSetupGenerationStorage(ctrl),
+			newMockDNSCacheStorage(ctrl),
+			mock_storage.NewMockLearnedIP(ctrl),
+			testRouteConfig(),
+			testDNSConfig(),
+		)
+	}
+
+	t.Run("routes use the interface mask and the network's override", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+		mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+		mockNetworkStorage.EXPECT().
+			Get(gomock.Any(), uint64(1)).
+			Return(&entity.Network{ID: 1, Name: "TestNetwork", Router: "192.168.1.254"}, nil)
+		mockCommandExecutor.EXPECT().
+			GetDefaultNetworkInterface(gomock.Any()).
+			Return(entity.NetworkInterface("en0"), nil)
+		mockCommandExecutor.EXPECT().
+			GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en0")).
+			Return(entity.NetworkService("Wi-Fi"), nil)
+		mockCommandExecutor.EXPECT().
+			GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("Wi-Fi")).
+			Return(&entity.NetworkInfo{NetworkService: "Wi-Fi", SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+
+		setups, err := newUseCase(ctrl, mockCommandExecutor, mockNetworkStorage).
+			PreviewByAddress(context.Background(), 1, "10.0.5.7")
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.NetworkHostSetup{
+			{NetworkHostIP: "10.0.5.7", SubnetMask: "255.255.255.0", Router: "192.168.1.254"},
+		}, setups)
+	})
+
+	t.Run("wildcards have no routes yet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+		mockNetworkStorage.EXPECT().
+			Get(gomock.Any(), uint64(1)).
+			Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+
+		setups, err := newUseCase(ctrl, mock_usecase.NewMockCommandExecutor(ctrl), mockNetworkStorage).
+			PreviewByAddress(context.Background(), 1, "*.corp.example")
+
+		require.NoError(t, err)
+		assert.Empty(t, setups)
+	})
+}
+
+func TestUseCase_listSetupsByNetwork_HostGroupStorageError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockHostGroupStorage := mock_storage.NewMockHostGroup(ctrl)
+
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{}, nil)
+	mockHostGroupStorage.EXPECT().
+		ListHosts(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("storage error"))
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mock_usecase.NewMockCommandExecutor(ctrl),
+		newMockScopedDNS(ctrl),
+		mock_storage.NewMockNetwork(ctrl),
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		mockHostGroupStorage,
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	setups, _, err := useCase.listSetupsByNetwork(
+		context.Background(),
+		&entity.Network{ID: 1, Name: "TestNetwork"},
+		nil,
+	)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list host group hosts")
+	assert.Nil(t, setups)
+}
+
+func TestUseCase_SyncByNetworkID_HostGroupRoutesAreNotStored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTrm := mock_trm.NewMockManager(ctrl)
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockNetworkHostSetupStorage := mock_storage.NewMockNetworkHostSetup(ctrl)
+	mockHostGroupStorage := mock_storage.NewMockHostGroup(ctrl)
+
+	network := &entity.Network{ID: 1, Name: "TestNetwork"}
+	mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "10.1.2.3"}}, nil)
+	mockHostGroupStorage.EXPECT().
+		ListHosts(gomock.Any(), gomock.Any()).
+		Return([]*entity.HostGroupHost{{ID: 10, HostGroupID: 1, Address: "10.20.0.0/16"}}, nil)
+	mockCommandExecutor.EXPECT().
+		GetDefaultNetworkInterface(gomock.Any()).
+		Return(entity.NetworkInterface("eth0"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkServiceByNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(entity.NetworkService("service"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkInfoByNetworkService(gomock.Any(), gomock.Any()).
+		Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+	mockCommandExecutor.EXPECT().
+		GetCurrentVPN(gomock.Any()).
+		Return(entity.VPNService("TestNetwork"), nil)
+	mockTrm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	ownSetup := &entity.NetworkHostSetup{
+		NetworkHostID: 1,
+		NetworkHostIP: "10.1.2.3",
+		SubnetMask:    "255.255.255.0",
+		Router:        "192.168.1.1",
+	}
+	groupSetup := &entity.NetworkHostSetup{
+		NetworkHostIP: "10.20.0.0",
+		SubnetMask:    "255.255.0.0",
+		Router:        "192.168.1.1",
+	}
+	mockNetworkHostSetupStorage.EXPECT().
+		DeleteBatchByNetworkHostIDs(gomock.Any(), []uint64{1}).
+		Return(nil)
+	mockNetworkHostSetupStorage.EXPECT().
+		AddBatch(gomock.Any(), []*entity.NetworkHostSetup{ownSetup}).
+		Return(nil)
+	appliedOwnSetup := *ownSetup
+	appliedOwnSetup.NetworkHostIDs = []uint64{1}
+	mockCommandExecutor.EXPECT().
+		SetNetworkAdditionalRoutes(gomock.Any(), network, []*entity.NetworkHostSetup{&appliedOwnSetup, groupSetup}).
+		Return(nil)
+
+	useCase := New(
+		mockTrm,
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mockNetworkHostSetupStorage,
+		mockHostGroupStorage,
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	err := useCase.SyncByNetworkID(context.Background(), 1)
+
+	require.NoError(t, err)
+}
+
+func expectWiFiNetworkInfo(mockCommandExecutor *mock_usecase.MockCommandExecutor) {
+	mockCommandExecutor.EXPECT().
+		GetDefaultNetworkInterface(gomock.Any()).
+		Return(entity.NetworkInterface("en0"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en0")).
+		Return(entity.NetworkService("Wi-Fi"), nil)
+	mockCommandExecutor.EXPECT().
+		GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("Wi-Fi")).
+		Return(&entity.NetworkInfo{NetworkService: "Wi-Fi", SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+}
+
+func TestInverseSetups(t *testing.T) {
+	networkInfo := &entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: "192.168.1.1"}
+	hostSetups := []*entity.NetworkHostSetup{
+		{NetworkHostID: 1, NetworkHostIP: "10.0.0.5", SubnetMask: "255.255.255.0", Router: "192.168.1.1"},
+		{NetworkHostID: 2, NetworkHostIP: "203.0.113.10", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+	}
+
+	setups := inverseSetups(hostSetups, networkInfo)
+
+	excluded := []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("127.0.0.0/8"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("192.168.1.0/24"),
+		netip.MustParsePrefix("203.0.113.10/32"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+	}
+	var excludedSize, coveredSize uint64
+	for _, prefix := range excluded {
+		excludedSize += 1 << (ipv4Bits - prefix.Bits())
+	}
+
+	for _, setup := range setups {
+		assert.Equal(t, uint64(0), setup.NetworkHostID)
+		assert.Equal(t, "192.168.1.1", setup.Router)
+
+		prefix, ok := setup.Prefix()
+		require.True(t, ok)
+		for _, exclusion := range excluded {
+			assert.False(t, prefix.Overlaps(exclusion), "%s overlaps %s", prefix, exclusion)
+		}
+		coveredSize += 1 << (ipv4Bits - prefix.Bits())
+	}
+
+	assert.Equal(t, uint64(1)<<ipv4Bits, coveredSize+excludedSize)
+}
+
+func TestUseCase_SyncByNetworkID_InverseMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTrm := mock_trm.NewMockManager(ctrl)
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+	mockNetworkHostSetupStorage := mock_storage.NewMockNetworkHostSetup(ctrl)
+
+	network := &entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeInverse}
+	directSetup := &entity.NetworkHostSetup{
+		NetworkHostID: 1,
+		NetworkHostIP: "203.0.113.10",
+		SubnetMask:    "255.255.255.255",
+		Router:        "192.168.1.1",
+	}
+
+	mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "203.0.113.10/32"}}, nil)
+	expectWiFiNetworkInfo(mockCommandExecutor)
+	mockCommandExecutor.EXPECT().
+		GetCurrentVPN(gomock.Any()).
+		Return(entity.VPNService("TestNetwork"), nil)
+	mockTrm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	mockNetworkHostSetupStorage.EXPECT().
+		DeleteBatchByNetworkHostIDs(gomock.Any(), []uint64{1}).
+		Return(nil)
+	mockNetworkHostSetupStorage.EXPECT().
+		AddBatch(gomock.Any(), []*entity.NetworkHostSetup{directSetup}).
+		Return(nil)
+	mockCommandExecutor.EXPECT().
+		SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entity.Network, tunnelSetups []*entity.NetworkHostSetup) error {
+			assert.NotEmpty(t, tunnelSetups)
+			for _, setup := range tunnelSetups {
+				prefix, ok := setup.Prefix()
+				require.True(t, ok)
+				assert.False(t, prefix.Contains(netip.MustParseAddr("203.0.113.10")))
+				assert.False(t, prefix.Contains(netip.MustParseAddr("192.168.1.20")))
+			}
+			return nil
+		})
+	appliedDirectSetup := *directSetup
+	appliedDirectSetup.NetworkHostIDs = []uint64{1}
+	// The user's own route on the physical service is kept alongside the direct routes.
+	mockCommandExecutor.EXPECT().
+		GetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Wi-Fi")).
+		Return([]*entity.AdditionalRoute{
+			{Destination: "192.168.50.0", SubnetMask: "255.255.255.0", Router: "192.168.1.254"},
+		}, nil)
+	mockCommandExecutor.EXPECT().
+		SetServiceAdditionalRoutes(
+			gomock.Any(),
+			entity.NetworkService("Wi-Fi"),
+			[]*entity.NetworkHostSetup{
+				{NetworkHostIP: "192.168.50.0", SubnetMask: "255.255.255.0", Router: "192.168.1.254"},
+				&appliedDirectSetup,
+			},
+		).
+		Return(nil)
+
+	useCase := New(
+		mockTrm,
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mockNetworkHostSetupStorage,
+		newMockHostGroupStorage(ctrl),
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	err := useCase.SyncByNetworkID(context.Background(), 1)
+
+	require.NoError(t, err)
+}
+
+func TestUseCase_ResetByNetworkID_InverseMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		directErr     error
+		expectedError string
+	}{
+		{name: "resets tunnel and direct routes"},
+		{
+			name:          "direct routes reset fails",
+			directErr:     errors.New("command failed"),
+			expectedError: "failed to reset direct routes: command failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockSetupGenerationStorage := mock_storage.NewMockNetworkSetupGeneration(ctrl)
+			mockSetupGenerationStorage.EXPECT().
+				Add(gomock.Any(), gomock.Any()).
+				Return(&entity.NetworkSetupGeneration{ID: 8}, nil).
+				MaxTimes(1)
+			mockSetupGenerationStorage.EXPECT().MarkApplied(gomock.Any(), uint64(8)).Return(nil).MaxTimes(1)
+
+			network := &entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeInverse}
+			mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+			mockCommandExecutor.EXPECT().
+				GetCurrentVPN(gomock.Any()).
+				Return(entity.VPNService("TestNetwork"), nil)
+			mockCommandExecutor.EXPECT().
+				SetNetworkAdditionalRoutes(gomock.Any(), network, []*entity.NetworkHostSetup{}).
+				Return(nil)
+			// The direct routes were applied on Ethernet, the service in use now doesn't matter.
+			mockSetupGenerationStorage.EXPECT().
+				GetLastApplied(gomock.Any(), uint64(1)).
+				Return(&entity.NetworkSetupGeneration{
+					ID:             7,
+					NetworkID:      1,
+					NetworkService: "Ethernet",
+					DirectRoutes: []*entity.NetworkHostSetup{
+						{NetworkHostIP: "203.0.113.10", SubnetMask: "255.255.255.255", Router: "192.168.5.1"},
+					},
+				}, nil)
+			mockCommandExecutor.EXPECT().
+				GetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Ethernet")).
+				Return([]*entity.AdditionalRoute{
+					{Destination: "203.0.113.10", SubnetMask: "255.255.255.255", Router: "192.168.5.1"},
+					{Destination: "192.168.50.0", SubnetMask: "255.255.255.0", Router: "192.168.5.254"},
+				}, nil)
+			mockCommandExecutor.EXPECT().
+				SetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Ethernet"), []*entity.NetworkHostSetup{
+					{NetworkHostIP: "192.168.50.0", SubnetMask: "255.255.255.0", Router: "192.168.5.254"},
+				}).
+				Return(tt.directErr)
+
+			useCase := New(
+				mock_trm.NewMockManager(ctrl),
+				mockCommandExecutor,
+				newMockScopedDNS(ctrl),
+				mockNetworkStorage,
+				mock_storage.NewMockNetworkHost(ctrl),
+				mock_storage.NewMockNetworkHostSetup(ctrl),
+				newMockHostGroupStorage(ctrl),
+				mockSetupGenerationStorage,
+				newMockDNSCacheStorage(ctrl),
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
+			)
+
+			err := useCase.ResetByNetworkID(context.Background(), 1)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_ExportScriptsByNetworkID_InverseMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+	mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+	mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+	network := &entity.Network{ID: 1, Name: "TestNetwork", RoutingMode: entity.RoutingModeInverse}
+	directSetups := []*entity.NetworkHostSetup{{
+		NetworkHostID:  1,
+		NetworkHostIP:  "203.0.113.10",
+		SubnetMask:     "255.255.255.255",
+		Router:         "192.168.1.1",
+		NetworkHostIDs: []uint64{1},
+	}}
+	applyDirectCommand := &entity.Command{Executable: "networksetup", Args: []string{"apply-direct"}}
+	teardownDirectCommand := &entity.Command{Executable: "networksetup", Args: []string{"teardown-direct"}}
+
+	mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+	mockNetworkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "203.0.113.10/32"}}, nil)
+	expectWiFiNetworkInfo(mockCommandExecutor)
+	mockCommandExecutor.EXPECT().
+		SetNetworkAdditionalRoutesCommand(network, gomock.Any()).
+		Return(&entity.Command{Executable: "networksetup"}).
+		Times(2)
+	mockCommandExecutor.EXPECT().
+		RouteCommands(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+	foreignSetups := []*entity.NetworkHostSetup{
+		{NetworkHostIP: "192.168.50.0", SubnetMask: "255.255.255.0", Router: "192.168.1.254"},
+	}
+	mockCommandExecutor.EXPECT().
+		GetServiceAdditionalRoutes(gomock.Any(), entity.NetworkService("Wi-Fi")).
+		Return([]*entity.AdditionalRoute{
+			{Destination: "203.0.113.10", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+			{Destination: "192.168.50.0", SubnetMask: "255.255.255.0", Router: "192.168.1.254"},
+		}, nil)
+	mockCommandExecutor.EXPECT().
+		SetServiceAdditionalRoutesCommand(entity.NetworkService("Wi-Fi"), slices.Concat(foreignSetups, directSetups)).
+		Return(applyDirectCommand)
+	mockCommandExecutor.EXPECT().
+		SetServiceAdditionalRoutesCommand(entity.NetworkService("Wi-Fi"), foreignSetups).
+		Return(teardownDirectCommand)
+
+	useCase := New(
+		mock_trm.NewMockManager(ctrl),
+		mockCommandExecutor,
+		newMockScopedDNS(ctrl),
+		mockNetworkStorage,
+		mockNetworkHostStorage,
+		mock_storage.NewMockNetworkHostSetup(ctrl),
+		newMockHostGroupStorage(ctrl),
+		newMockSetupGenerationStorage(ctrl),
+		newMockDNSCacheStorage(ctrl),
+		mock_storage.NewMockLearnedIP(ctrl),
+		testRouteConfig(),
+		testDNSConfig(),
+	)
+
+	scripts, err := useCase.ExportScriptsByNetworkID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, applyDirectCommand, scripts.Apply.DirectNetworkSetupCommand)
+	assert.Equal(t, teardownDirectCommand, scripts.Teardown.DirectNetworkSetupCommand)
+}
+
+func TestUseCase_optimizeSetups(t *testing.T) {
+	tests := []struct {
+		name                  string
+		aggregatePrefixLength int
+		setups                []*entity.NetworkHostSetup
+		expected              []*entity.NetworkHostSetup
+	}{
+		{
+			name:                  "empty",
+			aggregatePrefixLength: 32,
+			setups:                []*entity.NetworkHostSetup{},
+			expected:              []*entity.NetworkHostSetup{},
+		},
+		{
+			name:                  "identical routes of different hosts are applied once",
+			aggregatePrefixLength: 32,
+			setups: []*entity.NetworkHostSetup{
+				{NetworkHostID: 1, NetworkHostIP: "104.16.1.1", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+				{NetworkHostID: 2, NetworkHostIP: "104.16.1.1", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+			},
+			expected: []*entity.NetworkHostSetup{{
+				NetworkHostIP:  "104.16.1.1",
+				SubnetMask:     "255.255.255.255",
+				Router:         "192.168.1.1",
+				NetworkHostIDs: []uint64{1, 2},
+			}},
+		},
+		{
+			name:                  "contained routes and complete halves are merged",
+			aggregatePrefixLength: 32,
+			setups: []*entity.NetworkHostSetup{
+				{NetworkHostID: 1, NetworkHostIP: "10.0.0.0", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+				{NetworkHostID: 2, NetworkHostIP: "10.0.0.1", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+				{NetworkHostID: 3, NetworkHostIP: "10.20.0.0", SubnetMask: "255.255.0.0", Router: "192.168.1.1"},
+				{NetworkHostID: 4, NetworkHostIP: "10.20.3.4", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+			},
+			expected: []*entity.NetworkHostSetup{
+				{
+					NetworkHostIP:  "10.0.0.0",
+					SubnetMask:     "255.255.255.254",
+					Router:         "192.168.1.1",
+					NetworkHostIDs: []uint64{1, 2},
+				},
+				{
+					NetworkHostIP:  "10.20.0.0",
+					SubnetMask:     "255.255.0.0",
+					Router:         "192.168.1.1",
+					NetworkHostIDs: []uint64{3, 4},
+				},
+			},
+		},
+		{
+			name:                  "nearby routes are collapsed within the prefix length",
+			aggregatePrefixLength: 24,
+			setups: []*entity.NetworkHostSetup{
+				{NetworkHostID: 1, NetworkHostIP: "10.0.0.17", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+				{NetworkHostID: 2, NetworkHostIP: "10.0.0.30", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+				{NetworkHostID: 3, NetworkHostIP: "10.0.1.1", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+			},
+			expected: []*entity.NetworkHostSetup{
+				{
+					NetworkHostIP:  "10.0.0.16",
+					SubnetMask:     "255.255.255.240",
+					Router:         "192.168.1.1",
+					NetworkHostIDs: []uint64{1, 2},
+				},
+				{
+					NetworkHostID:  3,
+					NetworkHostIP:  "10.0.1.1",
+					SubnetMask:     "255.255.255.255",
+					Router:         "192.168.1.1",
+					NetworkHostIDs: []uint64{3},
+				},
+			},
+		},
+		{
+			name:                  "routes through different routers are not merged",
+			aggregatePrefixLength: 32,
+			setups: []*entity.NetworkHostSetup{
+				{NetworkHostID: 1, NetworkHostIP: "10.0.0.0", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+				{NetworkHostID: 2, NetworkHostIP: "10.0.0.1", SubnetMask: "255.255.255.255", Router: "192.168.2.1"},
+			},
+			expected: []*entity.NetworkHostSetup{
+				{
+					NetworkHostID:  1,
+					NetworkHostIP:  "10.0.0.0",
+					SubnetMask:     "255.255.255.255",
+					Router:         "192.168.1.1",
+					NetworkHostIDs: []uint64{1},
+				},
+				{
+					NetworkHostID:  2,
+					NetworkHostIP:  "10.0.0.1",
+					SubnetMask:     "255.255.255.255",
+					Router:         "192.168.2.1",
+					NetworkHostIDs: []uint64{2},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := &UseCase{aggregatePrefixLength: tt.aggregatePrefixLength}
+
+			assert.Equal(t, tt.expected, useCase.optimizeSetups(tt.setups))
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestUseCase_listIPByAddress_DNSCache(t *testing.T) {
+	lookupErr := errors.New("no such host")
+	staleEntry := &entity.DNSCacheEntry{
+		Address:    "git.corp.example",
+		IPs:        entity.IPList{"10.0.0.5"},
+		ResolvedAt: entity.TimestampFromTime(time.Now().Add(-2 * time.Hour)),
+		TTL:        3600,
+	}
+
+	tests := []struct {
+		name          string
+		address       string
+		lookupIP      func(context.Context, string) ([]net.IPAddr, error)
+		setupMocks    func(*mock_storage.MockDNSCache)
+		expected      []string
+		expectedStale []*entity.StaleResolution
+		expectedFresh []string
+		expectedError string
+	}{
+		{
+			name:    "successful lookup is recorded for caching",
+			address: "Git.Corp.Example",
+			lookupIP: func(context.Context, string) ([]net.IPAddr, error) {
+				return []net.IPAddr{{IP: net.ParseIP("10.0.0.5")}, {IP: net.ParseIP("fd00::5")}}, nil
+			},
+			setupMocks:    func(*mock_storage.MockDNSCache) {},
+			expected:      []string{"10.0.0.5"},
+			expectedFresh: []string{"git.corp.example"},
+		},
+		{
+			name:    "IP literals are not cached",
+			address: "10.0.0.5",
+			lookupIP: func(context.Context, string) ([]net.IPAddr, error) {
+				return []net.IPAddr{{IP: net.ParseIP("10.0.0.5")}}, nil
+			},
+			setupMocks: func(*mock_storage.MockDNSCache) {},
+			expected:   []string{"10.0.0.5"},
+		},
+		{
+			name:    "failed lookup falls back to cached IPs",
+			address: "git.corp.example",
+			lookupIP: func(context.Context, string) ([]net.IPAddr, error) {
+				return nil, lookupErr
+			},
+			setupMocks: func(mockDNSCacheStorage *mock_storage.MockDNSCache) {
+				mockDNSCacheStorage.EXPECT().
+					Get(gomock.Any(), "git.corp.example").
+					Return(staleEntry, nil)
+			},
+			expected: []string{"10.0.0.5"},
+			expectedStale: []*entity.StaleResolution{
+				{
+					Address:    "git.corp.example",
+					IPs:        []string{"10.0.0.5"},
+					ResolvedAt: staleEntry.ResolvedAt,
+					Expired:    true,
+					Error:      "failed to lookup IP for address git.corp.example: no such host",
+				},
+			},
+		},
+		{
+			name:    "failed lookup without cached IPs",
+			address: "git.corp.example",
+			lookupIP: func(context.Context, string) ([]net.IPAddr, error) {
+				return nil, lookupErr
+			},
+			setupMocks: func(mockDNSCacheStorage *mock_storage.MockDNSCache) {
+				mockDNSCacheStorage.EXPECT().
+					Get(gomock.Any(), "git.corp.example").
+					Return(nil, errs.ErrDNSCacheEntryNotFound)
+			},
+			expectedError: "failed to lookup IP for address git.corp.example: no such host",
+		},
+		{
+			name:    "failed lookup with unreadable cache",
+			address: "git.corp.example",
+			lookupIP: func(context.Context, string) ([]net.IPAddr, error) {
+				return []net.IPAddr{{IP: net.ParseIP("fd00::5")}}, nil
+			},
+			setupMocks: func(mockDNSCacheStorage *mock_storage.MockDNSCache) {
+				mockDNSCacheStorage.EXPECT().
+					Get(gomock.Any(), "git.corp.example").
+					Return(nil, errors.New("database is locked"))
+			},
+			expectedError: "no IPv4 addresses found for address git.corp.example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDNSCacheStorage := mock_storage.NewMockDNSCache(ctrl)
+			tt.setupMocks(mockDNSCacheStorage)
+
+			useCase := New(
+				mock_trm.NewMockManager(ctrl),
+				mock_usecase.NewMockCommandExecutor(ctrl),
+				newMockScopedDNS(ctrl),
+				mock_storage.NewMockNetwork(ctrl),
+				mock_storage.NewMockNetworkHost(ctrl),
+				mock_storage.NewMockNetworkHostSetup(ctrl),
+				mock_storage.NewMockHostGroup(ctrl),
+				newMockSetupGenerationStorage(ctrl),
+				mockDNSCacheStorage,
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
+			)
+			useCase.lookupIP = tt.lookupIP
+
+			resolved := new(resolutions)
+			ips, err := useCase.listIPByAddress(context.Background(), tt.address, resolved)
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				assert.Empty(t, resolved.stale)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ips)
+			assert.Equal(t, tt.expectedStale, resolved.stale)
+
+			var freshAddresses []string
+			for _, entry := range resolved.fresh {
+				assert.Equal(t, entity.IPList(tt.expected), entry.IPs)
+				assert.Equal(t, int64(3600), entry.TTL)
+				freshAddresses = append(freshAddresses, entry.Address)
+			}
+			assert.Equal(t, tt.expectedFresh, freshAddresses)
+		})
+	}
+}
+
+func TestUseCase_DNSCache_WrittenBySyncOnly(t *testing.T) {
+	newUseCase := func(ctrl *gomock.Controller, mockDNSCacheStorage *mock_storage.MockDNSCache) *UseCase {
+		mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+		mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+		mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+
+		mockNetworkStorage.EXPECT().
+			Get(gomock.Any(), uint64(1)).
+			Return(&entity.Network{ID: 1, Name: "TestNetwork"}, nil)
+		mockNetworkHostStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "git.corp.example"}}, nil)
+		expectCurrentNetworkInfo(mockCommandExecutor)
+		mockCommandExecutor.EXPECT().
+			GetCurrentVPN(gomock.Any()).
+			Return(entity.VPNService("OtherNetwork"), nil).
+			AnyTimes()
+
+		useCase := New(
+			mock_trm.NewMockManager(ctrl),
+			mockCommandExecutor,
+			newMockScopedDNS(ctrl),
+			mockNetworkStorage,
+			mockNetworkHostStorage,
+			mock_storage.NewMockNetworkHostSetup(ctrl),
+			newMockHostGroupStorage(ctrl),
+			newMockSetupGenerationStorage(ctrl),
+			mockDNSCacheStorage,
+			mock_storage.NewMockLearnedIP(ctrl),
+			testRouteConfig(),
+			testDNSConfig(),
+		)
+		useCase.lookupIP = func(context.Context, string) ([]net.IPAddr, error) {
+			return []net.IPAddr{{IP: net.ParseIP("10.0.0.5")}}, nil
+		}
+
+		return useCase
+	}
+
+	t.Run("preview leaves the cache alone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase := newUseCase(ctrl, mock_storage.NewMockDNSCache(ctrl))
+
+		_, err := useCase.PreviewByNetworkID(context.Background(), 1)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("sync caches its lookups", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDNSCacheStorage := mock_storage.NewMockDNSCache(ctrl)
+		mockDNSCacheStorage.EXPECT().
+			Upsert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *entity.DNSCacheEntry) error {
+				assert.Equal(t, "git.corp.example", entry.Address)
+				assert.Equal(t, entity.IPList{"10.0.0.5"}, entry.IPs)
+				return nil
+			})
+		useCase := newUseCase(ctrl, mockDNSCacheStorage)
+
+		_, err := useCase.SyncByNetworkIDWithResult(context.Background(), 1)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("failed cache write doesn't fail the sync", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDNSCacheStorage := mock_storage.NewMockDNSCache(ctrl)
+		mockDNSCacheStorage.EXPECT().
+			Upsert(gomock.Any(), gomock.Any()).
+			Return(errors.New("database is locked"))
+		useCase := newUseCase(ctrl, mockDNSCacheStorage)
+
+		_, err := useCase.SyncByNetworkIDWithResult(context.Background(), 1)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestUseCase_SyncByNetworkIDWithResult(t *testing.T) {
+	cachedEntry := &entity.DNSCacheEntry{
+		Address:    "git.corp.example",
+		IPs:        entity.IPList{"10.0.0.5", "10.0.2.6"},
+		ResolvedAt: entity.TimestampFromTime(time.Now().Add(-time.Minute)),
+		TTL:        3600,
+	}
+
+	tests := []struct {
+		name       string
+		currentVPN entity.VPNService
+		applied    bool
+	}{
+		{name: "applied to the connected network", currentVPN: "TestNetwork", applied: true},
+		{name: "not applied to a disconnected network", currentVPN: "OtherNetwork"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTrm := mock_trm.NewMockManager(ctrl)
+			mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+			mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+			mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+			mockNetworkHostSetupStorage := mock_storage.NewMockNetworkHostSetup(ctrl)
+			mockDNSCacheStorage := mock_storage.NewMockDNSCache(ctrl)
+
+			network := &entity.Network{ID: 1, Name: "TestNetwork"}
+			mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+			mockNetworkHostStorage.EXPECT().
+				List(gomock.Any(), gomock.Any()).
+				Return([]*entity.NetworkHost{{ID: 1, NetworkID: 1, Address: "git.corp.example"}}, nil)
+			expectCurrentNetworkInfo(mockCommandExecutor)
+			mockDNSCacheStorage.EXPECT().
+				Get(gomock.Any(), "git.corp.example").
+				Return(cachedEntry, nil)
+			mockCommandExecutor.EXPECT().
+				GetCurrentVPN(gomock.Any()).
+				Return(tt.currentVPN, nil)
+
+			if tt.applied {
+				mockTrm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				mockNetworkHostSetupStorage.EXPECT().
+					DeleteBatchByNetworkHostIDs(gomock.Any(), []uint64{1}).
+					Return(nil)
+				mockNetworkHostSetupStorage.EXPECT().
+					AddBatch(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockCommandExecutor.EXPECT().
+					SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Len(2)).
+					Return(nil)
+			}
+
+			useCase := New(
+				mockTrm,
+				mockCommandExecutor,
+				newMockScopedDNS(ctrl),
+				mockNetworkStorage,
+				mockNetworkHostStorage,
+				mockNetworkHostSetupStorage,
+				newMockHostGroupStorage(ctrl),
+				newMockSetupGenerationStorage(ctrl),
+				mockDNSCacheStorage,
+				mock_storage.NewMockLearnedIP(ctrl),
+				testRouteConfig(),
+				testDNSConfig(),
+			)
+			useCase.lookupIP = func(context.Context, string) ([]net.IPAddr, error) {
+				return nil, errors.New("no such host")
+			}
+
+			result, err := useCase.SyncByNetworkIDWithResult(context.Background(), 1)
+
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), result.NetworkID)
+			assert.Equal(t, tt.applied, result.Applied)
+			require.True(t, result.HasStaleResolutions())
+			require.Len(t, result.StaleResolutions, 1)
+			assert.Equal(t, "git.corp.example", result.StaleResolutions[0].Address)
+			assert.Equal(t, []string{"10.0.0.5", "10.0.2.6"}, result.StaleResolutions[0].IPs)
+			assert.False(t, result.StaleResolutions[0].Expired)
+			if tt.applied {
+				assert.Equal(t, 2, result.RouteCount)
+			} else {
+				assert.Zero(t, result.RouteCount)
+			}
+		})
+	}
+}
+
+func TestUseCase_SyncByNetworkIDWithResult_ScopedDNS(t *testing.T) {
+	network := &entity.Network{
+		ID:             1,
+		Name:           "TestNetwork",
+		DNSDomains:     entity.DomainList{"corp.example", "lab.example"},
+		DNSNameservers: entity.IPList{"10.8.0.1"},
+	}
+
+	newUseCase := func(
+		ctrl *gomock.Controller,
+		mockCommandExecutor *mock_usecase.MockCommandExecutor,
+		mockScopedDNS *mock_usecase.MockScopedDNS,
+	) *UseCase {
+		mockNetworkStorage := mock_storage.NewMockNetwork(ctrl)
+		mockNetworkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(network, nil)
+		mockNetworkHostStorage := mock_storage.NewMockNetworkHost(ctrl)
+		mockNetworkHostStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+		expectCurrentNetworkInfo(mockCommandExecutor)
+
+		mockTrm := mock_trm.NewMockManager(ctrl)
+		mockTrm.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).
+			AnyTimes()
+
+		return New(
+			mockTrm,
+			mockCommandExecutor,
+			mockScopedDNS,
+			mockNetworkStorage,
+			mockNetworkHostStorage,
+			mock_storage.NewMockNetworkHostSetup(ctrl),
+			newMockHostGroupStorage(ctrl),
+			newMockSetupGenerationStorage(ctrl),
+			newMockDNSCacheStorage(ctrl),
+			mock_storage.NewMockLearnedIP(ctrl),
+			testRouteConfig(),
+			testDNSConfig(),
+		)
+	}
+
+	t.Run("connected network writes its resolver files", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+		mockScopedDNS := mock_usecase.NewMockScopedDNS(ctrl)
+		useCase := newUseCase(ctrl, mockCommandExecutor, mockScopedDNS)
+
+		mockCommandExecutor.EXPECT().GetCurrentVPN(gomock.Any()).Return(entity.VPNService("TestNetwork"), nil)
+		mockCommandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Any()).Return(nil)
+		mockScopedDNS.EXPECT().ApplyByNetwork(gomock.Any(), network).Return([]string{"lab.example"}, nil)
+
+		result, err := useCase.SyncByNetworkIDWithResult(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+		assert.Equal(t, []string{"lab.example"}, result.SkippedDNSDomains)
+	})
+
+	t.Run("disconnected network removes its resolver files", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+		mockScopedDNS := mock_usecase.NewMockScopedDNS(ctrl)
+		useCase := newUseCase(ctrl, mockCommandExecutor, mockScopedDNS)
+
+		mockCommandExecutor.EXPECT().
+			GetCurrentVPN(gomock.Any()).
+			Return(entity.VPNService(""), errs.ErrVPNServiceNotFound)
+		mockScopedDNS.EXPECT().RemoveByNetworkID(gomock.Any(), uint64(1)).Return(errors.New("permission denied"))
+
+		result, err := useCase.SyncByNetworkIDWithResult(context.Background(), 1)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to remove scoped DNS: permission denied")
+		assert.Nil(t, result)
+	})
+
+	t.Run("failing to write resolver files is reported without failing the sync", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommandExecutor := mock_usecase.NewMockCommandExecutor(ctrl)
+		mockScopedDNS := mock_usecase.NewMockScopedDNS(ctrl)
+		useCase := newUseCase(ctrl, mockCommandExecutor, mockScopedDNS)
+
+		mockCommandExecutor.EXPECT().GetCurrentVPN(gomock.Any()).Return(entity.VPNService("TestNetwork"), nil)
+		mockCommandExecutor.EXPECT().SetNetworkAdditionalRoutes(gomock.Any(), network, gomock.Any()).Return(nil)
+		mockScopedDNS.EXPECT().ApplyByNetwork(gomock.Any(), network).Return(nil, errors.New("read-only"))
+
+		result, err := useCase.SyncByNetworkIDWithResult(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+		assert.Equal(t, "read-only", result.ScopedDNSError)
+	})
+}
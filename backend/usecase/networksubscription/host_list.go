@@ -0,0 +1,79 @@
+package networksubscription
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+// parseHostList reads a subscription body. It accepts the JSON export format, or plain text
+// with one address per line, optionally followed by a description. Text after "#" is a comment.
+// Invalid addresses are skipped so one bad entry doesn't block the rest of the list.
+func parseHostList(body []byte) ([]entity.NetworkHostDTO, error) {
+	trimmed := bytes.TrimSpace(body)
+
+	var (
+		hostDTOs []entity.NetworkHostDTO
+		err      error
+	)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		hostDTOs, err = parseJSONHostList(trimmed)
+	} else {
+		hostDTOs, err = parseTextHostList(trimmed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	validHostDTOs := make([]entity.NetworkHostDTO, 0, len(hostDTOs))
+	for _, hostDTO := range hostDTOs {
+		if !entity.IsValidAddress(hostDTO.Address) {
+			slog.Warn("skipping invalid subscription host", "address", hostDTO.Address)
+			continue
+		}
+
+		validHostDTOs = append(validHostDTOs, hostDTO)
+	}
+
+	return validHostDTOs, nil
+}
+
+func parseJSONHostList(body []byte) ([]entity.NetworkHostDTO, error) {
+	var payload entity.NetworkHostContextExportPayload
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON host list: %w", err)
+	}
+
+	return payload.Hosts, nil
+}
+
+func parseTextHostList(body []byte) ([]entity.NetworkHostDTO, error) {
+	var hostDTOs []entity.NetworkHostDTO
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		hostDTOs = append(hostDTOs, entity.NetworkHostDTO{
+			Address:     fields[0],
+			Description: strings.Join(fields[1:], " "),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read text host list: %w", err)
+	}
+
+	return hostDTOs, nil
+}
@@ -0,0 +1,72 @@
+package networksubscription
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+)
+
+func TestParseHostList(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expected      []entity.NetworkHostDTO
+		expectedError string
+	}{
+		{
+			name: "JSON export format",
+			body: `{
+				"export_date": "2024-05-01T10:00:00Z",
+				"hosts": [
+					{"address": "git.corp.example", "description": "GitLab"},
+					{"address": "10.20.0.0/16"},
+					{"address": "not valid"}
+				]
+			}`,
+			expected: []entity.NetworkHostDTO{
+				{Address: "git.corp.example", Description: "GitLab"},
+				{Address: "10.20.0.0/16"},
+			},
+		},
+		{
+			name: "text format",
+			body: "# VPN-only endpoints\n" +
+				"git.corp.example GitLab server\n" +
+				"\n" +
+				"  10.1.2.3   # build agent\n" +
+				"-invalid.example\n",
+			expected: []entity.NetworkHostDTO{
+				{Address: "git.corp.example", Description: "GitLab server"},
+				{Address: "10.1.2.3"},
+			},
+		},
+		{
+			name:     "empty body",
+			body:     "  \n",
+			expected: []entity.NetworkHostDTO{},
+		},
+		{
+			name:          "malformed JSON",
+			body:          `{"hosts": [`,
+			expectedError: "failed to parse JSON host list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseHostList([]byte(tt.body))
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
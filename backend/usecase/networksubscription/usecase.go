@@ -0,0 +1,443 @@
+package networksubscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+const (
+	defaultHTTPTimeout = 30 * time.Second
+	maxHostListSize    = 10 << 20
+)
+
+type UseCase struct {
+	trm                trm.Manager
+	networkHostSetupUC usecase.NetworkHostSetup
+
+	networkStorage             storage.Network
+	networkHostStorage         storage.NetworkHost
+	networkSubscriptionStorage storage.NetworkSubscription
+
+	httpClient      *http.Client
+	refreshInterval time.Duration
+}
+
+func New(
+	trm trm.Manager,
+	networkHostSetupUC usecase.NetworkHostSetup,
+	networkStorage storage.Network,
+	networkHostStorage storage.NetworkHost,
+	networkSubscriptionStorage storage.NetworkSubscription,
+	subscriptionCfg *config.Subscription,
+) *UseCase {
+	return &UseCase{
+		trm:                        trm,
+		networkHostSetupUC:         networkHostSetupUC,
+		networkStorage:             networkStorage,
+		networkHostStorage:         networkHostStorage,
+		networkSubscriptionStorage: networkSubscriptionStorage,
+		httpClient: &http.Client{
+			Timeout: defaultHTTPTimeout,
+		},
+		refreshInterval: subscriptionCfg.RefreshInterval,
+	}
+}
+
+// Add subscribes a network to a host list URL and fetches it right away. A failed first
+// refresh doesn't undo the subscription, it is reported through LastError instead.
+func (u *UseCase) Add(ctx context.Context, networkID uint64, rawURL string) (*entity.NetworkSubscription, error) {
+	subscription, err := entity.NewNetworkSubscription(networkID, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.networkStorage.Get(ctx, networkID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkNotFound) {
+			return nil, fmt.Errorf("network with ID %d not found: %w", networkID, err)
+		}
+		return nil, fmt.Errorf("failed to validate network: %w", err)
+	}
+
+	subscription, err = u.networkSubscriptionStorage.Add(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add network subscription: %w", err)
+	}
+
+	err = u.Refresh(ctx, subscription.ID)
+	if err != nil {
+		slog.Warn("failed to refresh new network subscription", "url", subscription.URL, "error", err)
+	}
+
+	refreshed, err := u.networkSubscriptionStorage.Get(ctx, subscription.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network subscription: %w", err)
+	}
+
+	return refreshed, nil
+}
+
+func (u *UseCase) List(ctx context.Context, networkID uint64) ([]*entity.NetworkSubscription, error) {
+	return u.networkSubscriptionStorage.List(ctx, &entity.ListNetworkSubscriptionFilter{
+		NetworkID: []uint64{networkID},
+	})
+}
+
+// Delete removes a subscription together with the hosts it manages.
+func (u *UseCase) Delete(ctx context.Context, id uint64) error {
+	subscription, err := u.networkSubscriptionStorage.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkSubscriptionNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get network subscription: %w", err)
+	}
+
+	var changed bool
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		managedHosts, trErr := u.listManagedHosts(ctx, subscription.ID)
+		if trErr != nil {
+			return trErr
+		}
+
+		for _, managedHost := range managedHosts {
+			trErr = u.networkHostStorage.Delete(ctx, managedHost.ID)
+			if trErr != nil {
+				return fmt.Errorf("failed to delete managed host %s: %w", managedHost.Address, trErr)
+			}
+		}
+
+		trErr = u.networkSubscriptionStorage.Delete(ctx, subscription.ID)
+		if trErr != nil {
+			return fmt.Errorf("failed to delete network subscription: %w", trErr)
+		}
+
+		changed = len(managedHosts) > 0
+		if !changed {
+			return nil
+		}
+
+		return u.reconcileSiblings(ctx, subscription)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply transaction: %w", err)
+	}
+
+	return u.syncIfNeeded(ctx, subscription.NetworkID, changed)
+}
+
+// Refresh fetches the subscription's host list and reconciles the network's managed hosts with it.
+// The outcome, including any error, is saved on the subscription.
+func (u *UseCase) Refresh(ctx context.Context, id uint64) error {
+	subscription, err := u.networkSubscriptionStorage.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get network subscription: %w", err)
+	}
+
+	previous := *subscription
+
+	err = u.refresh(ctx, subscription)
+	if err != nil {
+		// Keep the previous ETag so the next refresh fetches the full list again.
+		lastError := err.Error()
+		previous.LastError = &lastError
+
+		updateErr := u.networkSubscriptionStorage.UpdateRefreshState(ctx, &previous)
+		if updateErr != nil {
+			slog.Error("failed to save network subscription error", "url", subscription.URL, "error", updateErr)
+		}
+
+		return fmt.Errorf("failed to refresh network subscription %s: %w", subscription.URL, err)
+	}
+
+	return nil
+}
+
+// RefreshAll refreshes every subscription, continuing past failures.
+func (u *UseCase) RefreshAll(ctx context.Context) error {
+	subscriptions, err := u.networkSubscriptionStorage.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list network subscriptions: %w", err)
+	}
+
+	var refreshErrs []error
+	for _, subscription := range subscriptions {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		refreshErr := u.Refresh(ctx, subscription.ID)
+		if refreshErr != nil {
+			refreshErrs = append(refreshErrs, refreshErr)
+		}
+	}
+
+	return errors.Join(refreshErrs...)
+}
+
+// Run refreshes all subscriptions right away and then on every refresh interval until ctx is done.
+func (u *UseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		err := u.RefreshAll(ctx)
+		if err != nil {
+			slog.Warn("failed to refresh network subscriptions", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *UseCase) refresh(ctx context.Context, subscription *entity.NetworkSubscription) error {
+	hostList, err := u.fetch(ctx, subscription)
+	if err != nil {
+		return err
+	}
+
+	refreshedAt := entity.NewTimestamp()
+	subscription.LastRefreshedAt = &refreshedAt
+	subscription.LastError = nil
+
+	// An unchanged list is reconciled again from the stored copy, a host it shares with another subscription
+	// of the network is re-added once that subscription drops it.
+	if hostList.notModified {
+		if subscription.HostList == nil {
+			return u.networkSubscriptionStorage.UpdateRefreshState(ctx, subscription)
+		}
+	} else {
+		body := string(hostList.body)
+		subscription.HostList = &body
+		subscription.ETag = hostList.etag
+	}
+
+	hostDTOs, err := parseHostList([]byte(*subscription.HostList))
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	err = u.trm.Do(ctx, func(ctx context.Context) error {
+		var trErr error
+		changed, trErr = u.reconcileManagedHosts(ctx, subscription, hostDTOs)
+		if trErr != nil {
+			return trErr
+		}
+
+		trErr = u.networkSubscriptionStorage.UpdateRefreshState(ctx, subscription)
+		if trErr != nil {
+			return fmt.Errorf("failed to update network subscription: %w", trErr)
+		}
+
+		if !changed {
+			return nil
+		}
+
+		trErr = u.reconcileSiblings(ctx, subscription)
+		if trErr != nil {
+			return trErr
+		}
+
+		// A listed host the sync couldn't route rolls the whole refresh back, Refresh records it as LastError.
+		trErr = u.networkHostSetupUC.ValidateByNetworkID(ctx, subscription.NetworkID)
+		if trErr != nil {
+			return fmt.Errorf("failed to resolve network routes: %w", trErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.syncIfNeeded(ctx, subscription.NetworkID, changed)
+}
+
+// reconcileManagedHosts adds hosts that appeared in the list and removes managed hosts that
+// disappeared from it. Addresses that already exist as manual hosts are left alone.
+func (u *UseCase) reconcileManagedHosts(
+	ctx context.Context,
+	subscription *entity.NetworkSubscription,
+	hostDTOs []entity.NetworkHostDTO,
+) (bool, error) {
+	managedHosts, err := u.listManagedHosts(ctx, subscription.ID)
+	if err != nil {
+		return false, err
+	}
+
+	managedAddresses := make(map[string]struct{}, len(managedHosts))
+	for _, managedHost := range managedHosts {
+		managedAddresses[managedHost.Address] = struct{}{}
+	}
+
+	changed := false
+	listedAddresses := make(map[string]struct{}, len(hostDTOs))
+	for _, hostDTO := range hostDTOs {
+		listedAddresses[hostDTO.Address] = struct{}{}
+		if _, exists := managedAddresses[hostDTO.Address]; exists {
+			continue
+		}
+
+		added, addErr := u.addManagedHost(ctx, subscription, hostDTO)
+		if addErr != nil {
+			return false, addErr
+		}
+		changed = changed || added
+	}
+
+	for _, managedHost := range managedHosts {
+		if _, listed := listedAddresses[managedHost.Address]; listed {
+			continue
+		}
+
+		err = u.networkHostStorage.Delete(ctx, managedHost.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to delete managed host %s: %w", managedHost.Address, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// reconcileSiblings reconciles the other subscriptions of the network with their stored lists. A host two
+// subscriptions list is managed by the one that added it, the others re-add it once that one removes it.
+func (u *UseCase) reconcileSiblings(ctx context.Context, subscription *entity.NetworkSubscription) error {
+	siblings, err := u.networkSubscriptionStorage.List(ctx, &entity.ListNetworkSubscriptionFilter{
+		NetworkID: []uint64{subscription.NetworkID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list network subscriptions: %w", err)
+	}
+
+	for _, sibling := range siblings {
+		if sibling.ID == subscription.ID || sibling.HostList == nil {
+			continue
+		}
+
+		hostDTOs, parseErr := parseHostList([]byte(*sibling.HostList))
+		if parseErr != nil {
+			return fmt.Errorf("failed to parse stored host list of %s: %w", sibling.URL, parseErr)
+		}
+
+		_, reconcileErr := u.reconcileManagedHosts(ctx, sibling, hostDTOs)
+		if reconcileErr != nil {
+			return reconcileErr
+		}
+	}
+
+	return nil
+}
+
+func (u *UseCase) addManagedHost(
+	ctx context.Context,
+	subscription *entity.NetworkSubscription,
+	hostDTO entity.NetworkHostDTO,
+) (bool, error) {
+	networkHost, err := entity.NewNetworkHost(subscription.NetworkID, hostDTO.Address, hostDTO.Description)
+	if err != nil {
+		return false, fmt.Errorf("failed to create network host for %s: %w", hostDTO.Address, err)
+	}
+	networkHost.SubscriptionID = &subscription.ID
+
+	_, err = u.networkHostStorage.Add(ctx, networkHost)
+	if err != nil {
+		if errors.Is(err, errs.ErrNetworkHostAlreadyExists) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to add managed host %s: %w", hostDTO.Address, err)
+	}
+
+	return true, nil
+}
+
+func (u *UseCase) listManagedHosts(ctx context.Context, subscriptionID uint64) ([]*entity.NetworkHost, error) {
+	managedHosts, err := u.networkHostStorage.List(ctx, &entity.ListNetworkHostFilter{
+		SubscriptionID: []uint64{subscriptionID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed hosts: %w", err)
+	}
+
+	return managedHosts, nil
+}
+
+func (u *UseCase) syncIfNeeded(ctx context.Context, networkID uint64, changed bool) error {
+	if !changed {
+		return nil
+	}
+
+	err := u.networkHostSetupUC.SyncByNetworkID(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("failed to sync network host setup: %w", err)
+	}
+
+	return nil
+}
+
+type fetchedHostList struct {
+	body        []byte
+	etag        *string
+	notModified bool
+}
+
+func (u *UseCase) fetch(ctx context.Context, subscription *entity.NetworkSubscription) (*fetchedHostList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscription.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json, text/plain")
+	if subscription.ETag != nil {
+		req.Header.Set("If-None-Match", *subscription.ETag)
+	}
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch host list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Continue with processing
+	case http.StatusNotModified:
+		return &fetchedHostList{notModified: true}, nil
+	default:
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHostListSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host list: %w", err)
+	}
+	if len(body) > maxHostListSize {
+		return nil, fmt.Errorf("host list is larger than %d bytes", maxHostListSize)
+	}
+
+	hostList := &fetchedHostList{body: body}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		hostList.etag = &etag
+	}
+
+	return hostList, nil
+}
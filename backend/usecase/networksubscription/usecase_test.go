@@ -0,0 +1,606 @@
+package networksubscription
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_trm "github.com/dmitrorlov/splitr/backend/mocks/trm"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type testMocks struct {
+	trm                        *mock_trm.MockManager
+	networkHostSetupUC         *mock_usecase.MockNetworkHostSetup
+	networkStorage             *mock_storage.MockNetwork
+	networkHostStorage         *mock_storage.MockNetworkHost
+	networkSubscriptionStorage *mock_storage.MockNetworkSubscription
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testMocks) {
+	mocks := &testMocks{
+		trm:                        mock_trm.NewMockManager(ctrl),
+		networkHostSetupUC:         mock_usecase.NewMockNetworkHostSetup(ctrl),
+		networkStorage:             mock_storage.NewMockNetwork(ctrl),
+		networkHostStorage:         mock_storage.NewMockNetworkHost(ctrl),
+		networkSubscriptionStorage: mock_storage.NewMockNetworkSubscription(ctrl),
+	}
+
+	useCase := New(
+		mocks.trm,
+		mocks.networkHostSetupUC,
+		mocks.networkStorage,
+		mocks.networkHostStorage,
+		mocks.networkSubscriptionStorage,
+		&config.Subscription{RefreshInterval: time.Hour},
+	)
+
+	return useCase, mocks
+}
+
+func (m *testMocks) expectTransaction() {
+	m.trm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+
+	require.NotNil(t, useCase)
+	assert.Equal(t, mocks.networkSubscriptionStorage, useCase.networkSubscriptionStorage)
+	assert.Equal(t, time.Hour, useCase.refreshInterval)
+	assert.Equal(t, defaultHTTPTimeout, useCase.httpClient.Timeout)
+}
+
+func TestUseCase_Refresh_UpdatesManagedHosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v2"`)
+		_, _ = w.Write([]byte("keep.example\nnew.example New host\nmanual.example\n"))
+	}))
+	defer server.Close()
+
+	useCase, mocks := newTestUseCase(ctrl)
+	subscription := &entity.NetworkSubscription{ID: 3, NetworkID: 1, URL: server.URL, ETag: stringPtr(`"v1"`)}
+
+	mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(subscription, nil)
+	mocks.expectTransaction()
+	mocks.networkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{SubscriptionID: []uint64{3}}).
+		Return([]*entity.NetworkHost{
+			{ID: 10, NetworkID: 1, Address: "old.example", SubscriptionID: uint64Ptr(3)},
+			{ID: 11, NetworkID: 1, Address: "keep.example", SubscriptionID: uint64Ptr(3)},
+		}, nil)
+	mocks.networkHostStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+			assert.Equal(t, "new.example", host.Address)
+			assert.Equal(t, "New host", *host.Description)
+			assert.Equal(t, uint64Ptr(3), host.SubscriptionID)
+			return host, nil
+		})
+	mocks.networkHostStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		Return(nil, errs.ErrNetworkHostAlreadyExists)
+	mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(10)).Return(nil)
+	mocks.networkSubscriptionStorage.EXPECT().
+		UpdateRefreshState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, updated *entity.NetworkSubscription) error {
+			assert.Equal(t, `"v2"`, *updated.ETag)
+			assert.NotNil(t, updated.LastRefreshedAt)
+			assert.Nil(t, updated.LastError)
+			require.NotNil(t, updated.HostList)
+			assert.Equal(t, "keep.example\nnew.example New host\nmanual.example\n", *updated.HostList)
+			return nil
+		})
+	mocks.networkSubscriptionStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkSubscriptionFilter{NetworkID: []uint64{1}}).
+		Return([]*entity.NetworkSubscription{subscription}, nil)
+	mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+	mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+
+	err := useCase.Refresh(context.Background(), 3)
+
+	require.NoError(t, err)
+}
+
+func TestUseCase_Refresh_UnresolvableHostRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("ETag", `"v2"`)
+		_, _ = w.Write([]byte("missing.invalid\n"))
+	}))
+	defer server.Close()
+
+	useCase, mocks := newTestUseCase(ctrl)
+	subscription := &entity.NetworkSubscription{ID: 3, NetworkID: 1, URL: server.URL, ETag: stringPtr(`"v1"`)}
+
+	committed := false
+	mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(subscription, nil)
+	mocks.trm.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			err := fn(ctx)
+			committed = err == nil
+			return err
+		})
+	mocks.networkHostStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mocks.networkHostStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+			return host, nil
+		})
+	mocks.networkSubscriptionStorage.EXPECT().UpdateRefreshState(gomock.Any(), gomock.Any()).Return(nil)
+	mocks.networkSubscriptionStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkSubscription{subscription}, nil)
+	mocks.networkHostSetupUC.EXPECT().
+		ValidateByNetworkID(gomock.Any(), uint64(1)).
+		Return(errors.New("no such host"))
+	mocks.networkSubscriptionStorage.EXPECT().
+		UpdateRefreshState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, updated *entity.NetworkSubscription) error {
+			assert.Equal(t, `"v1"`, *updated.ETag)
+			require.NotNil(t, updated.LastError)
+			assert.Contains(t, *updated.LastError, "failed to resolve network routes: no such host")
+			return nil
+		})
+
+	err := useCase.Refresh(context.Background(), 3)
+
+	require.Error(t, err)
+	assert.False(t, committed, "the listed hosts must not be committed")
+}
+
+func TestUseCase_Refresh_Unchanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"hosts": [{"address": "keep.example"}]}`))
+	}))
+	defer server.Close()
+
+	useCase, mocks := newTestUseCase(ctrl)
+	subscription := &entity.NetworkSubscription{ID: 3, NetworkID: 1, URL: server.URL}
+
+	mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(subscription, nil)
+	mocks.expectTransaction()
+	mocks.networkHostStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkHost{{ID: 11, NetworkID: 1, Address: "keep.example", SubscriptionID: uint64Ptr(3)}}, nil)
+	mocks.networkSubscriptionStorage.EXPECT().
+		UpdateRefreshState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, updated *entity.NetworkSubscription) error {
+			assert.Nil(t, updated.ETag)
+			return nil
+		})
+	// No sync since nothing changed
+
+	err := useCase.Refresh(context.Background(), 3)
+
+	require.NoError(t, err)
+}
+
+func TestUseCase_Refresh_NotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	useCase, mocks := newTestUseCase(ctrl)
+	subscription := &entity.NetworkSubscription{
+		ID:        3,
+		NetworkID: 1,
+		URL:       server.URL,
+		ETag:      stringPtr(`"v1"`),
+		LastError: stringPtr("previous failure"),
+	}
+
+	mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(subscription, nil)
+	mocks.networkSubscriptionStorage.EXPECT().
+		UpdateRefreshState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, updated *entity.NetworkSubscription) error {
+			assert.Equal(t, `"v1"`, *updated.ETag)
+			assert.NotNil(t, updated.LastRefreshedAt)
+			assert.Nil(t, updated.LastError)
+			return nil
+		})
+
+	err := useCase.Refresh(context.Background(), 3)
+
+	require.NoError(t, err)
+}
+
+func TestUseCase_Refresh_NotModifiedReconcilesStoredList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	useCase, mocks := newTestUseCase(ctrl)
+	subscription := &entity.NetworkSubscription{
+		ID:        3,
+		NetworkID: 1,
+		URL:       server.URL,
+		ETag:      stringPtr(`"v1"`),
+		HostList:  stringPtr("keep.example\nshared.example\n"),
+	}
+
+	mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(subscription, nil)
+	mocks.expectTransaction()
+	// shared.example was managed by another subscription that has since dropped it
+	mocks.networkHostStorage.EXPECT().
+		List(gomock.Any(), &entity.ListNetworkHostFilter{SubscriptionID: []uint64{3}}).
+		Return([]*entity.NetworkHost{{ID: 11, NetworkID: 1, Address: "keep.example", SubscriptionID: uint64Ptr(3)}}, nil)
+	mocks.networkHostStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+			assert.Equal(t, "shared.example", host.Address)
+			assert.Equal(t, uint64Ptr(3), host.SubscriptionID)
+			return host, nil
+		})
+	mocks.networkSubscriptionStorage.EXPECT().
+		UpdateRefreshState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, updated *entity.NetworkSubscription) error {
+			assert.Equal(t, `"v1"`, *updated.ETag)
+			return nil
+		})
+	mocks.networkSubscriptionStorage.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*entity.NetworkSubscription{subscription}, nil)
+	mocks.networkHostSetupUC.EXPECT().ValidateByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+	mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+
+	err := useCase.Refresh(context.Background(), 3)
+
+	require.NoError(t, err)
+}
+
+func TestUseCase_Refresh_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		setupMocks    func(*testMocks)
+		expectedError string
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectedError: "unexpected status code 500",
+		},
+		{
+			name: "malformed JSON",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("ETag", `"v2"`)
+				_, _ = w.Write([]byte(`{"hosts": [`))
+			},
+			expectedError: "failed to parse JSON host list",
+		},
+		{
+			name: "storage error rolls back",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("ETag", `"v2"`)
+				_, _ = w.Write([]byte("new.example\n"))
+			},
+			setupMocks: func(mocks *testMocks) {
+				mocks.trm.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				mocks.networkHostStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: "failed to list managed hosts: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			useCase, mocks := newTestUseCase(ctrl)
+			subscription := &entity.NetworkSubscription{ID: 3, NetworkID: 1, URL: server.URL, ETag: stringPtr(`"v1"`)}
+
+			mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(subscription, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mocks)
+			}
+			mocks.networkSubscriptionStorage.EXPECT().
+				UpdateRefreshState(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, updated *entity.NetworkSubscription) error {
+					// The previous ETag is kept so the next refresh downloads the list again
+					assert.Equal(t, `"v1"`, *updated.ETag)
+					assert.Nil(t, updated.LastRefreshedAt)
+					require.NotNil(t, updated.LastError)
+					assert.Contains(t, *updated.LastError, tt.expectedError)
+					return nil
+				})
+
+			err := useCase.Refresh(context.Background(), 3)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to refresh network subscription")
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestUseCase_Add(t *testing.T) {
+	t.Run("invalid URL", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, _ := newTestUseCase(ctrl)
+
+		result, err := useCase.Add(context.Background(), 1, "ftp://example.com/hosts")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "subscription URL must use http or https")
+		assert.Nil(t, result)
+	})
+
+	t.Run("network not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(nil, errs.ErrNetworkNotFound)
+
+		result, err := useCase.Add(context.Background(), 1, "https://example.com/hosts")
+
+		require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("failed first refresh is kept on the subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		added := &entity.NetworkSubscription{ID: 3, NetworkID: 1, URL: server.URL}
+		failed := &entity.NetworkSubscription{
+			ID:        3,
+			NetworkID: 1,
+			URL:       server.URL,
+			LastError: stringPtr("unexpected status code 404"),
+		}
+
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(&entity.Network{ID: 1}, nil)
+		mocks.networkSubscriptionStorage.EXPECT().Add(gomock.Any(), gomock.Any()).Return(added, nil)
+		gomock.InOrder(
+			mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(added, nil),
+			mocks.networkSubscriptionStorage.EXPECT().UpdateRefreshState(gomock.Any(), gomock.Any()).Return(nil),
+			mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(failed, nil),
+		)
+
+		result, err := useCase.Add(context.Background(), 1, server.URL)
+
+		require.NoError(t, err)
+		assert.Equal(t, failed, result)
+	})
+
+	t.Run("duplicate subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(&entity.Network{ID: 1}, nil)
+		mocks.networkSubscriptionStorage.EXPECT().
+			Add(gomock.Any(), gomock.Any()).
+			Return(nil, errs.ErrNetworkSubscriptionAlreadyExists)
+
+		result, err := useCase.Add(context.Background(), 1, "https://example.com/hosts")
+
+		require.ErrorIs(t, err, errs.ErrNetworkSubscriptionAlreadyExists)
+		assert.Nil(t, result)
+	})
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	t.Run("removes managed hosts and syncs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+
+		mocks.networkSubscriptionStorage.EXPECT().
+			Get(gomock.Any(), uint64(3)).
+			Return(&entity.NetworkSubscription{ID: 3, NetworkID: 1}, nil)
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), &entity.ListNetworkHostFilter{SubscriptionID: []uint64{3}}).
+			Return([]*entity.NetworkHost{{ID: 10, Address: "a.example"}, {ID: 11, Address: "b.example"}}, nil)
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(10)).Return(nil)
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(11)).Return(nil)
+		mocks.networkSubscriptionStorage.EXPECT().Delete(gomock.Any(), uint64(3)).Return(nil)
+		mocks.networkSubscriptionStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+
+		err := useCase.Delete(context.Background(), 3)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("sibling subscription re-adds a shared host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		sibling := &entity.NetworkSubscription{ID: 4, NetworkID: 1, HostList: stringPtr("a.example\n")}
+
+		mocks.networkSubscriptionStorage.EXPECT().
+			Get(gomock.Any(), uint64(3)).
+			Return(&entity.NetworkSubscription{ID: 3, NetworkID: 1}, nil)
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), &entity.ListNetworkHostFilter{SubscriptionID: []uint64{3}}).
+			Return([]*entity.NetworkHost{{ID: 10, Address: "a.example"}}, nil)
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(10)).Return(nil)
+		mocks.networkSubscriptionStorage.EXPECT().Delete(gomock.Any(), uint64(3)).Return(nil)
+		mocks.networkSubscriptionStorage.EXPECT().
+			List(gomock.Any(), &entity.ListNetworkSubscriptionFilter{NetworkID: []uint64{1}}).
+			Return([]*entity.NetworkSubscription{sibling}, nil)
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), &entity.ListNetworkHostFilter{SubscriptionID: []uint64{4}}).
+			Return(nil, nil)
+		mocks.networkHostStorage.EXPECT().
+			Add(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, host *entity.NetworkHost) (*entity.NetworkHost, error) {
+				assert.Equal(t, "a.example", host.Address)
+				assert.Equal(t, uint64Ptr(4), host.SubscriptionID)
+				return host, nil
+			})
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+
+		err := useCase.Delete(context.Background(), 3)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("missing subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+		mocks.networkSubscriptionStorage.EXPECT().
+			Get(gomock.Any(), uint64(3)).
+			Return(nil, errs.ErrNetworkSubscriptionNotFound)
+
+		err := useCase.Delete(context.Background(), 3)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("sync error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl)
+
+		mocks.networkSubscriptionStorage.EXPECT().
+			Get(gomock.Any(), uint64(3)).
+			Return(&entity.NetworkSubscription{ID: 3, NetworkID: 1}, nil)
+		mocks.expectTransaction()
+		mocks.networkHostStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			Return([]*entity.NetworkHost{{ID: 10, Address: "a.example"}}, nil)
+		mocks.networkHostStorage.EXPECT().Delete(gomock.Any(), uint64(10)).Return(nil)
+		mocks.networkSubscriptionStorage.EXPECT().Delete(gomock.Any(), uint64(3)).Return(nil)
+		mocks.networkSubscriptionStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(1)).Return(errors.New("sync failed"))
+
+		err := useCase.Delete(context.Background(), 3)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to sync network host setup: sync failed")
+	})
+}
+
+func TestUseCase_RefreshAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer okServer.Close()
+
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failingServer.Close()
+
+	useCase, mocks := newTestUseCase(ctrl)
+	failing := &entity.NetworkSubscription{ID: 1, NetworkID: 1, URL: failingServer.URL}
+	ok := &entity.NetworkSubscription{ID: 2, NetworkID: 1, URL: okServer.URL}
+
+	mocks.networkSubscriptionStorage.EXPECT().
+		List(gomock.Any(), nil).
+		Return([]*entity.NetworkSubscription{failing, ok}, nil)
+	mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(failing, nil)
+	mocks.networkSubscriptionStorage.EXPECT().Get(gomock.Any(), uint64(2)).Return(ok, nil)
+	mocks.networkSubscriptionStorage.EXPECT().UpdateRefreshState(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	err := useCase.RefreshAll(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status code 502")
+}
+
+func TestUseCase_Run_StopsOnContextCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase, mocks := newTestUseCase(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mocks.networkSubscriptionStorage.EXPECT().
+		List(gomock.Any(), nil).
+		DoAndReturn(func(_ context.Context, _ *entity.ListNetworkSubscriptionFilter) ([]*entity.NetworkSubscription, error) {
+			cancel()
+			return nil, nil
+		})
+
+	done := make(chan struct{})
+	go func() {
+		useCase.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,166 @@
+package networkwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+// UseCase watches the physical network: the default interface, its network service, router and subnet mask.
+// Routes are synced with the router of the network they were planned on, so when it changes, e.g. moving
+// from the office Wi-Fi to the home one, the active network is synced again.
+type UseCase struct {
+	commandExecutorUC  usecase.CommandExecutor
+	networkHostSetupUC usecase.NetworkHostSetup
+	networkStorage     storage.Network
+
+	enabled       bool
+	checkInterval time.Duration
+
+	// lastNetworkInfo is the physical network the active network was last synced for, nil before the first check.
+	lastNetworkInfo *entity.NetworkInfo
+}
+
+func New(
+	commandExecutorUC usecase.CommandExecutor,
+	networkHostSetupUC usecase.NetworkHostSetup,
+	networkStorage storage.Network,
+	watchCfg *config.NetworkWatch,
+) *UseCase {
+	return &UseCase{
+		commandExecutorUC:  commandExecutorUC,
+		networkHostSetupUC: networkHostSetupUC,
+		networkStorage:     networkStorage,
+		enabled:            watchCfg.Enabled,
+		checkInterval:      watchCfg.CheckInterval,
+	}
+}
+
+// Check detects the physical network and syncs the active networks when it has changed since the last check.
+// The first check only records the network. A change whose sync fails is picked up again by the next check.
+// While the network can't be detected, e.g. with no network at all, the last one detected is kept.
+func (u *UseCase) Check(ctx context.Context) error {
+	networkInfo, err := u.detect(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous := u.lastNetworkInfo
+	if previous == nil {
+		u.lastNetworkInfo = networkInfo
+		return nil
+	}
+	if *previous == *networkInfo {
+		return nil
+	}
+
+	slog.Info(
+		"physical network changed",
+		"previous_interface", previous.NetworkInterface,
+		"previous_service", previous.NetworkService,
+		"previous_router", previous.Router,
+		"previous_subnet_mask", previous.SubnetMask,
+		"interface", networkInfo.NetworkInterface,
+		"service", networkInfo.NetworkService,
+		"router", networkInfo.Router,
+		"subnet_mask", networkInfo.SubnetMask,
+	)
+
+	err = u.syncActiveNetworks(ctx)
+	if err != nil {
+		return err
+	}
+	u.lastNetworkInfo = networkInfo
+
+	return nil
+}
+
+// Run checks the physical network right away and then on every check interval until ctx is done.
+// It returns immediately when the watcher is disabled.
+func (u *UseCase) Run(ctx context.Context) {
+	if !u.enabled {
+		return
+	}
+
+	ticker := time.NewTicker(u.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		err := u.Check(ctx)
+		if err != nil {
+			slog.Warn("failed to check physical network", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *UseCase) detect(ctx context.Context) (*entity.NetworkInfo, error) {
+	networkInterface, err := u.commandExecutorUC.GetDefaultNetworkInterface(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default network interface: %w", err)
+	}
+
+	networkService, err := u.commandExecutorUC.GetNetworkServiceByNetworkInterface(ctx, networkInterface)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network service by network interface %s: %w", networkInterface, err)
+	}
+
+	networkInfo, err := u.commandExecutorUC.GetNetworkInfoByNetworkService(ctx, networkService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network info by network service %s: %w", networkService, err)
+	}
+
+	return &entity.NetworkInfo{
+		NetworkInterface: networkInterface,
+		NetworkService:   networkService,
+		SubnetMask:       networkInfo.SubnetMask,
+		Router:           networkInfo.Router,
+	}, nil
+}
+
+// syncActiveNetworks syncs the networks of the connected VPN, nothing is routed while none is connected.
+// A network that fails to sync doesn't stop the others.
+func (u *UseCase) syncActiveNetworks(ctx context.Context) error {
+	currentVPN, err := u.commandExecutorUC.GetCurrentVPN(ctx)
+	if err != nil {
+		if errors.Is(err, errs.ErrVPNServiceNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get current VPN: %w", err)
+	}
+
+	networks, err := u.networkStorage.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var syncErrs []error
+	for _, network := range networks {
+		if entity.VPNService(network.Name) != currentVPN {
+			continue
+		}
+
+		syncErr := u.networkHostSetupUC.SyncByNetworkID(ctx, network.ID)
+		if syncErr != nil {
+			syncErrs = append(syncErrs, fmt.Errorf("failed to sync network %s: %w", network.Name, syncErr))
+			continue
+		}
+
+		slog.Info("network re-synced after physical network change", "network_id", network.ID, "name", network.Name)
+	}
+
+	return errors.Join(syncErrs...)
+}
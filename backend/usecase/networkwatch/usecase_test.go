@@ -0,0 +1,215 @@
+package networkwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type testMocks struct {
+	commandExecutorUC  *mock_usecase.MockCommandExecutor
+	networkHostSetupUC *mock_usecase.MockNetworkHostSetup
+	networkStorage     *mock_storage.MockNetwork
+}
+
+func newTestUseCase(ctrl *gomock.Controller, enabled bool) (*UseCase, *testMocks) {
+	mocks := &testMocks{
+		commandExecutorUC:  mock_usecase.NewMockCommandExecutor(ctrl),
+		networkHostSetupUC: mock_usecase.NewMockNetworkHostSetup(ctrl),
+		networkStorage:     mock_storage.NewMockNetwork(ctrl),
+	}
+
+	useCase := New(
+		mocks.commandExecutorUC,
+		mocks.networkHostSetupUC,
+		mocks.networkStorage,
+		&config.NetworkWatch{Enabled: enabled, CheckInterval: time.Minute},
+	)
+
+	return useCase, mocks
+}
+
+func (m *testMocks) expectNetwork(networkInterface, networkService, router string) {
+	m.commandExecutorUC.EXPECT().
+		GetDefaultNetworkInterface(gomock.Any()).
+		Return(entity.NetworkInterface(networkInterface), nil)
+	m.commandExecutorUC.EXPECT().
+		GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface(networkInterface)).
+		Return(entity.NetworkService(networkService), nil)
+	m.commandExecutorUC.EXPECT().
+		GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService(networkService)).
+		Return(&entity.NetworkInfo{SubnetMask: "255.255.255.0", Router: router}, nil)
+}
+
+func (m *testMocks) expectActiveNetworks(currentVPN entity.VPNService) {
+	m.commandExecutorUC.EXPECT().GetCurrentVPN(gomock.Any()).Return(currentVPN, nil)
+	m.networkStorage.EXPECT().
+		List(gomock.Any(), nil).
+		Return([]*entity.Network{
+			{ID: 1, Name: "Office"},
+			{ID: 2, Name: "Lab"},
+		}, nil)
+}
+
+func TestUseCase_Check(t *testing.T) {
+	t.Run("first check only records the network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+
+		require.NoError(t, useCase.Check(context.Background()))
+		assert.Equal(t, &entity.NetworkInfo{
+			NetworkInterface: "en0",
+			NetworkService:   "Wi-Fi",
+			SubnetMask:       "255.255.255.0",
+			Router:           "192.168.1.1",
+		}, useCase.lastNetworkInfo)
+	})
+
+	t.Run("unchanged network isn't synced", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+
+		require.NoError(t, useCase.Check(context.Background()))
+		require.NoError(t, useCase.Check(context.Background()))
+	})
+
+	t.Run("changed router syncs the active network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+		mocks.expectNetwork("en0", "Wi-Fi", "10.0.0.1")
+		mocks.expectActiveNetworks("Office")
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+
+		require.NoError(t, useCase.Check(context.Background()))
+		require.NoError(t, useCase.Check(context.Background()))
+		assert.Equal(t, "10.0.0.1", useCase.lastNetworkInfo.Router)
+	})
+
+	t.Run("changed interface syncs the active network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+		mocks.expectNetwork("en7", "USB LAN", "192.168.1.1")
+		mocks.expectActiveNetworks("Lab")
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(2)).Return(nil)
+
+		require.NoError(t, useCase.Check(context.Background()))
+		require.NoError(t, useCase.Check(context.Background()))
+		assert.Equal(t, entity.NetworkInterface("en7"), useCase.lastNetworkInfo.NetworkInterface)
+	})
+
+	t.Run("failed sync is retried on the next check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+		mocks.expectNetwork("en0", "Wi-Fi", "10.0.0.1")
+		mocks.expectActiveNetworks("Office")
+		mocks.networkHostSetupUC.EXPECT().
+			SyncByNetworkID(gomock.Any(), uint64(1)).
+			Return(errors.New("lookup failed"))
+		mocks.expectNetwork("en0", "Wi-Fi", "10.0.0.1")
+		mocks.expectActiveNetworks("Office")
+		mocks.networkHostSetupUC.EXPECT().SyncByNetworkID(gomock.Any(), uint64(1)).Return(nil)
+
+		require.NoError(t, useCase.Check(context.Background()))
+		err := useCase.Check(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to sync network Office: lookup failed")
+		assert.Equal(t, "192.168.1.1", useCase.lastNetworkInfo.Router)
+
+		require.NoError(t, useCase.Check(context.Background()))
+		assert.Equal(t, "10.0.0.1", useCase.lastNetworkInfo.Router)
+	})
+
+	t.Run("change without a connected VPN only records the network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+		mocks.expectNetwork("en0", "Wi-Fi", "10.0.0.1")
+		mocks.commandExecutorUC.EXPECT().
+			GetCurrentVPN(gomock.Any()).
+			Return(entity.VPNService(""), errs.ErrVPNServiceNotFound)
+
+		require.NoError(t, useCase.Check(context.Background()))
+		require.NoError(t, useCase.Check(context.Background()))
+		assert.Equal(t, "10.0.0.1", useCase.lastNetworkInfo.Router)
+	})
+
+	t.Run("undetectable network keeps the last one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+		mocks.commandExecutorUC.EXPECT().
+			GetDefaultNetworkInterface(gomock.Any()).
+			Return(entity.NetworkInterface(""), errors.New("no default route"))
+
+		require.NoError(t, useCase.Check(context.Background()))
+		err := useCase.Check(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get default network interface: no default route")
+		assert.Equal(t, "192.168.1.1", useCase.lastNetworkInfo.Router)
+	})
+}
+
+func TestUseCase_Run(t *testing.T) {
+	t.Run("disabled watcher returns right away", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, _ := newTestUseCase(ctrl, false)
+
+		useCase.Run(context.Background())
+	})
+
+	t.Run("stops on context cancel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		useCase, mocks := newTestUseCase(ctrl, true)
+		mocks.expectNetwork("en0", "Wi-Fi", "192.168.1.1")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		done := make(chan struct{})
+		go func() {
+			useCase.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run did not stop after context cancel")
+		}
+	})
+}
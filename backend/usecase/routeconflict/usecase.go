@@ -0,0 +1,325 @@
+package routeconflict
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/storage"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+type lookupIPFunc func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+type UseCase struct {
+	commandExecutorUC  usecase.CommandExecutor
+	networkHostSetupUC usecase.NetworkHostSetup
+	networkStorage     storage.Network
+
+	lookupIP lookupIPFunc
+}
+
+// networkRoutes is a network with the routes sync would apply to its tunnel. Direct routes of an inverse network
+// go through the physical gateway, where they can't take the machine off its LAN or the tunnel off its server.
+type networkRoutes struct {
+	network *entity.Network
+	setups  []*entity.NetworkHostSetup
+}
+
+func New(
+	commandExecutorUC usecase.CommandExecutor,
+	networkHostSetupUC usecase.NetworkHostSetup,
+	networkStorage storage.Network,
+) *UseCase {
+	return &UseCase{
+		commandExecutorUC:  commandExecutorUC,
+		networkHostSetupUC: networkHostSetupUC,
+		networkStorage:     networkStorage,
+		lookupIP:           net.DefaultResolver.LookupIPAddr,
+	}
+}
+
+// Analyze checks the routes of every network against the local subnet, against each other and against
+// the network's VPN server.
+func (u *UseCase) Analyze(ctx context.Context) ([]*entity.RouteConflict, error) {
+	return u.analyze(ctx, 0)
+}
+
+// AnalyzeByNetworkID returns the conflicts a network's routes are involved in.
+func (u *UseCase) AnalyzeByNetworkID(ctx context.Context, networkID uint64) ([]*entity.RouteConflict, error) {
+	if _, err := u.networkStorage.Get(ctx, networkID); err != nil {
+		return nil, fmt.Errorf("failed to get network by id %d: %w", networkID, err)
+	}
+
+	return u.analyze(ctx, networkID)
+}
+
+// CheckAddress returns the conflicts the routes of an address would cause once added to a network.
+// The routes are resolved the way a sync builds them, so the check agrees with the analysis after the sync.
+// Hosts of an inverse network go through the physical gateway and can't conflict.
+func (u *UseCase) CheckAddress(
+	ctx context.Context,
+	networkID uint64,
+	address string,
+) ([]*entity.RouteConflict, error) {
+	network, err := u.networkStorage.Get(ctx, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network by id %d: %w", networkID, err)
+	}
+
+	conflicts := make([]*entity.RouteConflict, 0)
+	if network.IsInverse() {
+		return conflicts, nil
+	}
+
+	setups, err := u.networkHostSetupUC.PreviewByAddress(ctx, networkID, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview routes of address %s: %w", address, err)
+	}
+	if len(setups) == 0 {
+		return conflicts, nil
+	}
+
+	candidate := &networkRoutes{network: network, setups: setups}
+
+	localPrefix, err := u.getLocalPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts = append(conflicts, localSubnetConflicts(candidate, localPrefix)...)
+	conflicts = append(conflicts, u.vpnServerConflicts(ctx, candidate)...)
+
+	others, err := u.listNetworkRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range others {
+		if other.network.ID == networkID || other.network.IsInverse() {
+			continue
+		}
+
+		conflicts = append(conflicts, networkConflicts(candidate, other)...)
+	}
+
+	return conflicts, nil
+}
+
+// analyze checks every network, or only the conflicts involving focusNetworkID when it is set.
+func (u *UseCase) analyze(ctx context.Context, focusNetworkID uint64) ([]*entity.RouteConflict, error) {
+	routesList, err := u.listNetworkRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	localPrefix, err := u.getLocalPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]*entity.RouteConflict, 0)
+	for i, routes := range routesList {
+		focused := focusNetworkID == 0 || routes.network.ID == focusNetworkID
+		if focused {
+			conflicts = append(conflicts, localSubnetConflicts(routes, localPrefix)...)
+			conflicts = append(conflicts, u.vpnServerConflicts(ctx, routes)...)
+		}
+
+		// Inverse networks send nearly everything through their tunnel, overlapping other networks is expected.
+		if routes.network.IsInverse() {
+			continue
+		}
+
+		for _, other := range routesList[i+1:] {
+			if other.network.IsInverse() || !focused && other.network.ID != focusNetworkID {
+				continue
+			}
+
+			conflicts = append(conflicts, networkConflicts(routes, other)...)
+		}
+	}
+
+	return conflicts, nil
+}
+
+func (u *UseCase) listNetworkRoutes(ctx context.Context) ([]*networkRoutes, error) {
+	networks, err := u.networkStorage.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	routesList := make([]*networkRoutes, 0, len(networks))
+	for _, network := range networks {
+		previews, previewErr := u.networkHostSetupUC.PreviewByNetworkID(ctx, network.ID)
+		if previewErr != nil {
+			return nil, fmt.Errorf("failed to preview routes of network %s: %w", network.Name, previewErr)
+		}
+
+		routes := &networkRoutes{network: network}
+		for _, preview := range previews {
+			if preview.Path == entity.RoutePathTunnel {
+				routes.setups = append(routes.setups, preview.Setup)
+			}
+		}
+		routesList = append(routesList, routes)
+	}
+
+	return routesList, nil
+}
+
+func localSubnetConflicts(routes *networkRoutes, localPrefix netip.Prefix) []*entity.RouteConflict {
+	var conflicts []*entity.RouteConflict
+	for _, setup := range routes.setups {
+		prefix, ok := setup.Prefix()
+		if !ok || !prefix.Overlaps(localPrefix) {
+			continue
+		}
+
+		conflicts = append(conflicts, &entity.RouteConflict{
+			Kind:           entity.RouteConflictKindLocalSubnet,
+			NetworkID:      routes.network.ID,
+			NetworkName:    routes.network.Name,
+			NetworkHostIDs: setup.NetworkHostIDs,
+			Route:          prefix.String(),
+			ConflictsWith:  localPrefix.String(),
+		})
+	}
+
+	return conflicts
+}
+
+// vpnServerConflicts finds routes covering the network's VPN server. A server that can't be looked up
+// is skipped, the network may not be a configured VPN service on this machine.
+func (u *UseCase) vpnServerConflicts(ctx context.Context, routes *networkRoutes) []*entity.RouteConflict {
+	serverAddress, err := u.commandExecutorUC.GetVPNServerAddress(ctx, entity.VPNService(routes.network.Name))
+	if err != nil {
+		slog.Debug("failed to get VPN server address", "network", routes.network.Name, "error", err)
+		return nil
+	}
+
+	serverPrefixes, err := u.addressPrefixes(ctx, serverAddress)
+	if err != nil {
+		slog.Debug("failed to resolve VPN server address", "address", serverAddress, "error", err)
+		return nil
+	}
+
+	var conflicts []*entity.RouteConflict
+	for _, setup := range routes.setups {
+		prefix, ok := setup.Prefix()
+		if !ok {
+			continue
+		}
+
+		for _, serverPrefix := range serverPrefixes {
+			if !prefix.Overlaps(serverPrefix) {
+				continue
+			}
+
+			conflicts = append(conflicts, &entity.RouteConflict{
+				Kind:           entity.RouteConflictKindVPNServer,
+				NetworkID:      routes.network.ID,
+				NetworkName:    routes.network.Name,
+				NetworkHostIDs: setup.NetworkHostIDs,
+				Route:          prefix.String(),
+				ConflictsWith:  serverPrefix.Addr().String(),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+func networkConflicts(routes, other *networkRoutes) []*entity.RouteConflict {
+	var conflicts []*entity.RouteConflict
+	for _, setup := range routes.setups {
+		prefix, ok := setup.Prefix()
+		if !ok {
+			continue
+		}
+
+		for _, otherSetup := range other.setups {
+			otherPrefix, otherOK := otherSetup.Prefix()
+			if !otherOK || !prefix.Overlaps(otherPrefix) {
+				continue
+			}
+
+			conflicts = append(conflicts, &entity.RouteConflict{
+				Kind:             entity.RouteConflictKindNetwork,
+				NetworkID:        routes.network.ID,
+				NetworkName:      routes.network.Name,
+				NetworkHostIDs:   setup.NetworkHostIDs,
+				Route:            prefix.String(),
+				ConflictsWith:    otherPrefix.String(),
+				OtherNetworkID:   other.network.ID,
+				OtherNetworkName: other.network.Name,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// addressPrefixes turns a CIDR, an IP or a hostname into the IPv4 prefixes it covers.
+// A wildcard covers nothing until the DNS forwarder learns its IPs.
+func (u *UseCase) addressPrefixes(ctx context.Context, address string) ([]netip.Prefix, error) {
+	if entity.IsWildcard(address) {
+		return nil, nil
+	}
+
+	if prefix, err := netip.ParsePrefix(address); err == nil {
+		return []netip.Prefix{prefix.Masked()}, nil
+	}
+
+	if addr, err := netip.ParseAddr(address); err == nil {
+		return []netip.Prefix{netip.PrefixFrom(addr, addr.BitLen())}, nil
+	}
+
+	ips, err := u.lookupIP(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup IP for address %s: %w", address, err)
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(ips))
+	for _, ip := range ips {
+		addr, ok := netip.AddrFromSlice(ip.IP.To4())
+		if !ok {
+			continue
+		}
+
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return prefixes, nil
+}
+
+func (u *UseCase) getLocalPrefix(ctx context.Context) (netip.Prefix, error) {
+	defaultNetworkInterface, err := u.commandExecutorUC.GetDefaultNetworkInterface(ctx)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to get default network interface: %w", err)
+	}
+
+	networkService, err := u.commandExecutorUC.GetNetworkServiceByNetworkInterface(ctx, defaultNetworkInterface)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf(
+			"failed to get network service by network interface %s: %w",
+			defaultNetworkInterface,
+			err,
+		)
+	}
+
+	networkInfo, err := u.commandExecutorUC.GetNetworkInfoByNetworkService(ctx, networkService)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to get network info by network service %s: %w", networkService, err)
+	}
+
+	localPrefix, ok := networkInfo.LocalPrefix()
+	if !ok {
+		return netip.Prefix{}, fmt.Errorf("failed to parse local subnet from %s", networkInfo)
+	}
+
+	return localPrefix, nil
+}
@@ -0,0 +1,335 @@
+package routeconflict
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	mock_usecase "github.com/dmitrorlov/splitr/backend/mocks/usecase"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+type testMocks struct {
+	commandExecutor  *mock_usecase.MockCommandExecutor
+	networkHostSetup *mock_usecase.MockNetworkHostSetup
+	networkStorage   *mock_storage.MockNetwork
+}
+
+func newTestUseCase(t *testing.T) (*UseCase, *testMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := &testMocks{
+		commandExecutor:  mock_usecase.NewMockCommandExecutor(ctrl),
+		networkHostSetup: mock_usecase.NewMockNetworkHostSetup(ctrl),
+		networkStorage:   mock_storage.NewMockNetwork(ctrl),
+	}
+
+	useCase := New(mocks.commandExecutor, mocks.networkHostSetup, mocks.networkStorage)
+	useCase.lookupIP = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		if host == "vpn.corp.example" {
+			return []net.IPAddr{{IP: net.ParseIP("10.1.1.1")}, {IP: net.ParseIP("fd00::1")}}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	return useCase, mocks
+}
+
+func testNetworks() []*entity.Network {
+	return []*entity.Network{
+		{ID: 1, Name: "Corp", RoutingMode: entity.RoutingModeSplit},
+		{ID: 2, Name: "Lab", RoutingMode: entity.RoutingModeSplit},
+		{ID: 3, Name: "Full", RoutingMode: entity.RoutingModeInverse},
+	}
+}
+
+func route(ip, mask string, networkHostIDs ...uint64) *entity.NetworkHostSetup {
+	return &entity.NetworkHostSetup{
+		NetworkHostIP:  ip,
+		SubnetMask:     mask,
+		Router:         "192.168.1.1",
+		NetworkHostIDs: networkHostIDs,
+	}
+}
+
+func expectNetworkRoutes(mocks *testMocks) {
+	mocks.networkStorage.EXPECT().List(gomock.Any(), nil).Return(testNetworks(), nil)
+	mocks.networkHostSetup.EXPECT().
+		PreviewByNetworkID(gomock.Any(), uint64(1)).
+		Return(entity.NewRoutePreviews([]*entity.NetworkHostSetup{
+			route("192.168.0.0", "255.255.0.0", 1),
+			route("10.0.0.0", "255.0.0.0", 2),
+		}, nil), nil)
+	mocks.networkHostSetup.EXPECT().
+		PreviewByNetworkID(gomock.Any(), uint64(2)).
+		Return(entity.NewRoutePreviews([]*entity.NetworkHostSetup{route("10.20.0.0", "255.255.0.0", 3)}, nil), nil)
+	// The direct route of the inverse network stays on the LAN through the physical gateway, it doesn't conflict.
+	mocks.networkHostSetup.EXPECT().
+		PreviewByNetworkID(gomock.Any(), uint64(3)).
+		Return(entity.NewRoutePreviews(
+			[]*entity.NetworkHostSetup{route("10.0.0.0", "255.0.0.0"), route("203.0.0.0", "255.0.0.0")},
+			[]*entity.NetworkHostSetup{route("192.168.1.20", "255.255.255.255", 4)},
+		), nil)
+}
+
+func expectLocalSubnet(mocks *testMocks) {
+	mocks.commandExecutor.EXPECT().
+		GetDefaultNetworkInterface(gomock.Any()).
+		Return(entity.NetworkInterface("en0"), nil)
+	mocks.commandExecutor.EXPECT().
+		GetNetworkServiceByNetworkInterface(gomock.Any(), entity.NetworkInterface("en0")).
+		Return(entity.NetworkService("Wi-Fi"), nil)
+	mocks.commandExecutor.EXPECT().
+		GetNetworkInfoByNetworkService(gomock.Any(), entity.NetworkService("Wi-Fi")).
+		Return(&entity.NetworkInfo{NetworkService: "Wi-Fi", SubnetMask: "255.255.255.0", Router: "192.168.1.1"}, nil)
+}
+
+func TestUseCase_Analyze(t *testing.T) {
+	useCase, mocks := newTestUseCase(t)
+
+	expectNetworkRoutes(mocks)
+	expectLocalSubnet(mocks)
+	mocks.commandExecutor.EXPECT().
+		GetVPNServerAddress(gomock.Any(), entity.VPNService("Corp")).
+		Return("vpn.corp.example", nil)
+	mocks.commandExecutor.EXPECT().
+		GetVPNServerAddress(gomock.Any(), entity.VPNService("Lab")).
+		Return("", errors.New("not a VPN service"))
+	mocks.commandExecutor.EXPECT().
+		GetVPNServerAddress(gomock.Any(), entity.VPNService("Full")).
+		Return("203.0.113.7", nil)
+
+	conflicts, err := useCase.Analyze(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.RouteConflict{
+		{
+			Kind:           entity.RouteConflictKindLocalSubnet,
+			NetworkID:      1,
+			NetworkName:    "Corp",
+			NetworkHostIDs: []uint64{1},
+			Route:          "192.168.0.0/16",
+			ConflictsWith:  "192.168.1.0/24",
+		},
+		{
+			Kind:           entity.RouteConflictKindVPNServer,
+			NetworkID:      1,
+			NetworkName:    "Corp",
+			NetworkHostIDs: []uint64{2},
+			Route:          "10.0.0.0/8",
+			ConflictsWith:  "10.1.1.1",
+		},
+		{
+			Kind:             entity.RouteConflictKindNetwork,
+			NetworkID:        1,
+			NetworkName:      "Corp",
+			NetworkHostIDs:   []uint64{2},
+			Route:            "10.0.0.0/8",
+			ConflictsWith:    "10.20.0.0/16",
+			OtherNetworkID:   2,
+			OtherNetworkName: "Lab",
+		},
+		{
+			Kind:          entity.RouteConflictKindVPNServer,
+			NetworkID:     3,
+			NetworkName:   "Full",
+			Route:         "203.0.0.0/8",
+			ConflictsWith: "203.0.113.7",
+		},
+	}, conflicts)
+}
+
+func TestUseCase_Analyze_Errors(t *testing.T) {
+	t.Run("list networks fails", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.networkStorage.EXPECT().List(gomock.Any(), nil).Return(nil, errors.New("db error"))
+
+		conflicts, err := useCase.Analyze(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list networks: db error")
+		assert.Nil(t, conflicts)
+	})
+
+	t.Run("preview fails", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.networkStorage.EXPECT().List(gomock.Any(), nil).Return(testNetworks()[:1], nil)
+		mocks.networkHostSetup.EXPECT().
+			PreviewByNetworkID(gomock.Any(), uint64(1)).
+			Return(nil, errors.New("lookup failed"))
+
+		conflicts, err := useCase.Analyze(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to preview routes of network Corp: lookup failed")
+		assert.Nil(t, conflicts)
+	})
+
+	t.Run("local subnet lookup fails", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.networkStorage.EXPECT().List(gomock.Any(), nil).Return(nil, nil)
+		mocks.commandExecutor.EXPECT().
+			GetDefaultNetworkInterface(gomock.Any()).
+			Return(entity.NetworkInterface(""), errors.New("no default route"))
+
+		conflicts, err := useCase.Analyze(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get default network interface: no default route")
+		assert.Nil(t, conflicts)
+	})
+}
+
+func TestUseCase_AnalyzeByNetworkID(t *testing.T) {
+	t.Run("only conflicts involving the network", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(2)).Return(testNetworks()[1], nil)
+		expectNetworkRoutes(mocks)
+		expectLocalSubnet(mocks)
+		mocks.commandExecutor.EXPECT().
+			GetVPNServerAddress(gomock.Any(), entity.VPNService("Lab")).
+			Return("", errors.New("not a VPN service"))
+
+		conflicts, err := useCase.AnalyzeByNetworkID(context.Background(), 2)
+
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, entity.RouteConflictKindNetwork, conflicts[0].Kind)
+		assert.Equal(t, uint64(1), conflicts[0].NetworkID)
+		assert.Equal(t, uint64(2), conflicts[0].OtherNetworkID)
+	})
+
+	t.Run("network not found", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(9)).Return(nil, errs.ErrNetworkNotFound)
+
+		conflicts, err := useCase.AnalyzeByNetworkID(context.Background(), 9)
+
+		require.ErrorIs(t, err, errs.ErrNetworkNotFound)
+		assert.Nil(t, conflicts)
+	})
+}
+
+func TestUseCase_CheckAddress(t *testing.T) {
+	t.Run("address overlapping another network and the VPN server", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(testNetworks()[0], nil)
+		mocks.networkHostSetup.EXPECT().
+			PreviewByAddress(gomock.Any(), uint64(1), "10.0.0.0/8").
+			Return([]*entity.NetworkHostSetup{route("10.0.0.0", "255.0.0.0")}, nil)
+		expectLocalSubnet(mocks)
+		mocks.commandExecutor.EXPECT().
+			GetVPNServerAddress(gomock.Any(), entity.VPNService("Corp")).
+			Return("vpn.corp.example", nil)
+		expectNetworkRoutes(mocks)
+
+		conflicts, err := useCase.CheckAddress(context.Background(), 1, "10.0.0.0/8")
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.RouteConflict{
+			{
+				Kind:          entity.RouteConflictKindVPNServer,
+				NetworkID:     1,
+				NetworkName:   "Corp",
+				Route:         "10.0.0.0/8",
+				ConflictsWith: "10.1.1.1",
+			},
+			{
+				Kind:             entity.RouteConflictKindNetwork,
+				NetworkID:        1,
+				NetworkName:      "Corp",
+				Route:            "10.0.0.0/8",
+				ConflictsWith:    "10.20.0.0/16",
+				OtherNetworkID:   2,
+				OtherNetworkName: "Lab",
+			},
+		}, conflicts)
+	})
+
+	t.Run("IP inside the local subnet", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(2)).Return(testNetworks()[1], nil)
+		mocks.networkHostSetup.EXPECT().
+			PreviewByAddress(gomock.Any(), uint64(2), "192.168.1.20").
+			Return([]*entity.NetworkHostSetup{route("192.168.1.20", "255.255.255.255")}, nil)
+		expectLocalSubnet(mocks)
+		mocks.commandExecutor.EXPECT().
+			GetVPNServerAddress(gomock.Any(), entity.VPNService("Lab")).
+			Return("", errors.New("not a VPN service"))
+		mocks.networkStorage.EXPECT().List(gomock.Any(), nil).Return(nil, nil)
+
+		conflicts, err := useCase.CheckAddress(context.Background(), 2, "192.168.1.20")
+
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, entity.RouteConflictKindLocalSubnet, conflicts[0].Kind)
+		assert.Equal(t, "192.168.1.20/32", conflicts[0].Route)
+	})
+
+	t.Run("routes use the mask a sync applies", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(2)).Return(testNetworks()[1], nil)
+		mocks.networkHostSetup.EXPECT().
+			PreviewByAddress(gomock.Any(), uint64(2), "db.lab.example").
+			Return([]*entity.NetworkHostSetup{route("10.0.5.7", "255.255.0.0")}, nil)
+		expectLocalSubnet(mocks)
+		mocks.commandExecutor.EXPECT().
+			GetVPNServerAddress(gomock.Any(), entity.VPNService("Lab")).
+			Return("", errors.New("not a VPN service"))
+		expectNetworkRoutes(mocks)
+
+		conflicts, err := useCase.CheckAddress(context.Background(), 2, "db.lab.example")
+
+		require.NoError(t, err)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, "10.0.0.0/16", conflicts[0].Route)
+		assert.Equal(t, "10.0.0.0/8", conflicts[0].ConflictsWith)
+	})
+
+	t.Run("inverse network hosts never conflict", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(3)).Return(testNetworks()[2], nil)
+
+		conflicts, err := useCase.CheckAddress(context.Background(), 3, "192.168.1.20")
+
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+	})
+
+	t.Run("wildcards have no routes to conflict yet", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(testNetworks()[0], nil)
+		mocks.networkHostSetup.EXPECT().PreviewByAddress(gomock.Any(), uint64(1), "*.corp.example").Return(nil, nil)
+
+		conflicts, err := useCase.CheckAddress(context.Background(), 1, "*.corp.example")
+
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+	})
+
+	t.Run("unresolvable hostname", func(t *testing.T) {
+		useCase, mocks := newTestUseCase(t)
+		mocks.networkStorage.EXPECT().Get(gomock.Any(), uint64(1)).Return(testNetworks()[0], nil)
+		mocks.networkHostSetup.EXPECT().
+			PreviewByAddress(gomock.Any(), uint64(1), "missing.example").
+			Return(nil, errors.New("no such host"))
+
+		conflicts, err := useCase.CheckAddress(context.Background(), 1, "missing.example")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to preview routes of address missing.example: no such host")
+		assert.Nil(t, conflicts)
+	})
+}
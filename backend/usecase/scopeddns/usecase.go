@@ -0,0 +1,158 @@
+package scopeddns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/storage"
+)
+
+const (
+	resolverDirPerm  = 0o755
+	resolverFilePerm = 0o644
+)
+
+// UseCase keeps the resolver files of networks' DNS domains, so the system resolves those domains through
+// the nameservers of the network while it is the active VPN. Files Splitr didn't create are never touched.
+type UseCase struct {
+	resolverFileStorage storage.ResolverFile
+
+	resolverDir string
+}
+
+func New(resolverFileStorage storage.ResolverFile, dnsCfg *config.DNS) *UseCase {
+	return &UseCase{
+		resolverFileStorage: resolverFileStorage,
+		resolverDir:         dnsCfg.ResolverDir,
+	}
+}
+
+// ApplyByNetwork writes a resolver file for every DNS domain of the network and removes the files of
+// domains it no longer has. Domains whose file Splitr didn't create or another network owns are left alone
+// and returned.
+func (u *UseCase) ApplyByNetwork(ctx context.Context, network *entity.Network) ([]string, error) {
+	var domains, skippedDomains []string
+	if network.HasScopedDNS() {
+		domains = network.DNSDomains
+	}
+
+	for _, domain := range domains {
+		written, err := u.writeResolverFile(ctx, network, domain)
+		if err != nil {
+			return nil, err
+		}
+
+		if !written {
+			skippedDomains = append(skippedDomains, domain)
+		}
+	}
+
+	resolverFiles, err := u.resolverFileStorage.ListByNetworkID(ctx, network.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resolver files: %w", err)
+	}
+
+	for _, resolverFile := range resolverFiles {
+		if slices.Contains(domains, resolverFile.Domain) {
+			continue
+		}
+
+		err = u.removeResolverFile(ctx, resolverFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return skippedDomains, nil
+}
+
+// RemoveByNetworkID removes every resolver file Splitr created for the network.
+func (u *UseCase) RemoveByNetworkID(ctx context.Context, networkID uint64) error {
+	resolverFiles, err := u.resolverFileStorage.ListByNetworkID(ctx, networkID)
+	if err != nil {
+		return fmt.Errorf("failed to list resolver files: %w", err)
+	}
+
+	for _, resolverFile := range resolverFiles {
+		err = u.removeResolverFile(ctx, resolverFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeResolverFile writes the resolver file of a domain unless a file Splitr doesn't own is in the way.
+// Ownership is recorded before writing, so a file is never left behind without a record of it.
+func (u *UseCase) writeResolverFile(ctx context.Context, network *entity.Network, domain string) (bool, error) {
+	path := filepath.Join(u.resolverDir, domain)
+
+	resolverFile, err := u.resolverFileStorage.Get(ctx, domain)
+	switch {
+	case err == nil:
+		if resolverFile.NetworkID != network.ID {
+			slog.Warn(
+				"resolver file belongs to another network, leaving it alone",
+				"path", path,
+				"network_id", resolverFile.NetworkID,
+			)
+			return false, nil
+		}
+	case errors.Is(err, errs.ErrResolverFileNotFound):
+		_, statErr := os.Lstat(path)
+		if statErr == nil {
+			slog.Warn("resolver file wasn't created by Splitr, leaving it alone", "path", path)
+			return false, nil
+		}
+		if !errors.Is(statErr, fs.ErrNotExist) {
+			return false, fmt.Errorf("failed to check resolver file %s: %w", path, statErr)
+		}
+
+		err = u.resolverFileStorage.Add(ctx, entity.NewResolverFile(network, domain))
+		if err != nil {
+			return false, fmt.Errorf("failed to record resolver file %s: %w", path, err)
+		}
+	default:
+		return false, fmt.Errorf("failed to get resolver file %s: %w", path, err)
+	}
+
+	err = os.MkdirAll(u.resolverDir, resolverDirPerm)
+	if err != nil {
+		return false, fmt.Errorf("failed to create resolver directory %s: %w", u.resolverDir, err)
+	}
+
+	// The system resolver runs as its own user and has to be able to read the file.
+	err = os.WriteFile(path, entity.ResolverFileContent(network, domain), resolverFilePerm)
+	if err != nil {
+		return false, fmt.Errorf("failed to write resolver file %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// removeResolverFile removes a file Splitr created along with the record of it. A file already gone is fine.
+func (u *UseCase) removeResolverFile(ctx context.Context, resolverFile *entity.ResolverFile) error {
+	path := filepath.Join(u.resolverDir, resolverFile.Domain)
+
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove resolver file %s: %w", path, err)
+	}
+
+	err = u.resolverFileStorage.Delete(ctx, resolverFile.Domain)
+	if err != nil {
+		return fmt.Errorf("failed to delete record of resolver file %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package scopeddns
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dmitrorlov/splitr/backend/config"
+	"github.com/dmitrorlov/splitr/backend/entity"
+	mock_storage "github.com/dmitrorlov/splitr/backend/mocks/storage"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+)
+
+func newTestUseCase(t *testing.T) (*UseCase, *mock_storage.MockResolverFile) {
+	t.Helper()
+
+	mockResolverFileStorage := mock_storage.NewMockResolverFile(gomock.NewController(t))
+	useCase := New(mockResolverFileStorage, &config.DNS{ResolverDir: filepath.Join(t.TempDir(), "resolver")})
+
+	return useCase, mockResolverFileStorage
+}
+
+func testNetwork() *entity.Network {
+	return &entity.Network{
+		ID:             1,
+		Name:           "Corp",
+		DNSDomains:     entity.DomainList{"corp.example.com", "lab.example.com", "shared.example.com"},
+		DNSNameservers: entity.IPList{"10.8.0.1"},
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestNew(t *testing.T) {
+	mockResolverFileStorage := mock_storage.NewMockResolverFile(gomock.NewController(t))
+
+	useCase := New(mockResolverFileStorage, &config.DNS{ResolverDir: "/etc/resolver"})
+
+	assert.Equal(t, mockResolverFileStorage, useCase.resolverFileStorage)
+	assert.Equal(t, "/etc/resolver", useCase.resolverDir)
+}
+
+func TestUseCase_ApplyByNetwork(t *testing.T) {
+	useCase, mockResolverFileStorage := newTestUseCase(t)
+	network := testNetwork()
+	dir := useCase.resolverDir
+
+	// corp is new, lab was created by someone else, shared belongs to another network
+	// and old is a domain the network dropped.
+	writeFile(t, filepath.Join(dir, "lab.example.com"), "nameserver 1.1.1.1\n")
+	writeFile(t, filepath.Join(dir, "old.example.com"), "nameserver 10.8.0.1\n")
+	mockResolverFileStorage.EXPECT().
+		Get(gomock.Any(), "corp.example.com").
+		Return(nil, errs.ErrResolverFileNotFound)
+	mockResolverFileStorage.EXPECT().
+		Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, resolverFile *entity.ResolverFile) error {
+			assert.Equal(t, "corp.example.com", resolverFile.Domain)
+			assert.Equal(t, uint64(1), resolverFile.NetworkID)
+			return nil
+		})
+	mockResolverFileStorage.EXPECT().
+		Get(gomock.Any(), "lab.example.com").
+		Return(nil, errs.ErrResolverFileNotFound)
+	mockResolverFileStorage.EXPECT().
+		Get(gomock.Any(), "shared.example.com").
+		Return(&entity.ResolverFile{Domain: "shared.example.com", NetworkID: 2}, nil)
+	mockResolverFileStorage.EXPECT().
+		ListByNetworkID(gomock.Any(), uint64(1)).
+		Return([]*entity.ResolverFile{
+			{Domain: "corp.example.com", NetworkID: 1},
+			{Domain: "old.example.com", NetworkID: 1},
+		}, nil)
+	mockResolverFileStorage.EXPECT().Delete(gomock.Any(), "old.example.com").Return(nil)
+
+	skippedDomains, err := useCase.ApplyByNetwork(context.Background(), network)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lab.example.com", "shared.example.com"}, skippedDomains)
+	assert.Equal(t, string(entity.ResolverFileContent(network, "corp.example.com")),
+		readFile(t, filepath.Join(dir, "corp.example.com")))
+	assert.Equal(t, "nameserver 1.1.1.1\n", readFile(t, filepath.Join(dir, "lab.example.com")))
+	assert.NoFileExists(t, filepath.Join(dir, "shared.example.com"))
+	assert.NoFileExists(t, filepath.Join(dir, "old.example.com"))
+}
+
+func TestUseCase_ApplyByNetwork_RewritesOwnedFiles(t *testing.T) {
+	useCase, mockResolverFileStorage := newTestUseCase(t)
+	network := testNetwork()
+	network.DNSDomains = entity.DomainList{"corp.example.com"}
+	path := filepath.Join(useCase.resolverDir, "corp.example.com")
+
+	writeFile(t, path, "nameserver 10.0.0.1\n")
+	mockResolverFileStorage.EXPECT().
+		Get(gomock.Any(), "corp.example.com").
+		Return(&entity.ResolverFile{Domain: "corp.example.com", NetworkID: 1}, nil)
+	mockResolverFileStorage.EXPECT().
+		ListByNetworkID(gomock.Any(), uint64(1)).
+		Return([]*entity.ResolverFile{{Domain: "corp.example.com", NetworkID: 1}}, nil)
+
+	skippedDomains, err := useCase.ApplyByNetwork(context.Background(), network)
+
+	require.NoError(t, err)
+	assert.Empty(t, skippedDomains)
+	assert.Contains(t, readFile(t, path), "nameserver 10.8.0.1\n")
+}
+
+func TestUseCase_ApplyByNetwork_WithoutNameserversRemovesFiles(t *testing.T) {
+	useCase, mockResolverFileStorage := newTestUseCase(t)
+	network := testNetwork()
+	network.DNSNameservers = nil
+	path := filepath.Join(useCase.resolverDir, "corp.example.com")
+
+	writeFile(t, path, "nameserver 10.8.0.1\n")
+	mockResolverFileStorage.EXPECT().
+		ListByNetworkID(gomock.Any(), uint64(1)).
+		Return([]*entity.ResolverFile{{Domain: "corp.example.com", NetworkID: 1}}, nil)
+	mockResolverFileStorage.EXPECT().Delete(gomock.Any(), "corp.example.com").Return(nil)
+
+	skippedDomains, err := useCase.ApplyByNetwork(context.Background(), network)
+
+	require.NoError(t, err)
+	assert.Empty(t, skippedDomains)
+	assert.NoFileExists(t, path)
+}
+
+func TestUseCase_ApplyByNetwork_Errors(t *testing.T) {
+	t.Run("ownership lookup fails", func(t *testing.T) {
+		useCase, mockResolverFileStorage := newTestUseCase(t)
+		mockResolverFileStorage.EXPECT().
+			Get(gomock.Any(), "corp.example.com").
+			Return(nil, errors.New("locked"))
+
+		_, err := useCase.ApplyByNetwork(context.Background(), testNetwork())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get resolver file")
+	})
+
+	t.Run("ownership can't be recorded", func(t *testing.T) {
+		useCase, mockResolverFileStorage := newTestUseCase(t)
+		mockResolverFileStorage.EXPECT().
+			Get(gomock.Any(), "corp.example.com").
+			Return(nil, errs.ErrResolverFileNotFound)
+		mockResolverFileStorage.EXPECT().Add(gomock.Any(), gomock.Any()).Return(errors.New("locked"))
+
+		_, err := useCase.ApplyByNetwork(context.Background(), testNetwork())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to record resolver file")
+		assert.NoFileExists(t, filepath.Join(useCase.resolverDir, "corp.example.com"))
+	})
+
+	t.Run("resolver directory can't be created", func(t *testing.T) {
+		useCase, mockResolverFileStorage := newTestUseCase(t)
+		writeFile(t, useCase.resolverDir, "not a directory")
+		mockResolverFileStorage.EXPECT().
+			Get(gomock.Any(), "corp.example.com").
+			Return(&entity.ResolverFile{Domain: "corp.example.com", NetworkID: 1}, nil)
+
+		_, err := useCase.ApplyByNetwork(context.Background(), testNetwork())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create resolver directory")
+	})
+}
+
+func TestUseCase_RemoveByNetworkID(t *testing.T) {
+	t.Run("removes owned files, missing ones included", func(t *testing.T) {
+		useCase, mockResolverFileStorage := newTestUseCase(t)
+		corpPath := filepath.Join(useCase.resolverDir, "corp.example.com")
+		foreignPath := filepath.Join(useCase.resolverDir, "foreign.example.com")
+
+		writeFile(t, corpPath, "nameserver 10.8.0.1\n")
+		writeFile(t, foreignPath, "nameserver 1.1.1.1\n")
+		mockResolverFileStorage.EXPECT().
+			ListByNetworkID(gomock.Any(), uint64(1)).
+			Return([]*entity.ResolverFile{
+				{Domain: "corp.example.com", NetworkID: 1},
+				{Domain: "gone.example.com", NetworkID: 1},
+			}, nil)
+		mockResolverFileStorage.EXPECT().Delete(gomock.Any(), "corp.example.com").Return(nil)
+		mockResolverFileStorage.EXPECT().Delete(gomock.Any(), "gone.example.com").Return(nil)
+
+		err := useCase.RemoveByNetworkID(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.NoFileExists(t, corpPath)
+		assert.FileExists(t, foreignPath)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		useCase, mockResolverFileStorage := newTestUseCase(t)
+		mockResolverFileStorage.EXPECT().
+			ListByNetworkID(gomock.Any(), uint64(1)).
+			Return(nil, errors.New("locked"))
+
+		err := useCase.RemoveByNetworkID(context.Background(), 1)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list resolver files: locked")
+	})
+}
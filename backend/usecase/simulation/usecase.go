@@ -0,0 +1,312 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+	"github.com/dmitrorlov/splitr/backend/usecase/command"
+)
+
+const (
+	defaultNetworkInterface entity.NetworkInterface = "en0"
+	defaultNetworkService   entity.NetworkService   = "Wi-Fi"
+	defaultRouter                                   = "192.168.1.1"
+	defaultSubnetMask                               = "255.255.255.0"
+)
+
+// UseCase is an in-memory macOS network stack that stands in for the command executor in simulation mode:
+// a Wi-Fi service on the default interface, a few L2TP VPN services that can be connected and disconnected,
+// and the additional routes set on each service. Nothing is run on the system.
+type UseCase struct {
+	// commands builds the commands route scripts are made of, they are never run.
+	commands usecase.CommandExecutor
+
+	mu               sync.Mutex
+	defaultInterface entity.NetworkInterface
+	networkInfo      map[entity.NetworkInterface]*entity.NetworkInfo
+	vpns             []*entity.SimulatedVPN
+	additionalRoutes map[entity.NetworkService][]*entity.AdditionalRoute
+}
+
+func New() *UseCase {
+	return &UseCase{
+		commands:         command.NewExecutorWithRunner(nil),
+		defaultInterface: defaultNetworkInterface,
+		networkInfo: map[entity.NetworkInterface]*entity.NetworkInfo{
+			defaultNetworkInterface: {
+				NetworkService: defaultNetworkService,
+				SubnetMask:     defaultSubnetMask,
+				Router:         defaultRouter,
+			},
+		},
+		vpns: []*entity.SimulatedVPN{
+			{Service: "Office VPN", ServerAddress: "vpn.example.com"},
+			{Service: "Lab VPN", ServerAddress: "203.0.113.10"},
+		},
+		additionalRoutes: make(map[entity.NetworkService][]*entity.AdditionalRoute),
+	}
+}
+
+// ListSimulatedVPN returns the simulated VPN services with their connection state.
+func (u *UseCase) ListSimulatedVPN(_ context.Context) ([]*entity.SimulatedVPN, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	vpns := make([]*entity.SimulatedVPN, 0, len(u.vpns))
+	for _, vpn := range u.vpns {
+		vpnCopy := *vpn
+		vpns = append(vpns, &vpnCopy)
+	}
+
+	return vpns, nil
+}
+
+// ConnectVPN connects a simulated VPN service.
+func (u *UseCase) ConnectVPN(ctx context.Context, vpnService entity.VPNService) error {
+	return u.setConnected(ctx, vpnService, true)
+}
+
+// DisconnectVPN disconnects a simulated VPN service.
+func (u *UseCase) DisconnectVPN(ctx context.Context, vpnService entity.VPNService) error {
+	return u.setConnected(ctx, vpnService, false)
+}
+
+func (u *UseCase) setConnected(ctx context.Context, vpnService entity.VPNService, connected bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	vpn := u.findVPN(vpnService)
+	if vpn == nil {
+		return errs.ErrVPNServiceNotFound
+	}
+
+	vpn.Connected = connected
+	slog.InfoContext(ctx, "simulated vpn connection changed", "vpn_service", vpnService, "connected", connected)
+
+	return nil
+}
+
+func (u *UseCase) GetDefaultNetworkInterface(ctx context.Context) (entity.NetworkInterface, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.defaultInterface, nil
+}
+
+func (u *UseCase) GetNetworkServiceByNetworkInterface(
+	ctx context.Context,
+	networkInterface entity.NetworkInterface,
+) (entity.NetworkService, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	networkInfo, ok := u.networkInfo[networkInterface]
+	if !ok {
+		return "", fmt.Errorf("failed to find network service by interface %s", networkInterface)
+	}
+
+	return networkInfo.NetworkService, nil
+}
+
+func (u *UseCase) GetNetworkInfoByNetworkService(
+	ctx context.Context,
+	networkService entity.NetworkService,
+) (*entity.NetworkInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, networkInfo := range u.networkInfo {
+		if networkInfo.NetworkService == networkService {
+			return &entity.NetworkInfo{
+				NetworkService: networkService,
+				SubnetMask:     networkInfo.SubnetMask,
+				Router:         networkInfo.Router,
+			}, nil
+		}
+	}
+
+	return nil, errs.ErrNetworkServiceNotRecognized
+}
+
+func (u *UseCase) SetNetworkAdditionalRoutes(
+	ctx context.Context,
+	network *entity.Network,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) error {
+	return u.SetServiceAdditionalRoutes(ctx, entity.NetworkService(network.Name), networkHostSetupList)
+}
+
+func (u *UseCase) SetServiceAdditionalRoutes(
+	ctx context.Context,
+	networkService entity.NetworkService,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.hasService(networkService) {
+		return errs.ErrNetworkServiceNotRecognized
+	}
+
+	routes := make([]*entity.AdditionalRoute, 0, len(networkHostSetupList))
+	for _, networkHostSetup := range networkHostSetupList {
+		routes = append(routes, &entity.AdditionalRoute{
+			Destination: networkHostSetup.NetworkHostIP,
+			SubnetMask:  networkHostSetup.SubnetMask,
+			Router:      networkHostSetup.Router,
+		})
+	}
+	u.additionalRoutes[networkService] = routes
+	slog.InfoContext(ctx, "simulated additional routes set", "network_service", networkService, "routes", len(routes))
+
+	return nil
+}
+
+func (u *UseCase) GetNetworkAdditionalRoutes(
+	ctx context.Context,
+	network *entity.Network,
+) ([]*entity.AdditionalRoute, error) {
+	return u.GetServiceAdditionalRoutes(ctx, entity.NetworkService(network.Name))
+}
+
+func (u *UseCase) GetServiceAdditionalRoutes(
+	ctx context.Context,
+	networkService entity.NetworkService,
+) ([]*entity.AdditionalRoute, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.hasService(networkService) {
+		return nil, errs.ErrNetworkServiceNotRecognized
+	}
+
+	return slices.Clone(u.additionalRoutes[networkService]), nil
+}
+
+func (u *UseCase) SetNetworkAdditionalRoutesCommand(
+	network *entity.Network,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) *entity.Command {
+	return u.commands.SetNetworkAdditionalRoutesCommand(network, networkHostSetupList)
+}
+
+func (u *UseCase) SetServiceAdditionalRoutesCommand(
+	networkService entity.NetworkService,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) *entity.Command {
+	return u.commands.SetServiceAdditionalRoutesCommand(networkService, networkHostSetupList)
+}
+
+func (u *UseCase) RouteCommands(
+	action entity.RouteAction,
+	networkHostSetupList []*entity.NetworkHostSetup,
+) []*entity.Command {
+	return u.commands.RouteCommands(action, networkHostSetupList)
+}
+
+func (u *UseCase) ListVPN(ctx context.Context) ([]entity.VPNService, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	vpnServices := make([]entity.VPNService, 0, len(u.vpns))
+	for _, vpn := range u.vpns {
+		vpnServices = append(vpnServices, vpn.Service)
+	}
+
+	return vpnServices, nil
+}
+
+func (u *UseCase) GetCurrentVPN(ctx context.Context) (entity.VPNService, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, vpn := range u.vpns {
+		if vpn.Connected {
+			return vpn.Service, nil
+		}
+	}
+
+	return "", errs.ErrVPNServiceNotFound
+}
+
+func (u *UseCase) GetVPNServerAddress(ctx context.Context, vpnService entity.VPNService) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	vpn := u.findVPN(vpnService)
+	if vpn == nil {
+		return "", fmt.Errorf("failed to find server address of VPN service %s", vpnService)
+	}
+
+	return vpn.ServerAddress, nil
+}
+
+// OpenInFinder only logs the path, there is no Finder to reveal it in.
+func (u *UseCase) OpenInFinder(ctx context.Context, path string) error {
+	slog.InfoContext(ctx, "simulated reveal in finder", "path", path)
+
+	return nil
+}
+
+func (u *UseCase) findVPN(vpnService entity.VPNService) *entity.SimulatedVPN {
+	for _, vpn := range u.vpns {
+		if vpn.Service == vpnService {
+			return vpn
+		}
+	}
+
+	return nil
+}
+
+// hasService reports whether a VPN or physical network service has the name.
+func (u *UseCase) hasService(networkService entity.NetworkService) bool {
+	if u.findVPN(entity.VPNService(networkService)) != nil {
+		return true
+	}
+
+	for _, networkInfo := range u.networkInfo {
+		if networkInfo.NetworkService == networkService {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dmitrorlov/splitr/backend/entity"
+	"github.com/dmitrorlov/splitr/backend/pkg/errs"
+	"github.com/dmitrorlov/splitr/backend/usecase"
+)
+
+var (
+	_ usecase.CommandExecutor = (*UseCase)(nil)
+	_ usecase.Simulation      = (*UseCase)(nil)
+)
+
+func TestUseCase_PhysicalNetwork(t *testing.T) {
+	simulationUC := New()
+	ctx := context.Background()
+
+	networkInterface, err := simulationUC.GetDefaultNetworkInterface(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entity.NetworkInterface("en0"), networkInterface)
+
+	networkService, err := simulationUC.GetNetworkServiceByNetworkInterface(ctx, networkInterface)
+	require.NoError(t, err)
+	assert.Equal(t, entity.NetworkService("Wi-Fi"), networkService)
+
+	networkInfo, err := simulationUC.GetNetworkInfoByNetworkService(ctx, networkService)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.NetworkInfo{
+		NetworkService: "Wi-Fi",
+		SubnetMask:     "255.255.255.0",
+		Router:         "192.168.1.1",
+	}, networkInfo)
+
+	_, err = simulationUC.GetNetworkServiceByNetworkInterface(ctx, "en7")
+	require.Error(t, err)
+
+	_, err = simulationUC.GetNetworkInfoByNetworkService(ctx, "Thunderbolt Bridge")
+	require.ErrorIs(t, err, errs.ErrNetworkServiceNotRecognized)
+}
+
+func TestUseCase_ConnectVPN(t *testing.T) {
+	simulationUC := New()
+	ctx := context.Background()
+
+	vpnServices, err := simulationUC.ListVPN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.VPNService{"Office VPN", "Lab VPN"}, vpnServices)
+
+	_, err = simulationUC.GetCurrentVPN(ctx)
+	require.ErrorIs(t, err, errs.ErrVPNServiceNotFound)
+
+	require.NoError(t, simulationUC.ConnectVPN(ctx, "Lab VPN"))
+
+	current, err := simulationUC.GetCurrentVPN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entity.VPNService("Lab VPN"), current)
+
+	vpns, err := simulationUC.ListSimulatedVPN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.SimulatedVPN{
+		{Service: "Office VPN", ServerAddress: "vpn.example.com"},
+		{Service: "Lab VPN", ServerAddress: "203.0.113.10", Connected: true},
+	}, vpns)
+
+	vpns[1].Connected = false
+	current, err = simulationUC.GetCurrentVPN(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entity.VPNService("Lab VPN"), current, "listed VPNs are copies")
+
+	require.NoError(t, simulationUC.DisconnectVPN(ctx, "Lab VPN"))
+	_, err = simulationUC.GetCurrentVPN(ctx)
+	require.ErrorIs(t, err, errs.ErrVPNServiceNotFound)
+
+	require.ErrorIs(t, simulationUC.ConnectVPN(ctx, "Unknown VPN"), errs.ErrVPNServiceNotFound)
+}
+
+func TestUseCase_GetVPNServerAddress(t *testing.T) {
+	simulationUC := New()
+	ctx := context.Background()
+
+	address, err := simulationUC.GetVPNServerAddress(ctx, "Office VPN")
+	require.NoError(t, err)
+	assert.Equal(t, "vpn.example.com", address)
+
+	_, err = simulationUC.GetVPNServerAddress(ctx, "Unknown VPN")
+	require.Error(t, err)
+}
+
+func TestUseCase_AdditionalRoutes(t *testing.T) {
+	simulationUC := New()
+	ctx := context.Background()
+	network := &entity.Network{Name: "Office VPN"}
+
+	routes, err := simulationUC.GetNetworkAdditionalRoutes(ctx, network)
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	err = simulationUC.SetNetworkAdditionalRoutes(ctx, network, []*entity.NetworkHostSetup{
+		{NetworkHostIP: "10.0.0.0", SubnetMask: "255.0.0.0", Router: "10.8.0.1"},
+	})
+	require.NoError(t, err)
+	err = simulationUC.SetServiceAdditionalRoutes(ctx, "Wi-Fi", []*entity.NetworkHostSetup{
+		{NetworkHostIP: "203.0.113.5", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+	})
+	require.NoError(t, err)
+
+	routes, err = simulationUC.GetNetworkAdditionalRoutes(ctx, network)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.AdditionalRoute{
+		{Destination: "10.0.0.0", SubnetMask: "255.0.0.0", Router: "10.8.0.1"},
+	}, routes)
+	routes, err = simulationUC.GetServiceAdditionalRoutes(ctx, "Wi-Fi")
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.AdditionalRoute{
+		{Destination: "203.0.113.5", SubnetMask: "255.255.255.255", Router: "192.168.1.1"},
+	}, routes)
+
+	err = simulationUC.SetNetworkAdditionalRoutes(ctx, network, nil)
+	require.NoError(t, err)
+	routes, err = simulationUC.GetNetworkAdditionalRoutes(ctx, network)
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	err = simulationUC.SetNetworkAdditionalRoutes(ctx, &entity.Network{Name: "Unknown VPN"}, nil)
+	require.ErrorIs(t, err, errs.ErrNetworkServiceNotRecognized)
+	_, err = simulationUC.GetNetworkAdditionalRoutes(ctx, &entity.Network{Name: "Unknown VPN"})
+	require.ErrorIs(t, err, errs.ErrNetworkServiceNotRecognized)
+}
+
+func TestUseCase_Commands(t *testing.T) {
+	simulationUC := New()
+	networkHostSetupList := []*entity.NetworkHostSetup{
+		{NetworkHostIP: "10.0.0.0", SubnetMask: "255.0.0.0", Router: "10.8.0.1"},
+	}
+
+	command := simulationUC.SetNetworkAdditionalRoutesCommand(&entity.Network{Name: "Office VPN"}, networkHostSetupList)
+	assert.Equal(t, "networksetup -setadditionalroutes 'Office VPN' 10.0.0.0 255.0.0.0 10.8.0.1", command.ShellString())
+
+	commands := simulationUC.RouteCommands(entity.RouteActionAdd, networkHostSetupList)
+	require.Len(t, commands, 1)
+	assert.Equal(t, "route", commands[0].Executable)
+}
+
+func TestUseCase_CancelledContext(t *testing.T) {
+	simulationUC := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := simulationUC.ListVPN(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	err = simulationUC.SetServiceAdditionalRoutes(ctx, "Wi-Fi", nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -44,9 +44,10 @@ func New(appName, appVersion string, githubCfg *config.GitHub) *Update {
 	}
 }
 
-func (u *Update) CheckForUpdates() (*entity.UpdateInfo, error) {
+// CheckForUpdates fetches the latest GitHub release and compares it with the running version.
+func (u *Update) CheckForUpdates(ctx context.Context) (*entity.UpdateInfo, error) {
 	url := fmt.Sprintf(githubReleaseURL, u.githubCfg.RepoOwner, u.githubCfg.RepoName)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -1,6 +1,7 @@
 package update
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -312,7 +313,7 @@ func TestUpdate_CheckForUpdates_HTTPRequestCreationError(t *testing.T) {
 	}
 	update := New("test-app", "v1.0.0", githubCfg)
 
-	_, err := update.CheckForUpdates()
+	_, err := update.CheckForUpdates(context.Background())
 	if err == nil {
 		t.Fatal("expected error but got none")
 	}
@@ -329,7 +330,7 @@ func TestUpdate_CheckForUpdates_HTTPClientError(t *testing.T) {
 	update := New("test-app", "v1.0.0", githubCfg)
 	update.httpClient = newMockClient(0, "", errors.New("network error"))
 
-	_, err := update.CheckForUpdates()
+	_, err := update.CheckForUpdates(context.Background())
 	if err == nil {
 		t.Fatal("expected error but got none")
 	}
@@ -396,7 +397,7 @@ func TestUpdate_CheckForUpdates_HTTPStatusCodes(t *testing.T) {
 			update := New("test-app", "v1.0.0", githubCfg)
 			update.httpClient = newMockClient(tt.statusCode, "", nil)
 
-			_, err := update.CheckForUpdates()
+			_, err := update.CheckForUpdates(context.Background())
 			if err == nil {
 				t.Fatal("expected error but got none")
 			}
@@ -415,7 +416,7 @@ func TestUpdate_CheckForUpdates_JSONDecodeError(t *testing.T) {
 	update := New("test-app", "v1.0.0", githubCfg)
 	update.httpClient = newMockClient(http.StatusOK, `{"tag_name":`, nil)
 
-	_, err := update.CheckForUpdates()
+	_, err := update.CheckForUpdates(context.Background())
 	if err == nil {
 		t.Fatal("expected error but got none")
 	}
@@ -465,7 +466,7 @@ func TestUpdate_CheckForUpdates_DraftAndPrerelease(t *testing.T) {
 			)
 			update.httpClient = newMockClient(http.StatusOK, releaseJSON, nil)
 
-			updateInfo, err := update.CheckForUpdates()
+			updateInfo, err := update.CheckForUpdates(context.Background())
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -515,7 +516,7 @@ func TestUpdate_CheckForUpdates_Success(t *testing.T) {
 	releaseJSON := createValidReleaseJSON("v1.2.0", false, false, publishedAt, assets)
 	update.httpClient = newMockClient(http.StatusOK, releaseJSON, nil)
 
-	updateInfo, err := update.CheckForUpdates()
+	updateInfo, err := update.CheckForUpdates(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -590,7 +591,7 @@ func TestUpdate_CheckForUpdates_NoMatchingAsset(t *testing.T) {
 	releaseJSON := createValidReleaseJSON("v1.2.0", false, false, publishedAt, assets)
 	update.httpClient = newMockClient(http.StatusOK, releaseJSON, nil)
 
-	updateInfo, err := update.CheckForUpdates()
+	updateInfo, err := update.CheckForUpdates(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -648,7 +649,7 @@ func TestUpdate_CheckForUpdates_VersionComparisonEdgeCases(t *testing.T) {
 			releaseJSON := createValidReleaseJSON(tt.latestVersion, false, false, publishedAt, nil)
 			update.httpClient = newMockClient(http.StatusOK, releaseJSON, nil)
 
-			updateInfo, err := update.CheckForUpdates()
+			updateInfo, err := update.CheckForUpdates(context.Background())
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
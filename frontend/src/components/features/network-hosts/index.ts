@@ -1,3 +1,5 @@
 export { default as NetworkHostCard } from './NetworkHostCard.vue'
 export { default as NetworkHostForm } from './NetworkHostForm.vue'
 export { default as NetworkHostList } from './NetworkHostList.vue'
+export { default as SSHConfigImportDialog } from './SSHConfigImportDialog.vue'
+export { default as SystemRoutesImportDialog } from './SystemRoutesImportDialog.vue'
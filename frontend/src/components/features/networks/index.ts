@@ -1,3 +1,4 @@
 export { default as NetworkCard } from './NetworkCard.vue'
 export { default as NetworkForm } from './NetworkForm.vue'
 export { default as NetworkList } from './NetworkList.vue'
+export { default as SimulatedVPNPanel } from './SimulatedVPNPanel.vue'
@@ -1,6 +1,7 @@
 // useNotifications composable - provides easy access to notification system
 import { useUIStore } from '@/stores/ui'
-import type { Notification, NotificationType } from '@/types'
+import type { Notification, NotificationType, RouteConflict } from '@/types'
+import { formatRouteConflict } from '@/utils'
 
 export interface UseNotificationsReturn {
   // Show notifications
@@ -108,6 +109,28 @@ export function useNetworkNotifications() {
     )
   }
 
+  const notifyNetworkSyncedWithStaleIPs = (networkName: string, addresses: string[]) => {
+    return notifications.showWarning(
+      'Network Synced With Cached IPs',
+      `DNS lookup failed for ${addresses.join(', ')}, "${networkName}" routes their last known IPs.`
+    )
+  }
+
+  const notifyRouteConflicts = (conflicts: RouteConflict[]) => {
+    return notifications.showWarning(
+      'Route Conflicts',
+      conflicts.map(formatRouteConflict).join('\n'),
+      { duration: 10000 }
+    )
+  }
+
+  const notifyScopedDNSFailed = (networkName: string, error: string) => {
+    return notifications.showWarning(
+      'Scoped DNS Not Applied',
+      `Routes for "${networkName}" were synced, but its resolver files couldn't be written: ${error}`
+    )
+  }
+
   const notifyNetworkReset = (networkName: string) => {
     return notifications.showSuccess(
       'Network Reset',
@@ -123,6 +146,9 @@ export function useNetworkNotifications() {
     notifyNetworkCreated,
     notifyNetworkDeleted,
     notifyNetworkSynced,
+    notifyNetworkSyncedWithStaleIPs,
+    notifyRouteConflicts,
+    notifyScopedDNSFailed,
     notifyNetworkReset,
     notifyNetworkError,
   }
@@ -142,6 +168,20 @@ export function useHostNotifications() {
     return notifications.showSuccess('Host Deleted', `Host "${hostAddress}" has been deleted.`)
   }
 
+  const notifyHostAttached = (hostAddress: string, networkName: string) => {
+    return notifications.showSuccess(
+      'Host Attached',
+      `Host "${hostAddress}" is now routed through "${networkName}".`
+    )
+  }
+
+  const notifyHostDetached = (hostAddress: string, networkName: string) => {
+    return notifications.showSuccess(
+      'Host Detached',
+      `Host "${hostAddress}" is no longer routed through "${networkName}".`
+    )
+  }
+
   const notifyHostError = (action: string, hostAddress: string, error: Error | string) => {
     return notifications.showApiError(error, `${action} Host "${hostAddress}"`)
   }
@@ -149,6 +189,8 @@ export function useHostNotifications() {
   return {
     notifyHostCreated,
     notifyHostDeleted,
+    notifyHostAttached,
+    notifyHostDetached,
     notifyHostError,
   }
 }
@@ -12,6 +12,46 @@ export class ApiServiceError extends Error {
   }
 }
 
+// Typed backend errors (backend/pkg/errs) the UI can explain, matched by their message
+const knownErrors = [
+  {
+    message: 'command requires admin privileges',
+    code: 'command_requires_admin',
+    hint: 'Changing routes requires administrator privileges.',
+  },
+  {
+    message: 'network service is not recognized',
+    code: 'network_service_not_recognized',
+    hint: 'macOS does not know a network service with this name. Check the network name matches the VPN service.',
+  },
+  {
+    message: 'command parameters were not valid',
+    code: 'command_invalid_parameters',
+    hint: 'networksetup rejected the routes. Check the addresses, subnet masks and routers.',
+  },
+  {
+    message: 'vpn service not found',
+    code: 'vpn_service_not_found',
+    hint: 'The VPN service was not found.',
+  },
+]
+
+const toApiError = (error: unknown): ApiServiceError => {
+  let message = 'API call failed'
+  if (error instanceof Error) {
+    message = error.message
+  } else if (typeof error === 'string') {
+    message = error
+  }
+
+  const known = knownErrors.find(knownError => message.includes(knownError.message))
+  if (!known) {
+    return new ApiServiceError(message)
+  }
+
+  return new ApiServiceError(`${known.hint} (${message})`, known.code)
+}
+
 export const api = {
   // Error wrapper for all API calls
   async call<T>(fn: () => Promise<T>, options: ApiCallOptions<T> = {}): Promise<T> {
@@ -20,9 +60,7 @@ export const api = {
       options.onSuccess?.(result)
       return result
     } catch (error) {
-      const apiError = new ApiServiceError(
-        error instanceof Error ? error.message : 'API call failed'
-      )
+      const apiError = toApiError(error)
       options.onError?.(apiError)
       throw apiError
     }
@@ -1,5 +1,12 @@
 // Hosts service - handles all host-related API calls
-import { AddHost, DeleteHost, ListHosts } from '../../wailsjs/go/app/App'
+import {
+  AddHost,
+  AttachHostToNetwork,
+  DeleteHost,
+  DetachHostFromNetwork,
+  ListHosts,
+  ListHostsWithUsage,
+} from '../../wailsjs/go/app/App'
 import type { entity } from '../../wailsjs/go/models'
 
 export const hostsService = {
@@ -7,6 +14,10 @@ export const hostsService = {
     return ListHosts('')
   },
 
+  async listWithUsage(): Promise<entity.HostWithUsage[]> {
+    return ListHostsWithUsage('')
+  },
+
   async add(address: string, description = ''): Promise<entity.Host> {
     return AddHost(address, description)
   },
@@ -14,4 +25,12 @@ export const hostsService = {
   async delete(id: number): Promise<void> {
     return DeleteHost(id)
   },
+
+  async attachToNetwork(hostId: number, networkId: number): Promise<entity.NetworkHost> {
+    return AttachHostToNetwork(hostId, networkId)
+  },
+
+  async detachFromNetwork(hostId: number, networkId: number): Promise<void> {
+    return DetachHostFromNetwork(hostId, networkId)
+  },
 }
@@ -1,4 +1,10 @@
 export { ApiServiceError, api } from './api'
 export { hostsService } from './hosts.service'
 export { networkHostsService } from './networkHosts.service'
-export { networksService, vpnService } from './networks.service'
+export { networkSubscriptionsService } from './networkSubscriptions.service'
+export {
+  networksService,
+  operationsService,
+  simulationService,
+  vpnService,
+} from './networks.service'
@@ -1,5 +1,12 @@
 // Network hosts service - handles all network host-related API calls
-import { AddNetworkHost, DeleteNetworkHost, ListNetworkHosts } from '../../wailsjs/go/app/App'
+import {
+  AddNetworkHost,
+  DeleteNetworkHost,
+  ImportNetworkHostCandidates,
+  ListNetworkHosts,
+  PreviewSSHConfigImport,
+  PreviewSystemRoutesImport,
+} from '../../wailsjs/go/app/App'
 import type { entity } from '../../wailsjs/go/models'
 
 export const networkHostsService = {
@@ -7,11 +14,34 @@ export const networkHostsService = {
     return ListNetworkHosts(networkId, search)
   },
 
-  async add(networkId: number, address: string, description = ''): Promise<entity.NetworkHost> {
-    return AddNetworkHost(networkId, address, description)
+  async add(
+    networkId: number,
+    address: string,
+    description = '',
+    expiresIn = ''
+  ): Promise<entity.AddNetworkHostResult> {
+    return AddNetworkHost(networkId, address, description, expiresIn)
   },
 
   async delete(id: number): Promise<void> {
     return DeleteNetworkHost(id)
   },
+
+  async previewSystemRoutesImport(
+    networkId: number,
+    reverseResolve = false
+  ): Promise<entity.NetworkHostImportPreview> {
+    return PreviewSystemRoutesImport(networkId, reverseResolve)
+  },
+
+  async previewSSHConfigImport(
+    networkId: number,
+    includeKnownHosts = false
+  ): Promise<entity.NetworkHostImportPreview> {
+    return PreviewSSHConfigImport(networkId, includeKnownHosts)
+  },
+
+  async importCandidates(networkId: number, hosts: entity.NetworkHostDTO[]): Promise<void> {
+    return ImportNetworkHostCandidates(networkId, hosts)
+  },
 }
@@ -0,0 +1,26 @@
+// Network subscriptions service - handles remote host list subscriptions of a network
+import {
+  AddNetworkSubscription,
+  DeleteNetworkSubscription,
+  ListNetworkSubscriptions,
+  RefreshNetworkSubscription,
+} from '../../wailsjs/go/app/App'
+import type { entity } from '../../wailsjs/go/models'
+
+export const networkSubscriptionsService = {
+  async list(networkId: number): Promise<entity.NetworkSubscription[]> {
+    return ListNetworkSubscriptions(networkId)
+  },
+
+  async add(networkId: number, url: string): Promise<entity.NetworkSubscription> {
+    return AddNetworkSubscription(networkId, url)
+  },
+
+  async delete(id: number): Promise<void> {
+    return DeleteNetworkSubscription(id)
+  },
+
+  async refresh(id: number): Promise<void> {
+    return RefreshNetworkSubscription(id)
+  },
+}
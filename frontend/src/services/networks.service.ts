@@ -1,8 +1,15 @@
 import {
   AddNetwork,
+  CancelOperation,
+  ConnectSimulatedVPN,
   DeleteNetwork,
+  DisconnectSimulatedVPN,
+  IsSimulationEnabled,
   ListNetworks,
+  ListOperations,
+  ListSimulatedVPN,
   ListVPNServices,
+  PreviewNetworkRoutes,
   ResetNetworkHostSetup,
   SyncNetworkHostSetup,
 } from '../../wailsjs/go/app/App'
@@ -21,13 +28,17 @@ export const networksService = {
     return DeleteNetwork(id)
   },
 
-  async sync(id: number): Promise<void> {
+  async sync(id: number): Promise<entity.SyncResult> {
     return SyncNetworkHostSetup(id)
   },
 
   async reset(id: number): Promise<void> {
     return ResetNetworkHostSetup(id)
   },
+
+  async previewRoutes(id: number): Promise<entity.RoutePreview[]> {
+    return PreviewNetworkRoutes(id)
+  },
 }
 
 export const vpnService = {
@@ -35,3 +46,33 @@ export const vpnService = {
     return ListVPNServices() as unknown as Promise<string[]>
   },
 }
+
+// Long-running calls (sync, reset, import, update check) are listed while they run and can be cancelled
+export const operationsService = {
+  async list(): Promise<entity.Operation[]> {
+    return ListOperations()
+  },
+
+  async cancel(id: number): Promise<void> {
+    return CancelOperation(id)
+  },
+}
+
+// Controls of the in-memory network stack the app runs on in simulation mode (SPLITR_SIMULATE)
+export const simulationService = {
+  async isEnabled(): Promise<boolean> {
+    return IsSimulationEnabled()
+  },
+
+  async listVPN(): Promise<entity.SimulatedVPN[]> {
+    return ListSimulatedVPN()
+  },
+
+  async connect(vpnService: string): Promise<void> {
+    return ConnectSimulatedVPN(vpnService)
+  },
+
+  async disconnect(vpnService: string): Promise<void> {
+    return DisconnectSimulatedVPN(vpnService)
+  },
+}
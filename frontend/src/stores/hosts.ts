@@ -2,17 +2,19 @@
 import { defineStore } from 'pinia'
 import { computed, ref } from 'vue'
 import { hostsService } from '@/services'
-import type { entity } from '../../wailsjs/go/models'
+import { isCIDR } from '@/utils'
+import { entity } from '../../wailsjs/go/models'
 
 export const useHostsStore = defineStore('hosts', () => {
   // State
-  const hosts = ref<entity.Host[]>([])
+  const hosts = ref<entity.HostWithUsage[]>([])
   const loading = ref(false)
   const error = ref<string | null>(null)
   const searchTerm = ref('')
 
   // Loading states for specific operations
   const deletingHostId = ref<number | null>(null)
+  const updatingUsageHostId = ref<number | null>(null)
 
   // Getters
   const filteredHosts = computed(() => {
@@ -34,7 +36,7 @@ export const useHostsStore = defineStore('hosts', () => {
   const totalHosts = computed(() => hosts.value.length)
 
   const hostsByAddress = computed(() => {
-    const hostsMap = new Map<string, entity.Host>()
+    const hostsMap = new Map<string, entity.HostWithUsage>()
     hosts.value.forEach(host => {
       hostsMap.set(host.Address, host)
     })
@@ -46,7 +48,7 @@ export const useHostsStore = defineStore('hosts', () => {
     try {
       loading.value = true
       error.value = null
-      hosts.value = await hostsService.list()
+      hosts.value = await hostsService.listWithUsage()
     } catch (err) {
       error.value = err instanceof Error ? err.message : 'Failed to fetch hosts'
       console.error('Failed to fetch hosts:', err)
@@ -59,8 +61,8 @@ export const useHostsStore = defineStore('hosts', () => {
     try {
       const newHost = await hostsService.add(address, description)
 
-      // Add to local state
-      hosts.value.push(newHost)
+      // Add to local state, a new host is not attached anywhere yet
+      hosts.value.push(entity.HostWithUsage.createFrom({ ...newHost, UsedIn: [] }))
 
       return newHost
     } catch (err) {
@@ -84,6 +86,42 @@ export const useHostsStore = defineStore('hosts', () => {
     }
   }
 
+  const attachToNetwork = async (id: number, network: entity.Network): Promise<void> => {
+    try {
+      updatingUsageHostId.value = id
+      await hostsService.attachToNetwork(id, network.ID)
+
+      // Record the network in local state
+      const host = getHostById(id)
+      if (host && !host.UsedIn.some(n => n.ID === network.ID)) {
+        host.UsedIn = [...host.UsedIn, network]
+      }
+    } catch (err) {
+      error.value = err instanceof Error ? err.message : 'Failed to attach host'
+      throw err
+    } finally {
+      updatingUsageHostId.value = null
+    }
+  }
+
+  const detachFromNetwork = async (id: number, networkId: number): Promise<void> => {
+    try {
+      updatingUsageHostId.value = id
+      await hostsService.detachFromNetwork(id, networkId)
+
+      // Drop the network from local state
+      const host = getHostById(id)
+      if (host) {
+        host.UsedIn = host.UsedIn.filter(n => n.ID !== networkId)
+      }
+    } catch (err) {
+      error.value = err instanceof Error ? err.message : 'Failed to detach host'
+      throw err
+    } finally {
+      updatingUsageHostId.value = null
+    }
+  }
+
   const setSearchTerm = (term: string) => {
     searchTerm.value = term
   }
@@ -96,11 +134,11 @@ export const useHostsStore = defineStore('hosts', () => {
     error.value = null
   }
 
-  const getHostById = (id: number): entity.Host | undefined => {
+  const getHostById = (id: number): entity.HostWithUsage | undefined => {
     return hosts.value.find(host => host.ID === id)
   }
 
-  const getHostByAddress = (address: string): entity.Host | undefined => {
+  const getHostByAddress = (address: string): entity.HostWithUsage | undefined => {
     return hosts.value.find(host => host.Address === address)
   }
 
@@ -108,6 +146,10 @@ export const useHostsStore = defineStore('hosts', () => {
     return deletingHostId.value === id
   }
 
+  const isHostUsageUpdating = (id: number): boolean => {
+    return updatingUsageHostId.value === id
+  }
+
   // Validation helpers
   const isAddressExists = (address: string, excludeId?: number): boolean => {
     return hosts.value.some(
@@ -120,14 +162,14 @@ export const useHostsStore = defineStore('hosts', () => {
       return 'Address is required'
     }
 
-    // Basic IP address or hostname validation
+    // Basic IP address, CIDR or hostname validation
     const ipPattern =
       /^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$/
     const hostnamePattern =
       /^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$/
 
-    if (!ipPattern.test(address) && !hostnamePattern.test(address)) {
-      return 'Please enter a valid IP address or hostname'
+    if (!ipPattern.test(address) && !isCIDR(address) && !hostnamePattern.test(address)) {
+      return 'Please enter a valid IP address, CIDR or hostname'
     }
 
     if (isAddressExists(address)) {
@@ -144,6 +186,7 @@ export const useHostsStore = defineStore('hosts', () => {
     error,
     searchTerm,
     deletingHostId,
+    updatingUsageHostId,
 
     // Getters
     filteredHosts,
@@ -155,12 +198,15 @@ export const useHostsStore = defineStore('hosts', () => {
     fetchHosts,
     addHost,
     deleteHost,
+    attachToNetwork,
+    detachFromNetwork,
     setSearchTerm,
     clearSearch,
     clearError,
     getHostById,
     getHostByAddress,
     isHostDeleting,
+    isHostUsageUpdating,
 
     // Validation
     isAddressExists,
@@ -2,4 +2,5 @@ export { useHostsStore } from './hosts'
 export { useNavigationStore } from './navigation'
 export { useNetworkHostsStore } from './networkHosts'
 export { useNetworksStore } from './networks'
+export { useOperationsStore } from './operations'
 export { useUIStore } from './ui'
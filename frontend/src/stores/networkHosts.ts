@@ -2,6 +2,7 @@
 import { defineStore } from 'pinia'
 import { computed, ref } from 'vue'
 import { networkHostsService } from '@/services'
+import { isCIDR } from '@/utils'
 import type { entity } from '../../wailsjs/go/models'
 
 export const useNetworkHostsStore = defineStore('networkHosts', () => {
@@ -61,16 +62,16 @@ export const useNetworkHostsStore = defineStore('networkHosts', () => {
     networkId: number,
     address: string,
     description?: string
-  ): Promise<entity.NetworkHost> => {
+  ): Promise<entity.AddNetworkHostResult> => {
     try {
-      const newHost = await networkHostsService.add(networkId, address, description)
+      const result = await networkHostsService.add(networkId, address, description)
 
       // Add to local state if we're viewing the same network
       if (currentNetworkId.value === networkId) {
-        networkHosts.value.push(newHost)
+        networkHosts.value.push(result.NetworkHost)
       }
 
-      return newHost
+      return result
     } catch (err) {
       error.value = err instanceof Error ? err.message : 'Failed to add network host'
       throw err
@@ -134,14 +135,26 @@ export const useNetworkHostsStore = defineStore('networkHosts', () => {
       return 'Address is required'
     }
 
-    // Basic IP address or hostname validation
+    // Basic IP address, CIDR or hostname validation
     const ipPattern =
       /^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$/
     const hostnamePattern =
       /^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$/
 
-    if (!ipPattern.test(address) && !hostnamePattern.test(address)) {
-      return 'Please enter a valid IP address or hostname'
+    // Wildcards cover every subdomain, e.g. *.corp.example.com or .corp.example.com
+    const wildcardSuffix = address.replace(/^\*?\./, '')
+    const isWildcard =
+      wildcardSuffix !== address &&
+      wildcardSuffix.includes('.') &&
+      hostnamePattern.test(wildcardSuffix)
+
+    if (
+      !ipPattern.test(address) &&
+      !isCIDR(address) &&
+      !hostnamePattern.test(address) &&
+      !isWildcard
+    ) {
+      return 'Please enter a valid IP address, CIDR, hostname or wildcard domain'
     }
 
     if (isAddressExists(address)) {
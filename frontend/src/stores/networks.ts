@@ -87,13 +87,14 @@ export const useNetworksStore = defineStore('networks', () => {
     }
   }
 
-  const syncNetwork = async (id: number): Promise<void> => {
+  const syncNetwork = async (id: number): Promise<entity.SyncResult> => {
     try {
       syncingNetworkId.value = id
-      await networksService.sync(id)
+      const result = await networksService.sync(id)
 
       // Refresh networks to get updated status
       await fetchNetworks()
+      return result
     } catch (err) {
       error.value = err instanceof Error ? err.message : 'Failed to sync network'
       throw err
@@ -0,0 +1,81 @@
+import { defineStore } from 'pinia'
+import { ref } from 'vue'
+import { operationsService } from '@/services'
+import type { Operation, OperationKind } from '@/types/entities'
+import { EventsOn } from '../../wailsjs/runtime/runtime'
+
+// Events the backend emits when a long-running call starts (with the operation) and finishes (with its ID)
+const OPERATION_STARTED_EVENT = 'operation:started'
+const OPERATION_FINISHED_EVENT = 'operation:finished'
+
+export const useOperationsStore = defineStore('operations', () => {
+  const operations = ref<Operation[]>([])
+  const cancellingOperationId = ref<number | null>(null)
+
+  let unsubscribe: (() => void) | null = null
+
+  const add = (operation: Operation) => {
+    if (!operations.value.some(running => running.ID === operation.ID)) {
+      operations.value.push(operation)
+    }
+  }
+
+  const remove = (id: number) => {
+    operations.value = operations.value.filter(operation => operation.ID !== id)
+  }
+
+  // Follows the backend's operations, picking up the ones already running
+  const subscribe = async () => {
+    if (unsubscribe) return
+
+    const offStarted = EventsOn(OPERATION_STARTED_EVENT, (operation: Operation) => add(operation))
+    const offFinished = EventsOn(OPERATION_FINISHED_EVENT, (id: number) => remove(id))
+    unsubscribe = () => {
+      offStarted()
+      offFinished()
+    }
+
+    try {
+      const running = await operationsService.list()
+      running.forEach(operation => add(operation as unknown as Operation))
+    } catch (err) {
+      console.error('Failed to list operations:', err)
+    }
+  }
+
+  const unsubscribeAll = () => {
+    unsubscribe?.()
+    unsubscribe = null
+  }
+
+  const findOperation = (kind: OperationKind, networkId?: number): Operation | undefined => {
+    return operations.value.find(
+      operation =>
+        operation.Kind === kind && (networkId === undefined || operation.NetworkID === networkId)
+    )
+  }
+
+  const cancel = async (id: number): Promise<void> => {
+    try {
+      cancellingOperationId.value = id
+      await operationsService.cancel(id)
+    } finally {
+      cancellingOperationId.value = null
+    }
+  }
+
+  const isCancelling = (id: number): boolean => {
+    return cancellingOperationId.value === id
+  }
+
+  return {
+    operations,
+    cancellingOperationId,
+
+    subscribe,
+    unsubscribeAll,
+    findOperation,
+    cancel,
+    isCancelling,
+  }
+})
@@ -1,6 +1,21 @@
 // API-related types and error handling
 
-import type { Host, Network, NetworkHost, NetworkWithStatus, VPNService } from './entities'
+import type {
+  AddNetworkHostResult,
+  Host,
+  HostWithUsage,
+  Network,
+  NetworkHost,
+  NetworkHostDTO,
+  NetworkHostImportPreview,
+  NetworkSubscription,
+  NetworkWithStatus,
+  Operation,
+  RoutePreview,
+  SimulatedVPN,
+  SyncResult,
+  VPNService,
+} from './entities'
 
 export class ApiError extends Error {
   constructor(
@@ -24,18 +39,48 @@ export interface ApiCallOptions<T = unknown> {
 export interface WailsApi {
   AddHost: (address: string, description: string) => Promise<Host>
   AddNetwork: (name: string) => Promise<Network>
-  AddNetworkHost: (networkId: number, address: string, description: string) => Promise<NetworkHost>
+  AddNetworkHost: (
+    networkId: number,
+    address: string,
+    description: string,
+    expiresIn: string
+  ) => Promise<AddNetworkHostResult>
+  AddNetworkSubscription: (networkId: number, url: string) => Promise<NetworkSubscription>
+  AttachHostToNetwork: (hostId: number, networkId: number) => Promise<NetworkHost>
+  CancelOperation: (id: number) => Promise<void>
+  ConnectSimulatedVPN: (vpnService: string) => Promise<void>
   DeleteHost: (id: number) => Promise<void>
   DeleteNetwork: (id: number) => Promise<void>
   DeleteNetworkHost: (id: number) => Promise<void>
+  DeleteNetworkSubscription: (id: number) => Promise<void>
+  DetachHostFromNetwork: (hostId: number, networkId: number) => Promise<void>
+  DisconnectSimulatedVPN: (vpnService: string) => Promise<void>
   ExportNetworkHosts: (networkId: number) => Promise<string>
+  ExportNetworkHostsPAC: (networkId: number, proxyTarget: string) => Promise<string>
+  ExportNetworkRouteScripts: (networkId: number) => Promise<string>
+  ImportNetworkHostCandidates: (networkId: number, hosts: NetworkHostDTO[]) => Promise<void>
   ImportNetworkHosts: (networkId: number, jsonData: string) => Promise<void>
+  IsSimulationEnabled: () => Promise<boolean>
   ListHosts: (search: string) => Promise<Host[]>
+  ListHostsWithUsage: (search: string) => Promise<HostWithUsage[]>
   ListNetworkHosts: (networkId: number, search: string) => Promise<NetworkHost[]>
+  ListNetworkSubscriptions: (networkId: number) => Promise<NetworkSubscription[]>
   ListNetworks: (search: string) => Promise<NetworkWithStatus[]>
+  ListOperations: () => Promise<Operation[]>
+  ListSimulatedVPN: () => Promise<SimulatedVPN[]>
   ListVPNServices: () => Promise<VPNService[]>
+  PreviewNetworkRoutes: (networkId: number) => Promise<RoutePreview[]>
+  PreviewSSHConfigImport: (
+    networkId: number,
+    includeKnownHosts: boolean
+  ) => Promise<NetworkHostImportPreview>
+  PreviewSystemRoutesImport: (
+    networkId: number,
+    reverseResolve: boolean
+  ) => Promise<NetworkHostImportPreview>
+  RefreshNetworkSubscription: (id: number) => Promise<void>
   SaveFileWithDialog: (defaultName: string, content: string) => Promise<string>
-  SyncNetworkHostSetup: (networkId: number) => Promise<void>
+  SyncNetworkHostSetup: (networkId: number) => Promise<SyncResult>
   ResetNetworkHostSetup: (networkId: number) => Promise<void>
 }
 
@@ -50,8 +95,11 @@ export interface NetworkService {
 
 export interface HostService {
   list(): Promise<Host[]>
+  listWithUsage(): Promise<HostWithUsage[]>
   add(address: string, description?: string): Promise<Host>
   delete(id: number): Promise<void>
+  attachToNetwork(hostId: number, networkId: number): Promise<NetworkHost>
+  detachFromNetwork(hostId: number, networkId: number): Promise<void>
 }
 
 export interface NetworkHostService {
@@ -78,6 +126,7 @@ export interface AddNetworkHostRequest {
   networkId: number
   address: string
   description?: string
+  expiresIn?: string
 }
 
 // Generic API response wrapper
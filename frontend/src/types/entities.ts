@@ -8,8 +8,17 @@ export interface Host extends BaseEntity {
   Description?: string
 }
 
+export interface HostWithUsage extends Host {
+  UsedIn: Network[]
+}
+
 export interface Network extends BaseEntity {
   Name: string
+  DNSDomains?: string[]
+  DNSNameservers?: string[]
+  Router?: string
+  SubnetMask?: string
+  NetworkInterface?: string
 }
 
 export interface NetworkWithStatus extends Network {
@@ -20,10 +29,114 @@ export interface NetworkHost extends BaseEntity {
   NetworkID: number
   Address: string
   Description?: string
+  PinnedIPs?: string[]
+  PinMode?: 'replace' | 'append'
+  Router?: string
+  SubnetMask?: string
+  NetworkInterface?: string
+}
+
+export interface NetworkSubscription extends BaseEntity {
+  NetworkID: number
+  URL: string
+  ETag?: string
+  LastRefreshedAt?: string
+  LastError?: string
 }
 
 export type VPNService = string
 
+export interface StaleResolution {
+  Address: string
+  IPs: string[]
+  ResolvedAt: string
+  Expired: boolean
+  Error: string
+}
+
+export type RouteConflictKind = 'local_subnet' | 'network' | 'vpn_server'
+
+export interface RouteConflict {
+  Kind: RouteConflictKind
+  NetworkID: number
+  NetworkName: string
+  NetworkHostIDs?: number[]
+  Route: string
+  ConflictsWith: string
+  OtherNetworkID?: number
+  OtherNetworkName?: string
+}
+
+export interface SyncResult {
+  NetworkID: number
+  Applied: boolean
+  RouteCount: number
+  StaleResolutions?: StaleResolution[]
+  SkippedDNSDomains?: string[]
+  ScopedDNSError: string
+  RouteConflicts?: RouteConflict[]
+}
+
+export interface AddNetworkHostResult {
+  NetworkHost: NetworkHost
+  RouteConflicts?: RouteConflict[]
+}
+
+export interface NetworkHostImportCandidate {
+  address: string
+  description?: string
+  suggested_hostnames?: string[]
+  already_exists: boolean
+}
+
+export interface NetworkHostImportSkipped {
+  value: string
+  reason: string
+}
+
+export interface NetworkHostImportPreview {
+  network_id: number
+  candidates: NetworkHostImportCandidate[]
+  skipped?: NetworkHostImportSkipped[]
+}
+
+export interface NetworkHostDTO {
+  address: string
+  description?: string
+}
+
+export interface NetworkHostSetup {
+  NetworkHostID: number
+  NetworkHostIP: string
+  SubnetMask: string
+  Router: string
+  NetworkHostIDs?: number[]
+}
+
+// Tunnel routes go through the VPN, direct ones through the physical gateway (inverse mode only)
+export type RoutePath = 'tunnel' | 'direct'
+
+export interface RoutePreview {
+  Path: RoutePath
+  Setup: NetworkHostSetup
+}
+
+export interface SimulatedVPN {
+  Service: string
+  ServerAddress: string
+  Connected: boolean
+}
+
+export type OperationKind = 'sync' | 'reset' | 'import' | 'update_check'
+
+export interface Operation {
+  ID: number
+  Kind: OperationKind
+  NetworkID?: number
+  StartedAt: string
+  Deadline: string
+}
+
 export interface ListFilter {
   search?: string
   limit?: number
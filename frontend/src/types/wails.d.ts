@@ -5,7 +5,7 @@ declare global {
         App?: {
           AddHost: (arg1: string, arg2: string) => Promise<any>
           AddNetwork: (arg1: string) => Promise<any>
-          AddNetworkHost: (arg1: number, arg2: string, arg3: string) => Promise<any>
+          AddNetworkHost: (arg1: number, arg2: string, arg3: string, arg4: string) => Promise<any>
           DeleteHost: (arg1: number) => Promise<any>
           DeleteNetwork: (arg1: number) => Promise<any>
           DeleteNetworkHost: (arg1: number) => Promise<any>
@@ -24,7 +24,7 @@ declare global {
         App?: {
           AddHost: (arg1: string, arg2: string) => Promise<any>
           AddNetwork: (arg1: string) => Promise<any>
-          AddNetworkHost: (arg1: number, arg2: string, arg3: string) => Promise<any>
+          AddNetworkHost: (arg1: number, arg2: string, arg3: string, arg4: string) => Promise<any>
           DeleteHost: (arg1: number) => Promise<any>
           DeleteNetwork: (arg1: number) => Promise<any>
           DeleteNetworkHost: (arg1: number) => Promise<any>
@@ -1,3 +1,5 @@
+import type { RouteConflict } from '@/types/entities'
+
 export const formatTimestamp = (timestamp: string): string => {
   try {
     const date = new Date(timestamp)
@@ -78,3 +80,17 @@ export const pluralize = (word: string, count: number): string => {
   }
   return `${word}s`
 }
+
+// Mirrors RouteConflict.String() in the backend
+export const formatRouteConflict = (conflict: RouteConflict): string => {
+  switch (conflict.Kind) {
+    case 'local_subnet':
+      return `Route ${conflict.Route} of "${conflict.NetworkName}" overlaps the local subnet ${conflict.ConflictsWith}`
+    case 'network':
+      return `Route ${conflict.Route} of "${conflict.NetworkName}" overlaps route ${conflict.ConflictsWith} of "${conflict.OtherNetworkName}"`
+    case 'vpn_server':
+      return `Route ${conflict.Route} of "${conflict.NetworkName}" covers its VPN server ${conflict.ConflictsWith}`
+    default:
+      return `Route ${conflict.Route} of "${conflict.NetworkName}" conflicts with ${conflict.ConflictsWith}`
+  }
+}
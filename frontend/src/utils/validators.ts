@@ -0,0 +1,16 @@
+import { VALIDATION_CONSTANTS } from './constants'
+
+// isCIDR mirrors the backend check: an IPv4 network in canonical CIDR notation, e.g. 10.0.0.0/8.
+// Addresses with host bits set (10.0.0.1/8) are rejected, they don't name a network.
+export const isCIDR = (address: string): boolean => {
+  const [ip, prefix, ...rest] = address.split('/')
+  if (rest.length > 0 || prefix === undefined || !/^\d{1,2}$/.test(prefix)) return false
+  if (!VALIDATION_CONSTANTS.IP_ADDRESS.PATTERN.test(ip)) return false
+
+  const bits = Number(prefix)
+  if (bits > 32) return false
+
+  const value = ip.split('.').reduce((acc, octet) => acc * 256 + Number(octet), 0)
+  const hostBits = 2 ** (32 - bits)
+  return value % hostBits === 0
+}
@@ -74,7 +74,7 @@ describe('networkHostsService', () => {
       
       const result = await networkHostsService.add(1, '192.168.1.100', 'Database server')
       
-      expect(AddNetworkHost).toHaveBeenCalledWith(1, '192.168.1.100', 'Database server')
+      expect(AddNetworkHost).toHaveBeenCalledWith(1, '192.168.1.100', 'Database server', '')
       expect(result).toEqual(mockNetworkHost)
     })
 
@@ -88,7 +88,7 @@ describe('networkHostsService', () => {
       
       const result = await networkHostsService.add(1, '192.168.1.100')
       
-      expect(AddNetworkHost).toHaveBeenCalledWith(1, '192.168.1.100', '')
+      expect(AddNetworkHost).toHaveBeenCalledWith(1, '192.168.1.100', '', '')
       expect(result).toEqual(mockNetworkHost)
     })
 
@@ -97,7 +97,7 @@ describe('networkHostsService', () => {
       vi.mocked(AddNetworkHost).mockRejectedValue(error)
       
       await expect(networkHostsService.add(1, '192.168.1.100', 'Test host')).rejects.toThrow('Failed to add network host')
-      expect(AddNetworkHost).toHaveBeenCalledWith(1, '192.168.1.100', 'Test host')
+      expect(AddNetworkHost).toHaveBeenCalledWith(1, '192.168.1.100', 'Test host', '')
     })
 
     it('should handle multiple network ids', async () => {
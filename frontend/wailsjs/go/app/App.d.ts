@@ -5,32 +5,110 @@ import {menu} from '../models';
 
 export function AddHost(arg1:string,arg2:string):Promise<entity.Host>;
 
+export function AddHostGroup(arg1:string,arg2:string):Promise<entity.HostGroup>;
+
+export function AddHostGroupHost(arg1:number,arg2:string,arg3:string):Promise<entity.HostGroupHost>;
+
 export function AddNetwork(arg1:string):Promise<entity.Network>;
 
-export function AddNetworkHost(arg1:number,arg2:string,arg3:string):Promise<entity.NetworkHost>;
+export function AddNetworkExclusion(arg1:number,arg2:string,arg3:string):Promise<entity.NetworkHost>;
+
+export function AddNetworkHost(arg1:number,arg2:string,arg3:string,arg4:string):Promise<entity.AddNetworkHostResult>;
+
+export function AddNetworkSubscription(arg1:number,arg2:string):Promise<entity.NetworkSubscription>;
+
+export function AnalyzeNetworkRouteConflicts(arg1:number):Promise<Array<entity.RouteConflict>>;
+
+export function AnalyzeRouteConflicts():Promise<Array<entity.RouteConflict>>;
+
+export function AttachHostGroupToNetwork(arg1:number,arg2:number):Promise<void>;
+
+export function AttachHostToNetwork(arg1:number,arg2:number):Promise<entity.NetworkHost>;
+
+export function CancelOperation(arg1:number):Promise<void>;
+
+export function CheckNetworkHostConflicts(arg1:number,arg2:string):Promise<Array<entity.RouteConflict>>;
+
+export function ConnectSimulatedVPN(arg1:string):Promise<void>;
 
 export function CreateMenu():Promise<menu.Menu>;
 
 export function DeleteHost(arg1:number):Promise<void>;
 
+export function DeleteHostGroup(arg1:number):Promise<void>;
+
+export function DeleteHostGroupHost(arg1:number):Promise<void>;
+
 export function DeleteNetwork(arg1:number):Promise<void>;
 
 export function DeleteNetworkHost(arg1:number):Promise<void>;
 
+export function DeleteNetworkSubscription(arg1:number):Promise<void>;
+
+export function DetachHostFromNetwork(arg1:number,arg2:number):Promise<void>;
+
+export function DetachHostGroupFromNetwork(arg1:number,arg2:number):Promise<void>;
+
+export function DisconnectSimulatedVPN(arg1:string):Promise<void>;
+
 export function ExportNetworkHosts(arg1:number):Promise<string>;
 
+export function ExportNetworkHostsPAC(arg1:number,arg2:string):Promise<string>;
+
+export function ExportNetworkRouteScripts(arg1:number):Promise<string>;
+
+export function ImportNetworkHostCandidates(arg1:number,arg2:Array<entity.NetworkHostDTO>):Promise<void>;
+
 export function ImportNetworkHosts(arg1:number,arg2:string):Promise<void>;
 
+export function IsSimulationEnabled():Promise<boolean>;
+
+export function ListHostGroupHosts(arg1:number):Promise<Array<entity.HostGroupHost>>;
+
+export function ListHostGroups(arg1:string):Promise<Array<entity.HostGroup>>;
+
 export function ListHosts(arg1:string):Promise<Array<entity.Host>>;
 
+export function ListHostsWithUsage(arg1:string):Promise<Array<entity.HostWithUsage>>;
+
+export function ListNetworkHostGroups(arg1:number):Promise<Array<entity.HostGroup>>;
+
 export function ListNetworkHosts(arg1:number,arg2:string):Promise<Array<entity.NetworkHost>>;
 
+export function ListNetworkSubscriptions(arg1:number):Promise<Array<entity.NetworkSubscription>>;
+
 export function ListNetworks(arg1:string):Promise<Array<entity.NetworkWithStatus>>;
 
+export function ListOperations():Promise<Array<entity.Operation>>;
+
+export function ListSimulatedVPN():Promise<Array<entity.SimulatedVPN>>;
+
 export function ListVPNServices():Promise<Array<entity.VPNService>>;
 
+export function PreviewNetworkRoutes(arg1:number):Promise<Array<entity.RoutePreview>>;
+
+export function PreviewSSHConfigImport(arg1:number,arg2:boolean):Promise<entity.NetworkHostImportPreview>;
+
+export function PreviewSystemRoutesImport(arg1:number,arg2:boolean):Promise<entity.NetworkHostImportPreview>;
+
+export function RefreshNetworkSubscription(arg1:number):Promise<void>;
+
 export function ResetNetworkHostSetup(arg1:number):Promise<void>;
 
 export function SaveFileWithDialog(arg1:string,arg2:string):Promise<string>;
 
-export function SyncNetworkHostSetup(arg1:number):Promise<void>;
+export function SetNetworkDNS(arg1:number,arg2:Array<string>,arg3:Array<string>):Promise<entity.Network>;
+
+export function SetNetworkHostEnabled(arg1:number,arg2:boolean):Promise<entity.NetworkHost>;
+
+export function SetNetworkHostPinnedIPs(arg1:number,arg2:Array<string>,arg3:string):Promise<entity.NetworkHost>;
+
+export function SetNetworkHostRouteOverride(arg1:number,arg2:string,arg3:string,arg4:string):Promise<entity.NetworkHost>;
+
+export function SetNetworkRouteOverride(arg1:number,arg2:string,arg3:string,arg4:string):Promise<entity.Network>;
+
+export function SetNetworkRoutingMode(arg1:number,arg2:string):Promise<entity.Network>;
+
+export function SyncNetworkHostSetup(arg1:number):Promise<entity.SyncResult>;
+
+export function UpdateHost(arg1:number,arg2:string,arg3:string):Promise<entity.Host>;
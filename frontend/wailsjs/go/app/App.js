@@ -6,12 +6,56 @@ export function AddHost(arg1, arg2) {
   return window['go']['app']['App']['AddHost'](arg1, arg2);
 }
 
+export function AddHostGroup(arg1, arg2) {
+  return window['go']['app']['App']['AddHostGroup'](arg1, arg2);
+}
+
+export function AddHostGroupHost(arg1, arg2, arg3) {
+  return window['go']['app']['App']['AddHostGroupHost'](arg1, arg2, arg3);
+}
+
 export function AddNetwork(arg1) {
   return window['go']['app']['App']['AddNetwork'](arg1);
 }
 
-export function AddNetworkHost(arg1, arg2, arg3) {
-  return window['go']['app']['App']['AddNetworkHost'](arg1, arg2, arg3);
+export function AddNetworkExclusion(arg1, arg2, arg3) {
+  return window['go']['app']['App']['AddNetworkExclusion'](arg1, arg2, arg3);
+}
+
+export function AddNetworkHost(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['AddNetworkHost'](arg1, arg2, arg3, arg4);
+}
+
+export function AddNetworkSubscription(arg1, arg2) {
+  return window['go']['app']['App']['AddNetworkSubscription'](arg1, arg2);
+}
+
+export function AnalyzeNetworkRouteConflicts(arg1) {
+  return window['go']['app']['App']['AnalyzeNetworkRouteConflicts'](arg1);
+}
+
+export function AnalyzeRouteConflicts() {
+  return window['go']['app']['App']['AnalyzeRouteConflicts']();
+}
+
+export function AttachHostGroupToNetwork(arg1, arg2) {
+  return window['go']['app']['App']['AttachHostGroupToNetwork'](arg1, arg2);
+}
+
+export function AttachHostToNetwork(arg1, arg2) {
+  return window['go']['app']['App']['AttachHostToNetwork'](arg1, arg2);
+}
+
+export function CancelOperation(arg1) {
+  return window['go']['app']['App']['CancelOperation'](arg1);
+}
+
+export function CheckNetworkHostConflicts(arg1, arg2) {
+  return window['go']['app']['App']['CheckNetworkHostConflicts'](arg1, arg2);
+}
+
+export function ConnectSimulatedVPN(arg1) {
+  return window['go']['app']['App']['ConnectSimulatedVPN'](arg1);
 }
 
 export function CreateMenu() {
@@ -22,6 +66,14 @@ export function DeleteHost(arg1) {
   return window['go']['app']['App']['DeleteHost'](arg1);
 }
 
+export function DeleteHostGroup(arg1) {
+  return window['go']['app']['App']['DeleteHostGroup'](arg1);
+}
+
+export function DeleteHostGroupHost(arg1) {
+  return window['go']['app']['App']['DeleteHostGroupHost'](arg1);
+}
+
 export function DeleteNetwork(arg1) {
   return window['go']['app']['App']['DeleteNetwork'](arg1);
 }
@@ -30,30 +82,106 @@ export function DeleteNetworkHost(arg1) {
   return window['go']['app']['App']['DeleteNetworkHost'](arg1);
 }
 
+export function DeleteNetworkSubscription(arg1) {
+  return window['go']['app']['App']['DeleteNetworkSubscription'](arg1);
+}
+
+export function DetachHostFromNetwork(arg1, arg2) {
+  return window['go']['app']['App']['DetachHostFromNetwork'](arg1, arg2);
+}
+
+export function DetachHostGroupFromNetwork(arg1, arg2) {
+  return window['go']['app']['App']['DetachHostGroupFromNetwork'](arg1, arg2);
+}
+
+export function DisconnectSimulatedVPN(arg1) {
+  return window['go']['app']['App']['DisconnectSimulatedVPN'](arg1);
+}
+
 export function ExportNetworkHosts(arg1) {
   return window['go']['app']['App']['ExportNetworkHosts'](arg1);
 }
 
+export function ExportNetworkHostsPAC(arg1, arg2) {
+  return window['go']['app']['App']['ExportNetworkHostsPAC'](arg1, arg2);
+}
+
+export function ExportNetworkRouteScripts(arg1) {
+  return window['go']['app']['App']['ExportNetworkRouteScripts'](arg1);
+}
+
+export function ImportNetworkHostCandidates(arg1, arg2) {
+  return window['go']['app']['App']['ImportNetworkHostCandidates'](arg1, arg2);
+}
+
 export function ImportNetworkHosts(arg1, arg2) {
   return window['go']['app']['App']['ImportNetworkHosts'](arg1, arg2);
 }
 
+export function IsSimulationEnabled() {
+  return window['go']['app']['App']['IsSimulationEnabled']();
+}
+
+export function ListHostGroupHosts(arg1) {
+  return window['go']['app']['App']['ListHostGroupHosts'](arg1);
+}
+
+export function ListHostGroups(arg1) {
+  return window['go']['app']['App']['ListHostGroups'](arg1);
+}
+
 export function ListHosts(arg1) {
   return window['go']['app']['App']['ListHosts'](arg1);
 }
 
+export function ListHostsWithUsage(arg1) {
+  return window['go']['app']['App']['ListHostsWithUsage'](arg1);
+}
+
+export function ListNetworkHostGroups(arg1) {
+  return window['go']['app']['App']['ListNetworkHostGroups'](arg1);
+}
+
 export function ListNetworkHosts(arg1, arg2) {
   return window['go']['app']['App']['ListNetworkHosts'](arg1, arg2);
 }
 
+export function ListNetworkSubscriptions(arg1) {
+  return window['go']['app']['App']['ListNetworkSubscriptions'](arg1);
+}
+
 export function ListNetworks(arg1) {
   return window['go']['app']['App']['ListNetworks'](arg1);
 }
 
+export function ListOperations() {
+  return window['go']['app']['App']['ListOperations']();
+}
+
+export function ListSimulatedVPN() {
+  return window['go']['app']['App']['ListSimulatedVPN']();
+}
+
 export function ListVPNServices() {
   return window['go']['app']['App']['ListVPNServices']();
 }
 
+export function PreviewNetworkRoutes(arg1) {
+  return window['go']['app']['App']['PreviewNetworkRoutes'](arg1);
+}
+
+export function PreviewSSHConfigImport(arg1, arg2) {
+  return window['go']['app']['App']['PreviewSSHConfigImport'](arg1, arg2);
+}
+
+export function PreviewSystemRoutesImport(arg1, arg2) {
+  return window['go']['app']['App']['PreviewSystemRoutesImport'](arg1, arg2);
+}
+
+export function RefreshNetworkSubscription(arg1) {
+  return window['go']['app']['App']['RefreshNetworkSubscription'](arg1);
+}
+
 export function ResetNetworkHostSetup(arg1) {
   return window['go']['app']['App']['ResetNetworkHostSetup'](arg1);
 }
@@ -62,6 +190,34 @@ export function SaveFileWithDialog(arg1, arg2) {
   return window['go']['app']['App']['SaveFileWithDialog'](arg1, arg2);
 }
 
+export function SetNetworkDNS(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SetNetworkDNS'](arg1, arg2, arg3);
+}
+
+export function SetNetworkHostEnabled(arg1, arg2) {
+  return window['go']['app']['App']['SetNetworkHostEnabled'](arg1, arg2);
+}
+
+export function SetNetworkHostPinnedIPs(arg1, arg2, arg3) {
+  return window['go']['app']['App']['SetNetworkHostPinnedIPs'](arg1, arg2, arg3);
+}
+
+export function SetNetworkHostRouteOverride(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['SetNetworkHostRouteOverride'](arg1, arg2, arg3, arg4);
+}
+
+export function SetNetworkRouteOverride(arg1, arg2, arg3, arg4) {
+  return window['go']['app']['App']['SetNetworkRouteOverride'](arg1, arg2, arg3, arg4);
+}
+
+export function SetNetworkRoutingMode(arg1, arg2) {
+  return window['go']['app']['App']['SetNetworkRoutingMode'](arg1, arg2);
+}
+
 export function SyncNetworkHostSetup(arg1) {
   return window['go']['app']['App']['SyncNetworkHostSetup'](arg1);
 }
+
+export function UpdateHost(arg1, arg2, arg3) {
+  return window['go']['app']['App']['UpdateHost'](arg1, arg2, arg3);
+}
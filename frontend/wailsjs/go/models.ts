@@ -1,5 +1,31 @@
 export namespace entity {
 	
+	export class RouteConflict {
+	    Kind: string;
+	    NetworkID: number;
+	    NetworkName: string;
+	    NetworkHostIDs?: number[];
+	    Route: string;
+	    ConflictsWith: string;
+	    OtherNetworkID?: number;
+	    OtherNetworkName?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RouteConflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.NetworkID = source["NetworkID"];
+	        this.NetworkName = source["NetworkName"];
+	        this.NetworkHostIDs = source["NetworkHostIDs"];
+	        this.Route = source["Route"];
+	        this.ConflictsWith = source["ConflictsWith"];
+	        this.OtherNetworkID = source["OtherNetworkID"];
+	        this.OtherNetworkName = source["OtherNetworkName"];
+	    }
+	}
 	export class Timestamp {
 	
 	
@@ -12,6 +38,96 @@ export namespace entity {
 	
 	    }
 	}
+	export class NetworkHost {
+	    ID: number;
+	    NetworkID: number;
+	    Address: string;
+	    Description?: string;
+	    CreatedAt: Timestamp;
+	    SubscriptionID?: number;
+	    HostID?: number;
+	    Enabled: boolean;
+	    ExpiresAt?: Timestamp;
+	    Kind: string;
+	    PinnedIPs: string[];
+	    PinMode: string;
+	    Router: string;
+	    SubnetMask: string;
+	    NetworkInterface: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkHost(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.NetworkID = source["NetworkID"];
+	        this.Address = source["Address"];
+	        this.Description = source["Description"];
+	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
+	        this.SubscriptionID = source["SubscriptionID"];
+	        this.HostID = source["HostID"];
+	        this.Enabled = source["Enabled"];
+	        this.ExpiresAt = this.convertValues(source["ExpiresAt"], Timestamp);
+	        this.Kind = source["Kind"];
+	        this.PinnedIPs = source["PinnedIPs"];
+	        this.PinMode = source["PinMode"];
+	        this.Router = source["Router"];
+	        this.SubnetMask = source["SubnetMask"];
+	        this.NetworkInterface = source["NetworkInterface"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AddNetworkHostResult {
+	    NetworkHost?: NetworkHost;
+	    RouteConflicts: RouteConflict[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AddNetworkHostResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.NetworkHost = this.convertValues(source["NetworkHost"], NetworkHost);
+	        this.RouteConflicts = this.convertValues(source["RouteConflicts"], RouteConflict);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Host {
 	    ID: number;
 	    Address: string;
@@ -48,10 +164,90 @@ export namespace entity {
 		    return a;
 		}
 	}
+	export class HostGroup {
+	    ID: number;
+	    Name: string;
+	    Description?: string;
+	    CreatedAt: Timestamp;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Name = source["Name"];
+	        this.Description = source["Description"];
+	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HostGroupHost {
+	    ID: number;
+	    HostGroupID: number;
+	    Address: string;
+	    Description?: string;
+	    CreatedAt: Timestamp;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostGroupHost(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.HostGroupID = source["HostGroupID"];
+	        this.Address = source["Address"];
+	        this.Description = source["Description"];
+	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Network {
 	    ID: number;
 	    Name: string;
+	    RoutingMode: string;
 	    CreatedAt: Timestamp;
+	    DNSDomains: string[];
+	    DNSNameservers: string[];
+	    Router: string;
+	    SubnetMask: string;
+	    NetworkInterface: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Network(source);
@@ -61,7 +257,13 @@ export namespace entity {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.ID = source["ID"];
 	        this.Name = source["Name"];
+	        this.RoutingMode = source["RoutingMode"];
 	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
+	        this.DNSDomains = source["DNSDomains"];
+	        this.DNSNameservers = source["DNSNameservers"];
+	        this.Router = source["Router"];
+	        this.SubnetMask = source["SubnetMask"];
+	        this.NetworkInterface = source["NetworkInterface"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -82,24 +284,199 @@ export namespace entity {
 		    return a;
 		}
 	}
-	export class NetworkHost {
+	export class HostWithUsage {
 	    ID: number;
-	    NetworkID: number;
 	    Address: string;
 	    Description?: string;
 	    CreatedAt: Timestamp;
+	    UsedIn: Network[];
 	
 	    static createFrom(source: any = {}) {
-	        return new NetworkHost(source);
+	        return new HostWithUsage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.ID = source["ID"];
-	        this.NetworkID = source["NetworkID"];
 	        this.Address = source["Address"];
 	        this.Description = source["Description"];
 	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
+	        this.UsedIn = this.convertValues(source["UsedIn"], Network);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class NetworkHostDTO {
+	    address: string;
+	    description?: string;
+	    enabled?: boolean;
+	    kind?: string;
+	    pinned_ips?: string[];
+	    pin_mode?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkHostDTO(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.description = source["description"];
+	        this.enabled = source["enabled"];
+	        this.kind = source["kind"];
+	        this.pinned_ips = source["pinned_ips"];
+	        this.pin_mode = source["pin_mode"];
+	    }
+	}
+	export class NetworkHostImportCandidate {
+	    address: string;
+	    description?: string;
+	    suggested_hostnames?: string[];
+	    already_exists: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkHostImportCandidate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.description = source["description"];
+	        this.suggested_hostnames = source["suggested_hostnames"];
+	        this.already_exists = source["already_exists"];
+	    }
+	}
+	export class NetworkHostImportSkipped {
+	    value: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkHostImportSkipped(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class NetworkHostImportPreview {
+	    network_id: number;
+	    candidates: NetworkHostImportCandidate[];
+	    skipped?: NetworkHostImportSkipped[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkHostImportPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.network_id = source["network_id"];
+	        this.candidates = this.convertValues(source["candidates"], NetworkHostImportCandidate);
+	        this.skipped = this.convertValues(source["skipped"], NetworkHostImportSkipped);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class NetworkHostSetup {
+	    ID: number;
+	    NetworkHostID: number;
+	    NetworkHostIP: string;
+	    SubnetMask: string;
+	    Router: string;
+	    CreatedAt: Timestamp;
+	    NetworkHostIDs?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkHostSetup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.NetworkHostID = source["NetworkHostID"];
+	        this.NetworkHostIP = source["NetworkHostIP"];
+	        this.SubnetMask = source["SubnetMask"];
+	        this.Router = source["Router"];
+	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
+	        this.NetworkHostIDs = source["NetworkHostIDs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NetworkSubscription {
+	    ID: number;
+	    NetworkID: number;
+	    URL: string;
+	    ETag?: string;
+	    LastRefreshedAt?: Timestamp;
+	    LastError?: string;
+	    CreatedAt: Timestamp;
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkSubscription(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.NetworkID = source["NetworkID"];
+	        this.URL = source["URL"];
+	        this.ETag = source["ETag"];
+	        this.LastRefreshedAt = this.convertValues(source["LastRefreshedAt"], Timestamp);
+	        this.LastError = source["LastError"];
+	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -123,7 +500,13 @@ export namespace entity {
 	export class NetworkWithStatus {
 	    ID: number;
 	    Name: string;
+	    RoutingMode: string;
 	    CreatedAt: Timestamp;
+	    DNSDomains: string[];
+	    DNSNameservers: string[];
+	    Router: string;
+	    SubnetMask: string;
+	    NetworkInterface: string;
 	    IsActive: boolean;
 	
 	    static createFrom(source: any = {}) {
@@ -134,7 +517,13 @@ export namespace entity {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.ID = source["ID"];
 	        this.Name = source["Name"];
+	        this.RoutingMode = source["RoutingMode"];
 	        this.CreatedAt = this.convertValues(source["CreatedAt"], Timestamp);
+	        this.DNSDomains = source["DNSDomains"];
+	        this.DNSNameservers = source["DNSNameservers"];
+	        this.Router = source["Router"];
+	        this.SubnetMask = source["SubnetMask"];
+	        this.NetworkInterface = source["NetworkInterface"];
 	        this.IsActive = source["IsActive"];
 	    }
 	
@@ -156,6 +545,173 @@ export namespace entity {
 		    return a;
 		}
 	}
+	export class Operation {
+	    ID: number;
+	    Kind: string;
+	    NetworkID?: number;
+	    StartedAt: Timestamp;
+	    Deadline: Timestamp;
+	
+	    static createFrom(source: any = {}) {
+	        return new Operation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Kind = source["Kind"];
+	        this.NetworkID = source["NetworkID"];
+	        this.StartedAt = this.convertValues(source["StartedAt"], Timestamp);
+	        this.Deadline = this.convertValues(source["Deadline"], Timestamp);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RoutePreview {
+	    Path: string;
+	    Setup?: NetworkHostSetup;
+	
+	    static createFrom(source: any = {}) {
+	        return new RoutePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Setup = this.convertValues(source["Setup"], NetworkHostSetup);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SimulatedVPN {
+	    Service: string;
+	    ServerAddress: string;
+	    Connected: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SimulatedVPN(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Service = source["Service"];
+	        this.ServerAddress = source["ServerAddress"];
+	        this.Connected = source["Connected"];
+	    }
+	}
+	export class StaleResolution {
+	    Address: string;
+	    IPs: string[];
+	    ResolvedAt: Timestamp;
+	    Expired: boolean;
+	    Error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StaleResolution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Address = source["Address"];
+	        this.IPs = source["IPs"];
+	        this.ResolvedAt = this.convertValues(source["ResolvedAt"], Timestamp);
+	        this.Expired = source["Expired"];
+	        this.Error = source["Error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SyncResult {
+	    NetworkID: number;
+	    Applied: boolean;
+	    RouteCount: number;
+	    StaleResolutions: StaleResolution[];
+	    SkippedDNSDomains: string[];
+	    ScopedDNSError: string;
+	    RouteConflicts: RouteConflict[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.NetworkID = source["NetworkID"];
+	        this.Applied = source["Applied"];
+	        this.RouteCount = source["RouteCount"];
+	        this.StaleResolutions = this.convertValues(source["StaleResolutions"], StaleResolution);
+	        this.SkippedDNSDomains = source["SkippedDNSDomains"];
+	        this.ScopedDNSError = source["ScopedDNSError"];
+	        this.RouteConflicts = this.convertValues(source["RouteConflicts"], RouteConflict);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
@@ -2,7 +2,9 @@
 package main
 
 import (
+	"context"
 	"embed"
+	"fmt"
 	"log/slog"
 
 	"github.com/wailsapp/wails/v2"
@@ -13,15 +15,31 @@ import (
 	"github.com/dmitrorlov/splitr/backend/config"
 	"github.com/dmitrorlov/splitr/backend/pkg/database"
 	"github.com/dmitrorlov/splitr/backend/pkg/logging"
+	"github.com/dmitrorlov/splitr/backend/storage/dnscache"
 	"github.com/dmitrorlov/splitr/backend/storage/host"
+	"github.com/dmitrorlov/splitr/backend/storage/hostgroup"
+	"github.com/dmitrorlov/splitr/backend/storage/learnedip"
 	"github.com/dmitrorlov/splitr/backend/storage/network"
 	"github.com/dmitrorlov/splitr/backend/storage/networkhost"
 	"github.com/dmitrorlov/splitr/backend/storage/networkhostsetup"
+	"github.com/dmitrorlov/splitr/backend/storage/networksetupgeneration"
+	"github.com/dmitrorlov/splitr/backend/storage/networksubscription"
+	"github.com/dmitrorlov/splitr/backend/storage/resolverfile"
+	"github.com/dmitrorlov/splitr/backend/usecase"
 	commandUsecase "github.com/dmitrorlov/splitr/backend/usecase/command"
+	dnsforwarderUsecase "github.com/dmitrorlov/splitr/backend/usecase/dnsforwarder"
 	hostUsecase "github.com/dmitrorlov/splitr/backend/usecase/host"
+	hostgroupUsecase "github.com/dmitrorlov/splitr/backend/usecase/hostgroup"
 	networkUsecase "github.com/dmitrorlov/splitr/backend/usecase/network"
 	networkhostUsecase "github.com/dmitrorlov/splitr/backend/usecase/networkhost"
+	networkhostexpiryUsecase "github.com/dmitrorlov/splitr/backend/usecase/networkhostexpiry"
+	networkhostimportUsecase "github.com/dmitrorlov/splitr/backend/usecase/networkhostimport"
 	networkhostsetupUsecase "github.com/dmitrorlov/splitr/backend/usecase/networkhostsetup"
+	networksubscriptionUsecase "github.com/dmitrorlov/splitr/backend/usecase/networksubscription"
+	networkwatchUsecase "github.com/dmitrorlov/splitr/backend/usecase/networkwatch"
+	routeconflictUsecase "github.com/dmitrorlov/splitr/backend/usecase/routeconflict"
+	scopeddnsUsecase "github.com/dmitrorlov/splitr/backend/usecase/scopeddns"
+	simulationUsecase "github.com/dmitrorlov/splitr/backend/usecase/simulation"
 	updateUsecase "github.com/dmitrorlov/splitr/backend/usecase/update"
 )
 
@@ -46,7 +64,7 @@ const (
 	defaultColorB       = 54
 )
 
-func main() {
+func main() { //nolint:funlen // wires up every storage and use case
 	appConfig, err := config.New()
 	if err != nil {
 		slog.Error("failed to create config", "error", err)
@@ -73,19 +91,38 @@ func main() {
 	}
 
 	hostStorage := host.New(db)
+	hostgroupStorage := hostgroup.New(db)
 	networkStorage := network.New(db)
 	networkhostStorage := networkhost.New(db)
 	networkhostsetupStorage := networkhostsetup.New(db)
+	networksetupgenerationStorage := networksetupgeneration.New(db)
+	networksubscriptionStorage := networksubscription.New(db)
+	dnscacheStorage := dnscache.New(db)
+	learnedipStorage := learnedip.New(db)
+	resolverfileStorage := resolverfile.New(db)
 
-	commandUC := commandUsecase.NewExecutor()
-	hostUC := hostUsecase.New(hostStorage)
+	commandUC, simulationUC, err := newCommandExecutor(appConfig)
+	if err != nil {
+		slog.Error("failed to create command executor", "error", err)
+		return
+	}
+	scopedDNSUC := scopeddnsUsecase.New(resolverfileStorage, &appConfig.DNS)
 	networkHostSetupUC := networkhostsetupUsecase.New(
 		txManager,
 		commandUC,
+		scopedDNSUC,
 		networkStorage,
 		networkhostStorage,
 		networkhostsetupStorage,
+		hostgroupStorage,
+		networksetupgenerationStorage,
+		dnscacheStorage,
+		learnedipStorage,
+		&appConfig.Route,
+		&appConfig.DNS,
 	)
+	hostUC := hostUsecase.New(txManager, networkHostSetupUC, hostStorage, networkStorage, networkhostStorage)
+	hostGroupUC := hostgroupUsecase.New(txManager, networkHostSetupUC, networkStorage, hostgroupStorage)
 	networkUC := networkUsecase.New(commandUC, networkStorage, networkHostSetupUC)
 	networkHostUC := networkhostUsecase.New(
 		txManager,
@@ -93,6 +130,38 @@ func main() {
 		networkStorage,
 		networkhostStorage,
 	)
+	networkHostImportUC := networkhostimportUsecase.New(
+		commandUC,
+		networkStorage,
+		networkhostStorage,
+	)
+	networkSubscriptionUC := networksubscriptionUsecase.New(
+		txManager,
+		networkHostSetupUC,
+		networkStorage,
+		networkhostStorage,
+		networksubscriptionStorage,
+		&appConfig.Subscription,
+	)
+	networkHostExpiryUC := networkhostexpiryUsecase.New(
+		txManager,
+		networkHostSetupUC,
+		networkhostStorage,
+		&appConfig.Expiry,
+	)
+	dnsForwarderUC := dnsforwarderUsecase.New(
+		networkHostSetupUC,
+		networkhostStorage,
+		learnedipStorage,
+		&appConfig.DNS.Forwarder,
+	)
+	networkWatchUC := networkwatchUsecase.New(
+		commandUC,
+		networkHostSetupUC,
+		networkStorage,
+		&appConfig.NetworkWatch,
+	)
+	routeConflictUC := routeconflictUsecase.New(commandUC, networkHostSetupUC, networkStorage)
 	updateUC := updateUsecase.New(appName, version, &appConfig.GitHub)
 	app := app.New(
 		appName,
@@ -103,11 +172,25 @@ func main() {
 		wailsLogger,
 		commandUC,
 		hostUC,
+		hostGroupUC,
 		networkUC,
 		networkHostUC,
+		networkHostImportUC,
+		networkSubscriptionUC,
 		networkHostSetupUC,
+		routeConflictUC,
 		updateUC,
+		simulationUC,
+		&appConfig.Operation,
 	)
+
+	backgroundCtx, cancelBackground := context.WithCancel(context.Background())
+	defer cancelBackground()
+	go networkSubscriptionUC.Run(backgroundCtx)
+	go networkHostExpiryUC.Run(backgroundCtx)
+	go dnsForwarderUC.Run(backgroundCtx)
+	go networkWatchUC.Run(backgroundCtx)
+
 	err = wails.Run(&options.App{
 		Title:  appName,
 		Width:  defaultWindowWidth,
@@ -126,3 +209,20 @@ func main() {
 		slog.Error("failed to run application", "error", err)
 	}
 }
+
+// newCommandExecutor returns the simulated network stack in simulation mode, with the controls for it,
+// and the executor of system commands otherwise.
+func newCommandExecutor(appConfig *config.Config) (usecase.CommandExecutor, usecase.Simulation, error) {
+	if appConfig.Simulation.Enabled {
+		slog.Warn("simulation mode: the network stack is simulated in memory, no commands are run")
+		simulationUC := simulationUsecase.New()
+		return simulationUC, simulationUC, nil
+	}
+
+	commandRunner, err := commandUsecase.NewRunner(&appConfig.Command)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create command runner: %w", err)
+	}
+
+	return commandUsecase.NewExecutorWithRunner(commandRunner), nil, nil
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS network_hosts_subscription_id_idx;
+ALTER TABLE network_hosts DROP COLUMN subscription_id;
+DROP TABLE IF EXISTS network_subscriptions;
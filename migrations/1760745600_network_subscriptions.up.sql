@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS network_subscriptions
+(
+    id                INTEGER PRIMARY KEY,
+    network_id        INTEGER                             NOT NULL,
+    url               VARCHAR(2048)                       NOT NULL,
+    etag              VARCHAR(255),
+    last_refreshed_at TIMESTAMP,
+    last_error        TEXT,
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (network_id, url),
+    FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE
+);
+
+ALTER TABLE network_hosts ADD COLUMN subscription_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS network_hosts_subscription_id_idx ON network_hosts (subscription_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS network_hosts_host_id_idx;
+ALTER TABLE network_hosts DROP COLUMN host_id;
@@ -0,0 +1,3 @@
+ALTER TABLE network_hosts ADD COLUMN host_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS network_hosts_host_id_idx ON network_hosts (host_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS network_host_groups;
+DROP TABLE IF EXISTS host_group_hosts;
+DROP TABLE IF EXISTS host_groups;
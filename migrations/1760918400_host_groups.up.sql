@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS host_groups
+(
+    id          INTEGER PRIMARY KEY,
+    name        VARCHAR(255) UNIQUE                 NOT NULL,
+    description VARCHAR(255),
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS host_group_hosts
+(
+    id            INTEGER PRIMARY KEY,
+    host_group_id INTEGER                             NOT NULL,
+    address       VARCHAR(255)                        NOT NULL,
+    description   VARCHAR(255),
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (host_group_id, address),
+    FOREIGN KEY (host_group_id) REFERENCES host_groups (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS network_host_groups
+(
+    network_id    INTEGER                             NOT NULL,
+    host_group_id INTEGER                             NOT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (network_id, host_group_id),
+    FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE,
+    FOREIGN KEY (host_group_id) REFERENCES host_groups (id) ON DELETE CASCADE
+);
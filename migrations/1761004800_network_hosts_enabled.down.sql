@@ -0,0 +1 @@
+ALTER TABLE network_hosts DROP COLUMN enabled;
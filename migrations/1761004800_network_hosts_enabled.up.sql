@@ -0,0 +1 @@
+ALTER TABLE network_hosts ADD COLUMN enabled BOOLEAN DEFAULT 1 NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS network_hosts_expires_at_idx;
+ALTER TABLE network_hosts DROP COLUMN expires_at;
@@ -0,0 +1,3 @@
+ALTER TABLE network_hosts ADD COLUMN expires_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS network_hosts_expires_at_idx ON network_hosts (expires_at);
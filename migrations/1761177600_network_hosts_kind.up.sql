@@ -0,0 +1 @@
+ALTER TABLE network_hosts ADD COLUMN kind TEXT DEFAULT 'include' NOT NULL;
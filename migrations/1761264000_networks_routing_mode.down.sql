@@ -0,0 +1 @@
+ALTER TABLE networks DROP COLUMN routing_mode;
@@ -0,0 +1 @@
+ALTER TABLE networks ADD COLUMN routing_mode TEXT DEFAULT 'split' NOT NULL;
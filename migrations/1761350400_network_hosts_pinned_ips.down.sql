@@ -0,0 +1,2 @@
+ALTER TABLE network_hosts DROP COLUMN pin_mode;
+ALTER TABLE network_hosts DROP COLUMN pinned_ips;
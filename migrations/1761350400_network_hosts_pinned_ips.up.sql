@@ -0,0 +1,2 @@
+ALTER TABLE network_hosts ADD COLUMN pinned_ips TEXT DEFAULT '' NOT NULL;
+ALTER TABLE network_hosts ADD COLUMN pin_mode TEXT DEFAULT 'replace' NOT NULL;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS dns_cache
+(
+    address     TEXT PRIMARY KEY,
+    ips         TEXT     NOT NULL,
+    resolved_at DATETIME NOT NULL,
+    ttl         INTEGER  NOT NULL
+);
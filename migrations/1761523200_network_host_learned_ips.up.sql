@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS network_host_learned_ips
+(
+    network_host_id INTEGER  NOT NULL,
+    network_id      INTEGER  NOT NULL,
+    ip              TEXT     NOT NULL,
+    name            TEXT     NOT NULL,
+    expires_at      DATETIME NOT NULL,
+    PRIMARY KEY (network_host_id, ip),
+    FOREIGN KEY (network_host_id) REFERENCES network_hosts (id) ON DELETE CASCADE,
+    FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE
+);
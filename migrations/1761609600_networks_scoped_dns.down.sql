@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS resolver_files;
+
+ALTER TABLE networks DROP COLUMN dns_nameservers;
+ALTER TABLE networks DROP COLUMN dns_domains;
@@ -0,0 +1,10 @@
+ALTER TABLE networks ADD COLUMN dns_domains TEXT DEFAULT '' NOT NULL;
+ALTER TABLE networks ADD COLUMN dns_nameservers TEXT DEFAULT '' NOT NULL;
+
+CREATE TABLE IF NOT EXISTS resolver_files
+(
+    domain     TEXT PRIMARY KEY,
+    network_id INTEGER                             NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+ALTER TABLE network_hosts DROP COLUMN network_interface;
+ALTER TABLE network_hosts DROP COLUMN subnet_mask;
+ALTER TABLE network_hosts DROP COLUMN router;
+
+ALTER TABLE networks DROP COLUMN network_interface;
+ALTER TABLE networks DROP COLUMN subnet_mask;
+ALTER TABLE networks DROP COLUMN router;
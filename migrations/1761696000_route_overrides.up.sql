@@ -0,0 +1,7 @@
+ALTER TABLE networks ADD COLUMN router TEXT DEFAULT '' NOT NULL;
+ALTER TABLE networks ADD COLUMN subnet_mask TEXT DEFAULT '' NOT NULL;
+ALTER TABLE networks ADD COLUMN network_interface TEXT DEFAULT '' NOT NULL;
+
+ALTER TABLE network_hosts ADD COLUMN router TEXT DEFAULT '' NOT NULL;
+ALTER TABLE network_hosts ADD COLUMN subnet_mask TEXT DEFAULT '' NOT NULL;
+ALTER TABLE network_hosts ADD COLUMN network_interface TEXT DEFAULT '' NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS network_setup_generations_network_id_idx;
+DROP TABLE IF EXISTS network_setup_generations;
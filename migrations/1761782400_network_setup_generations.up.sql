@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS network_setup_generations
+(
+    id              INTEGER PRIMARY KEY,
+    network_id      INTEGER                             NOT NULL,
+    status          VARCHAR(16)                         NOT NULL,
+    network_service VARCHAR(255) DEFAULT ''             NOT NULL,
+    tunnel_routes   TEXT         DEFAULT '[]'           NOT NULL,
+    direct_routes   TEXT         DEFAULT '[]'           NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS network_setup_generations_network_id_idx ON network_setup_generations (network_id);
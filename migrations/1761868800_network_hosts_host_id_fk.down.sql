@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS network_hosts_old
+(
+    id                INTEGER PRIMARY KEY,
+    network_id        INTEGER                             NOT NULL,
+    address           VARCHAR(255)                        NOT NULL,
+    description       VARCHAR(255),
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    subscription_id   INTEGER,
+    host_id           INTEGER,
+    enabled           BOOLEAN   DEFAULT 1                 NOT NULL,
+    expires_at        DATETIME,
+    kind              TEXT      DEFAULT 'include'         NOT NULL,
+    pinned_ips        TEXT      DEFAULT ''                NOT NULL,
+    pin_mode          TEXT      DEFAULT 'replace'         NOT NULL,
+    router            TEXT      DEFAULT ''                NOT NULL,
+    subnet_mask       TEXT      DEFAULT ''                NOT NULL,
+    network_interface TEXT      DEFAULT ''                NOT NULL,
+    UNIQUE (network_id, address),
+    FOREIGN KEY (network_id) REFERENCES networks (id) ON DELETE CASCADE
+);
+
+INSERT INTO network_hosts_old SELECT * FROM network_hosts;
+
+DROP TABLE network_hosts;
+ALTER TABLE network_hosts_old RENAME TO network_hosts;
+
+CREATE INDEX IF NOT EXISTS network_hosts_subscription_id_idx ON network_hosts (subscription_id);
+CREATE INDEX IF NOT EXISTS network_hosts_host_id_idx ON network_hosts (host_id);
+CREATE INDEX IF NOT EXISTS network_hosts_expires_at_idx ON network_hosts (expires_at);
@@ -0,0 +1 @@
+ALTER TABLE network_subscriptions DROP COLUMN host_list;
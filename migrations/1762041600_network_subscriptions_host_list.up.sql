@@ -0,0 +1 @@
+ALTER TABLE network_subscriptions ADD COLUMN host_list TEXT;